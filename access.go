@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/cybozu-go/scim/schema"
+)
+
+// attributeSet is a set of SCIM attribute names. As SCIM attribute names
+// are case-insensitive, names are stored and looked up in lower case
+type attributeSet map[string]struct{}
+
+func (s attributeSet) Add(names ...string) {
+	for _, name := range names {
+		s[strings.ToLower(name)] = struct{}{}
+	}
+}
+
+func (s attributeSet) Has(name string) bool {
+	_, ok := s[strings.ToLower(name)]
+	return ok
+}
+
+// AttributePolicy restricts the attributes that a principal is allowed
+// to read or write, on top of the per-operation scopes.
+//
+// Attributes are specified per resource type ("User" or "Group") using
+// their top-level SCIM attribute names, such as "x509Certificates" or "roles".
+// The attributes of schema extensions are specified by their own name,
+// such as "department" for the enterprise User extension.
+type AttributePolicy struct {
+	deniedReads  map[string]attributeSet
+	deniedWrites map[string]attributeSet
+}
+
+func NewAttributePolicy() *AttributePolicy {
+	return &AttributePolicy{
+		deniedReads:  make(map[string]attributeSet),
+		deniedWrites: make(map[string]attributeSet),
+	}
+}
+
+func addToPolicy(m map[string]attributeSet, resourceType string, names []string) {
+	set, ok := m[resourceType]
+	if !ok {
+		set = make(attributeSet)
+		m[resourceType] = set
+	}
+	set.Add(names...)
+}
+
+// DenyRead prevents the given attributes from being returned in responses,
+// or being used in filters.
+func (p *AttributePolicy) DenyRead(resourceType string, names ...string) *AttributePolicy {
+	addToPolicy(p.deniedReads, resourceType, names)
+	return p
+}
+
+// DenyWrite prevents the given attributes from being specified in
+// Create, Replace or Patch operations. On Replace, the stored values
+// of these attributes are left untouched.
+func (p *AttributePolicy) DenyWrite(resourceType string, names ...string) *AttributePolicy {
+	addToPolicy(p.deniedWrites, resourceType, names)
+	return p
+}
+
+func (p *AttributePolicy) CanRead(resourceType, name string) bool {
+	return p == nil || !p.deniedReads[resourceType].Has(name)
+}
+
+func (p *AttributePolicy) CanWrite(resourceType, name string) bool {
+	return p == nil || !p.deniedWrites[resourceType].Has(name)
+}
+
+func (p *AttributePolicy) readDenials(resourceType string) attributeSet {
+	if p == nil {
+		return nil
+	}
+	return p.deniedReads[resourceType]
+}
+
+func (p *AttributePolicy) writeDenials(resourceType string) attributeSet {
+	if p == nil {
+		return nil
+	}
+	return p.deniedWrites[resourceType]
+}
+
+// WithAttributePolicy associates an attribute-level access policy
+// with the principal
+func (p *Principal) WithAttributePolicy(policy *AttributePolicy) *Principal {
+	p.policy = policy
+	return p
+}
+
+func (p *Principal) AttributePolicy() *AttributePolicy {
+	return p.policy
+}
+
+// attributePolicyFromContext returns the attribute policy in effect for the
+// given context, or nil if there is none
+func attributePolicyFromContext(ctx context.Context) *AttributePolicy {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.policy
+	}
+	return nil
+}
+
+// readExclusions returns the list of attributes to exclude when loading
+// a resource of the given type: the attributes explicitly excluded by
+// the client, plus those that the client may not read
+func readExclusions(ctx context.Context, resourceType string, excludedFields []string) []string {
+	denied := attributePolicyFromContext(ctx).readDenials(resourceType)
+	if len(denied) == 0 {
+		return excludedFields
+	}
+
+	list := make([]string, 0, len(excludedFields)+len(denied))
+	list = append(list, excludedFields...)
+	for _, name := range canonicalNames(resourceType, denied) {
+		list = append(list, name)
+	}
+	return list
+}
+
+// canonicalNames converts the (lower cased) attribute names in a set to
+// the names used by the resource package
+func canonicalNames(resourceType string, set attributeSet) []string {
+	list := make([]string, 0, len(set))
+	for _, key := range attributeKeys(resourceType) {
+		if set.Has(key) {
+			list = append(list, key)
+		}
+	}
+	return list
+}
+
+// attributeKeys returns the names of the top level attributes of
+// a resource type, as used by the resource package
+func attributeKeys(resourceType string) []string {
+	switch resourceType {
+	case `User`:
+		return userAttributeKeys
+	case `Group`:
+		return groupAttributeKeys
+	}
+	return nil
+}
+
+var userAttributeKeys = []string{
+	resource.UserActiveKey,
+	resource.UserAddressesKey,
+	resource.UserDisplayNameKey,
+	resource.UserEmailsKey,
+	resource.UserEntitlementsKey,
+	resource.UserExternalIDKey,
+	resource.UserGroupsKey,
+	resource.UserIDKey,
+	resource.UserIMSKey,
+	resource.UserLocaleKey,
+	resource.UserMetaKey,
+	resource.UserNameKey,
+	resource.UserNickNameKey,
+	resource.UserPasswordKey,
+	resource.UserPhoneNumbersKey,
+	resource.UserPhotosKey,
+	resource.UserPreferredLanguageKey,
+	resource.UserProfileURLKey,
+	resource.UserRolesKey,
+	resource.UserTimezoneKey,
+	resource.UserTitleKey,
+	resource.UserUserNameKey,
+	resource.UserUserTypeKey,
+	resource.UserX509CertificatesKey,
+}
+
+var groupAttributeKeys = []string{
+	resource.GroupDisplayNameKey,
+	resource.GroupExternalIDKey,
+	resource.GroupIDKey,
+	resource.GroupMembersKey,
+	resource.GroupMetaKey,
+}
+
+// scrubUser clears the fields of a loaded User that the client is not
+// allowed to read, so that they are not included in the SCIM resource.
+// This also covers values that are always loaded, such as group memberships
+func scrubUser(ctx context.Context, in *ent.User) {
+	denied := attributePolicyFromContext(ctx).readDenials(`User`)
+	for name := range denied {
+		switch name {
+		case strings.ToLower(resource.UserActiveKey):
+			in.Active = false
+		case strings.ToLower(resource.UserAddressesKey):
+			in.Edges.Addresses = nil
+		case strings.ToLower(resource.UserDisplayNameKey):
+			in.DisplayName = ""
+		case strings.ToLower(resource.UserEmailsKey):
+			in.Edges.Emails = nil
+		case strings.ToLower(resource.UserEntitlementsKey):
+			in.Edges.Entitlements = nil
+		case strings.ToLower(resource.UserExternalIDKey):
+			in.ExternalID = ""
+		case strings.ToLower(resource.UserGroupsKey):
+			in.Groups = nil
+		case strings.ToLower(resource.UserIMSKey):
+			in.Edges.IMS = nil
+		case strings.ToLower(resource.UserLocaleKey):
+			in.Locale = ""
+		case strings.ToLower(resource.UserNameKey):
+			in.Edges.Name = nil
+		case strings.ToLower(resource.UserNickNameKey):
+			in.NickName = ""
+		case strings.ToLower(resource.UserPhoneNumbersKey):
+			in.Edges.PhoneNumbers = nil
+		case strings.ToLower(resource.UserPhotosKey):
+			in.Edges.Photos = nil
+		case strings.ToLower(resource.UserPreferredLanguageKey):
+			in.PreferredLanguage = ""
+		case strings.ToLower(resource.UserProfileURLKey):
+			in.ProfileURL = ""
+		case strings.ToLower(resource.UserRolesKey):
+			in.Edges.Roles = nil
+		case strings.ToLower(resource.UserTimezoneKey):
+			in.Timezone = ""
+		case strings.ToLower(resource.UserTitleKey):
+			in.Title = ""
+		case strings.ToLower(resource.UserUserTypeKey):
+			in.UserType = ""
+		case strings.ToLower(resource.UserX509CertificatesKey):
+			in.Edges.X509Certificates = nil
+		}
+	}
+}
+
+// scrubGroup is the Group counterpart of scrubUser
+func scrubGroup(ctx context.Context, in *ent.Group) {
+	denied := attributePolicyFromContext(ctx).readDenials(`Group`)
+	for name := range denied {
+		switch name {
+		case strings.ToLower(resource.GroupDisplayNameKey):
+			in.DisplayName = ""
+		case strings.ToLower(resource.GroupExternalIDKey):
+			in.ExternalID = ""
+		case strings.ToLower(resource.GroupMembersKey):
+			in.Edges.Members = nil
+		}
+	}
+}
+
+// attributeNames returns the names of the attributes present in a SCIM
+// resource or a JSON object, normalized as in topLevelAttribute. The
+// attributes of schema extensions, which are nested in an object named
+// after the schema URN, are listed by their own names
+func attributeNames(v interface{}) ([]string, error) {
+	buf, ok := v.(json.RawMessage)
+	if !ok {
+		var err error
+		buf, err = json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to serialize resource: %w`, err)
+		}
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, fmt.Errorf(`failed to parse resource: %w`, err)
+	}
+
+	names := make([]string, 0, len(m))
+	for name, value := range m {
+		if isSchemaURN(name) {
+			sub, err := attributeNames(value)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, sub...)
+			continue
+		}
+		names = append(names, topLevelAttribute(name))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// isSchemaURN reports if name is the URN of a schema, such as the
+// enterprise User extension, rather than a URN-qualified attribute
+func isSchemaURN(name string) bool {
+	switch strings.ToLower(name) {
+	case strings.ToLower(resource.UserSchemaURI), strings.ToLower(resource.GroupSchemaURI), strings.ToLower(resource.EnterpriseUserSchemaURI):
+		return true
+	}
+	_, ok := schema.Get(name)
+	return ok
+}
+
+// topLevelAttribute extracts the top-level attribute name from an
+// attribute path such as `emails[type eq "work"].value` or `name.givenName`.
+// Paths that are qualified with a schema URN are stripped of the URN.
+func topLevelAttribute(path string) string {
+	if strings.HasPrefix(strings.ToLower(path), `urn:`) {
+		if i := strings.IndexByte(path, '['); i > -1 {
+			path = path[:i]
+		}
+		if i := strings.LastIndexByte(path, ':'); i > -1 {
+			path = path[i+1:]
+		}
+	}
+	if i := strings.IndexAny(path, `[.`); i > -1 {
+		path = path[:i]
+	}
+	return path
+}
+
+func forbiddenWriteError(resourceType string, names []string) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusBadRequest).
+		ScimType(resource.ErrMutability).
+		Detail(fmt.Sprintf(`client is not allowed to modify the following attributes of %s: %s`, resourceType, strings.Join(names, `, `))).
+		MustBuild()
+}
+
+// checkWritable verifies that the client is allowed to write every
+// attribute present in the given resource (or JSON object)
+func checkWritable(ctx context.Context, resourceType string, in interface{}) error {
+	denied := attributePolicyFromContext(ctx).writeDenials(resourceType)
+	if len(denied) == 0 {
+		return nil
+	}
+
+	names, err := attributeNames(in)
+	if err != nil {
+		return err
+	}
+
+	var forbidden []string
+	for _, name := range names {
+		if denied.Has(name) {
+			forbidden = append(forbidden, name)
+		}
+	}
+	if len(forbidden) > 0 {
+		return forbiddenWriteError(resourceType, forbidden)
+	}
+	return nil
+}
+
+// checkPatchWritable verifies that the client is allowed to modify
+// the attribute targeted by a PATCH operation
+func checkPatchWritable(ctx context.Context, resourceType string, op *resource.PatchOperation) error {
+	if op.Path() == "" || isSchemaURN(op.Path()) {
+		// The value contains the attributes to be modified
+		return checkWritable(ctx, resourceType, json.RawMessage(op.Value()))
+	}
+
+	denied := attributePolicyFromContext(ctx).writeDenials(resourceType)
+	if name := topLevelAttribute(op.Path()); denied.Has(name) {
+		return forbiddenWriteError(resourceType, []string{name})
+	}
+	return nil
+}
+
+// preservedAttributes returns the set of attributes whose stored values
+// must be left untouched when a resource is replaced: attributes that the
+// principal may not write, and immutable attributes absent from the payload
+func preservedAttributes(ctx context.Context, resourceType string, in interface{}) attributeSet {
+	preserved := make(attributeSet)
+	for name := range attributePolicyFromContext(ctx).writeDenials(resourceType) {
+		preserved.Add(name)
+	}
+	preserved.Add(immutableAttributes(resourceType, in)...)
+	return preserved
+}
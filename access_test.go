@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributePolicy(t *testing.T) {
+	b := newBackend(t, server.WithPatchSupport(true))
+
+	var in resource.User
+	decode(t, `{"userName":"alice","title":"Engineer","emails":[{"value":"alice@example.com"}],"roles":[{"value":"admin"}]}`, &in)
+	u, err := b.CreateUser(as("admin", server.ScopeUsersRead, server.ScopeUsersWrite), &in)
+	require.NoError(t, err, `CreateUser should succeed`)
+
+	helpdesk := server.WithPrincipal(context.Background(), server.NewPrincipal("helpdesk", server.ScopeUsersRead, server.ScopeUsersWrite).
+		WithAttributePolicy(server.NewAttributePolicy().
+			DenyRead(`User`, "emails", "roles").
+			DenyWrite(`User`, "roles", "department"),
+		))
+
+	t.Run("denied attributes are not returned", func(t *testing.T) {
+		got, err := b.RetrieveUser(helpdesk, u.ID(), nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Equal(t, "alice", got.UserName(), `userName should be returned`)
+		require.Empty(t, got.Emails(), `emails should not be returned`)
+		require.Empty(t, got.Roles(), `roles should not be returned`)
+	})
+	t.Run("denied attributes cannot be filtered on", func(t *testing.T) {
+		var req resource.SearchRequest
+		decode(t, `{"filter":"emails.value eq \"alice@example.com\""}`, &req)
+		_, err := b.SearchUser(helpdesk, &req)
+		requireSCIMError(t, err, http.StatusForbidden)
+	})
+	t.Run("denied attributes cannot be written", func(t *testing.T) {
+		for _, src := range []string{
+			`{"userName":"bob","roles":[{"value":"admin"}]}`,
+			`{"userName":"bob","urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":"R&D"}}`,
+		} {
+			var in resource.User
+			decode(t, src, &in)
+			_, err := b.CreateUser(helpdesk, &in)
+			serr := requireSCIMError(t, err, http.StatusBadRequest)
+			require.Equal(t, resource.ErrMutability, serr.ScimType(), `error should be a mutability error`)
+		}
+	})
+	t.Run("denied attributes cannot be patched", func(t *testing.T) {
+		for _, src := range []string{
+			`{"Operations":[{"op":"add","path":"roles","value":[{"value":"auditor"}]}]}`,
+			`{"Operations":[{"op":"add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department","value":"R&D"}]}`,
+			`{"Operations":[{"op":"add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User","value":{"department":"R&D"}}]}`,
+			`{"Operations":[{"op":"add","value":{"roles":[{"value":"auditor"}]}}]}`,
+		} {
+			var req resource.PatchRequest
+			decode(t, src, &req)
+			_, err := b.PatchUser(helpdesk, u.ID(), &req)
+			serr := requireSCIMError(t, err, http.StatusBadRequest)
+			require.Equal(t, resource.ErrMutability, serr.ScimType(), `error should be a mutability error (%s)`, src)
+		}
+	})
+	t.Run("denied attributes are preserved on replace", func(t *testing.T) {
+		var in resource.User
+		decode(t, `{"userName":"alice","title":"Lead"}`, &in)
+		_, err := b.ReplaceUser(helpdesk, u.ID(), &in)
+		require.NoError(t, err, `ReplaceUser should succeed`)
+
+		got, err := b.RetrieveUser(as("admin", server.ScopeUsersRead), u.ID(), nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Equal(t, "Lead", got.Title(), `title should be replaced`)
+		require.Len(t, got.Roles(), 1, `roles should be preserved`)
+	})
+}
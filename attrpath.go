@@ -0,0 +1,133 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybozu-go/scim/resource"
+)
+
+// normalizeAttrPath converts an attribute path found in a filter to the
+// form used by the predicate builders. Attribute names are case insensitive
+// (RFC7643 Section 2.1), and may be qualified by the URN of the schema that
+// defines them (RFC7644 Section 3.10), so that for Users
+//
+//	USERNAME
+//	urn:ietf:params:scim:schemas:core:2.0:User:userName
+//
+// both become "userName", and "Emails.Value" becomes "emails.value".
+// Names that are not known are returned as is, and are reported by the
+// predicate builders. Attributes of other schemas, such as extensions,
+// cannot be used in filters
+func normalizeAttrPath(resourceType, path string) (string, error) {
+	if strings.HasPrefix(strings.ToLower(path), `urn:`) {
+		urn := coreSchemaURI(resourceType)
+		if urn == "" || len(path) <= len(urn)+1 || !strings.EqualFold(path[:len(urn)+1], urn+`:`) {
+			return "", invalidFilter(fmt.Errorf(`attribute %q cannot be used in filters on %s`, path, resourceType))
+		}
+		path = path[len(urn)+1:]
+	}
+
+	field, subfield, err := splitScimField(path)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range attributeKeys(resourceType) {
+		if strings.EqualFold(key, field) {
+			field = key
+			break
+		}
+	}
+	if subfield == "" {
+		return field, nil
+	}
+
+	for _, key := range subAttributeKeys(resourceType, field) {
+		if strings.EqualFold(key, subfield) {
+			subfield = key
+			break
+		}
+	}
+	return field + `.` + subfield, nil
+}
+
+// coreSchemaURI returns the URN of the core schema of a resource type
+func coreSchemaURI(resourceType string) string {
+	switch resourceType {
+	case `User`:
+		return resource.UserSchemaURI
+	case `Group`:
+		return resource.GroupSchemaURI
+	}
+	return ""
+}
+
+// subAttributeKeys returns the names of the sub-attributes of a complex
+// attribute of a resource type, as used by the resource package
+func subAttributeKeys(resourceType, attr string) []string {
+	switch resourceType {
+	case `User`:
+		return userSubAttributeKeys[attr]
+	case `Group`:
+		return groupSubAttributeKeys[attr]
+	}
+	return nil
+}
+
+// multiValuedSubAttributeKeys are the sub-attributes shared by most
+// multi-valued attributes (RFC7643 Section 2.4)
+var multiValuedSubAttributeKeys = []string{
+	resource.EmailDisplayKey,
+	resource.EmailPrimaryKey,
+	resource.EmailTypeKey,
+	resource.EmailValueKey,
+}
+
+var groupMemberSubAttributeKeys = []string{
+	resource.GroupMemberDisplayKey,
+	resource.GroupMemberRefKey,
+	resource.GroupMemberTypeKey,
+	resource.GroupMemberValueKey,
+}
+
+var metaSubAttributeKeys = []string{
+	resource.MetaCreatedKey,
+	resource.MetaLastModifiedKey,
+	resource.MetaLocationKey,
+	resource.MetaResourceTypeKey,
+	resource.MetaVersionKey,
+}
+
+var userSubAttributeKeys = map[string][]string{
+	resource.UserAddressesKey: {
+		resource.AddressCountryKey,
+		resource.AddressFormattedKey,
+		resource.AddressLocalityKey,
+		resource.AddressPostalCodeKey,
+		resource.AddressRegionKey,
+		resource.AddressStreetAddressKey,
+	},
+	resource.UserEmailsKey:       multiValuedSubAttributeKeys,
+	resource.UserEntitlementsKey: multiValuedSubAttributeKeys,
+	resource.UserGroupsKey:       groupMemberSubAttributeKeys,
+	resource.UserIMSKey:          multiValuedSubAttributeKeys,
+	resource.UserMetaKey:         metaSubAttributeKeys,
+	resource.UserNameKey: {
+		resource.NamesFamilyNameKey,
+		resource.NamesFormattedKey,
+		resource.NamesGivenNameKey,
+		resource.NamesHonorificPrefixKey,
+		resource.NamesHonorificSuffixKey,
+		resource.NamesMiddleNameKey,
+	},
+	resource.UserPhoneNumbersKey:     multiValuedSubAttributeKeys,
+	resource.UserPhotosKey:           multiValuedSubAttributeKeys,
+	resource.UserRolesKey:            multiValuedSubAttributeKeys,
+	resource.UserX509CertificatesKey: multiValuedSubAttributeKeys,
+}
+
+var groupSubAttributeKeys = map[string][]string{
+	resource.GroupMembersKey: groupMemberSubAttributeKeys,
+	resource.GroupMetaKey:    metaSubAttributeKeys,
+}
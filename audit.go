@@ -0,0 +1,571 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim/resource"
+)
+
+// The audit log records the provisioning operations performed by the
+// Backend (see WithAudit), whether they succeed or not. The entries of
+// successful operations are appended in the transaction of the
+// operation, so that they are recorded if and only if they are
+// committed. The entries of failed operations, including those that were
+// denied, are appended once their transaction is rolled back (see
+// auditFailures). Each entry includes the hash of the previous one in its own
+// hash, so that modifying, inserting or removing entries breaks the
+// chain (see VerifyAuditLog). Removing the most recent entries can only
+// be detected by comparing the head of the chain with a copy kept
+// elsewhere.
+//
+// Entries list the paths of the attributes that an operation sets or
+// removes, but never their values, so that passwords and other sensitive
+// values do not end up in the log. The literal values of the filters in
+// PATCH paths, such as emails[value eq "..."], are redacted as well
+
+// AuditOperation is the kind of provisioning operation recorded in the
+// audit log
+type AuditOperation string
+
+const (
+	AuditCreate  AuditOperation = `create`
+	AuditReplace AuditOperation = `replace`
+	AuditPatch   AuditOperation = `patch`
+	AuditDelete  AuditOperation = `delete`
+	AuditRestore AuditOperation = `restore`
+
+	// AuditUpsert is only recorded for upserts that fail, as successful
+	// upserts are recorded as the creation or replacement they perform
+	AuditUpsert AuditOperation = `upsert`
+)
+
+const (
+	auditSuccess = `success`
+	auditFailure = `failure`
+
+	// auditGenesis is the previous hash of the first entry
+	auditGenesis = `0000000000000000000000000000000000000000000000000000000000000000`
+
+	redactedValue = `"[REDACTED]"`
+)
+
+// AuditEntry is an entry of the audit log. Status is the HTTP status of
+// the response to the operation, and Outcome is "success" or "failure"
+// depending on it
+type AuditEntry struct {
+	Sequence     int            `json:"sequence"`
+	Timestamp    time.Time      `json:"timestamp"`
+	Client       string         `json:"client"`
+	Operation    AuditOperation `json:"operation"`
+	ResourceType string         `json:"resourceType,omitempty"`
+	ResourceID   string         `json:"resourceId,omitempty"`
+	Attributes   []string       `json:"attributes,omitempty"`
+	Status       int            `json:"status"`
+	Outcome      string         `json:"outcome"`
+	PrevHash     string         `json:"prevHash"`
+	Hash         string         `json:"hash"`
+}
+
+// auditHash computes the hash of an entry, which covers every field
+// but the sequence number and the hash itself
+func auditHash(e *AuditEntry) string {
+	attributes := e.Attributes
+	if attributes == nil {
+		attributes = []string{}
+	}
+	buf, _ := json.Marshal(struct {
+		Timestamp    string   `json:"timestamp"`
+		Client       string   `json:"client"`
+		Operation    string   `json:"operation"`
+		ResourceType string   `json:"resourceType"`
+		ResourceID   string   `json:"resourceId"`
+		Attributes   []string `json:"attributes"`
+		Status       int      `json:"status"`
+		Outcome      string   `json:"outcome"`
+		PrevHash     string   `json:"prevHash"`
+	}{
+		Timestamp:    e.Timestamp.UTC().Format(time.RFC3339Nano),
+		Client:       e.Client,
+		Operation:    string(e.Operation),
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Attributes:   attributes,
+		Status:       e.Status,
+		Outcome:      e.Outcome,
+		PrevHash:     e.PrevHash,
+	})
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
+
+func auditEntryFromEnt(row *ent.AuditEntry) *AuditEntry {
+	return &AuditEntry{
+		Sequence:     row.ID,
+		Timestamp:    row.Timestamp,
+		Client:       row.ClientName,
+		Operation:    AuditOperation(row.Operation),
+		ResourceType: row.ResourceType,
+		ResourceID:   row.ResourceID,
+		Attributes:   row.Attributes,
+		Status:       row.Status,
+		Outcome:      row.Outcome,
+		PrevHash:     row.PrevHash,
+		Hash:         row.Hash,
+	}
+}
+
+// recordAudit appends the entry of an operation on a resource to the
+// audit log, if it is enabled. It is called with the transaction of the
+// operation once the operation has succeeded, so that the operation
+// fails if its entry cannot be appended. v is the payload of the
+// operation (a resource or a PatchRequest), if any
+func (b *Backend) recordAudit(ctx context.Context, client *ent.Client, op AuditOperation, resourceType, id string, v interface{}) error {
+	if !b.audit {
+		return nil
+	}
+
+	attributes, err := auditAttributes(v)
+	if err != nil {
+		return internalError(err)
+	}
+	status := http.StatusOK
+	switch op {
+	case AuditCreate:
+		status = http.StatusCreated
+	case AuditDelete:
+		status = http.StatusNoContent
+	}
+
+	err = b.appendAudit(ctx, client, &AuditEntry{
+		Timestamp:    b.clock.Now(),
+		Client:       clientName(ctx),
+		Operation:    op,
+		ResourceType: resourceType,
+		ResourceID:   id,
+		Attributes:   attributes,
+		Status:       status,
+		Outcome:      auditSuccess,
+	})
+	if err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+type identAuditedOperation struct{}
+
+// auditFailures returns the context of an operation, and a function to
+// be deferred with the address of the error it returns. If the operation
+// fails, its entry is appended to the audit log in a transaction of its
+// own, with the status of the SCIM error, and the error is replaced by
+// an internal error if the entry cannot be appended. Operations called
+// by another operation (e.g. CreateUser by UpsertUser) leave their
+// failures to the outer one
+func (b *Backend) auditFailures(ctx context.Context, op AuditOperation, resourceType, id string, v interface{}) (context.Context, func(*error)) {
+	if !b.audit || ctx.Value(identAuditedOperation{}) != nil {
+		return ctx, func(*error) {}
+	}
+
+	ctx = context.WithValue(ctx, identAuditedOperation{}, true)
+	return ctx, func(errp *error) {
+		if *errp == nil {
+			return
+		}
+
+		status := http.StatusInternalServerError
+		if serr, ok := asSCIMError(*errp); ok {
+			status = serr.Status()
+		}
+		// payloads that cannot be encoded are recorded without their
+		// attributes
+		attributes, _ := auditAttributes(v)
+		e := &AuditEntry{
+			Timestamp:    b.clock.Now(),
+			Client:       clientName(ctx),
+			Operation:    op,
+			ResourceType: resourceType,
+			ResourceID:   id,
+			Attributes:   attributes,
+			Status:       status,
+			Outcome:      auditFailure,
+		}
+		err := b.inTx(ctx, func(b *Backend) error {
+			return b.appendAudit(ctx, b.db, e)
+		})
+		if err != nil {
+			*errp = internalError(fmt.Errorf(`failed to record failed operation in audit log: %s (original error = %s)`, err, *errp))
+		}
+	}
+}
+
+const auditAppendAttempts = 5
+
+// appendAudit chains an entry to the last one. Transactions appending
+// entries concurrently are detected by the uniqueness of prevHash, in
+// which case the entry is chained again
+func (b *Backend) appendAudit(ctx context.Context, client *ent.Client, e *AuditEntry) error {
+	// timestamps are stored with microsecond precision by every dialect
+	e.Timestamp = e.Timestamp.UTC().Truncate(time.Microsecond)
+
+	for attempt := 1; ; attempt++ {
+		last, err := client.AuditEntry.Query().
+			Order(ent.Desc(auditentry.FieldID)).
+			Select(auditentry.FieldHash).
+			First(ctx)
+		switch {
+		case err == nil:
+			e.PrevHash = last.Hash
+		case ent.IsNotFound(err):
+			e.PrevHash = auditGenesis
+		default:
+			return fmt.Errorf(`failed to look up the last audit entry: %w`, err)
+		}
+		e.Hash = auditHash(e)
+
+		// a failed statement aborts the whole transaction with some
+		// dialects, so the insertion is rolled back to a savepoint
+		if _, err := client.ExecContext(ctx, `SAVEPOINT audit_entry`); err != nil {
+			return fmt.Errorf(`failed to create savepoint: %w`, err)
+		}
+		row, err := client.AuditEntry.Create().
+			SetTimestamp(e.Timestamp).
+			SetClientName(e.Client).
+			SetOperation(string(e.Operation)).
+			SetResourceType(e.ResourceType).
+			SetResourceID(e.ResourceID).
+			SetAttributes(e.Attributes).
+			SetStatus(e.Status).
+			SetOutcome(e.Outcome).
+			SetPrevHash(e.PrevHash).
+			SetHash(e.Hash).
+			Save(ctx)
+		if err == nil {
+			if _, err := client.ExecContext(ctx, `RELEASE SAVEPOINT audit_entry`); err != nil {
+				return fmt.Errorf(`failed to release savepoint: %w`, err)
+			}
+			e.Sequence = row.ID
+			return nil
+		}
+		if _, rerr := client.ExecContext(ctx, `ROLLBACK TO SAVEPOINT audit_entry`); rerr != nil {
+			return fmt.Errorf(`failed to roll back to savepoint: %s (original error = %w)`, rerr, err)
+		}
+		if !ent.IsConstraintError(err) || attempt == auditAppendAttempts {
+			return fmt.Errorf(`failed to append audit entry: %w`, err)
+		}
+	}
+}
+
+// AuditQuery selects entries of the audit log. Empty fields match every
+// entry, and Since and Until are inclusive
+type AuditQuery struct {
+	ResourceType string
+	ResourceID   string
+	Client       string
+	Since        time.Time
+	Until        time.Time
+
+	// StartIndex is the 1-based index of the first entry to return, and
+	// Count the maximum number of entries (negative if unlimited, up to
+	// WithMaxResults)
+	StartIndex int
+	Count      int
+}
+
+// AuditEntries returns the entries of the audit log matching the query,
+// oldest first. It requires the ScopeAuditRead scope
+func (b *Backend) AuditEntries(ctx context.Context, q *AuditQuery) ([]*AuditEntry, error) {
+	if err := b.authorize(ctx, ScopeAuditRead); err != nil {
+		return nil, err
+	}
+
+	var where []predicate.AuditEntry
+	if q.ResourceType != "" {
+		where = append(where, auditentry.ResourceTypeEQ(q.ResourceType))
+	}
+	if q.ResourceID != "" {
+		where = append(where, auditentry.ResourceIDEQ(q.ResourceID))
+	}
+	if q.Client != "" {
+		where = append(where, auditentry.ClientNameEQ(q.Client))
+	}
+	if !q.Since.IsZero() {
+		where = append(where, auditentry.TimestampGTE(q.Since.UTC()))
+	}
+	if !q.Until.IsZero() {
+		where = append(where, auditentry.TimestampLTE(q.Until.UTC()))
+	}
+
+	count := q.Count
+	if b.maxResults > 0 && (count < 0 || count > b.maxResults) {
+		count = b.maxResults
+	}
+	offset := 0
+	if q.StartIndex > 1 {
+		offset = q.StartIndex - 1
+	}
+
+	query := b.db.AuditEntry.Query().
+		Where(where...).
+		Order(ent.Asc(auditentry.FieldID)).
+		Offset(offset)
+	if count >= 0 {
+		query.Limit(count)
+	}
+	rows, err := query.All(ctx)
+	if err != nil {
+		return nil, internalError(fmt.Errorf(`failed to query audit log: %w`, err))
+	}
+
+	list := make([]*AuditEntry, len(rows))
+	for i, row := range rows {
+		list[i] = auditEntryFromEnt(row)
+	}
+	return list, nil
+}
+
+// AuditVerification is the result of VerifyAuditLog
+type AuditVerification struct {
+	// Entries is the number of entries that were verified
+	Entries int `json:"entries"`
+
+	// Head is the hash of the last valid entry. Keeping a copy of it
+	// allows detecting the removal of the entries that follow
+	Head string `json:"head"`
+
+	// Broken is the sequence number of the first entry that does not
+	// match the chain, or 0 if the whole log is valid
+	Broken int    `json:"broken,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+const auditVerifyBatchSize = 1000
+
+// VerifyAuditLog checks that every entry of the audit log matches its
+// hash, and is chained to the previous entry. It does not require a
+// Backend, so that the log can be verified without modifying the
+// database
+func VerifyAuditLog(ctx context.Context, client *ent.Client) (*AuditVerification, error) {
+	res := AuditVerification{Head: auditGenesis}
+	after := 0
+	for {
+		rows, err := client.AuditEntry.Query().
+			Where(auditentry.IDGT(after)).
+			Order(ent.Asc(auditentry.FieldID)).
+			Limit(auditVerifyBatchSize).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to read audit log: %w`, err)
+		}
+		if len(rows) == 0 {
+			return &res, nil
+		}
+
+		for _, row := range rows {
+			e := auditEntryFromEnt(row)
+			switch {
+			case e.PrevHash != res.Head:
+				res.Broken, res.Reason = e.Sequence, `previous hash does not match the preceding entry`
+			case auditHash(e) != e.Hash:
+				res.Broken, res.Reason = e.Sequence, `hash does not match the contents of the entry`
+			}
+			if res.Broken != 0 {
+				return &res, nil
+			}
+			res.Entries++
+			res.Head = e.Hash
+			after = e.Sequence
+		}
+	}
+}
+
+// VerifyAuditLog checks the audit log of the Backend (see the function
+// of the same name). It requires the ScopeAuditRead scope
+func (b *Backend) VerifyAuditLog(ctx context.Context) (*AuditVerification, error) {
+	if err := b.authorize(ctx, ScopeAuditRead); err != nil {
+		return nil, err
+	}
+	res, err := VerifyAuditLog(ctx, b.db)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return res, nil
+}
+
+// auditAttributes lists the attribute paths set or removed by the payload
+// of an operation, without their values
+func auditAttributes(v interface{}) ([]string, error) {
+	set := make(map[string]struct{})
+	switch v := v.(type) {
+	case nil:
+	case *resource.PatchRequest:
+		for _, op := range v.Operations() {
+			if path := op.Path(); path != "" {
+				set[redactFilterValues(path)] = struct{}{}
+				continue
+			}
+			var value map[string]interface{}
+			if err := json.Unmarshal(op.Value(), &value); err == nil {
+				resourcePaths(value, set)
+			}
+		}
+	default:
+		buf, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to encode payload for audit log: %w`, err)
+		}
+		var in map[string]interface{}
+		if err := json.Unmarshal(buf, &in); err != nil {
+			return nil, fmt.Errorf(`failed to decode payload for audit log: %w`, err)
+		}
+		resourcePaths(in, set)
+	}
+
+	if len(set) == 0 {
+		return nil, nil
+	}
+	list := make([]string, 0, len(set))
+	for path := range set {
+		list = append(list, path)
+	}
+	sort.Strings(list)
+	return list, nil
+}
+
+// resourcePaths adds the paths of the attributes of a resource to set.
+// Sub-attributes of complex attributes are listed individually, as well
+// as the attributes of schema extensions (as "urn:...:User:manager")
+func resourcePaths(in map[string]interface{}, set map[string]struct{}) {
+	for name, v := range in {
+		switch name {
+		case `schemas`, `id`, `meta`:
+			continue
+		}
+
+		sep := `.`
+		if strings.HasPrefix(strings.ToLower(name), `urn:`) {
+			sep = `:`
+		}
+		sub, ok := v.(map[string]interface{})
+		if !ok || len(sub) == 0 {
+			set[name] = struct{}{}
+			continue
+		}
+		for subName, subValue := range sub {
+			path := name + sep + subName
+			if sep == `:` {
+				if complex, ok := subValue.(map[string]interface{}); ok && len(complex) > 0 {
+					for k := range complex {
+						set[path+`.`+k] = struct{}{}
+					}
+					continue
+				}
+			}
+			set[path] = struct{}{}
+		}
+	}
+}
+
+// redactFilterValues replaces the literal values of the filters in a
+// PATCH path, which may hold the values of any attribute
+func redactFilterValues(path string) string {
+	if !strings.ContainsRune(path, '[') {
+		return path
+	}
+
+	tmpl, _, ok := filterTemplate(path)
+	if !ok {
+		return path[:strings.IndexByte(path, '[')] + `[` + redactedValue + `]`
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != 0 {
+			buf.WriteByte(tmpl[i])
+			continue
+		}
+		// skip the type of the placeholder
+		i++
+		buf.WriteString(redactedValue)
+	}
+	return buf.String()
+}
+
+// AuditLogHandler returns an http.Handler serving the audit log for GET
+// requests. Entries are selected by the "resourceType", "resourceId",
+// "client", "since" and "until" (RFC3339 timestamps) query parameters,
+// and paginated by "startIndex" and "count" (see AuditEntries). The
+// "verify" parameter serves the result of VerifyAuditLog instead. It
+// must be wrapped with Authenticate
+func AuditLogHandler(b *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set(`Allow`, http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		params := r.URL.Query()
+		var v interface{}
+		var err error
+		if params.Has(`verify`) {
+			v, err = b.VerifyAuditLog(r.Context())
+		} else {
+			q := AuditQuery{
+				ResourceType: params.Get(`resourceType`),
+				ResourceID:   params.Get(`resourceId`),
+				Client:       params.Get(`client`),
+				StartIndex:   1,
+				Count:        -1,
+			}
+			err = parseAuditParams(params.Get, &q)
+			if err == nil {
+				v, err = b.AuditEntries(r.Context(), &q)
+			}
+		}
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+
+		w.Header().Set(`Content-Type`, `application/json`)
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
+
+func parseAuditParams(get func(string) string, q *AuditQuery) error {
+	for name, dst := range map[string]*time.Time{`since`: &q.Since, `until`: &q.Until} {
+		v := get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return invalidValue(fmt.Errorf(`invalid %s: %w`, name, err))
+		}
+		*dst = t
+	}
+	for name, dst := range map[string]*int{`startIndex`: &q.StartIndex, `count`: &q.Count} {
+		v := get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return invalidValue(fmt.Errorf(`invalid %s: %w`, name, err))
+		}
+		*dst = n
+	}
+	return nil
+}
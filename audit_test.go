@@ -0,0 +1,178 @@
+package server_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	ctx := context.Background()
+	hr := as("hr", server.ScopeUsersWrite, server.ScopeGroupsWrite)
+
+	// entries lists the audit log as "status operation resourceType"
+	// (with the attributes, if any)
+	entries := func(t *testing.T, b *server.Backend) []string {
+		t.Helper()
+
+		list, err := b.AuditEntries(ctx, &server.AuditQuery{Count: -1})
+		require.NoError(t, err, `AuditEntries should succeed`)
+		entries := make([]string, len(list))
+		for i, e := range list {
+			outcome := "success"
+			if e.Status >= http.StatusBadRequest {
+				outcome = "failure"
+			}
+			require.Equal(t, outcome, e.Outcome, `outcome should match the status`)
+			entries[i] = strconv.Itoa(e.Status) + ` ` + string(e.Operation) + ` ` + e.ResourceType
+			if len(e.Attributes) > 0 {
+				entries[i] += ` ` + strings.Join(e.Attributes, `,`)
+			}
+		}
+		return entries
+	}
+
+	t.Run("operations", func(t *testing.T) {
+		b := newBackend(t, server.WithAudit(true), server.WithSoftDelete(time.Hour))
+
+		var u resource.User
+		decode(t, `{"userName":"alice","password":"s3cr3t","emails":[{"value":"alice@example.com"}]}`, &u)
+		alice, err := b.CreateUser(hr, &u)
+		require.NoError(t, err, `CreateUser should succeed`)
+
+		var patch resource.PatchRequest
+		decode(t, `{"Operations":[{"op":"add","path":"title","value":"Engineer"},{"op":"remove","path":"emails[value eq \"alice@example.com\"]"}]}`, &patch)
+		_, err = b.PatchUser(hr, alice.ID(), &patch)
+		require.NoError(t, err, `PatchUser should succeed`)
+
+		decode(t, `{"userName":"alice","displayName":"Alice"}`, &u)
+		_, err = b.ReplaceUser(hr, alice.ID(), &u)
+		require.NoError(t, err, `ReplaceUser should succeed`)
+
+		var g resource.Group
+		decode(t, `{"displayName":"staff"}`, &g)
+		_, _, err = b.UpsertGroup(hr, &g)
+		require.NoError(t, err, `UpsertGroup should succeed`)
+
+		require.NoError(t, b.DeleteUser(hr, alice.ID()), `DeleteUser should succeed`)
+		_, err = b.RestoreUser(hr, alice.ID())
+		require.NoError(t, err, `RestoreUser should succeed`)
+
+		require.Equal(t, []string{
+			`201 create User emails,password,userName`,
+			`200 patch User emails[value eq "[REDACTED]"],title`,
+			`200 replace User displayName,userName`,
+			`201 create Group displayName`,
+			`204 delete User`,
+			`200 restore User`,
+		}, entries(t, b), `successful operations should be recorded`)
+
+		list, err := b.AuditEntries(ctx, &server.AuditQuery{ResourceID: alice.ID(), Count: -1})
+		require.NoError(t, err, `AuditEntries should succeed`)
+		require.Len(t, list, 5, `entries should be selected by resource`)
+		for _, e := range list {
+			require.Equal(t, "hr", e.Client, `client should be recorded`)
+		}
+	})
+	t.Run("failed operations", func(t *testing.T) {
+		b := newBackend(t, server.WithAudit(true))
+		ids := createUsers(t, b, `{"userName":"alice"}`)
+		sales := as("sales", server.ScopeUsersRead)
+
+		var u resource.User
+		decode(t, `{"userName":"bob","password":"s3cr3t"}`, &u)
+		_, err := b.CreateUser(sales, &u)
+		requireSCIMError(t, err, http.StatusForbidden)
+		_, _, err = b.UpsertUser(sales, &u)
+		requireSCIMError(t, err, http.StatusForbidden)
+
+		decode(t, `{"userName":"alice"}`, &u)
+		_, err = b.CreateUser(hr, &u)
+		requireSCIMError(t, err, http.StatusConflict)
+
+		var patch resource.PatchRequest
+		decode(t, `{"Operations":[{"op":"replace","path":"title","value":"Engineer"}]}`, &patch)
+		_, err = b.PatchUser(hr, ids["alice"], &patch)
+		requireSCIMError(t, err, http.StatusBadRequest)
+
+		requireSCIMError(t, b.DeleteUser(hr, "00000000-0000-0000-0000-000000000000"), http.StatusNotFound)
+
+		require.Equal(t, []string{
+			`201 create User userName`,
+			`403 create User password,userName`,
+			`403 upsert User password,userName`,
+			`409 create User userName`,
+			`400 patch User title`,
+			`404 delete User`,
+		}, entries(t, b), `failed operations should be recorded`)
+
+		list, err := b.AuditEntries(ctx, &server.AuditQuery{Client: "sales", Count: -1})
+		require.NoError(t, err, `AuditEntries should succeed`)
+		require.Len(t, list, 2, `client of denied operations should be recorded`)
+		res, err := b.VerifyAuditLog(ctx)
+		require.NoError(t, err, `VerifyAuditLog should succeed`)
+		require.Zero(t, res.Broken, `failures should be chained`)
+	})
+	t.Run("unavailable log", func(t *testing.T) {
+		b := newBackend(t, server.WithAudit(true))
+		db, err := sql.Open("sqlite3", "file:"+strings.ReplaceAll(t.Name(), "/", "_")+"?mode=memory&cache=shared&_fk=1")
+		require.NoError(t, err, `sql.Open should succeed`)
+		defer db.Close()
+
+		_, err = db.Exec(`DROP TABLE audit_entries`)
+		require.NoError(t, err, `audit_entries should be dropped`)
+
+		var u resource.User
+		decode(t, `{"userName":"alice"}`, &u)
+		_, err = b.CreateUser(hr, &u)
+		requireSCIMError(t, err, http.StatusInternalServerError)
+		require.Empty(t, searchUserNames(t, b, `userName pr`), `operations should fail if they cannot be recorded`)
+	})
+	t.Run("verification", func(t *testing.T) {
+		b := newBackend(t, server.WithAudit(true))
+		db, err := sql.Open("sqlite3", "file:"+strings.ReplaceAll(t.Name(), "/", "_")+"?mode=memory&cache=shared&_fk=1")
+		require.NoError(t, err, `sql.Open should succeed`)
+		defer db.Close()
+
+		createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`, `{"userName":"carol"}`, `{"userName":"dave"}`)
+		list, err := b.AuditEntries(ctx, &server.AuditQuery{Count: -1})
+		require.NoError(t, err, `AuditEntries should succeed`)
+
+		res, err := b.VerifyAuditLog(ctx)
+		require.NoError(t, err, `VerifyAuditLog should succeed`)
+		require.Equal(t, &server.AuditVerification{Entries: 4, Head: list[3].Hash}, res, `audit log should be valid`)
+
+		_, err = db.Exec(`UPDATE audit_entries SET client_name = 'mallory' WHERE id = ?`, list[1].Sequence)
+		require.NoError(t, err, `entry should be modified`)
+		res, err = b.VerifyAuditLog(ctx)
+		require.NoError(t, err, `VerifyAuditLog should succeed`)
+		require.Equal(t, list[1].Sequence, res.Broken, `modified entries should be detected`)
+		require.Equal(t, 1, res.Entries, `entries before the modified one should be valid`)
+		require.Equal(t, list[0].Hash, res.Head, `head should be the last valid entry`)
+		require.Contains(t, res.Reason, `contents`, `reason should be reported`)
+
+		_, err = db.Exec(`UPDATE audit_entries SET client_name = ? WHERE id = ?`, list[1].Client, list[1].Sequence)
+		require.NoError(t, err, `entry should be restored`)
+		_, err = db.Exec(`DELETE FROM audit_entries WHERE id = ?`, list[2].Sequence)
+		require.NoError(t, err, `entry should be removed`)
+		res, err = b.VerifyAuditLog(ctx)
+		require.NoError(t, err, `VerifyAuditLog should succeed`)
+		require.Equal(t, list[3].Sequence, res.Broken, `removed entries should be detected`)
+		require.Contains(t, res.Reason, `previous hash`, `reason should be reported`)
+
+		// new entries are chained to the last one, even if the chain is
+		// broken before
+		createUsers(t, b, `{"userName":"erin"}`)
+		last, err := b.AuditEntries(ctx, &server.AuditQuery{StartIndex: 4, Count: 1})
+		require.NoError(t, err, `AuditEntries should succeed`)
+		require.Equal(t, list[3].Hash, last[0].PrevHash, `new entries should be chained to the last one`)
+	})
+}
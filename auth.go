@@ -0,0 +1,236 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cybozu-go/scim/resource"
+)
+
+// Scope names a class of operations that a provisioning client
+// is allowed to perform against the Backend.
+type Scope string
+
+const (
+	ScopeUsersRead   Scope = `users:read`
+	ScopeUsersWrite  Scope = `users:write`
+	ScopeGroupsRead  Scope = `groups:read`
+	ScopeGroupsWrite Scope = `groups:write`
+	ScopeBulk        Scope = `bulk`
+	ScopeAuditRead   Scope = `audit:read`
+	ScopeAdmin       Scope = `admin`
+)
+
+var knownScopes = map[Scope]struct{}{
+	ScopeUsersRead:   {},
+	ScopeUsersWrite:  {},
+	ScopeGroupsRead:  {},
+	ScopeGroupsWrite: {},
+	ScopeBulk:        {},
+	ScopeAuditRead:   {},
+	ScopeAdmin:       {},
+}
+
+// Valid reports if s is one of the scopes defined by this package
+func (s Scope) Valid() bool {
+	_, ok := knownScopes[s]
+	return ok
+}
+
+// Principal represents an authenticated provisioning client, along with
+// the scopes that it has been granted.
+type Principal struct {
+	name   string
+	scopes map[Scope]struct{}
+	policy *AttributePolicy
+}
+
+func NewPrincipal(name string, scopes ...Scope) *Principal {
+	p := &Principal{
+		name:   name,
+		scopes: make(map[Scope]struct{}, len(scopes)),
+	}
+	for _, s := range scopes {
+		p.scopes[s] = struct{}{}
+	}
+	return p
+}
+
+func (p *Principal) Name() string {
+	return p.name
+}
+
+func (p *Principal) HasScope(s Scope) bool {
+	_, ok := p.scopes[s]
+	return ok
+}
+
+// Scopes returns the list of scopes granted to this principal, sorted
+func (p *Principal) Scopes() []Scope {
+	list := make([]Scope, 0, len(p.scopes))
+	for s := range p.scopes {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a new context that carries the given principal.
+// Backend methods called with this context are authorized against
+// the scopes granted to the principal.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal associated with the context, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// authorize checks if the principal associated with the context has been
+// granted the given scope.
+//
+// Contexts that do not carry a principal are assumed to originate from
+// a trusted, in-process caller (e.g. a program embedding the Backend directly).
+// Requests coming in via HTTP are expected to go through Authenticate, which
+// guarantees that a principal is always present.
+func (b *Backend) authorize(ctx context.Context, scope Scope) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.HasScope(scope) {
+		return nil
+	}
+	return forbidden(p, scope)
+}
+
+func forbidden(p *Principal, scope Scope) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusForbidden).
+		ScimType(resource.ErrUnknown).
+		Detail(fmt.Sprintf(`client %q is not allowed to perform this operation (requires scope %q)`, p.Name(), scope)).
+		MustBuild()
+}
+
+// canRead reports if the principal associated with the context (if any) is
+// allowed to read the given scope. Unlike authorize, this does not
+// generate an error, and is used to narrow down the search domain
+func (b *Backend) canRead(ctx context.Context, scope Scope) bool {
+	return b.authorize(ctx, scope) == nil
+}
+
+// Authenticator resolves the provisioning client that sent a request.
+type Authenticator interface {
+	// Authenticate returns the principal associated with the request.
+	// The boolean return value should be false if the request
+	// does not contain credentials that this authenticator understands,
+	// or if the credentials are not valid.
+	Authenticate(*http.Request) (*Principal, bool)
+}
+
+// BearerTokens is an Authenticator that maps static OAuth bearer tokens
+// (RFC 6750) to principals
+type BearerTokens map[string]*Principal
+
+func (t BearerTokens) Authenticate(r *http.Request) (*Principal, bool) {
+	v := r.Header.Get(`Authorization`)
+	const prefix = `bearer `
+	if len(v) <= len(prefix) || !strings.EqualFold(v[:len(prefix)], prefix) {
+		return nil, false
+	}
+
+	p, ok := t[strings.TrimSpace(v[len(prefix):])]
+	return p, ok
+}
+
+// Authenticate wraps an http.Handler (usually the SCIM server handler) so
+// that every request is required to be authenticated by one of the given
+// authenticators. The resolved principal is attached to the request context
+// so that the Backend can authorize each operation.
+//
+// Requests that cannot be authenticated are rejected with a 401 status.
+// Bulk requests (POST /Bulk) are rejected with a 403 status unless the
+// principal has been granted ScopeBulk, on top of the scopes required by
+// each of their operations. They are recognized by the last segment of
+// their path, so that the handler can be mounted under a prefix (such as
+// /scim/v2/) with or without http.StripPrefix.
+func Authenticate(h http.Handler, authenticators ...Authenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, a := range authenticators {
+			if p, ok := a.Authenticate(r); ok {
+				if isBulkRequest(r) && !p.HasScope(ScopeBulk) {
+					writeError(w, http.StatusForbidden, forbidden(p, ScopeBulk))
+					return
+				}
+				h.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), p)))
+				return
+			}
+		}
+
+		w.Header().Set(`WWW-Authenticate`, `Bearer realm="scim"`)
+		writeError(w, http.StatusUnauthorized, resource.NewErrorBuilder().
+			Status(http.StatusUnauthorized).
+			ScimType(resource.ErrUnknown).
+			Detail(`authentication required`).
+			MustBuild())
+	})
+}
+
+// RequireScope wraps an http.Handler so that only requests whose principal
+// has been granted the given scope are passed to it. Others are rejected
+// with a 403 status, or with a 401 status if the request does not carry
+// a principal at all. It must be placed behind Authenticate.
+func RequireScope(h http.Handler, scope Scope) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			writeError(w, http.StatusUnauthorized, resource.NewErrorBuilder().
+				Status(http.StatusUnauthorized).
+				ScimType(resource.ErrUnknown).
+				Detail(`authentication required`).
+				MustBuild())
+			return
+		}
+		if !p.HasScope(scope) {
+			writeError(w, http.StatusForbidden, forbidden(p, scope))
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// isBulkRequest reports if r is a request to the Bulk endpoint
+// (RFC7644 Section 3.7), whatever the prefix of the SCIM endpoints
+func isBulkRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && path.Base(strings.TrimSuffix(r.URL.Path, `/`)) == `Bulk`
+}
+
+// writeError writes a SCIM error response. It is used by handlers that
+// respond to clients without going through the SCIM server
+func writeError(w http.ResponseWriter, status int, serr error) {
+	buf, err := json.Marshal(serr)
+	if err != nil {
+		http.Error(w, serr.Error(), status)
+		return
+	}
+
+	w.Header().Set(`Content-Type`, `application/scim+json`)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf)
+}
+
+// writeSCIMError writes the response for an error returned by the
+// Backend. Errors other than SCIM errors are reported as internal errors
+func writeSCIMError(w http.ResponseWriter, err error) {
+	serr, ok := asSCIMError(err)
+	if !ok {
+		serr, _ = asSCIMError(internalError(err))
+	}
+	writeError(w, serr.Status(), serr)
+}
@@ -0,0 +1,202 @@
+package server_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tokens := server.BearerTokens{
+		"hr-reporting": server.NewPrincipal("hr", server.ScopeUsersRead),
+	}
+
+	var seen *server.Principal
+	h := server.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = server.PrincipalFromContext(r.Context())
+	}), tokens)
+
+	t.Run("valid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/Users", nil)
+		req.Header.Set("Authorization", "Bearer hr-reporting")
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, `status should be 200`)
+		require.NotNil(t, seen, `principal should be attached to the context`)
+		require.Equal(t, "hr", seen.Name(), `principal name should match`)
+		require.True(t, seen.HasScope(server.ScopeUsersRead), `principal should have users:read`)
+		require.False(t, seen.HasScope(server.ScopeUsersWrite), `principal should not have users:write`)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/Users", nil)
+		req.Header.Set("Authorization", "Bearer bogus")
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code, `status should be 401`)
+	})
+	t.Run("no credentials", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/Users", nil)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusUnauthorized, rr.Code, `status should be 401`)
+	})
+}
+
+func TestAuthenticateBulk(t *testing.T) {
+	tokens := server.BearerTokens{
+		"hr-connector": server.NewPrincipal("hr", server.ScopeUsersWrite),
+		"bulk-loader":  server.NewPrincipal("loader", server.ScopeUsersWrite, server.ScopeBulk),
+	}
+	h := server.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), tokens)
+
+	request := func(token, path string) int {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, request("hr-connector", "/Bulk"), `bulk request without the bulk scope should be rejected`)
+	require.Equal(t, http.StatusOK, request("bulk-loader", "/Bulk"), `bulk request with the bulk scope should be accepted`)
+	require.Equal(t, http.StatusOK, request("hr-connector", "/Users"), `other requests should not require the bulk scope`)
+
+	// handlers mounted under a prefix see the full path
+	mux := http.NewServeMux()
+	mux.Handle("/scim/v2/", h)
+	h = mux
+	require.Equal(t, http.StatusForbidden, request("hr-connector", "/scim/v2/Bulk"), `prefixed bulk request without the bulk scope should be rejected`)
+	require.Equal(t, http.StatusForbidden, request("hr-connector", "/scim/v2/Bulk/"), `prefixed bulk request without the bulk scope should be rejected`)
+	require.Equal(t, http.StatusOK, request("bulk-loader", "/scim/v2/Bulk"), `prefixed bulk request with the bulk scope should be accepted`)
+	require.Equal(t, http.StatusOK, request("hr-connector", "/scim/v2/Users"), `other prefixed requests should not require the bulk scope`)
+}
+
+func TestRequireScope(t *testing.T) {
+	tokens := server.BearerTokens{
+		"hr-connector": server.NewPrincipal("hr", server.ScopeUsersRead, server.ScopeUsersWrite),
+		"operator":     server.NewPrincipal("operator", server.ScopeAdmin),
+	}
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := server.Authenticate(server.RequireScope(inner, server.ScopeAdmin), tokens)
+
+	request := func(h http.Handler, token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/_metrics", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, request(h, "hr-connector"), `client without the admin scope should be rejected`)
+	require.Equal(t, http.StatusOK, request(h, "operator"), `client with the admin scope should be accepted`)
+	require.Equal(t, http.StatusUnauthorized, request(h, ""), `unauthenticated request should be rejected`)
+	require.Equal(t, http.StatusUnauthorized, request(server.RequireScope(inner, server.ScopeAdmin), ""), `request without a principal should be rejected`)
+}
+
+func TestScopeValid(t *testing.T) {
+	for _, s := range []server.Scope{server.ScopeUsersRead, server.ScopeUsersWrite, server.ScopeGroupsRead, server.ScopeGroupsWrite, server.ScopeBulk, server.ScopeAuditRead, server.ScopeAdmin} {
+		require.True(t, s.Valid(), `%q should be valid`, s)
+	}
+	for _, s := range []server.Scope{"", "users", "user:read", "Admin"} {
+		require.False(t, s.Valid(), `%q should not be valid`, s)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	b := newBackend(t)
+
+	var in resource.User
+	decode(t, `{"userName":"alice"}`, &in)
+
+	_, err := b.CreateUser(as("reporting", server.ScopeUsersRead), &in)
+	requireSCIMError(t, err, http.StatusForbidden)
+
+	u, err := b.CreateUser(as("hr", server.ScopeUsersWrite), &in)
+	require.NoError(t, err, `CreateUser should succeed with users:write`)
+
+	_, err = b.RetrieveUser(as("hr", server.ScopeUsersWrite), u.ID(), nil, nil)
+	requireSCIMError(t, err, http.StatusForbidden)
+
+	_, err = b.RetrieveUser(as("reporting", server.ScopeUsersRead), u.ID(), nil, nil)
+	require.NoError(t, err, `RetrieveUser should succeed with users:read`)
+
+	err = b.DeleteUser(as("groups", server.ScopeGroupsWrite), u.ID())
+	requireSCIMError(t, err, http.StatusForbidden)
+
+	// in-process callers are not restricted
+	_, err = b.RetrieveUser(context.Background(), u.ID(), nil, nil)
+	require.NoError(t, err, `RetrieveUser should succeed without a principal`)
+}
+
+func TestClientCertificates(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:                 pkix.Name{CommonName: "hr-connector", Organization: []string{"Example"}},
+		DNSNames:                []string{"connector.example.com"},
+		RawSubjectPublicKeyInfo: []byte("dummy public key"),
+	}
+
+	request := func(cert *x509.Certificate) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/Users", nil)
+		if cert != nil {
+			req.TLS = &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			}
+		}
+		return req
+	}
+
+	t.Run("subject", func(t *testing.T) {
+		certs := server.NewClientCertificates().
+			MapSubject("CN=hr-connector,O=Example", server.NewPrincipal("hr"))
+		p, ok := certs.Authenticate(request(cert))
+		require.True(t, ok, `certificate should be accepted`)
+		require.Equal(t, "hr", p.Name(), `principal name should match`)
+	})
+	t.Run("SAN", func(t *testing.T) {
+		certs := server.NewClientCertificates().
+			MapSAN("Connector.example.com", server.NewPrincipal("hr"))
+		_, ok := certs.Authenticate(request(cert))
+		require.True(t, ok, `certificate should be accepted`)
+	})
+	t.Run("fingerprint", func(t *testing.T) {
+		fp := server.SPKIFingerprint(cert)
+		pairs := make([]string, 0, len(fp)/2)
+		for i := 0; i < len(fp); i += 2 {
+			pairs = append(pairs, fp[i:i+2])
+		}
+		for _, format := range []string{
+			fp,
+			strings.ToUpper(fp),
+			strings.Join(pairs, ":"),
+			strings.ToUpper(strings.Join(pairs, ":")),
+		} {
+			certs := server.NewClientCertificates().
+				MapFingerprint(format, server.NewPrincipal("hr"))
+			_, ok := certs.Authenticate(request(cert))
+			require.True(t, ok, `certificate should be accepted for fingerprint %s`, format)
+		}
+	})
+	t.Run("unknown certificate", func(t *testing.T) {
+		certs := server.NewClientCertificates().
+			MapSubject("CN=someone-else", server.NewPrincipal("hr"))
+		_, ok := certs.Authenticate(request(cert))
+		require.False(t, ok, `certificate should be rejected`)
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		certs := server.NewClientCertificates().
+			MapSubject("CN=hr-connector,O=Example", server.NewPrincipal("hr"))
+		_, ok := certs.Authenticate(request(nil))
+		require.False(t, ok, `request should be rejected`)
+	})
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type baseURLKey struct{}
+
+// WithRequestBaseURL returns a new context that carries the base URL
+// to be used when building locations and references for the resources
+// returned in response to a request. This overrides the base URL
+// specified by WithBaseURL.
+func WithRequestBaseURL(ctx context.Context, u string) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, strings.TrimSuffix(u, `/`))
+}
+
+// baseURLFor returns the base URL used to build locations and references
+// for the request associated with the context
+func (b *Backend) baseURLFor(ctx context.Context) string {
+	if u, ok := ctx.Value(baseURLKey{}).(string); ok && u != "" {
+		return u
+	}
+	return b.baseURL
+}
+
+// TrustForwardedHeaders wraps an http.Handler so that the base URL of
+// each request is derived from the headers set by a reverse proxy:
+// the standard Forwarded header (RFC7239), or X-Forwarded-Proto and
+// X-Forwarded-Host. basePath is the path under which the SCIM endpoints
+// are served, such as "/scim/v2".
+//
+// As these headers can be set by anybody, they are only honored when the
+// request comes directly from one of the trusted networks. Requests from
+// other peers, or requests without forwarding headers, use the base URL
+// configured for the Backend.
+func TrustForwardedHeaders(h http.Handler, basePath string, trusted ...*net.IPNet) http.Handler {
+	basePath = strings.TrimSuffix(basePath, `/`)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isTrustedPeer(r.RemoteAddr, trusted) {
+			if proto, host, ok := forwardedOrigin(r); ok {
+				r = r.WithContext(WithRequestBaseURL(r.Context(), proto+`://`+host+basePath))
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func isTrustedPeer(remoteAddr string, trusted []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedOrigin extracts the scheme and host that the client used to
+// reach the reverse proxy. Only the first (i.e. closest to the client)
+// element of the Forwarded header is considered
+func forwardedOrigin(r *http.Request) (string, string, bool) {
+	var proto, host string
+	if v := r.Header.Get(`Forwarded`); v != "" {
+		if i := strings.IndexByte(v, ','); i > -1 {
+			v = v[:i]
+		}
+		for _, pair := range strings.Split(v, `;`) {
+			kv := strings.SplitN(strings.TrimSpace(pair), `=`, 2)
+			if len(kv) != 2 {
+				continue
+			}
+			value := strings.Trim(kv[1], `"`)
+			switch strings.ToLower(kv[0]) {
+			case `proto`:
+				proto = value
+			case `host`:
+				host = value
+			}
+		}
+	} else {
+		proto = firstValue(r.Header.Get(`X-Forwarded-Proto`))
+		host = firstValue(r.Header.Get(`X-Forwarded-Host`))
+	}
+
+	if host == "" {
+		return "", "", false
+	}
+
+	proto = strings.ToLower(proto)
+	switch proto {
+	case "":
+		proto = `https`
+	case `http`, `https`:
+	default:
+		return "", "", false
+	}
+
+	// Make sure that the header cannot be used to inject a path or
+	// otherwise modify the URL beyond the host part
+	if strings.ContainsAny(host, "/?#@\\ \t") {
+		return "", "", false
+	}
+	return proto, host, true
+}
+
+func firstValue(v string) string {
+	if i := strings.IndexByte(v, ','); i > -1 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
@@ -0,0 +1,128 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseURL(t *testing.T) {
+	check := func(t *testing.T, ctx context.Context, base string) {
+		t.Helper()
+
+		b := newBackend(t, server.WithBaseURL("https://scim.example.com/scim/v2"))
+
+		var in resource.User
+		decode(t, `{"userName":"alice"}`, &in)
+		u, err := b.CreateUser(ctx, &in)
+		require.NoError(t, err, `CreateUser should succeed`)
+		require.Equal(t, base+"/Users/"+u.ID(), u.Meta().Location(), `user location should use the base URL`)
+
+		var gin resource.Group
+		decode(t, `{"displayName":"staff","members":[{"value":"`+u.ID()+`"}]}`, &gin)
+		g, err := b.CreateGroup(ctx, &gin)
+		require.NoError(t, err, `CreateGroup should succeed`)
+		require.Equal(t, base+"/Groups/"+g.ID(), g.Meta().Location(), `group location should use the base URL`)
+		require.Len(t, g.Members(), 1, `group should have one member`)
+		require.Equal(t, base+"/Users/"+u.ID(), g.Members()[0].Ref(), `member $ref should use the base URL`)
+
+		u, err = b.RetrieveUser(ctx, u.ID(), nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Len(t, u.Groups(), 1, `user should belong to one group`)
+		require.Equal(t, base+"/Groups/"+g.ID(), u.Groups()[0].Ref(), `group $ref should use the base URL`)
+
+		rts, err := b.RetrieveResourceTypes(ctx)
+		require.NoError(t, err, `RetrieveResourceTypes should succeed`)
+		for _, rt := range rts {
+			require.Equal(t, base+"/ResourceTypes/"+rt.Name(), rt.Meta().Location(), `resource type location should use the base URL`)
+		}
+	}
+
+	t.Run("configured", func(t *testing.T) {
+		check(t, context.Background(), "https://scim.example.com/scim/v2")
+	})
+	t.Run("per request", func(t *testing.T) {
+		ctx := server.WithRequestBaseURL(context.Background(), "https://proxy.example.com/scim/v2/")
+		check(t, ctx, "https://proxy.example.com/scim/v2")
+	})
+}
+
+func TestTrustForwardedHeaders(t *testing.T) {
+	_, trusted, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err, `net.ParseCIDR should succeed`)
+
+	b := newBackend(t, server.WithBaseURL("https://scim.example.com/scim/v2"))
+	var in resource.User
+	decode(t, `{"userName":"alice"}`, &in)
+	u, err := b.CreateUser(context.Background(), &in)
+	require.NoError(t, err, `CreateUser should succeed`)
+
+	var location string
+	h := server.TrustForwardedHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, err := b.RetrieveUser(r.Context(), u.ID(), nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		location = u.Meta().Location()
+	}), "/scim/v2", trusted)
+
+	testcases := []struct {
+		Name     string
+		Remote   string
+		Headers  map[string]string
+		Expected string
+	}{
+		{
+			Name:     "Forwarded from a trusted proxy",
+			Remote:   "10.1.2.3:4567",
+			Headers:  map[string]string{"Forwarded": `for=192.0.2.60;proto=http;host="scim.internal:8080", for=10.1.2.3`},
+			Expected: "http://scim.internal:8080/scim/v2",
+		},
+		{
+			Name:     "X-Forwarded from a trusted proxy",
+			Remote:   "10.1.2.3:4567",
+			Headers:  map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "idp.example.org, proxy.local"},
+			Expected: "https://idp.example.org/scim/v2",
+		},
+		{
+			Name:     "untrusted peer",
+			Remote:   "192.0.2.1:4567",
+			Headers:  map[string]string{"X-Forwarded-Host": "evil.example.com"},
+			Expected: "https://scim.example.com/scim/v2",
+		},
+		{
+			Name:     "no headers",
+			Remote:   "10.1.2.3:4567",
+			Expected: "https://scim.example.com/scim/v2",
+		},
+		{
+			Name:     "path injection",
+			Remote:   "10.1.2.3:4567",
+			Headers:  map[string]string{"X-Forwarded-Host": "evil.example.com/x?"},
+			Expected: "https://scim.example.com/scim/v2",
+		},
+		{
+			Name:     "unknown scheme",
+			Remote:   "10.1.2.3:4567",
+			Headers:  map[string]string{"X-Forwarded-Proto": "javascript", "X-Forwarded-Host": "idp.example.org"},
+			Expected: "https://scim.example.com/scim/v2",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/Users/"+u.ID(), nil)
+			req.RemoteAddr = tc.Remote
+			for k, v := range tc.Headers {
+				req.Header.Set(k, v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			require.Equal(t, tc.Expected+"/Users/"+u.ID(), location, `location should match`)
+		})
+	}
+}
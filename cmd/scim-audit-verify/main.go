@@ -0,0 +1,66 @@
+// Command scim-audit-verify checks the hash chain of the audit log
+// recorded by github.com/cybozu-go/scim-server (see server.WithAudit).
+//
+// Usage:
+//
+//	scim-audit-verify -database "file:/var/lib/scim/scim.db?_fk=1"
+//
+// It prints the number of entries and the hash of the last one, and
+// exits with status 1 if an entry was modified, inserted or removed.
+// Comparing the hash with a copy from a previous run detects the removal
+// of the most recent entries.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	entsql "entgo.io/ent/dialect/sql"
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim-server/ent"
+	_ "github.com/cybozu-go/scim-server/ent/runtime"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	valid, err := _main()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(2)
+	}
+	if !valid {
+		os.Exit(1)
+	}
+}
+
+func _main() (bool, error) {
+	var dialect, database string
+	flag.StringVar(&dialect, "dialect", "sqlite3", "SQL dialect of the database")
+	flag.StringVar(&database, "database", "", "connection specification of the database")
+	flag.Parse()
+
+	if database == "" {
+		return false, fmt.Errorf(`-database is required`)
+	}
+
+	drv, err := entsql.Open(dialect, database)
+	if err != nil {
+		return false, fmt.Errorf(`failed to open database: %w`, err)
+	}
+	client := ent.NewClient(ent.Driver(drv))
+	defer client.Close()
+
+	res, err := server.VerifyAuditLog(context.Background(), client)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("entries: %d\nhead: %s\n", res.Entries, res.Head)
+	if res.Broken != 0 {
+		fmt.Printf("broken at entry %d: %s\n", res.Broken, res.Reason)
+		return false, nil
+	}
+	return true, nil
+}
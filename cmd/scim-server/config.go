@@ -0,0 +1,421 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	server "github.com/cybozu-go/scim-server"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration file format for scim-server. As JSON
+// is a subset of YAML, configuration files may be written in either format.
+//
+//	listen: ":8443"
+//	baseURL: "https://scim.example.com/scim/v2"
+//	trustedProxies: ["10.0.0.0/8"]
+//	database: "file:/var/lib/scim/scim.db?_fk=1"
+//	bucket: "file:///var/lib/scim/photos"
+//	fullTextSearch: true
+//	uniqueExternalID: true
+//	idempotencyKeyTTL: 24h
+//	idempotencyKeyLease: 1m
+//	audit: true
+//	softDelete:
+//	  retention: 720h
+//	  purgeInterval: 1h
+//	upsert:
+//	  always: false
+//	  key: externalId
+//	tls:
+//	  certFile: /etc/scim/server.crt
+//	  keyFile: /etc/scim/server.key
+//	  clientCAFile: /etc/scim/clients.crt
+//	auth:
+//	  clients:
+//	    - name: hr-connector
+//	      scopes: ["users:read", "users:write"]
+//	      tokens: ["..."]
+//	      denyWrite:
+//	        User: ["roles"]
+//	    - name: operator
+//	      scopes: ["users:read", "groups:read", "audit:read", "admin"]
+//	      tokens: ["..."]
+//	    - name: directory-sync
+//	      scopes: ["users:read", "groups:read"]
+//	      certificates:
+//	        subjects: ["CN=directory-sync,O=Example"]
+//	limits:
+//	  maxRequestBytes: 1048576
+//	  maxResults: 200
+//	  readTimeout: 30s
+//	  writeTimeout: 30s
+//	admin:
+//	  explain: true
+//	  metrics: true
+//	  history: true
+type Config struct {
+	// Listen is the address that the server listens to. Defaults to ":8080",
+	// or ":8443" when TLS is enabled
+	Listen string `yaml:"listen"`
+
+	// BaseURL is the externally visible URL of the SCIM endpoints.
+	// The SCIM handlers are mounted under the path of this URL
+	BaseURL string `yaml:"baseURL"`
+
+	// TrustedProxies lists the networks (in CIDR notation) of the reverse
+	// proxies whose Forwarded / X-Forwarded-* headers are used to compute
+	// the base URL of each request
+	TrustedProxies []string `yaml:"trustedProxies"`
+
+	// Database is the connection specification passed to the SQLite driver
+	Database string `yaml:"database"`
+
+	// Bucket is the URL of the gocloud.dev blob bucket where
+	// user photos are stored (e.g. "file:///var/lib/scim/photos", "mem://")
+	Bucket string `yaml:"bucket"`
+
+	// PhotoURL is the base URL used to construct the URLs of user photos.
+	// If not specified, photos are served from the bucket by scim-server
+	// itself, under "/photos/" relative to BaseURL. Note that photos
+	// served this way do not require authentication
+	PhotoURL string `yaml:"photoURL"`
+
+	// FullTextSearch enables full-text search over users, served by the
+	// "/_fulltext" endpoint (relative to BaseURL). The server must be
+	// built with the sqlite_fts5 tag
+	FullTextSearch bool `yaml:"fullTextSearch"`
+
+	// UniqueExternalID rejects Users and Groups whose externalId is
+	// already used by another resource provisioned by the same client
+	UniqueExternalID bool `yaml:"uniqueExternalID"`
+
+	// IdempotencyKeyTTL is how long the responses to POST requests sent
+	// with an Idempotency-Key header are kept for replay. Defaults to 24h
+	IdempotencyKeyTTL time.Duration `yaml:"idempotencyKeyTTL"`
+
+	// IdempotencyKeyLease is how long a request holds its Idempotency-Key
+	// while it is being processed, before a retry may take it over.
+	// Defaults to 1m
+	IdempotencyKeyLease time.Duration `yaml:"idempotencyKeyLease"`
+
+	// Audit records the provisioning operations in a hash-chained audit
+	// log, served by the "/_audit" endpoint (relative to BaseURL) to the
+	// clients with the "audit:read" scope, unless authentication is
+	// disabled. The log is checked by the scim-audit-verify command
+	Audit bool `yaml:"audit"`
+
+	TLS        TLSConfig        `yaml:"tls"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Limits     LimitsConfig     `yaml:"limits"`
+	Upsert     UpsertConfig     `yaml:"upsert"`
+	SoftDelete SoftDeleteConfig `yaml:"softDelete"`
+	Admin      AdminConfig      `yaml:"admin"`
+}
+
+type TLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+
+	// ClientCAFile is a PEM file containing the CA certificates used to
+	// verify client certificates. Specifying this enables mutual TLS
+	ClientCAFile string `yaml:"clientCAFile"`
+
+	// RequireClientCert rejects connections without a valid client
+	// certificate at the TLS level, instead of falling back to other
+	// authentication methods
+	RequireClientCert bool `yaml:"requireClientCert"`
+}
+
+func (c *TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+type AuthConfig struct {
+	// Disabled allows unauthenticated access to all operations.
+	// This is only meant for testing
+	Disabled bool           `yaml:"disabled"`
+	Clients  []ClientConfig `yaml:"clients"`
+}
+
+// ClientConfig describes a provisioning client, how it is authenticated,
+// and what it is allowed to do
+type ClientConfig struct {
+	Name         string            `yaml:"name"`
+	Scopes       []string          `yaml:"scopes"`
+	Tokens       []string          `yaml:"tokens"`
+	Certificates CertificateConfig `yaml:"certificates"`
+
+	// DenyRead and DenyWrite list the attributes that the client may not
+	// read or write, keyed by resource type ("User" or "Group")
+	DenyRead  map[string][]string `yaml:"denyRead"`
+	DenyWrite map[string][]string `yaml:"denyWrite"`
+}
+
+type CertificateConfig struct {
+	Subjects     []string `yaml:"subjects"`
+	SANs         []string `yaml:"sans"`
+	Fingerprints []string `yaml:"fingerprints"`
+}
+
+type LimitsConfig struct {
+	// MaxRequestBytes limits the size of request bodies. Defaults to 1MiB
+	MaxRequestBytes int64 `yaml:"maxRequestBytes"`
+
+	// MaxResults is the maximum number of resources returned by
+	// a single search. Defaults to 200
+	MaxResults int `yaml:"maxResults"`
+
+	ReadTimeout  time.Duration `yaml:"readTimeout"`
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+
+	// ShutdownTimeout is the time given to in-flight requests to complete
+	// after SIGTERM is received. Defaults to 30s
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+// SoftDeleteConfig controls the retention of deleted Users and Groups.
+// Deleted resources are listed and restored through the "/_deleted"
+// endpoint (relative to BaseURL)
+type SoftDeleteConfig struct {
+	// Retention is how long deleted resources are kept before being
+	// purged. Resources are deleted immediately if 0 (the default)
+	Retention time.Duration `yaml:"retention"`
+
+	// PurgeInterval is how often expired resources, and expired
+	// idempotency keys, are purged. Defaults to 1h
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+// UpsertConfig controls the creation of resources with upsert semantics.
+// Clients may always ask for an upsert with the X-SCIM-Upsert header
+type UpsertConfig struct {
+	// Always handles every creation of a User or Group as an upsert
+	Always bool `yaml:"always"`
+
+	// Key is the attribute matching the resource to replace: "name"
+	// (userName for Users, displayName for Groups) or "externalId".
+	// It is unique among the resources of each type. Defaults to "name"
+	Key string `yaml:"key"`
+}
+
+// AdminConfig enables the administrative endpoints. These, as well as
+// "/_deleted" and "/_fulltext", are only served to clients that have
+// been granted the "admin" scope, and are not served at all when
+// authentication is disabled
+type AdminConfig struct {
+	// Explain enables the "/_explain" endpoint (relative to BaseURL),
+	// which describes how a filter is translated into SQL queries
+	Explain bool `yaml:"explain"`
+
+	// Metrics enables the "/_metrics" endpoint (relative to BaseURL),
+	// which serves the expvar variables of the server, including the
+	// usage of the cache of compiled filters
+	Metrics bool `yaml:"metrics"`
+
+	// History enables the "/_history" endpoint (relative to BaseURL),
+	// which serves the recorded versions of Users and Groups. It also
+	// requires the scopes needed to read them
+	History bool `yaml:"history"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to read configuration file: %w`, err)
+	}
+
+	var c Config
+	if err := yaml.Unmarshal(buf, &c); err != nil {
+		return nil, fmt.Errorf(`failed to parse configuration file %q: %w`, path, err)
+	}
+
+	if err := c.setDefaults(); err != nil {
+		return nil, fmt.Errorf(`invalid configuration file %q: %w`, path, err)
+	}
+	return &c, nil
+}
+
+func (c *Config) setDefaults() error {
+	if c.Listen == "" {
+		if c.TLS.Enabled() {
+			c.Listen = `:8443`
+		} else {
+			c.Listen = `:8080`
+		}
+	}
+
+	if c.BaseURL == "" {
+		scheme := `http`
+		if c.TLS.Enabled() {
+			scheme = `https`
+		}
+		host := c.Listen
+		if strings.HasPrefix(host, `:`) {
+			host = `localhost` + host
+		}
+		c.BaseURL = scheme + `://` + host
+	}
+	c.BaseURL = strings.TrimSuffix(c.BaseURL, `/`)
+	if _, err := url.Parse(c.BaseURL); err != nil {
+		return fmt.Errorf(`invalid base URL: %w`, err)
+	}
+
+	if c.Database == "" {
+		return fmt.Errorf(`database must be specified`)
+	}
+
+	if c.Bucket == "" {
+		return fmt.Errorf(`bucket must be specified`)
+	}
+
+	if c.TLS.Enabled() && (c.TLS.CertFile == "" || c.TLS.KeyFile == "") {
+		return fmt.Errorf(`both tls.certFile and tls.keyFile must be specified`)
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		return fmt.Errorf(`tls.clientCAFile requires TLS to be enabled`)
+	}
+
+	if !c.Auth.Disabled && len(c.Auth.Clients) == 0 {
+		return fmt.Errorf(`no clients configured (set auth.disabled to allow unauthenticated access)`)
+	}
+	for i, client := range c.Auth.Clients {
+		for _, s := range client.Scopes {
+			if !server.Scope(s).Valid() {
+				return fmt.Errorf(`auth.clients[%d]: unknown scope %q`, i, s)
+			}
+		}
+		certs := client.Certificates
+		if c.TLS.ClientCAFile == "" && len(certs.Subjects)+len(certs.SANs)+len(certs.Fingerprints) > 0 {
+			return fmt.Errorf(`auth.clients[%d]: certificates require tls.clientCAFile to be specified`, i)
+		}
+	}
+
+	switch server.UpsertKey(c.Upsert.Key) {
+	case "":
+		c.Upsert.Key = string(server.UpsertByName)
+	case server.UpsertByName, server.UpsertByExternalID:
+	default:
+		return fmt.Errorf(`invalid upsert.key %q (must be %q or %q)`, c.Upsert.Key, server.UpsertByName, server.UpsertByExternalID)
+	}
+
+	if c.Limits.MaxRequestBytes == 0 {
+		c.Limits.MaxRequestBytes = 1 << 20
+	}
+	if c.Limits.MaxResults == 0 {
+		c.Limits.MaxResults = 200
+	}
+	if c.Limits.ShutdownTimeout == 0 {
+		c.Limits.ShutdownTimeout = 30 * time.Second
+	}
+	switch {
+	case c.SoftDelete.PurgeInterval == 0:
+		c.SoftDelete.PurgeInterval = time.Hour
+	case c.SoftDelete.PurgeInterval < 0:
+		return fmt.Errorf(`invalid softDelete.purgeInterval %s (must be positive)`, c.SoftDelete.PurgeInterval)
+	}
+	if c.IdempotencyKeyTTL == 0 {
+		c.IdempotencyKeyTTL = 24 * time.Hour
+	}
+	if c.IdempotencyKeyLease == 0 {
+		c.IdempotencyKeyLease = time.Minute
+	}
+	return nil
+}
+
+// authenticators builds the list of authenticators from the
+// configured clients
+func (c *AuthConfig) authenticators() ([]server.Authenticator, error) {
+	tokens := make(server.BearerTokens)
+	certs := server.NewClientCertificates()
+	var hasCerts bool
+
+	for i, client := range c.Clients {
+		if client.Name == "" {
+			return nil, fmt.Errorf(`auth.clients[%d]: name must be specified`, i)
+		}
+
+		scopes := make([]server.Scope, len(client.Scopes))
+		for j, s := range client.Scopes {
+			scopes[j] = server.Scope(s)
+		}
+		p := server.NewPrincipal(client.Name, scopes...)
+
+		if len(client.DenyRead) > 0 || len(client.DenyWrite) > 0 {
+			policy := server.NewAttributePolicy()
+			for rt, names := range client.DenyRead {
+				policy.DenyRead(rt, names...)
+			}
+			for rt, names := range client.DenyWrite {
+				policy.DenyWrite(rt, names...)
+			}
+			p.WithAttributePolicy(policy)
+		}
+
+		for _, token := range client.Tokens {
+			if _, ok := tokens[token]; ok {
+				return nil, fmt.Errorf(`auth.clients[%d]: token is already assigned to another client`, i)
+			}
+			tokens[token] = p
+		}
+
+		for _, dn := range client.Certificates.Subjects {
+			certs.MapSubject(dn, p)
+			hasCerts = true
+		}
+		for _, name := range client.Certificates.SANs {
+			certs.MapSAN(name, p)
+			hasCerts = true
+		}
+		for _, fp := range client.Certificates.Fingerprints {
+			certs.MapFingerprint(fp, p)
+			hasCerts = true
+		}
+	}
+
+	var list []server.Authenticator
+	if hasCerts {
+		list = append(list, certs)
+	}
+	if len(tokens) > 0 {
+		list = append(list, tokens)
+	}
+	return list, nil
+}
+
+func (c *TLSConfig) config() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to load server certificate: %w`, err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.ClientCAFile != "" {
+		buf, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to read client CA file: %w`, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, fmt.Errorf(`no certificates found in client CA file %q`, c.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		if c.RequireClientCert {
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		} else {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSetDefaults(t *testing.T) {
+	base := func() *Config {
+		return &Config{
+			Database: "file::memory:?_fk=1",
+			Bucket:   "mem://",
+			Auth: AuthConfig{
+				Clients: []ClientConfig{
+					{Name: "hr-connector", Scopes: []string{"users:read", "users:write"}, Tokens: []string{"token"}},
+				},
+			},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		c := base()
+		require.NoError(t, c.setDefaults(), `setDefaults should succeed`)
+		require.Equal(t, ":8080", c.Listen, `listen address should default to :8080`)
+		require.Equal(t, "http://localhost:8080", c.BaseURL, `base URL should be derived from the listen address`)
+		require.Equal(t, "name", c.Upsert.Key, `upsert key should default to name`)
+	})
+	t.Run("unknown scope", func(t *testing.T) {
+		c := base()
+		c.Auth.Clients[0].Scopes = append(c.Auth.Clients[0].Scopes, "users:delete")
+		err := c.setDefaults()
+		require.Error(t, err, `unknown scopes should be rejected`)
+		require.Contains(t, err.Error(), `"users:delete"`, `error should name the scope`)
+	})
+	t.Run("admin scope", func(t *testing.T) {
+		c := base()
+		c.Auth.Clients[0].Scopes = []string{"admin", "audit:read", "bulk"}
+		require.NoError(t, c.setDefaults(), `known scopes should be accepted`)
+	})
+	t.Run("certificates without client CA", func(t *testing.T) {
+		c := base()
+		c.TLS.CertFile = "server.crt"
+		c.TLS.KeyFile = "server.key"
+		c.Auth.Clients[0].Certificates.Fingerprints = []string{"0123"}
+		require.Error(t, c.setDefaults(), `certificate mappings without tls.clientCAFile should be rejected`)
+
+		c = base()
+		c.TLS.CertFile = "server.crt"
+		c.TLS.KeyFile = "server.key"
+		c.TLS.ClientCAFile = "clients.crt"
+		c.Auth.Clients[0].Certificates.Subjects = []string{"CN=hr-connector"}
+		require.NoError(t, c.setDefaults(), `certificate mappings with tls.clientCAFile should be accepted`)
+	})
+	t.Run("purge interval", func(t *testing.T) {
+		c := base()
+		require.NoError(t, c.setDefaults(), `setDefaults should succeed`)
+		require.Equal(t, time.Hour, c.SoftDelete.PurgeInterval, `purge interval should default to 1h`)
+
+		c = base()
+		c.SoftDelete.PurgeInterval = -time.Minute
+		require.Error(t, c.setDefaults(), `negative purge intervals should be rejected`)
+	})
+	t.Run("no clients", func(t *testing.T) {
+		c := base()
+		c.Auth.Clients = nil
+		require.Error(t, c.setDefaults(), `missing clients should be rejected`)
+		c.Auth.Disabled = true
+		require.NoError(t, c.setDefaults(), `missing clients should be accepted when auth is disabled`)
+	})
+}
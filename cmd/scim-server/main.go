@@ -0,0 +1,262 @@
+// Command scim-server runs a standalone SCIM server backed by
+// github.com/cybozu-go/scim-server.
+//
+// Usage:
+//
+//	scim-server -config /etc/scim/config.yaml
+//
+// See Config for the format of the configuration file.
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim-server/ent"
+	_ "github.com/cybozu-go/scim-server/ent/runtime"
+	"github.com/cybozu-go/scim-server/helper"
+	scimserver "github.com/cybozu-go/scim/server"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/memblob"
+)
+
+func main() {
+	if err := _main(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func _main() error {
+	var configFile string
+	flag.StringVar(&configFile, "config", "scim-server.yaml", "path to the configuration file (YAML or JSON)")
+	flag.Parse()
+
+	c, err := loadConfig(configFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer cancel()
+
+	return run(ctx, c)
+}
+
+func run(ctx context.Context, c *Config) error {
+	baseURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf(`failed to parse base URL: %w`, err)
+	}
+
+	bucket, err := blob.OpenBucket(ctx, c.Bucket)
+	if err != nil {
+		return fmt.Errorf(`failed to open bucket: %w`, err)
+	}
+	defer bucket.Close()
+
+	photoPrefix := c.PhotoURL
+	if photoPrefix == "" {
+		photoPrefix = c.BaseURL + `/photos`
+	}
+	photoPrefix = strings.TrimSuffix(photoPrefix, `/`)
+
+	backend, err := server.New(c.Database,
+		server.WithBaseURL(c.BaseURL),
+		server.WithMaxResults(c.Limits.MaxResults),
+		server.WithLogger(log.Default()),
+		server.WithFullTextSearch(c.FullTextSearch),
+		server.WithExternalIDUniqueness(c.UniqueExternalID),
+		server.WithUpsert(c.Upsert.Always),
+		server.WithUpsertKey(server.UpsertKey(c.Upsert.Key)),
+		server.WithIdempotencyKeyTTL(c.IdempotencyKeyTTL),
+		server.WithIdempotencyKeyLease(c.IdempotencyKeyLease),
+		server.WithSoftDelete(c.SoftDelete.Retention),
+		server.WithAudit(c.Audit),
+		server.WithEntOption(
+			ent.Bucket(bucket),
+			ent.PhotoURL(helper.PhotoURLFunc(func(_, path string) (string, error) {
+				return photoPrefix + `/` + url.PathEscape(path), nil
+			})),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf(`failed to create backend: %w`, err)
+	}
+	defer backend.Close()
+
+	// expired idempotency keys are purged even if soft deletion is
+	// disabled
+	go backend.RunPurger(ctx, c.SoftDelete.PurgeInterval)
+
+	scimHandler, err := scimserver.NewServer(backend)
+	if err != nil {
+		return fmt.Errorf(`failed to create SCIM handler: %w`, err)
+	}
+
+	authenticate := func(h http.Handler) http.Handler { return h }
+	if !c.Auth.Disabled {
+		authenticators, err := c.Auth.authenticators()
+		if err != nil {
+			return fmt.Errorf(`failed to configure authentication: %w`, err)
+		}
+		authenticate = func(h http.Handler) http.Handler {
+			return server.Authenticate(h, authenticators...)
+		}
+	}
+	h := authenticate(server.Idempotency(backend, server.Upsert(scimHandler)))
+
+	mux := http.NewServeMux()
+	prefix := strings.TrimSuffix(baseURL.Path, `/`)
+	mux.Handle(prefix+`/`, http.StripPrefix(prefix, limitRequestBody(h, c.Limits.MaxRequestBytes)))
+
+	// The administrative endpoints expose data across clients and resource
+	// types, so they are only served to authenticated clients that have been
+	// granted the admin scope (or the audit:read scope for the audit log)
+	restricted := func(pattern string, scope server.Scope, h http.Handler) {
+		if c.Auth.Disabled {
+			log.Printf("not serving %s as authentication is disabled", pattern)
+			return
+		}
+		mux.Handle(pattern, authenticate(server.RequireScope(h, scope)))
+	}
+	admin := func(pattern string, h http.Handler) {
+		restricted(pattern, server.ScopeAdmin, h)
+	}
+	if c.Admin.Explain {
+		admin(prefix+`/_explain`, server.ExplainHandler(backend))
+	}
+	if c.Admin.Metrics {
+		expvar.Publish(`filterCache`, expvar.Func(func() interface{} {
+			stats := backend.FilterCacheStats()
+			return struct {
+				server.FilterCacheStats
+				HitRate float64 `json:"hitRate"`
+			}{stats, stats.HitRate()}
+		}))
+		admin(prefix+`/_metrics`, expvar.Handler())
+	}
+	if c.SoftDelete.Retention > 0 {
+		admin(prefix+`/_deleted/`, http.StripPrefix(prefix+`/_deleted`, server.DeletedResourcesHandler(backend)))
+	}
+	if c.Admin.History {
+		admin(prefix+`/_history/`, http.StripPrefix(prefix+`/_history`, server.HistoryHandler(backend)))
+	}
+	if c.Audit {
+		restricted(prefix+`/_audit`, server.ScopeAuditRead, server.AuditLogHandler(backend))
+	}
+	if c.FullTextSearch {
+		admin(prefix+`/_fulltext`, server.FullTextSearchHandler(backend))
+	}
+	if c.PhotoURL == "" {
+		mux.Handle(prefix+`/photos/`, http.StripPrefix(prefix+`/photos/`, photoHandler(bucket)))
+	}
+
+	// The forwarding headers are honored for every endpoint, so that the
+	// administrative endpoints report the same locations as the SCIM ones
+	var root http.Handler = mux
+	if len(c.TrustedProxies) > 0 {
+		var trusted []*net.IPNet
+		for _, cidr := range c.TrustedProxies {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return fmt.Errorf(`invalid trusted proxy network %q: %w`, cidr, err)
+			}
+			trusted = append(trusted, n)
+		}
+		root = server.TrustForwardedHeaders(root, baseURL.Path, trusted...)
+	}
+
+	srv := &http.Server{
+		Addr:         c.Listen,
+		Handler:      root,
+		ReadTimeout:  c.Limits.ReadTimeout,
+		WriteTimeout: c.Limits.WriteTimeout,
+		IdleTimeout:  c.Limits.IdleTimeout,
+	}
+
+	if c.TLS.Enabled() {
+		tlsConfig, err := c.TLS.config()
+		if err != nil {
+			return err
+		}
+		srv.TLSConfig = tlsConfig
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("scim-server listening on %s (base URL %s)", c.Listen, c.BaseURL)
+		var err error
+		if srv.TLSConfig != nil {
+			// certificates are already loaded in TLSConfig
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf(`failed to serve: %w`, err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down (waiting up to %s for in-flight requests)", c.Limits.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), c.Limits.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf(`failed to shutdown server: %w`, err)
+	}
+	return nil
+}
+
+func limitRequestBody(h http.Handler, n int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, n)
+		h.ServeHTTP(w, r)
+	})
+}
+
+// photoHandler serves user photos stored in the bucket
+func photoHandler(bucket *blob.Bucket) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		rdr, err := bucket.NewReader(r.Context(), r.URL.Path, nil)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer rdr.Close()
+
+		w.Header().Set(`Content-Type`, rdr.ContentType())
+		w.Header().Set(`Content-Length`, strconv.FormatInt(rdr.Size(), 10))
+		w.Header().Set(`Last-Modified`, rdr.ModTime().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = io.Copy(w, rdr)
+	})
+}
@@ -0,0 +1,206 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
+)
+
+// AuditEntry is the model entity for the AuditEntry schema.
+type AuditEntry struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Timestamp holds the value of the "timestamp" field.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// ClientName holds the value of the "clientName" field.
+	ClientName string `json:"clientName,omitempty"`
+	// Operation holds the value of the "operation" field.
+	Operation string `json:"operation,omitempty"`
+	// ResourceType holds the value of the "resourceType" field.
+	ResourceType string `json:"resourceType,omitempty"`
+	// ResourceID holds the value of the "resourceID" field.
+	ResourceID string `json:"resourceID,omitempty"`
+	// Attributes holds the value of the "attributes" field.
+	Attributes []string `json:"attributes,omitempty"`
+	// Status holds the value of the "status" field.
+	Status int `json:"status,omitempty"`
+	// Outcome holds the value of the "outcome" field.
+	Outcome string `json:"outcome,omitempty"`
+	// PrevHash holds the value of the "prevHash" field.
+	PrevHash string `json:"prevHash,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash string `json:"hash,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuditEntry) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case auditentry.FieldAttributes:
+			values[i] = new([]byte)
+		case auditentry.FieldID, auditentry.FieldStatus:
+			values[i] = new(sql.NullInt64)
+		case auditentry.FieldClientName, auditentry.FieldOperation, auditentry.FieldResourceType, auditentry.FieldResourceID, auditentry.FieldOutcome, auditentry.FieldPrevHash, auditentry.FieldHash:
+			values[i] = new(sql.NullString)
+		case auditentry.FieldTimestamp:
+			values[i] = new(sql.NullTime)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type AuditEntry", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuditEntry fields.
+func (ae *AuditEntry) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case auditentry.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ae.ID = int(value.Int64)
+		case auditentry.FieldTimestamp:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field timestamp", values[i])
+			} else if value.Valid {
+				ae.Timestamp = value.Time
+			}
+		case auditentry.FieldClientName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field clientName", values[i])
+			} else if value.Valid {
+				ae.ClientName = value.String
+			}
+		case auditentry.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				ae.Operation = value.String
+			}
+		case auditentry.FieldResourceType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resourceType", values[i])
+			} else if value.Valid {
+				ae.ResourceType = value.String
+			}
+		case auditentry.FieldResourceID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resourceID", values[i])
+			} else if value.Valid {
+				ae.ResourceID = value.String
+			}
+		case auditentry.FieldAttributes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field attributes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ae.Attributes); err != nil {
+					return fmt.Errorf("unmarshal field attributes: %w", err)
+				}
+			}
+		case auditentry.FieldStatus:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				ae.Status = int(value.Int64)
+			}
+		case auditentry.FieldOutcome:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field outcome", values[i])
+			} else if value.Valid {
+				ae.Outcome = value.String
+			}
+		case auditentry.FieldPrevHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prevHash", values[i])
+			} else if value.Valid {
+				ae.PrevHash = value.String
+			}
+		case auditentry.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				ae.Hash = value.String
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this AuditEntry.
+// Note that you need to call AuditEntry.Unwrap() before calling this method if this AuditEntry
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ae *AuditEntry) Update() *AuditEntryUpdateOne {
+	return (&AuditEntryClient{config: ae.config}).UpdateOne(ae)
+}
+
+// Unwrap unwraps the AuditEntry entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ae *AuditEntry) Unwrap() *AuditEntry {
+	_tx, ok := ae.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuditEntry is not a transactional entity")
+	}
+	ae.config.driver = _tx.drv
+	return ae
+}
+
+// String implements the fmt.Stringer.
+func (ae *AuditEntry) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuditEntry(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ae.ID))
+	builder.WriteString("timestamp=")
+	builder.WriteString(ae.Timestamp.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("clientName=")
+	builder.WriteString(ae.ClientName)
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(ae.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("resourceType=")
+	builder.WriteString(ae.ResourceType)
+	builder.WriteString(", ")
+	builder.WriteString("resourceID=")
+	builder.WriteString(ae.ResourceID)
+	builder.WriteString(", ")
+	builder.WriteString("attributes=")
+	builder.WriteString(fmt.Sprintf("%v", ae.Attributes))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", ae.Status))
+	builder.WriteString(", ")
+	builder.WriteString("outcome=")
+	builder.WriteString(ae.Outcome)
+	builder.WriteString(", ")
+	builder.WriteString("prevHash=")
+	builder.WriteString(ae.PrevHash)
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(ae.Hash)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuditEntries is a parsable slice of AuditEntry.
+type AuditEntries []*AuditEntry
+
+func (ae AuditEntries) config(cfg config) {
+	for _i := range ae {
+		ae[_i].config = cfg
+	}
+}
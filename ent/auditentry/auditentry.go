@@ -0,0 +1,57 @@
+// Code generated by ent, DO NOT EDIT.
+
+package auditentry
+
+const (
+	// Label holds the string label denoting the auditentry type in the database.
+	Label = "audit_entry"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldTimestamp holds the string denoting the timestamp field in the database.
+	FieldTimestamp = "timestamp"
+	// FieldClientName holds the string denoting the clientname field in the database.
+	FieldClientName = "client_name"
+	// FieldOperation holds the string denoting the operation field in the database.
+	FieldOperation = "operation"
+	// FieldResourceType holds the string denoting the resourcetype field in the database.
+	FieldResourceType = "resource_type"
+	// FieldResourceID holds the string denoting the resourceid field in the database.
+	FieldResourceID = "resource_id"
+	// FieldAttributes holds the string denoting the attributes field in the database.
+	FieldAttributes = "attributes"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldOutcome holds the string denoting the outcome field in the database.
+	FieldOutcome = "outcome"
+	// FieldPrevHash holds the string denoting the prevhash field in the database.
+	FieldPrevHash = "prev_hash"
+	// FieldHash holds the string denoting the hash field in the database.
+	FieldHash = "hash"
+	// Table holds the table name of the auditentry in the database.
+	Table = "audit_entries"
+)
+
+// Columns holds all SQL columns for auditentry fields.
+var Columns = []string{
+	FieldID,
+	FieldTimestamp,
+	FieldClientName,
+	FieldOperation,
+	FieldResourceType,
+	FieldResourceID,
+	FieldAttributes,
+	FieldStatus,
+	FieldOutcome,
+	FieldPrevHash,
+	FieldHash,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
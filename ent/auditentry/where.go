@@ -0,0 +1,1147 @@
+// Code generated by ent, DO NOT EDIT.
+
+package auditentry
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// Timestamp applies equality check predicate on the "timestamp" field. It's identical to TimestampEQ.
+func Timestamp(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTimestamp), v))
+	})
+}
+
+// ClientName applies equality check predicate on the "clientName" field. It's identical to ClientNameEQ.
+func ClientName(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientName), v))
+	})
+}
+
+// Operation applies equality check predicate on the "operation" field. It's identical to OperationEQ.
+func Operation(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOperation), v))
+	})
+}
+
+// ResourceType applies equality check predicate on the "resourceType" field. It's identical to ResourceTypeEQ.
+func ResourceType(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceID applies equality check predicate on the "resourceID" field. It's identical to ResourceIDEQ.
+func ResourceID(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceID), v))
+	})
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldStatus), v))
+	})
+}
+
+// Outcome applies equality check predicate on the "outcome" field. It's identical to OutcomeEQ.
+func Outcome(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOutcome), v))
+	})
+}
+
+// PrevHash applies equality check predicate on the "prevHash" field. It's identical to PrevHashEQ.
+func PrevHash(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrevHash), v))
+	})
+}
+
+// Hash applies equality check predicate on the "hash" field. It's identical to HashEQ.
+func Hash(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldHash), v))
+	})
+}
+
+// TimestampEQ applies the EQ predicate on the "timestamp" field.
+func TimestampEQ(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldTimestamp), v))
+	})
+}
+
+// TimestampNEQ applies the NEQ predicate on the "timestamp" field.
+func TimestampNEQ(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldTimestamp), v))
+	})
+}
+
+// TimestampIn applies the In predicate on the "timestamp" field.
+func TimestampIn(vs ...time.Time) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldTimestamp), v...))
+	})
+}
+
+// TimestampNotIn applies the NotIn predicate on the "timestamp" field.
+func TimestampNotIn(vs ...time.Time) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldTimestamp), v...))
+	})
+}
+
+// TimestampGT applies the GT predicate on the "timestamp" field.
+func TimestampGT(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldTimestamp), v))
+	})
+}
+
+// TimestampGTE applies the GTE predicate on the "timestamp" field.
+func TimestampGTE(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldTimestamp), v))
+	})
+}
+
+// TimestampLT applies the LT predicate on the "timestamp" field.
+func TimestampLT(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldTimestamp), v))
+	})
+}
+
+// TimestampLTE applies the LTE predicate on the "timestamp" field.
+func TimestampLTE(v time.Time) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldTimestamp), v))
+	})
+}
+
+// ClientNameEQ applies the EQ predicate on the "clientName" field.
+func ClientNameEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameNEQ applies the NEQ predicate on the "clientName" field.
+func ClientNameNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameIn applies the In predicate on the "clientName" field.
+func ClientNameIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldClientName), v...))
+	})
+}
+
+// ClientNameNotIn applies the NotIn predicate on the "clientName" field.
+func ClientNameNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldClientName), v...))
+	})
+}
+
+// ClientNameGT applies the GT predicate on the "clientName" field.
+func ClientNameGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameGTE applies the GTE predicate on the "clientName" field.
+func ClientNameGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameLT applies the LT predicate on the "clientName" field.
+func ClientNameLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameLTE applies the LTE predicate on the "clientName" field.
+func ClientNameLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameContains applies the Contains predicate on the "clientName" field.
+func ClientNameContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameHasPrefix applies the HasPrefix predicate on the "clientName" field.
+func ClientNameHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameHasSuffix applies the HasSuffix predicate on the "clientName" field.
+func ClientNameHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameEqualFold applies the EqualFold predicate on the "clientName" field.
+func ClientNameEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldClientName), v))
+	})
+}
+
+// ClientNameContainsFold applies the ContainsFold predicate on the "clientName" field.
+func ClientNameContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldClientName), v))
+	})
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOperation), v))
+	})
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldOperation), v))
+	})
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldOperation), v...))
+	})
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldOperation), v...))
+	})
+}
+
+// OperationGT applies the GT predicate on the "operation" field.
+func OperationGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldOperation), v))
+	})
+}
+
+// OperationGTE applies the GTE predicate on the "operation" field.
+func OperationGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldOperation), v))
+	})
+}
+
+// OperationLT applies the LT predicate on the "operation" field.
+func OperationLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldOperation), v))
+	})
+}
+
+// OperationLTE applies the LTE predicate on the "operation" field.
+func OperationLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldOperation), v))
+	})
+}
+
+// OperationContains applies the Contains predicate on the "operation" field.
+func OperationContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldOperation), v))
+	})
+}
+
+// OperationHasPrefix applies the HasPrefix predicate on the "operation" field.
+func OperationHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldOperation), v))
+	})
+}
+
+// OperationHasSuffix applies the HasSuffix predicate on the "operation" field.
+func OperationHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldOperation), v))
+	})
+}
+
+// OperationEqualFold applies the EqualFold predicate on the "operation" field.
+func OperationEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldOperation), v))
+	})
+}
+
+// OperationContainsFold applies the ContainsFold predicate on the "operation" field.
+func OperationContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldOperation), v))
+	})
+}
+
+// ResourceTypeEQ applies the EQ predicate on the "resourceType" field.
+func ResourceTypeEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeNEQ applies the NEQ predicate on the "resourceType" field.
+func ResourceTypeNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeIn applies the In predicate on the "resourceType" field.
+func ResourceTypeIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldResourceType), v...))
+	})
+}
+
+// ResourceTypeNotIn applies the NotIn predicate on the "resourceType" field.
+func ResourceTypeNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldResourceType), v...))
+	})
+}
+
+// ResourceTypeGT applies the GT predicate on the "resourceType" field.
+func ResourceTypeGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeGTE applies the GTE predicate on the "resourceType" field.
+func ResourceTypeGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeLT applies the LT predicate on the "resourceType" field.
+func ResourceTypeLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeLTE applies the LTE predicate on the "resourceType" field.
+func ResourceTypeLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeContains applies the Contains predicate on the "resourceType" field.
+func ResourceTypeContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeHasPrefix applies the HasPrefix predicate on the "resourceType" field.
+func ResourceTypeHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeHasSuffix applies the HasSuffix predicate on the "resourceType" field.
+func ResourceTypeHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeIsNil applies the IsNil predicate on the "resourceType" field.
+func ResourceTypeIsNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldResourceType)))
+	})
+}
+
+// ResourceTypeNotNil applies the NotNil predicate on the "resourceType" field.
+func ResourceTypeNotNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldResourceType)))
+	})
+}
+
+// ResourceTypeEqualFold applies the EqualFold predicate on the "resourceType" field.
+func ResourceTypeEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeContainsFold applies the ContainsFold predicate on the "resourceType" field.
+func ResourceTypeContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceIDEQ applies the EQ predicate on the "resourceID" field.
+func ResourceIDEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDNEQ applies the NEQ predicate on the "resourceID" field.
+func ResourceIDNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDIn applies the In predicate on the "resourceID" field.
+func ResourceIDIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldResourceID), v...))
+	})
+}
+
+// ResourceIDNotIn applies the NotIn predicate on the "resourceID" field.
+func ResourceIDNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldResourceID), v...))
+	})
+}
+
+// ResourceIDGT applies the GT predicate on the "resourceID" field.
+func ResourceIDGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDGTE applies the GTE predicate on the "resourceID" field.
+func ResourceIDGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDLT applies the LT predicate on the "resourceID" field.
+func ResourceIDLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDLTE applies the LTE predicate on the "resourceID" field.
+func ResourceIDLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDContains applies the Contains predicate on the "resourceID" field.
+func ResourceIDContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDHasPrefix applies the HasPrefix predicate on the "resourceID" field.
+func ResourceIDHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDHasSuffix applies the HasSuffix predicate on the "resourceID" field.
+func ResourceIDHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDIsNil applies the IsNil predicate on the "resourceID" field.
+func ResourceIDIsNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldResourceID)))
+	})
+}
+
+// ResourceIDNotNil applies the NotNil predicate on the "resourceID" field.
+func ResourceIDNotNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldResourceID)))
+	})
+}
+
+// ResourceIDEqualFold applies the EqualFold predicate on the "resourceID" field.
+func ResourceIDEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDContainsFold applies the ContainsFold predicate on the "resourceID" field.
+func ResourceIDContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldResourceID), v))
+	})
+}
+
+// AttributesIsNil applies the IsNil predicate on the "attributes" field.
+func AttributesIsNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldAttributes)))
+	})
+}
+
+// AttributesNotNil applies the NotNil predicate on the "attributes" field.
+func AttributesNotNil() predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldAttributes)))
+	})
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldStatus), v))
+	})
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldStatus), v))
+	})
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...int) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldStatus), v...))
+	})
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...int) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldStatus), v...))
+	})
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldStatus), v))
+	})
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldStatus), v))
+	})
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldStatus), v))
+	})
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v int) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldStatus), v))
+	})
+}
+
+// OutcomeEQ applies the EQ predicate on the "outcome" field.
+func OutcomeEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeNEQ applies the NEQ predicate on the "outcome" field.
+func OutcomeNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeIn applies the In predicate on the "outcome" field.
+func OutcomeIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldOutcome), v...))
+	})
+}
+
+// OutcomeNotIn applies the NotIn predicate on the "outcome" field.
+func OutcomeNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldOutcome), v...))
+	})
+}
+
+// OutcomeGT applies the GT predicate on the "outcome" field.
+func OutcomeGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeGTE applies the GTE predicate on the "outcome" field.
+func OutcomeGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeLT applies the LT predicate on the "outcome" field.
+func OutcomeLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeLTE applies the LTE predicate on the "outcome" field.
+func OutcomeLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeContains applies the Contains predicate on the "outcome" field.
+func OutcomeContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeHasPrefix applies the HasPrefix predicate on the "outcome" field.
+func OutcomeHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeHasSuffix applies the HasSuffix predicate on the "outcome" field.
+func OutcomeHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeEqualFold applies the EqualFold predicate on the "outcome" field.
+func OutcomeEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldOutcome), v))
+	})
+}
+
+// OutcomeContainsFold applies the ContainsFold predicate on the "outcome" field.
+func OutcomeContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldOutcome), v))
+	})
+}
+
+// PrevHashEQ applies the EQ predicate on the "prevHash" field.
+func PrevHashEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashNEQ applies the NEQ predicate on the "prevHash" field.
+func PrevHashNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashIn applies the In predicate on the "prevHash" field.
+func PrevHashIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldPrevHash), v...))
+	})
+}
+
+// PrevHashNotIn applies the NotIn predicate on the "prevHash" field.
+func PrevHashNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldPrevHash), v...))
+	})
+}
+
+// PrevHashGT applies the GT predicate on the "prevHash" field.
+func PrevHashGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashGTE applies the GTE predicate on the "prevHash" field.
+func PrevHashGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashLT applies the LT predicate on the "prevHash" field.
+func PrevHashLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashLTE applies the LTE predicate on the "prevHash" field.
+func PrevHashLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashContains applies the Contains predicate on the "prevHash" field.
+func PrevHashContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashHasPrefix applies the HasPrefix predicate on the "prevHash" field.
+func PrevHashHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashHasSuffix applies the HasSuffix predicate on the "prevHash" field.
+func PrevHashHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashEqualFold applies the EqualFold predicate on the "prevHash" field.
+func PrevHashEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldPrevHash), v))
+	})
+}
+
+// PrevHashContainsFold applies the ContainsFold predicate on the "prevHash" field.
+func PrevHashContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldPrevHash), v))
+	})
+}
+
+// HashEQ applies the EQ predicate on the "hash" field.
+func HashEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldHash), v))
+	})
+}
+
+// HashNEQ applies the NEQ predicate on the "hash" field.
+func HashNEQ(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldHash), v))
+	})
+}
+
+// HashIn applies the In predicate on the "hash" field.
+func HashIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldHash), v...))
+	})
+}
+
+// HashNotIn applies the NotIn predicate on the "hash" field.
+func HashNotIn(vs ...string) predicate.AuditEntry {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldHash), v...))
+	})
+}
+
+// HashGT applies the GT predicate on the "hash" field.
+func HashGT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldHash), v))
+	})
+}
+
+// HashGTE applies the GTE predicate on the "hash" field.
+func HashGTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldHash), v))
+	})
+}
+
+// HashLT applies the LT predicate on the "hash" field.
+func HashLT(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldHash), v))
+	})
+}
+
+// HashLTE applies the LTE predicate on the "hash" field.
+func HashLTE(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldHash), v))
+	})
+}
+
+// HashContains applies the Contains predicate on the "hash" field.
+func HashContains(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldHash), v))
+	})
+}
+
+// HashHasPrefix applies the HasPrefix predicate on the "hash" field.
+func HashHasPrefix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldHash), v))
+	})
+}
+
+// HashHasSuffix applies the HasSuffix predicate on the "hash" field.
+func HashHasSuffix(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldHash), v))
+	})
+}
+
+// HashEqualFold applies the EqualFold predicate on the "hash" field.
+func HashEqualFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldHash), v))
+	})
+}
+
+// HashContainsFold applies the ContainsFold predicate on the "hash" field.
+func HashContainsFold(v string) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldHash), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.AuditEntry) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.AuditEntry) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.AuditEntry) predicate.AuditEntry {
+	return predicate.AuditEntry(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,387 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
+)
+
+// AuditEntryCreate is the builder for creating a AuditEntry entity.
+type AuditEntryCreate struct {
+	config
+	mutation *AuditEntryMutation
+	hooks    []Hook
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (aec *AuditEntryCreate) SetTimestamp(t time.Time) *AuditEntryCreate {
+	aec.mutation.SetTimestamp(t)
+	return aec
+}
+
+// SetClientName sets the "clientName" field.
+func (aec *AuditEntryCreate) SetClientName(s string) *AuditEntryCreate {
+	aec.mutation.SetClientName(s)
+	return aec
+}
+
+// SetOperation sets the "operation" field.
+func (aec *AuditEntryCreate) SetOperation(s string) *AuditEntryCreate {
+	aec.mutation.SetOperation(s)
+	return aec
+}
+
+// SetResourceType sets the "resourceType" field.
+func (aec *AuditEntryCreate) SetResourceType(s string) *AuditEntryCreate {
+	aec.mutation.SetResourceType(s)
+	return aec
+}
+
+// SetNillableResourceType sets the "resourceType" field if the given value is not nil.
+func (aec *AuditEntryCreate) SetNillableResourceType(s *string) *AuditEntryCreate {
+	if s != nil {
+		aec.SetResourceType(*s)
+	}
+	return aec
+}
+
+// SetResourceID sets the "resourceID" field.
+func (aec *AuditEntryCreate) SetResourceID(s string) *AuditEntryCreate {
+	aec.mutation.SetResourceID(s)
+	return aec
+}
+
+// SetNillableResourceID sets the "resourceID" field if the given value is not nil.
+func (aec *AuditEntryCreate) SetNillableResourceID(s *string) *AuditEntryCreate {
+	if s != nil {
+		aec.SetResourceID(*s)
+	}
+	return aec
+}
+
+// SetAttributes sets the "attributes" field.
+func (aec *AuditEntryCreate) SetAttributes(s []string) *AuditEntryCreate {
+	aec.mutation.SetAttributes(s)
+	return aec
+}
+
+// SetStatus sets the "status" field.
+func (aec *AuditEntryCreate) SetStatus(i int) *AuditEntryCreate {
+	aec.mutation.SetStatus(i)
+	return aec
+}
+
+// SetOutcome sets the "outcome" field.
+func (aec *AuditEntryCreate) SetOutcome(s string) *AuditEntryCreate {
+	aec.mutation.SetOutcome(s)
+	return aec
+}
+
+// SetPrevHash sets the "prevHash" field.
+func (aec *AuditEntryCreate) SetPrevHash(s string) *AuditEntryCreate {
+	aec.mutation.SetPrevHash(s)
+	return aec
+}
+
+// SetHash sets the "hash" field.
+func (aec *AuditEntryCreate) SetHash(s string) *AuditEntryCreate {
+	aec.mutation.SetHash(s)
+	return aec
+}
+
+// Mutation returns the AuditEntryMutation object of the builder.
+func (aec *AuditEntryCreate) Mutation() *AuditEntryMutation {
+	return aec.mutation
+}
+
+// Save creates the AuditEntry in the database.
+func (aec *AuditEntryCreate) Save(ctx context.Context) (*AuditEntry, error) {
+	var (
+		err  error
+		node *AuditEntry
+	)
+	if len(aec.hooks) == 0 {
+		if err = aec.check(); err != nil {
+			return nil, err
+		}
+		node, err = aec.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditEntryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = aec.check(); err != nil {
+				return nil, err
+			}
+			aec.mutation = mutation
+			if node, err = aec.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(aec.hooks) - 1; i >= 0; i-- {
+			if aec.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = aec.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, aec.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*AuditEntry)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from AuditEntryMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (aec *AuditEntryCreate) SaveX(ctx context.Context) *AuditEntry {
+	v, err := aec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aec *AuditEntryCreate) Exec(ctx context.Context) error {
+	_, err := aec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aec *AuditEntryCreate) ExecX(ctx context.Context) {
+	if err := aec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aec *AuditEntryCreate) check() error {
+	if _, ok := aec.mutation.Timestamp(); !ok {
+		return &ValidationError{Name: "timestamp", err: errors.New(`ent: missing required field "AuditEntry.timestamp"`)}
+	}
+	if _, ok := aec.mutation.ClientName(); !ok {
+		return &ValidationError{Name: "clientName", err: errors.New(`ent: missing required field "AuditEntry.clientName"`)}
+	}
+	if _, ok := aec.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "AuditEntry.operation"`)}
+	}
+	if _, ok := aec.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "AuditEntry.status"`)}
+	}
+	if _, ok := aec.mutation.Outcome(); !ok {
+		return &ValidationError{Name: "outcome", err: errors.New(`ent: missing required field "AuditEntry.outcome"`)}
+	}
+	if _, ok := aec.mutation.PrevHash(); !ok {
+		return &ValidationError{Name: "prevHash", err: errors.New(`ent: missing required field "AuditEntry.prevHash"`)}
+	}
+	if _, ok := aec.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "AuditEntry.hash"`)}
+	}
+	return nil
+}
+
+func (aec *AuditEntryCreate) sqlSave(ctx context.Context) (*AuditEntry, error) {
+	_node, _spec := aec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, aec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (aec *AuditEntryCreate) createSpec() (*AuditEntry, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditEntry{config: aec.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: auditentry.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditentry.FieldID,
+			},
+		}
+	)
+	if value, ok := aec.mutation.Timestamp(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditentry.FieldTimestamp,
+		})
+		_node.Timestamp = value
+	}
+	if value, ok := aec.mutation.ClientName(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldClientName,
+		})
+		_node.ClientName = value
+	}
+	if value, ok := aec.mutation.Operation(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOperation,
+		})
+		_node.Operation = value
+	}
+	if value, ok := aec.mutation.ResourceType(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceType,
+		})
+		_node.ResourceType = value
+	}
+	if value, ok := aec.mutation.ResourceID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceID,
+		})
+		_node.ResourceID = value
+	}
+	if value, ok := aec.mutation.Attributes(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: auditentry.FieldAttributes,
+		})
+		_node.Attributes = value
+	}
+	if value, ok := aec.mutation.Status(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditentry.FieldStatus,
+		})
+		_node.Status = value
+	}
+	if value, ok := aec.mutation.Outcome(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOutcome,
+		})
+		_node.Outcome = value
+	}
+	if value, ok := aec.mutation.PrevHash(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldPrevHash,
+		})
+		_node.PrevHash = value
+	}
+	if value, ok := aec.mutation.Hash(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldHash,
+		})
+		_node.Hash = value
+	}
+	return _node, _spec
+}
+
+// AuditEntryCreateBulk is the builder for creating many AuditEntry entities in bulk.
+type AuditEntryCreateBulk struct {
+	config
+	builders []*AuditEntryCreate
+}
+
+// Save creates the AuditEntry entities in the database.
+func (aecb *AuditEntryCreateBulk) Save(ctx context.Context) ([]*AuditEntry, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(aecb.builders))
+	nodes := make([]*AuditEntry, len(aecb.builders))
+	mutators := make([]Mutator, len(aecb.builders))
+	for i := range aecb.builders {
+		func(i int, root context.Context) {
+			builder := aecb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditEntryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, aecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, aecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, aecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aecb *AuditEntryCreateBulk) SaveX(ctx context.Context) []*AuditEntry {
+	v, err := aecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aecb *AuditEntryCreateBulk) Exec(ctx context.Context) error {
+	_, err := aecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aecb *AuditEntryCreateBulk) ExecX(ctx context.Context) {
+	if err := aecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,638 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+)
+
+// AuditEntryUpdate is the builder for updating AuditEntry entities.
+type AuditEntryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditEntryMutation
+}
+
+// Where appends a list predicates to the AuditEntryUpdate builder.
+func (aeu *AuditEntryUpdate) Where(ps ...predicate.AuditEntry) *AuditEntryUpdate {
+	aeu.mutation.Where(ps...)
+	return aeu
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (aeu *AuditEntryUpdate) SetTimestamp(t time.Time) *AuditEntryUpdate {
+	aeu.mutation.SetTimestamp(t)
+	return aeu
+}
+
+// SetClientName sets the "clientName" field.
+func (aeu *AuditEntryUpdate) SetClientName(s string) *AuditEntryUpdate {
+	aeu.mutation.SetClientName(s)
+	return aeu
+}
+
+// SetOperation sets the "operation" field.
+func (aeu *AuditEntryUpdate) SetOperation(s string) *AuditEntryUpdate {
+	aeu.mutation.SetOperation(s)
+	return aeu
+}
+
+// SetResourceType sets the "resourceType" field.
+func (aeu *AuditEntryUpdate) SetResourceType(s string) *AuditEntryUpdate {
+	aeu.mutation.SetResourceType(s)
+	return aeu
+}
+
+// SetNillableResourceType sets the "resourceType" field if the given value is not nil.
+func (aeu *AuditEntryUpdate) SetNillableResourceType(s *string) *AuditEntryUpdate {
+	if s != nil {
+		aeu.SetResourceType(*s)
+	}
+	return aeu
+}
+
+// ClearResourceType clears the value of the "resourceType" field.
+func (aeu *AuditEntryUpdate) ClearResourceType() *AuditEntryUpdate {
+	aeu.mutation.ClearResourceType()
+	return aeu
+}
+
+// SetResourceID sets the "resourceID" field.
+func (aeu *AuditEntryUpdate) SetResourceID(s string) *AuditEntryUpdate {
+	aeu.mutation.SetResourceID(s)
+	return aeu
+}
+
+// SetNillableResourceID sets the "resourceID" field if the given value is not nil.
+func (aeu *AuditEntryUpdate) SetNillableResourceID(s *string) *AuditEntryUpdate {
+	if s != nil {
+		aeu.SetResourceID(*s)
+	}
+	return aeu
+}
+
+// ClearResourceID clears the value of the "resourceID" field.
+func (aeu *AuditEntryUpdate) ClearResourceID() *AuditEntryUpdate {
+	aeu.mutation.ClearResourceID()
+	return aeu
+}
+
+// SetAttributes sets the "attributes" field.
+func (aeu *AuditEntryUpdate) SetAttributes(s []string) *AuditEntryUpdate {
+	aeu.mutation.SetAttributes(s)
+	return aeu
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (aeu *AuditEntryUpdate) ClearAttributes() *AuditEntryUpdate {
+	aeu.mutation.ClearAttributes()
+	return aeu
+}
+
+// SetStatus sets the "status" field.
+func (aeu *AuditEntryUpdate) SetStatus(i int) *AuditEntryUpdate {
+	aeu.mutation.ResetStatus()
+	aeu.mutation.SetStatus(i)
+	return aeu
+}
+
+// AddStatus adds i to the "status" field.
+func (aeu *AuditEntryUpdate) AddStatus(i int) *AuditEntryUpdate {
+	aeu.mutation.AddStatus(i)
+	return aeu
+}
+
+// SetOutcome sets the "outcome" field.
+func (aeu *AuditEntryUpdate) SetOutcome(s string) *AuditEntryUpdate {
+	aeu.mutation.SetOutcome(s)
+	return aeu
+}
+
+// SetPrevHash sets the "prevHash" field.
+func (aeu *AuditEntryUpdate) SetPrevHash(s string) *AuditEntryUpdate {
+	aeu.mutation.SetPrevHash(s)
+	return aeu
+}
+
+// SetHash sets the "hash" field.
+func (aeu *AuditEntryUpdate) SetHash(s string) *AuditEntryUpdate {
+	aeu.mutation.SetHash(s)
+	return aeu
+}
+
+// Mutation returns the AuditEntryMutation object of the builder.
+func (aeu *AuditEntryUpdate) Mutation() *AuditEntryMutation {
+	return aeu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (aeu *AuditEntryUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(aeu.hooks) == 0 {
+		affected, err = aeu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditEntryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			aeu.mutation = mutation
+			affected, err = aeu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(aeu.hooks) - 1; i >= 0; i-- {
+			if aeu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = aeu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, aeu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeu *AuditEntryUpdate) SaveX(ctx context.Context) int {
+	affected, err := aeu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (aeu *AuditEntryUpdate) Exec(ctx context.Context) error {
+	_, err := aeu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeu *AuditEntryUpdate) ExecX(ctx context.Context) {
+	if err := aeu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (aeu *AuditEntryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   auditentry.Table,
+			Columns: auditentry.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditentry.FieldID,
+			},
+		},
+	}
+	if ps := aeu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeu.mutation.Timestamp(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditentry.FieldTimestamp,
+		})
+	}
+	if value, ok := aeu.mutation.ClientName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldClientName,
+		})
+	}
+	if value, ok := aeu.mutation.Operation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOperation,
+		})
+	}
+	if value, ok := aeu.mutation.ResourceType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceType,
+		})
+	}
+	if aeu.mutation.ResourceTypeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditentry.FieldResourceType,
+		})
+	}
+	if value, ok := aeu.mutation.ResourceID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceID,
+		})
+	}
+	if aeu.mutation.ResourceIDCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditentry.FieldResourceID,
+		})
+	}
+	if value, ok := aeu.mutation.Attributes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: auditentry.FieldAttributes,
+		})
+	}
+	if aeu.mutation.AttributesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: auditentry.FieldAttributes,
+		})
+	}
+	if value, ok := aeu.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditentry.FieldStatus,
+		})
+	}
+	if value, ok := aeu.mutation.AddedStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditentry.FieldStatus,
+		})
+	}
+	if value, ok := aeu.mutation.Outcome(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOutcome,
+		})
+	}
+	if value, ok := aeu.mutation.PrevHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldPrevHash,
+		})
+	}
+	if value, ok := aeu.mutation.Hash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldHash,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, aeu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// AuditEntryUpdateOne is the builder for updating a single AuditEntry entity.
+type AuditEntryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuditEntryMutation
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (aeuo *AuditEntryUpdateOne) SetTimestamp(t time.Time) *AuditEntryUpdateOne {
+	aeuo.mutation.SetTimestamp(t)
+	return aeuo
+}
+
+// SetClientName sets the "clientName" field.
+func (aeuo *AuditEntryUpdateOne) SetClientName(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetClientName(s)
+	return aeuo
+}
+
+// SetOperation sets the "operation" field.
+func (aeuo *AuditEntryUpdateOne) SetOperation(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetOperation(s)
+	return aeuo
+}
+
+// SetResourceType sets the "resourceType" field.
+func (aeuo *AuditEntryUpdateOne) SetResourceType(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetResourceType(s)
+	return aeuo
+}
+
+// SetNillableResourceType sets the "resourceType" field if the given value is not nil.
+func (aeuo *AuditEntryUpdateOne) SetNillableResourceType(s *string) *AuditEntryUpdateOne {
+	if s != nil {
+		aeuo.SetResourceType(*s)
+	}
+	return aeuo
+}
+
+// ClearResourceType clears the value of the "resourceType" field.
+func (aeuo *AuditEntryUpdateOne) ClearResourceType() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearResourceType()
+	return aeuo
+}
+
+// SetResourceID sets the "resourceID" field.
+func (aeuo *AuditEntryUpdateOne) SetResourceID(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetResourceID(s)
+	return aeuo
+}
+
+// SetNillableResourceID sets the "resourceID" field if the given value is not nil.
+func (aeuo *AuditEntryUpdateOne) SetNillableResourceID(s *string) *AuditEntryUpdateOne {
+	if s != nil {
+		aeuo.SetResourceID(*s)
+	}
+	return aeuo
+}
+
+// ClearResourceID clears the value of the "resourceID" field.
+func (aeuo *AuditEntryUpdateOne) ClearResourceID() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearResourceID()
+	return aeuo
+}
+
+// SetAttributes sets the "attributes" field.
+func (aeuo *AuditEntryUpdateOne) SetAttributes(s []string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetAttributes(s)
+	return aeuo
+}
+
+// ClearAttributes clears the value of the "attributes" field.
+func (aeuo *AuditEntryUpdateOne) ClearAttributes() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearAttributes()
+	return aeuo
+}
+
+// SetStatus sets the "status" field.
+func (aeuo *AuditEntryUpdateOne) SetStatus(i int) *AuditEntryUpdateOne {
+	aeuo.mutation.ResetStatus()
+	aeuo.mutation.SetStatus(i)
+	return aeuo
+}
+
+// AddStatus adds i to the "status" field.
+func (aeuo *AuditEntryUpdateOne) AddStatus(i int) *AuditEntryUpdateOne {
+	aeuo.mutation.AddStatus(i)
+	return aeuo
+}
+
+// SetOutcome sets the "outcome" field.
+func (aeuo *AuditEntryUpdateOne) SetOutcome(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetOutcome(s)
+	return aeuo
+}
+
+// SetPrevHash sets the "prevHash" field.
+func (aeuo *AuditEntryUpdateOne) SetPrevHash(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetPrevHash(s)
+	return aeuo
+}
+
+// SetHash sets the "hash" field.
+func (aeuo *AuditEntryUpdateOne) SetHash(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetHash(s)
+	return aeuo
+}
+
+// Mutation returns the AuditEntryMutation object of the builder.
+func (aeuo *AuditEntryUpdateOne) Mutation() *AuditEntryMutation {
+	return aeuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aeuo *AuditEntryUpdateOne) Select(field string, fields ...string) *AuditEntryUpdateOne {
+	aeuo.fields = append([]string{field}, fields...)
+	return aeuo
+}
+
+// Save executes the query and returns the updated AuditEntry entity.
+func (aeuo *AuditEntryUpdateOne) Save(ctx context.Context) (*AuditEntry, error) {
+	var (
+		err  error
+		node *AuditEntry
+	)
+	if len(aeuo.hooks) == 0 {
+		node, err = aeuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*AuditEntryMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			aeuo.mutation = mutation
+			node, err = aeuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(aeuo.hooks) - 1; i >= 0; i-- {
+			if aeuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = aeuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, aeuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*AuditEntry)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from AuditEntryMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeuo *AuditEntryUpdateOne) SaveX(ctx context.Context) *AuditEntry {
+	node, err := aeuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aeuo *AuditEntryUpdateOne) Exec(ctx context.Context) error {
+	_, err := aeuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeuo *AuditEntryUpdateOne) ExecX(ctx context.Context) {
+	if err := aeuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (aeuo *AuditEntryUpdateOne) sqlSave(ctx context.Context) (_node *AuditEntry, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   auditentry.Table,
+			Columns: auditentry.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: auditentry.FieldID,
+			},
+		},
+	}
+	id, ok := aeuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditEntry.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aeuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditentry.FieldID)
+		for _, f := range fields {
+			if !auditentry.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditentry.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aeuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeuo.mutation.Timestamp(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: auditentry.FieldTimestamp,
+		})
+	}
+	if value, ok := aeuo.mutation.ClientName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldClientName,
+		})
+	}
+	if value, ok := aeuo.mutation.Operation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOperation,
+		})
+	}
+	if value, ok := aeuo.mutation.ResourceType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceType,
+		})
+	}
+	if aeuo.mutation.ResourceTypeCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditentry.FieldResourceType,
+		})
+	}
+	if value, ok := aeuo.mutation.ResourceID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldResourceID,
+		})
+	}
+	if aeuo.mutation.ResourceIDCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: auditentry.FieldResourceID,
+		})
+	}
+	if value, ok := aeuo.mutation.Attributes(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Value:  value,
+			Column: auditentry.FieldAttributes,
+		})
+	}
+	if aeuo.mutation.AttributesCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeJSON,
+			Column: auditentry.FieldAttributes,
+		})
+	}
+	if value, ok := aeuo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditentry.FieldStatus,
+		})
+	}
+	if value, ok := aeuo.mutation.AddedStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: auditentry.FieldStatus,
+		})
+	}
+	if value, ok := aeuo.mutation.Outcome(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldOutcome,
+		})
+	}
+	if value, ok := aeuo.mutation.PrevHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldPrevHash,
+		})
+	}
+	if value, ok := aeuo.mutation.Hash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: auditentry.FieldHash,
+		})
+	}
+	_node = &AuditEntry{config: aeuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aeuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -11,14 +11,17 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/cybozu-go/scim-server/ent/address"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
 	"github.com/cybozu-go/scim-server/ent/email"
 	"github.com/cybozu-go/scim-server/ent/entitlement"
 	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/idempotencykey"
 	"github.com/cybozu-go/scim-server/ent/ims"
 	"github.com/cybozu-go/scim-server/ent/member"
 	"github.com/cybozu-go/scim-server/ent/names"
 	"github.com/cybozu-go/scim-server/ent/phonenumber"
 	"github.com/cybozu-go/scim-server/ent/photo"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
 	"github.com/cybozu-go/scim-server/ent/role"
 	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim-server/ent/x509certificate"
@@ -35,6 +38,8 @@ type Client struct {
 	Schema *migrate.Schema
 	// Address is the client for interacting with the Address builders.
 	Address *AddressClient
+	// AuditEntry is the client for interacting with the AuditEntry builders.
+	AuditEntry *AuditEntryClient
 	// Email is the client for interacting with the Email builders.
 	Email *EmailClient
 	// Entitlement is the client for interacting with the Entitlement builders.
@@ -43,6 +48,8 @@ type Client struct {
 	Group *GroupClient
 	// IMS is the client for interacting with the IMS builders.
 	IMS *IMSClient
+	// IdempotencyKey is the client for interacting with the IdempotencyKey builders.
+	IdempotencyKey *IdempotencyKeyClient
 	// Member is the client for interacting with the Member builders.
 	Member *MemberClient
 	// Names is the client for interacting with the Names builders.
@@ -51,6 +58,8 @@ type Client struct {
 	PhoneNumber *PhoneNumberClient
 	// Photo is the client for interacting with the Photo builders.
 	Photo *PhotoClient
+	// ResourceVersion is the client for interacting with the ResourceVersion builders.
+	ResourceVersion *ResourceVersionClient
 	// Role is the client for interacting with the Role builders.
 	Role *RoleClient
 	// User is the client for interacting with the User builders.
@@ -71,14 +80,17 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.Address = NewAddressClient(c.config)
+	c.AuditEntry = NewAuditEntryClient(c.config)
 	c.Email = NewEmailClient(c.config)
 	c.Entitlement = NewEntitlementClient(c.config)
 	c.Group = NewGroupClient(c.config)
 	c.IMS = NewIMSClient(c.config)
+	c.IdempotencyKey = NewIdempotencyKeyClient(c.config)
 	c.Member = NewMemberClient(c.config)
 	c.Names = NewNamesClient(c.config)
 	c.PhoneNumber = NewPhoneNumberClient(c.config)
 	c.Photo = NewPhotoClient(c.config)
+	c.ResourceVersion = NewResourceVersionClient(c.config)
 	c.Role = NewRoleClient(c.config)
 	c.User = NewUserClient(c.config)
 	c.X509Certificate = NewX509CertificateClient(c.config)
@@ -116,14 +128,17 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		ctx:             ctx,
 		config:          cfg,
 		Address:         NewAddressClient(cfg),
+		AuditEntry:      NewAuditEntryClient(cfg),
 		Email:           NewEmailClient(cfg),
 		Entitlement:     NewEntitlementClient(cfg),
 		Group:           NewGroupClient(cfg),
 		IMS:             NewIMSClient(cfg),
+		IdempotencyKey:  NewIdempotencyKeyClient(cfg),
 		Member:          NewMemberClient(cfg),
 		Names:           NewNamesClient(cfg),
 		PhoneNumber:     NewPhoneNumberClient(cfg),
 		Photo:           NewPhotoClient(cfg),
+		ResourceVersion: NewResourceVersionClient(cfg),
 		Role:            NewRoleClient(cfg),
 		User:            NewUserClient(cfg),
 		X509Certificate: NewX509CertificateClient(cfg),
@@ -147,14 +162,17 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		ctx:             ctx,
 		config:          cfg,
 		Address:         NewAddressClient(cfg),
+		AuditEntry:      NewAuditEntryClient(cfg),
 		Email:           NewEmailClient(cfg),
 		Entitlement:     NewEntitlementClient(cfg),
 		Group:           NewGroupClient(cfg),
 		IMS:             NewIMSClient(cfg),
+		IdempotencyKey:  NewIdempotencyKeyClient(cfg),
 		Member:          NewMemberClient(cfg),
 		Names:           NewNamesClient(cfg),
 		PhoneNumber:     NewPhoneNumberClient(cfg),
 		Photo:           NewPhotoClient(cfg),
+		ResourceVersion: NewResourceVersionClient(cfg),
 		Role:            NewRoleClient(cfg),
 		User:            NewUserClient(cfg),
 		X509Certificate: NewX509CertificateClient(cfg),
@@ -188,14 +206,17 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	c.Address.Use(hooks...)
+	c.AuditEntry.Use(hooks...)
 	c.Email.Use(hooks...)
 	c.Entitlement.Use(hooks...)
 	c.Group.Use(hooks...)
 	c.IMS.Use(hooks...)
+	c.IdempotencyKey.Use(hooks...)
 	c.Member.Use(hooks...)
 	c.Names.Use(hooks...)
 	c.PhoneNumber.Use(hooks...)
 	c.Photo.Use(hooks...)
+	c.ResourceVersion.Use(hooks...)
 	c.Role.Use(hooks...)
 	c.User.Use(hooks...)
 	c.X509Certificate.Use(hooks...)
@@ -307,6 +328,96 @@ func (c *AddressClient) Hooks() []Hook {
 	return c.hooks.Address
 }
 
+// AuditEntryClient is a client for the AuditEntry schema.
+type AuditEntryClient struct {
+	config
+}
+
+// NewAuditEntryClient returns a client for the AuditEntry from the given config.
+func NewAuditEntryClient(c config) *AuditEntryClient {
+	return &AuditEntryClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `auditentry.Hooks(f(g(h())))`.
+func (c *AuditEntryClient) Use(hooks ...Hook) {
+	c.hooks.AuditEntry = append(c.hooks.AuditEntry, hooks...)
+}
+
+// Create returns a builder for creating a AuditEntry entity.
+func (c *AuditEntryClient) Create() *AuditEntryCreate {
+	mutation := newAuditEntryMutation(c.config, OpCreate)
+	return &AuditEntryCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of AuditEntry entities.
+func (c *AuditEntryClient) CreateBulk(builders ...*AuditEntryCreate) *AuditEntryCreateBulk {
+	return &AuditEntryCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for AuditEntry.
+func (c *AuditEntryClient) Update() *AuditEntryUpdate {
+	mutation := newAuditEntryMutation(c.config, OpUpdate)
+	return &AuditEntryUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AuditEntryClient) UpdateOne(ae *AuditEntry) *AuditEntryUpdateOne {
+	mutation := newAuditEntryMutation(c.config, OpUpdateOne, withAuditEntry(ae))
+	return &AuditEntryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AuditEntryClient) UpdateOneID(id int) *AuditEntryUpdateOne {
+	mutation := newAuditEntryMutation(c.config, OpUpdateOne, withAuditEntryID(id))
+	return &AuditEntryUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for AuditEntry.
+func (c *AuditEntryClient) Delete() *AuditEntryDelete {
+	mutation := newAuditEntryMutation(c.config, OpDelete)
+	return &AuditEntryDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AuditEntryClient) DeleteOne(ae *AuditEntry) *AuditEntryDeleteOne {
+	return c.DeleteOneID(ae.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *AuditEntryClient) DeleteOneID(id int) *AuditEntryDeleteOne {
+	builder := c.Delete().Where(auditentry.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AuditEntryDeleteOne{builder}
+}
+
+// Query returns a query builder for AuditEntry.
+func (c *AuditEntryClient) Query() *AuditEntryQuery {
+	return &AuditEntryQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a AuditEntry entity by its id.
+func (c *AuditEntryClient) Get(ctx context.Context, id int) (*AuditEntry, error) {
+	return c.Query().Where(auditentry.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AuditEntryClient) GetX(ctx context.Context, id int) *AuditEntry {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AuditEntryClient) Hooks() []Hook {
+	return c.hooks.AuditEntry
+}
+
 // EmailClient is a client for the Email schema.
 type EmailClient struct {
 	config
@@ -622,7 +733,8 @@ func (c *GroupClient) QueryMembers(gr *Group) *MemberQuery {
 
 // Hooks returns the client hooks.
 func (c *GroupClient) Hooks() []Hook {
-	return c.hooks.Group
+	hooks := c.hooks.Group
+	return append(hooks[:len(hooks):len(hooks)], group.Hooks[:]...)
 }
 
 // IMSClient is a client for the IMS schema.
@@ -731,6 +843,96 @@ func (c *IMSClient) Hooks() []Hook {
 	return c.hooks.IMS
 }
 
+// IdempotencyKeyClient is a client for the IdempotencyKey schema.
+type IdempotencyKeyClient struct {
+	config
+}
+
+// NewIdempotencyKeyClient returns a client for the IdempotencyKey from the given config.
+func NewIdempotencyKeyClient(c config) *IdempotencyKeyClient {
+	return &IdempotencyKeyClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `idempotencykey.Hooks(f(g(h())))`.
+func (c *IdempotencyKeyClient) Use(hooks ...Hook) {
+	c.hooks.IdempotencyKey = append(c.hooks.IdempotencyKey, hooks...)
+}
+
+// Create returns a builder for creating a IdempotencyKey entity.
+func (c *IdempotencyKeyClient) Create() *IdempotencyKeyCreate {
+	mutation := newIdempotencyKeyMutation(c.config, OpCreate)
+	return &IdempotencyKeyCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of IdempotencyKey entities.
+func (c *IdempotencyKeyClient) CreateBulk(builders ...*IdempotencyKeyCreate) *IdempotencyKeyCreateBulk {
+	return &IdempotencyKeyCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for IdempotencyKey.
+func (c *IdempotencyKeyClient) Update() *IdempotencyKeyUpdate {
+	mutation := newIdempotencyKeyMutation(c.config, OpUpdate)
+	return &IdempotencyKeyUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *IdempotencyKeyClient) UpdateOne(ik *IdempotencyKey) *IdempotencyKeyUpdateOne {
+	mutation := newIdempotencyKeyMutation(c.config, OpUpdateOne, withIdempotencyKey(ik))
+	return &IdempotencyKeyUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *IdempotencyKeyClient) UpdateOneID(id int) *IdempotencyKeyUpdateOne {
+	mutation := newIdempotencyKeyMutation(c.config, OpUpdateOne, withIdempotencyKeyID(id))
+	return &IdempotencyKeyUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for IdempotencyKey.
+func (c *IdempotencyKeyClient) Delete() *IdempotencyKeyDelete {
+	mutation := newIdempotencyKeyMutation(c.config, OpDelete)
+	return &IdempotencyKeyDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *IdempotencyKeyClient) DeleteOne(ik *IdempotencyKey) *IdempotencyKeyDeleteOne {
+	return c.DeleteOneID(ik.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *IdempotencyKeyClient) DeleteOneID(id int) *IdempotencyKeyDeleteOne {
+	builder := c.Delete().Where(idempotencykey.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &IdempotencyKeyDeleteOne{builder}
+}
+
+// Query returns a query builder for IdempotencyKey.
+func (c *IdempotencyKeyClient) Query() *IdempotencyKeyQuery {
+	return &IdempotencyKeyQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a IdempotencyKey entity by its id.
+func (c *IdempotencyKeyClient) Get(ctx context.Context, id int) (*IdempotencyKey, error) {
+	return c.Query().Where(idempotencykey.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *IdempotencyKeyClient) GetX(ctx context.Context, id int) *IdempotencyKey {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *IdempotencyKeyClient) Hooks() []Hook {
+	return c.hooks.IdempotencyKey
+}
+
 // MemberClient is a client for the Member schema.
 type MemberClient struct {
 	config
@@ -1156,6 +1358,96 @@ func (c *PhotoClient) Hooks() []Hook {
 	return append(hooks[:len(hooks):len(hooks)], photo.Hooks[:]...)
 }
 
+// ResourceVersionClient is a client for the ResourceVersion schema.
+type ResourceVersionClient struct {
+	config
+}
+
+// NewResourceVersionClient returns a client for the ResourceVersion from the given config.
+func NewResourceVersionClient(c config) *ResourceVersionClient {
+	return &ResourceVersionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `resourceversion.Hooks(f(g(h())))`.
+func (c *ResourceVersionClient) Use(hooks ...Hook) {
+	c.hooks.ResourceVersion = append(c.hooks.ResourceVersion, hooks...)
+}
+
+// Create returns a builder for creating a ResourceVersion entity.
+func (c *ResourceVersionClient) Create() *ResourceVersionCreate {
+	mutation := newResourceVersionMutation(c.config, OpCreate)
+	return &ResourceVersionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ResourceVersion entities.
+func (c *ResourceVersionClient) CreateBulk(builders ...*ResourceVersionCreate) *ResourceVersionCreateBulk {
+	return &ResourceVersionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ResourceVersion.
+func (c *ResourceVersionClient) Update() *ResourceVersionUpdate {
+	mutation := newResourceVersionMutation(c.config, OpUpdate)
+	return &ResourceVersionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ResourceVersionClient) UpdateOne(rv *ResourceVersion) *ResourceVersionUpdateOne {
+	mutation := newResourceVersionMutation(c.config, OpUpdateOne, withResourceVersion(rv))
+	return &ResourceVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ResourceVersionClient) UpdateOneID(id int) *ResourceVersionUpdateOne {
+	mutation := newResourceVersionMutation(c.config, OpUpdateOne, withResourceVersionID(id))
+	return &ResourceVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ResourceVersion.
+func (c *ResourceVersionClient) Delete() *ResourceVersionDelete {
+	mutation := newResourceVersionMutation(c.config, OpDelete)
+	return &ResourceVersionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ResourceVersionClient) DeleteOne(rv *ResourceVersion) *ResourceVersionDeleteOne {
+	return c.DeleteOneID(rv.ID)
+}
+
+// DeleteOne returns a builder for deleting the given entity by its id.
+func (c *ResourceVersionClient) DeleteOneID(id int) *ResourceVersionDeleteOne {
+	builder := c.Delete().Where(resourceversion.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ResourceVersionDeleteOne{builder}
+}
+
+// Query returns a query builder for ResourceVersion.
+func (c *ResourceVersionClient) Query() *ResourceVersionQuery {
+	return &ResourceVersionQuery{
+		config: c.config,
+	}
+}
+
+// Get returns a ResourceVersion entity by its id.
+func (c *ResourceVersionClient) Get(ctx context.Context, id int) (*ResourceVersion, error) {
+	return c.Query().Where(resourceversion.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ResourceVersionClient) GetX(ctx context.Context, id int) *ResourceVersion {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ResourceVersionClient) Hooks() []Hook {
+	return c.hooks.ResourceVersion
+}
+
 // RoleClient is a client for the Role schema.
 type RoleClient struct {
 	config
@@ -1493,7 +1785,8 @@ func (c *UserClient) QueryX509Certificates(u *User) *X509CertificateQuery {
 
 // Hooks returns the client hooks.
 func (c *UserClient) Hooks() []Hook {
-	return c.hooks.User
+	hooks := c.hooks.User
+	return append(hooks[:len(hooks):len(hooks)], user.Hooks[:]...)
 }
 
 // X509CertificateClient is a client for the X509Certificate schema.
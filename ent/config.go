@@ -3,6 +3,10 @@
 package ent
 
 import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
 	"github.com/cybozu-go/scim-server/helper"
@@ -29,14 +33,17 @@ type config struct {
 // hooks per client, for fast access.
 type hooks struct {
 	Address         []ent.Hook
+	AuditEntry      []ent.Hook
 	Email           []ent.Hook
 	Entitlement     []ent.Hook
 	Group           []ent.Hook
 	IMS             []ent.Hook
+	IdempotencyKey  []ent.Hook
 	Member          []ent.Hook
 	Names           []ent.Hook
 	PhoneNumber     []ent.Hook
 	Photo           []ent.Hook
+	ResourceVersion []ent.Hook
 	Role            []ent.Hook
 	User            []ent.Hook
 	X509Certificate []ent.Hook
@@ -86,3 +93,27 @@ func Bucket(v *blob.Bucket) Option {
 		c.Bucket = v
 	}
 }
+
+// ExecContext allows calling the underlying ExecContext method of the driver if it is supported by it.
+// See, database/sql#DB.ExecContext for more information.
+func (c *config) ExecContext(ctx context.Context, query string, args ...interface{}) (stdsql.Result, error) {
+	ex, ok := c.driver.(interface {
+		ExecContext(context.Context, string, ...interface{}) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the driver if it is supported by it.
+// See, database/sql#DB.QueryContext for more information.
+func (c *config) QueryContext(ctx context.Context, query string, args ...interface{}) (*stdsql.Rows, error) {
+	q, ok := c.driver.(interface {
+		QueryContext(context.Context, string, ...interface{}) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
@@ -11,14 +11,17 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/cybozu-go/scim-server/ent/address"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
 	"github.com/cybozu-go/scim-server/ent/email"
 	"github.com/cybozu-go/scim-server/ent/entitlement"
 	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/idempotencykey"
 	"github.com/cybozu-go/scim-server/ent/ims"
 	"github.com/cybozu-go/scim-server/ent/member"
 	"github.com/cybozu-go/scim-server/ent/names"
 	"github.com/cybozu-go/scim-server/ent/phonenumber"
 	"github.com/cybozu-go/scim-server/ent/photo"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
 	"github.com/cybozu-go/scim-server/ent/role"
 	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim-server/ent/x509certificate"
@@ -43,14 +46,17 @@ type OrderFunc func(*sql.Selector)
 func columnChecker(table string) func(string) error {
 	checks := map[string]func(string) bool{
 		address.Table:         address.ValidColumn,
+		auditentry.Table:      auditentry.ValidColumn,
 		email.Table:           email.ValidColumn,
 		entitlement.Table:     entitlement.ValidColumn,
 		group.Table:           group.ValidColumn,
 		ims.Table:             ims.ValidColumn,
+		idempotencykey.Table:  idempotencykey.ValidColumn,
 		member.Table:          member.ValidColumn,
 		names.Table:           names.ValidColumn,
 		phonenumber.Table:     phonenumber.ValidColumn,
 		photo.Table:           photo.ValidColumn,
+		resourceversion.Table: resourceversion.ValidColumn,
 		role.Table:            role.ValidColumn,
 		user.Table:            user.ValidColumn,
 		x509certificate.Table: x509certificate.ValidColumn,
@@ -22,6 +22,29 @@ func (a *Address) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, a.StreetAddress)
 	return nil
 }
+func (ae *AuditEntry) ComputeETag(h hash.Hash) error {
+	fmt.Fprint(h, "Timestamp")
+	fmt.Fprint(h, ae.Timestamp)
+	fmt.Fprint(h, "ClientName")
+	fmt.Fprint(h, ae.ClientName)
+	fmt.Fprint(h, "Operation")
+	fmt.Fprint(h, ae.Operation)
+	fmt.Fprint(h, "ResourceType")
+	fmt.Fprint(h, ae.ResourceType)
+	fmt.Fprint(h, "ResourceID")
+	fmt.Fprint(h, ae.ResourceID)
+	fmt.Fprint(h, "Attributes")
+	fmt.Fprint(h, ae.Attributes)
+	fmt.Fprint(h, "Status")
+	fmt.Fprint(h, ae.Status)
+	fmt.Fprint(h, "Outcome")
+	fmt.Fprint(h, ae.Outcome)
+	fmt.Fprint(h, "PrevHash")
+	fmt.Fprint(h, ae.PrevHash)
+	fmt.Fprint(h, "Hash")
+	fmt.Fprint(h, ae.Hash)
+	return nil
+}
 func (e *Email) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, "Display")
 	fmt.Fprint(h, e.Display)
@@ -45,6 +68,16 @@ func (e *Entitlement) ComputeETag(h hash.Hash) error {
 	return nil
 }
 func (gr *Group) ComputeETag(h hash.Hash) error {
+	fmt.Fprint(h, "NormalizedDisplayName")
+	fmt.Fprint(h, gr.NormalizedDisplayName)
+	fmt.Fprint(h, "ExternalIDClient")
+	fmt.Fprint(h, gr.ExternalIDClient)
+	fmt.Fprint(h, "DeletedAt")
+	fmt.Fprint(h, gr.DeletedAt)
+	fmt.Fprint(h, "DeletedBy")
+	fmt.Fprint(h, gr.DeletedBy)
+	fmt.Fprint(h, "DeletedMemberships")
+	fmt.Fprint(h, gr.DeletedMemberships)
 	fmt.Fprint(h, "DisplayName")
 	fmt.Fprint(h, gr.DisplayName)
 	fmt.Fprint(h, "ExternalID")
@@ -67,6 +100,29 @@ func (i *IMS) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, i.Value)
 	return nil
 }
+func (ik *IdempotencyKey) ComputeETag(h hash.Hash) error {
+	fmt.Fprint(h, "ClientName")
+	fmt.Fprint(h, ik.ClientName)
+	fmt.Fprint(h, "Key")
+	fmt.Fprint(h, ik.Key)
+	fmt.Fprint(h, "RequestHash")
+	fmt.Fprint(h, ik.RequestHash)
+	fmt.Fprint(h, "Status")
+	fmt.Fprint(h, ik.Status)
+	fmt.Fprint(h, "Header")
+	fmt.Fprint(h, ik.Header)
+	fmt.Fprint(h, "Body")
+	fmt.Fprint(h, ik.Body)
+	fmt.Fprint(h, "CreatedAt")
+	fmt.Fprint(h, ik.CreatedAt)
+	fmt.Fprint(h, "ExpiresAt")
+	fmt.Fprint(h, ik.ExpiresAt)
+	fmt.Fprint(h, "ReservedAt")
+	fmt.Fprint(h, ik.ReservedAt)
+	fmt.Fprint(h, "Reservation")
+	fmt.Fprint(h, ik.Reservation)
+	return nil
+}
 func (m *Member) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, "Value")
 	fmt.Fprint(h, m.Value)
@@ -115,6 +171,23 @@ func (ph *Photo) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, ph.Value)
 	return nil
 }
+func (rv *ResourceVersion) ComputeETag(h hash.Hash) error {
+	fmt.Fprint(h, "ResourceType")
+	fmt.Fprint(h, rv.ResourceType)
+	fmt.Fprint(h, "ResourceID")
+	fmt.Fprint(h, rv.ResourceID)
+	fmt.Fprint(h, "Version")
+	fmt.Fprint(h, rv.Version)
+	fmt.Fprint(h, "Operation")
+	fmt.Fprint(h, rv.Operation)
+	fmt.Fprint(h, "Actor")
+	fmt.Fprint(h, rv.Actor)
+	fmt.Fprint(h, "Data")
+	fmt.Fprint(h, rv.Data)
+	fmt.Fprint(h, "CreatedAt")
+	fmt.Fprint(h, rv.CreatedAt)
+	return nil
+}
 func (r *Role) ComputeETag(h hash.Hash) error {
 	fmt.Fprint(h, "Display")
 	fmt.Fprint(h, r.Display)
@@ -127,6 +200,16 @@ func (r *Role) ComputeETag(h hash.Hash) error {
 	return nil
 }
 func (u *User) ComputeETag(h hash.Hash) error {
+	fmt.Fprint(h, "NormalizedUserName")
+	fmt.Fprint(h, u.NormalizedUserName)
+	fmt.Fprint(h, "ExternalIDClient")
+	fmt.Fprint(h, u.ExternalIDClient)
+	fmt.Fprint(h, "DeletedAt")
+	fmt.Fprint(h, u.DeletedAt)
+	fmt.Fprint(h, "DeletedBy")
+	fmt.Fprint(h, u.DeletedBy)
+	fmt.Fprint(h, "DeletedMemberships")
+	fmt.Fprint(h, u.DeletedMemberships)
 	fmt.Fprint(h, "Active")
 	fmt.Fprint(h, u.Active)
 	fmt.Fprint(h, "DisplayName")
@@ -16,7 +16,9 @@ func main() {
 	var pr helper.PhotoURLFunc = func(string, string) (string, error) { return "dummy", nil }
 	// &helper.NilPhotoURL{}
 	err := entc.Generate("./schema",
-		&gen.Config{},
+		&gen.Config{
+			Features: []gen.Feature{gen.FeatureExecQuery},
+		},
 		entc.Extensions(&ETag{}),
 		entc.Dependency(
 			// object that is responsible for
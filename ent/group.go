@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/cybozu-go/scim-server/ent/group"
@@ -16,6 +17,16 @@ type Group struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID uuid.UUID `json:"id,omitempty"`
+	// NormalizedDisplayName holds the value of the "normalizedDisplayName" field.
+	NormalizedDisplayName string `json:"normalizedDisplayName,omitempty"`
+	// ExternalIDClient holds the value of the "externalIDClient" field.
+	ExternalIDClient string `json:"externalIDClient,omitempty"`
+	// DeletedAt holds the value of the "deletedAt" field.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// DeletedBy holds the value of the "deletedBy" field.
+	DeletedBy string `json:"deletedBy,omitempty"`
+	// DeletedMemberships holds the value of the "deletedMemberships" field.
+	DeletedMemberships []byte `json:"deletedMemberships,omitempty"`
 	// DisplayName holds the value of the "displayName" field.
 	DisplayName string `json:"displayName,omitempty"`
 	// ExternalID holds the value of the "externalID" field.
@@ -50,8 +61,12 @@ func (*Group) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case group.FieldDisplayName, group.FieldExternalID, group.FieldEtag:
+		case group.FieldDeletedMemberships:
+			values[i] = new([]byte)
+		case group.FieldNormalizedDisplayName, group.FieldExternalIDClient, group.FieldDeletedBy, group.FieldDisplayName, group.FieldExternalID, group.FieldEtag:
 			values[i] = new(sql.NullString)
+		case group.FieldDeletedAt:
+			values[i] = new(sql.NullTime)
 		case group.FieldID:
 			values[i] = new(uuid.UUID)
 		default:
@@ -75,6 +90,37 @@ func (gr *Group) assignValues(columns []string, values []interface{}) error {
 			} else if value != nil {
 				gr.ID = *value
 			}
+		case group.FieldNormalizedDisplayName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field normalizedDisplayName", values[i])
+			} else if value.Valid {
+				gr.NormalizedDisplayName = value.String
+			}
+		case group.FieldExternalIDClient:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field externalIDClient", values[i])
+			} else if value.Valid {
+				gr.ExternalIDClient = value.String
+			}
+		case group.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedAt", values[i])
+			} else if value.Valid {
+				gr.DeletedAt = new(time.Time)
+				*gr.DeletedAt = value.Time
+			}
+		case group.FieldDeletedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedBy", values[i])
+			} else if value.Valid {
+				gr.DeletedBy = value.String
+			}
+		case group.FieldDeletedMemberships:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedMemberships", values[i])
+			} else if value != nil {
+				gr.DeletedMemberships = *value
+			}
 		case group.FieldDisplayName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field displayName", values[i])
@@ -126,6 +172,23 @@ func (gr *Group) String() string {
 	var builder strings.Builder
 	builder.WriteString("Group(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", gr.ID))
+	builder.WriteString("normalizedDisplayName=")
+	builder.WriteString(gr.NormalizedDisplayName)
+	builder.WriteString(", ")
+	builder.WriteString("externalIDClient=")
+	builder.WriteString(gr.ExternalIDClient)
+	builder.WriteString(", ")
+	if v := gr.DeletedAt; v != nil {
+		builder.WriteString("deletedAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("deletedBy=")
+	builder.WriteString(gr.DeletedBy)
+	builder.WriteString(", ")
+	builder.WriteString("deletedMemberships=")
+	builder.WriteString(fmt.Sprintf("%v", gr.DeletedMemberships))
+	builder.WriteString(", ")
 	builder.WriteString("displayName=")
 	builder.WriteString(gr.DisplayName)
 	builder.WriteString(", ")
@@ -3,6 +3,7 @@
 package group
 
 import (
+	"entgo.io/ent"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,16 @@ const (
 	Label = "group"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldNormalizedDisplayName holds the string denoting the normalizeddisplayname field in the database.
+	FieldNormalizedDisplayName = "normalized_display_name"
+	// FieldExternalIDClient holds the string denoting the externalidclient field in the database.
+	FieldExternalIDClient = "external_id_client"
+	// FieldDeletedAt holds the string denoting the deletedat field in the database.
+	FieldDeletedAt = "deleted_at"
+	// FieldDeletedBy holds the string denoting the deletedby field in the database.
+	FieldDeletedBy = "deleted_by"
+	// FieldDeletedMemberships holds the string denoting the deletedmemberships field in the database.
+	FieldDeletedMemberships = "deleted_memberships"
 	// FieldDisplayName holds the string denoting the displayname field in the database.
 	FieldDisplayName = "display_name"
 	// FieldExternalID holds the string denoting the externalid field in the database.
@@ -33,6 +44,11 @@ const (
 // Columns holds all SQL columns for group fields.
 var Columns = []string{
 	FieldID,
+	FieldNormalizedDisplayName,
+	FieldExternalIDClient,
+	FieldDeletedAt,
+	FieldDeletedBy,
+	FieldDeletedMemberships,
 	FieldDisplayName,
 	FieldExternalID,
 	FieldEtag,
@@ -48,7 +64,14 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/cybozu-go/scim-server/ent/runtime"
+//
 var (
+	Hooks [1]ent.Hook
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
@@ -3,6 +3,8 @@
 package group
 
 import (
+	"time"
+
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"github.com/cybozu-go/scim-server/ent/predicate"
@@ -80,6 +82,41 @@ func IDLTE(id uuid.UUID) predicate.Group {
 	})
 }
 
+// NormalizedDisplayName applies equality check predicate on the "normalizedDisplayName" field. It's identical to NormalizedDisplayNameEQ.
+func NormalizedDisplayName(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// ExternalIDClient applies equality check predicate on the "externalIDClient" field. It's identical to ExternalIDClientEQ.
+func ExternalIDClient(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// DeletedAt applies equality check predicate on the "deletedAt" field. It's identical to DeletedAtEQ.
+func DeletedAt(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedBy applies equality check predicate on the "deletedBy" field. It's identical to DeletedByEQ.
+func DeletedBy(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedMemberships applies equality check predicate on the "deletedMemberships" field. It's identical to DeletedMembershipsEQ.
+func DeletedMemberships(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedMemberships), v))
+	})
+}
+
 // DisplayName applies equality check predicate on the "displayName" field. It's identical to DisplayNameEQ.
 func DisplayName(v string) predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
@@ -101,6 +138,561 @@ func Etag(v string) predicate.Group {
 	})
 }
 
+// NormalizedDisplayNameEQ applies the EQ predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameNEQ applies the NEQ predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameNEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameIn applies the In predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldNormalizedDisplayName), v...))
+	})
+}
+
+// NormalizedDisplayNameNotIn applies the NotIn predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameNotIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldNormalizedDisplayName), v...))
+	})
+}
+
+// NormalizedDisplayNameGT applies the GT predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameGT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameGTE applies the GTE predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameGTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameLT applies the LT predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameLT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameLTE applies the LTE predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameLTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameContains applies the Contains predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameContains(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameHasPrefix applies the HasPrefix predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameHasPrefix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameHasSuffix applies the HasSuffix predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameHasSuffix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameIsNil applies the IsNil predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameIsNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldNormalizedDisplayName)))
+	})
+}
+
+// NormalizedDisplayNameNotNil applies the NotNil predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameNotNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldNormalizedDisplayName)))
+	})
+}
+
+// NormalizedDisplayNameEqualFold applies the EqualFold predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameEqualFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// NormalizedDisplayNameContainsFold applies the ContainsFold predicate on the "normalizedDisplayName" field.
+func NormalizedDisplayNameContainsFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldNormalizedDisplayName), v))
+	})
+}
+
+// ExternalIDClientEQ applies the EQ predicate on the "externalIDClient" field.
+func ExternalIDClientEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientNEQ applies the NEQ predicate on the "externalIDClient" field.
+func ExternalIDClientNEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientIn applies the In predicate on the "externalIDClient" field.
+func ExternalIDClientIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldExternalIDClient), v...))
+	})
+}
+
+// ExternalIDClientNotIn applies the NotIn predicate on the "externalIDClient" field.
+func ExternalIDClientNotIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldExternalIDClient), v...))
+	})
+}
+
+// ExternalIDClientGT applies the GT predicate on the "externalIDClient" field.
+func ExternalIDClientGT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientGTE applies the GTE predicate on the "externalIDClient" field.
+func ExternalIDClientGTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientLT applies the LT predicate on the "externalIDClient" field.
+func ExternalIDClientLT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientLTE applies the LTE predicate on the "externalIDClient" field.
+func ExternalIDClientLTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientContains applies the Contains predicate on the "externalIDClient" field.
+func ExternalIDClientContains(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientHasPrefix applies the HasPrefix predicate on the "externalIDClient" field.
+func ExternalIDClientHasPrefix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientHasSuffix applies the HasSuffix predicate on the "externalIDClient" field.
+func ExternalIDClientHasSuffix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientIsNil applies the IsNil predicate on the "externalIDClient" field.
+func ExternalIDClientIsNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldExternalIDClient)))
+	})
+}
+
+// ExternalIDClientNotNil applies the NotNil predicate on the "externalIDClient" field.
+func ExternalIDClientNotNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldExternalIDClient)))
+	})
+}
+
+// ExternalIDClientEqualFold applies the EqualFold predicate on the "externalIDClient" field.
+func ExternalIDClientEqualFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// ExternalIDClientContainsFold applies the ContainsFold predicate on the "externalIDClient" field.
+func ExternalIDClientContainsFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldExternalIDClient), v))
+	})
+}
+
+// DeletedAtEQ applies the EQ predicate on the "deletedAt" field.
+func DeletedAtEQ(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtNEQ applies the NEQ predicate on the "deletedAt" field.
+func DeletedAtNEQ(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtIn applies the In predicate on the "deletedAt" field.
+func DeletedAtIn(vs ...time.Time) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldDeletedAt), v...))
+	})
+}
+
+// DeletedAtNotIn applies the NotIn predicate on the "deletedAt" field.
+func DeletedAtNotIn(vs ...time.Time) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldDeletedAt), v...))
+	})
+}
+
+// DeletedAtGT applies the GT predicate on the "deletedAt" field.
+func DeletedAtGT(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtGTE applies the GTE predicate on the "deletedAt" field.
+func DeletedAtGTE(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtLT applies the LT predicate on the "deletedAt" field.
+func DeletedAtLT(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtLTE applies the LTE predicate on the "deletedAt" field.
+func DeletedAtLTE(v time.Time) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDeletedAt), v))
+	})
+}
+
+// DeletedAtIsNil applies the IsNil predicate on the "deletedAt" field.
+func DeletedAtIsNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDeletedAt)))
+	})
+}
+
+// DeletedAtNotNil applies the NotNil predicate on the "deletedAt" field.
+func DeletedAtNotNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDeletedAt)))
+	})
+}
+
+// DeletedByEQ applies the EQ predicate on the "deletedBy" field.
+func DeletedByEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByNEQ applies the NEQ predicate on the "deletedBy" field.
+func DeletedByNEQ(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByIn applies the In predicate on the "deletedBy" field.
+func DeletedByIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldDeletedBy), v...))
+	})
+}
+
+// DeletedByNotIn applies the NotIn predicate on the "deletedBy" field.
+func DeletedByNotIn(vs ...string) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldDeletedBy), v...))
+	})
+}
+
+// DeletedByGT applies the GT predicate on the "deletedBy" field.
+func DeletedByGT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByGTE applies the GTE predicate on the "deletedBy" field.
+func DeletedByGTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByLT applies the LT predicate on the "deletedBy" field.
+func DeletedByLT(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByLTE applies the LTE predicate on the "deletedBy" field.
+func DeletedByLTE(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByContains applies the Contains predicate on the "deletedBy" field.
+func DeletedByContains(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByHasPrefix applies the HasPrefix predicate on the "deletedBy" field.
+func DeletedByHasPrefix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByHasSuffix applies the HasSuffix predicate on the "deletedBy" field.
+func DeletedByHasSuffix(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByIsNil applies the IsNil predicate on the "deletedBy" field.
+func DeletedByIsNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDeletedBy)))
+	})
+}
+
+// DeletedByNotNil applies the NotNil predicate on the "deletedBy" field.
+func DeletedByNotNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDeletedBy)))
+	})
+}
+
+// DeletedByEqualFold applies the EqualFold predicate on the "deletedBy" field.
+func DeletedByEqualFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedByContainsFold applies the ContainsFold predicate on the "deletedBy" field.
+func DeletedByContainsFold(v string) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldDeletedBy), v))
+	})
+}
+
+// DeletedMembershipsEQ applies the EQ predicate on the "deletedMemberships" field.
+func DeletedMembershipsEQ(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsNEQ applies the NEQ predicate on the "deletedMemberships" field.
+func DeletedMembershipsNEQ(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsIn applies the In predicate on the "deletedMemberships" field.
+func DeletedMembershipsIn(vs ...[]byte) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldDeletedMemberships), v...))
+	})
+}
+
+// DeletedMembershipsNotIn applies the NotIn predicate on the "deletedMemberships" field.
+func DeletedMembershipsNotIn(vs ...[]byte) predicate.Group {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.Group(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldDeletedMemberships), v...))
+	})
+}
+
+// DeletedMembershipsGT applies the GT predicate on the "deletedMemberships" field.
+func DeletedMembershipsGT(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsGTE applies the GTE predicate on the "deletedMemberships" field.
+func DeletedMembershipsGTE(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsLT applies the LT predicate on the "deletedMemberships" field.
+func DeletedMembershipsLT(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsLTE applies the LTE predicate on the "deletedMemberships" field.
+func DeletedMembershipsLTE(v []byte) predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldDeletedMemberships), v))
+	})
+}
+
+// DeletedMembershipsIsNil applies the IsNil predicate on the "deletedMemberships" field.
+func DeletedMembershipsIsNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldDeletedMemberships)))
+	})
+}
+
+// DeletedMembershipsNotNil applies the NotNil predicate on the "deletedMemberships" field.
+func DeletedMembershipsNotNil() predicate.Group {
+	return predicate.Group(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldDeletedMemberships)))
+	})
+}
+
 // DisplayNameEQ applies the EQ predicate on the "displayName" field.
 func DisplayNameEQ(v string) predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
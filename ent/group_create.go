@@ -5,6 +5,7 @@ package ent
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -20,6 +21,68 @@ type GroupCreate struct {
 	hooks    []Hook
 }
 
+// SetNormalizedDisplayName sets the "normalizedDisplayName" field.
+func (gc *GroupCreate) SetNormalizedDisplayName(s string) *GroupCreate {
+	gc.mutation.SetNormalizedDisplayName(s)
+	return gc
+}
+
+// SetNillableNormalizedDisplayName sets the "normalizedDisplayName" field if the given value is not nil.
+func (gc *GroupCreate) SetNillableNormalizedDisplayName(s *string) *GroupCreate {
+	if s != nil {
+		gc.SetNormalizedDisplayName(*s)
+	}
+	return gc
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (gc *GroupCreate) SetExternalIDClient(s string) *GroupCreate {
+	gc.mutation.SetExternalIDClient(s)
+	return gc
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (gc *GroupCreate) SetNillableExternalIDClient(s *string) *GroupCreate {
+	if s != nil {
+		gc.SetExternalIDClient(*s)
+	}
+	return gc
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (gc *GroupCreate) SetDeletedAt(t time.Time) *GroupCreate {
+	gc.mutation.SetDeletedAt(t)
+	return gc
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (gc *GroupCreate) SetNillableDeletedAt(t *time.Time) *GroupCreate {
+	if t != nil {
+		gc.SetDeletedAt(*t)
+	}
+	return gc
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (gc *GroupCreate) SetDeletedBy(s string) *GroupCreate {
+	gc.mutation.SetDeletedBy(s)
+	return gc
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (gc *GroupCreate) SetNillableDeletedBy(s *string) *GroupCreate {
+	if s != nil {
+		gc.SetDeletedBy(*s)
+	}
+	return gc
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (gc *GroupCreate) SetDeletedMemberships(b []byte) *GroupCreate {
+	gc.mutation.SetDeletedMemberships(b)
+	return gc
+}
+
 // SetDisplayName sets the "displayName" field.
 func (gc *GroupCreate) SetDisplayName(s string) *GroupCreate {
 	gc.mutation.SetDisplayName(s)
@@ -102,7 +165,9 @@ func (gc *GroupCreate) Save(ctx context.Context) (*Group, error) {
 		err  error
 		node *Group
 	)
-	gc.defaults()
+	if err := gc.defaults(); err != nil {
+		return nil, err
+	}
 	if len(gc.hooks) == 0 {
 		if err = gc.check(); err != nil {
 			return nil, err
@@ -167,11 +232,15 @@ func (gc *GroupCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (gc *GroupCreate) defaults() {
+func (gc *GroupCreate) defaults() error {
 	if _, ok := gc.mutation.ID(); !ok {
+		if group.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized group.DefaultID (forgotten import ent/runtime?)")
+		}
 		v := group.DefaultID()
 		gc.mutation.SetID(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -212,6 +281,46 @@ func (gc *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = &id
 	}
+	if value, ok := gc.mutation.NormalizedDisplayName(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldNormalizedDisplayName,
+		})
+		_node.NormalizedDisplayName = value
+	}
+	if value, ok := gc.mutation.ExternalIDClient(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldExternalIDClient,
+		})
+		_node.ExternalIDClient = value
+	}
+	if value, ok := gc.mutation.DeletedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: group.FieldDeletedAt,
+		})
+		_node.DeletedAt = &value
+	}
+	if value, ok := gc.mutation.DeletedBy(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldDeletedBy,
+		})
+		_node.DeletedBy = value
+	}
+	if value, ok := gc.mutation.DeletedMemberships(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: group.FieldDeletedMemberships,
+		})
+		_node.DeletedMemberships = value
+	}
 	if value, ok := gc.mutation.DisplayName(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -292,12 +292,12 @@ func (gq *GroupQuery) WithMembers(opts ...func(*MemberQuery)) *GroupQuery {
 // Example:
 //
 //	var v []struct {
-//		DisplayName string `json:"displayName,omitempty"`
+//		NormalizedDisplayName string `json:"normalizedDisplayName,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.Group.Query().
-//		GroupBy(group.FieldDisplayName).
+//		GroupBy(group.FieldNormalizedDisplayName).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 //
@@ -321,11 +321,11 @@ func (gq *GroupQuery) GroupBy(field string, fields ...string) *GroupGroupBy {
 // Example:
 //
 //	var v []struct {
-//		DisplayName string `json:"displayName,omitempty"`
+//		NormalizedDisplayName string `json:"normalizedDisplayName,omitempty"`
 //	}
 //
 //	client.Group.Query().
-//		Select(group.FieldDisplayName).
+//		Select(group.FieldNormalizedDisplayName).
 //		Scan(ctx, &v)
 //
 func (gq *GroupQuery) Select(fields ...string) *GroupSelect {
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -28,6 +29,98 @@ func (gu *GroupUpdate) Where(ps ...predicate.Group) *GroupUpdate {
 	return gu
 }
 
+// SetNormalizedDisplayName sets the "normalizedDisplayName" field.
+func (gu *GroupUpdate) SetNormalizedDisplayName(s string) *GroupUpdate {
+	gu.mutation.SetNormalizedDisplayName(s)
+	return gu
+}
+
+// SetNillableNormalizedDisplayName sets the "normalizedDisplayName" field if the given value is not nil.
+func (gu *GroupUpdate) SetNillableNormalizedDisplayName(s *string) *GroupUpdate {
+	if s != nil {
+		gu.SetNormalizedDisplayName(*s)
+	}
+	return gu
+}
+
+// ClearNormalizedDisplayName clears the value of the "normalizedDisplayName" field.
+func (gu *GroupUpdate) ClearNormalizedDisplayName() *GroupUpdate {
+	gu.mutation.ClearNormalizedDisplayName()
+	return gu
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (gu *GroupUpdate) SetExternalIDClient(s string) *GroupUpdate {
+	gu.mutation.SetExternalIDClient(s)
+	return gu
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (gu *GroupUpdate) SetNillableExternalIDClient(s *string) *GroupUpdate {
+	if s != nil {
+		gu.SetExternalIDClient(*s)
+	}
+	return gu
+}
+
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (gu *GroupUpdate) ClearExternalIDClient() *GroupUpdate {
+	gu.mutation.ClearExternalIDClient()
+	return gu
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (gu *GroupUpdate) SetDeletedAt(t time.Time) *GroupUpdate {
+	gu.mutation.SetDeletedAt(t)
+	return gu
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (gu *GroupUpdate) SetNillableDeletedAt(t *time.Time) *GroupUpdate {
+	if t != nil {
+		gu.SetDeletedAt(*t)
+	}
+	return gu
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (gu *GroupUpdate) ClearDeletedAt() *GroupUpdate {
+	gu.mutation.ClearDeletedAt()
+	return gu
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (gu *GroupUpdate) SetDeletedBy(s string) *GroupUpdate {
+	gu.mutation.SetDeletedBy(s)
+	return gu
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (gu *GroupUpdate) SetNillableDeletedBy(s *string) *GroupUpdate {
+	if s != nil {
+		gu.SetDeletedBy(*s)
+	}
+	return gu
+}
+
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (gu *GroupUpdate) ClearDeletedBy() *GroupUpdate {
+	gu.mutation.ClearDeletedBy()
+	return gu
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (gu *GroupUpdate) SetDeletedMemberships(b []byte) *GroupUpdate {
+	gu.mutation.SetDeletedMemberships(b)
+	return gu
+}
+
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (gu *GroupUpdate) ClearDeletedMemberships() *GroupUpdate {
+	gu.mutation.ClearDeletedMemberships()
+	return gu
+}
+
 // SetDisplayName sets the "displayName" field.
 func (gu *GroupUpdate) SetDisplayName(s string) *GroupUpdate {
 	gu.mutation.SetDisplayName(s)
@@ -201,6 +294,71 @@ func (gu *GroupUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := gu.mutation.NormalizedDisplayName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldNormalizedDisplayName,
+		})
+	}
+	if gu.mutation.NormalizedDisplayNameCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldNormalizedDisplayName,
+		})
+	}
+	if value, ok := gu.mutation.ExternalIDClient(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldExternalIDClient,
+		})
+	}
+	if gu.mutation.ExternalIDClientCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldExternalIDClient,
+		})
+	}
+	if value, ok := gu.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: group.FieldDeletedAt,
+		})
+	}
+	if gu.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: group.FieldDeletedAt,
+		})
+	}
+	if value, ok := gu.mutation.DeletedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldDeletedBy,
+		})
+	}
+	if gu.mutation.DeletedByCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldDeletedBy,
+		})
+	}
+	if value, ok := gu.mutation.DeletedMemberships(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: group.FieldDeletedMemberships,
+		})
+	}
+	if gu.mutation.DeletedMembershipsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: group.FieldDeletedMemberships,
+		})
+	}
 	if value, ok := gu.mutation.DisplayName(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
@@ -313,6 +471,98 @@ type GroupUpdateOne struct {
 	mutation *GroupMutation
 }
 
+// SetNormalizedDisplayName sets the "normalizedDisplayName" field.
+func (guo *GroupUpdateOne) SetNormalizedDisplayName(s string) *GroupUpdateOne {
+	guo.mutation.SetNormalizedDisplayName(s)
+	return guo
+}
+
+// SetNillableNormalizedDisplayName sets the "normalizedDisplayName" field if the given value is not nil.
+func (guo *GroupUpdateOne) SetNillableNormalizedDisplayName(s *string) *GroupUpdateOne {
+	if s != nil {
+		guo.SetNormalizedDisplayName(*s)
+	}
+	return guo
+}
+
+// ClearNormalizedDisplayName clears the value of the "normalizedDisplayName" field.
+func (guo *GroupUpdateOne) ClearNormalizedDisplayName() *GroupUpdateOne {
+	guo.mutation.ClearNormalizedDisplayName()
+	return guo
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (guo *GroupUpdateOne) SetExternalIDClient(s string) *GroupUpdateOne {
+	guo.mutation.SetExternalIDClient(s)
+	return guo
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (guo *GroupUpdateOne) SetNillableExternalIDClient(s *string) *GroupUpdateOne {
+	if s != nil {
+		guo.SetExternalIDClient(*s)
+	}
+	return guo
+}
+
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (guo *GroupUpdateOne) ClearExternalIDClient() *GroupUpdateOne {
+	guo.mutation.ClearExternalIDClient()
+	return guo
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (guo *GroupUpdateOne) SetDeletedAt(t time.Time) *GroupUpdateOne {
+	guo.mutation.SetDeletedAt(t)
+	return guo
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (guo *GroupUpdateOne) SetNillableDeletedAt(t *time.Time) *GroupUpdateOne {
+	if t != nil {
+		guo.SetDeletedAt(*t)
+	}
+	return guo
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (guo *GroupUpdateOne) ClearDeletedAt() *GroupUpdateOne {
+	guo.mutation.ClearDeletedAt()
+	return guo
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (guo *GroupUpdateOne) SetDeletedBy(s string) *GroupUpdateOne {
+	guo.mutation.SetDeletedBy(s)
+	return guo
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (guo *GroupUpdateOne) SetNillableDeletedBy(s *string) *GroupUpdateOne {
+	if s != nil {
+		guo.SetDeletedBy(*s)
+	}
+	return guo
+}
+
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (guo *GroupUpdateOne) ClearDeletedBy() *GroupUpdateOne {
+	guo.mutation.ClearDeletedBy()
+	return guo
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (guo *GroupUpdateOne) SetDeletedMemberships(b []byte) *GroupUpdateOne {
+	guo.mutation.SetDeletedMemberships(b)
+	return guo
+}
+
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (guo *GroupUpdateOne) ClearDeletedMemberships() *GroupUpdateOne {
+	guo.mutation.ClearDeletedMemberships()
+	return guo
+}
+
 // SetDisplayName sets the "displayName" field.
 func (guo *GroupUpdateOne) SetDisplayName(s string) *GroupUpdateOne {
 	guo.mutation.SetDisplayName(s)
@@ -516,6 +766,71 @@ func (guo *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error
 			}
 		}
 	}
+	if value, ok := guo.mutation.NormalizedDisplayName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldNormalizedDisplayName,
+		})
+	}
+	if guo.mutation.NormalizedDisplayNameCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldNormalizedDisplayName,
+		})
+	}
+	if value, ok := guo.mutation.ExternalIDClient(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldExternalIDClient,
+		})
+	}
+	if guo.mutation.ExternalIDClientCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldExternalIDClient,
+		})
+	}
+	if value, ok := guo.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: group.FieldDeletedAt,
+		})
+	}
+	if guo.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: group.FieldDeletedAt,
+		})
+	}
+	if value, ok := guo.mutation.DeletedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: group.FieldDeletedBy,
+		})
+	}
+	if guo.mutation.DeletedByCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: group.FieldDeletedBy,
+		})
+	}
+	if value, ok := guo.mutation.DeletedMemberships(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: group.FieldDeletedMemberships,
+		})
+	}
+	if guo.mutation.DeletedMembershipsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: group.FieldDeletedMemberships,
+		})
+	}
 	if value, ok := guo.mutation.DisplayName(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeString,
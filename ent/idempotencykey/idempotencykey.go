@@ -0,0 +1,57 @@
+// Code generated by ent, DO NOT EDIT.
+
+package idempotencykey
+
+const (
+	// Label holds the string label denoting the idempotencykey type in the database.
+	Label = "idempotency_key"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldClientName holds the string denoting the clientname field in the database.
+	FieldClientName = "client_name"
+	// FieldKey holds the string denoting the key field in the database.
+	FieldKey = "key"
+	// FieldRequestHash holds the string denoting the requesthash field in the database.
+	FieldRequestHash = "request_hash"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldHeader holds the string denoting the header field in the database.
+	FieldHeader = "header"
+	// FieldBody holds the string denoting the body field in the database.
+	FieldBody = "body"
+	// FieldCreatedAt holds the string denoting the createdat field in the database.
+	FieldCreatedAt = "created_at"
+	// FieldExpiresAt holds the string denoting the expiresat field in the database.
+	FieldExpiresAt = "expires_at"
+	// FieldReservedAt holds the string denoting the reservedat field in the database.
+	FieldReservedAt = "reserved_at"
+	// FieldReservation holds the string denoting the reservation field in the database.
+	FieldReservation = "reservation"
+	// Table holds the table name of the idempotencykey in the database.
+	Table = "idempotency_keys"
+)
+
+// Columns holds all SQL columns for idempotencykey fields.
+var Columns = []string{
+	FieldID,
+	FieldClientName,
+	FieldKey,
+	FieldRequestHash,
+	FieldStatus,
+	FieldHeader,
+	FieldBody,
+	FieldCreatedAt,
+	FieldExpiresAt,
+	FieldReservedAt,
+	FieldReservation,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
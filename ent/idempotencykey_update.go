@@ -0,0 +1,702 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/idempotencykey"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+)
+
+// IdempotencyKeyUpdate is the builder for updating IdempotencyKey entities.
+type IdempotencyKeyUpdate struct {
+	config
+	hooks    []Hook
+	mutation *IdempotencyKeyMutation
+}
+
+// Where appends a list predicates to the IdempotencyKeyUpdate builder.
+func (iku *IdempotencyKeyUpdate) Where(ps ...predicate.IdempotencyKey) *IdempotencyKeyUpdate {
+	iku.mutation.Where(ps...)
+	return iku
+}
+
+// SetClientName sets the "clientName" field.
+func (iku *IdempotencyKeyUpdate) SetClientName(s string) *IdempotencyKeyUpdate {
+	iku.mutation.SetClientName(s)
+	return iku
+}
+
+// SetKey sets the "key" field.
+func (iku *IdempotencyKeyUpdate) SetKey(s string) *IdempotencyKeyUpdate {
+	iku.mutation.SetKey(s)
+	return iku
+}
+
+// SetRequestHash sets the "requestHash" field.
+func (iku *IdempotencyKeyUpdate) SetRequestHash(s string) *IdempotencyKeyUpdate {
+	iku.mutation.SetRequestHash(s)
+	return iku
+}
+
+// SetStatus sets the "status" field.
+func (iku *IdempotencyKeyUpdate) SetStatus(i int) *IdempotencyKeyUpdate {
+	iku.mutation.ResetStatus()
+	iku.mutation.SetStatus(i)
+	return iku
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (iku *IdempotencyKeyUpdate) SetNillableStatus(i *int) *IdempotencyKeyUpdate {
+	if i != nil {
+		iku.SetStatus(*i)
+	}
+	return iku
+}
+
+// AddStatus adds i to the "status" field.
+func (iku *IdempotencyKeyUpdate) AddStatus(i int) *IdempotencyKeyUpdate {
+	iku.mutation.AddStatus(i)
+	return iku
+}
+
+// ClearStatus clears the value of the "status" field.
+func (iku *IdempotencyKeyUpdate) ClearStatus() *IdempotencyKeyUpdate {
+	iku.mutation.ClearStatus()
+	return iku
+}
+
+// SetHeader sets the "header" field.
+func (iku *IdempotencyKeyUpdate) SetHeader(b []byte) *IdempotencyKeyUpdate {
+	iku.mutation.SetHeader(b)
+	return iku
+}
+
+// ClearHeader clears the value of the "header" field.
+func (iku *IdempotencyKeyUpdate) ClearHeader() *IdempotencyKeyUpdate {
+	iku.mutation.ClearHeader()
+	return iku
+}
+
+// SetBody sets the "body" field.
+func (iku *IdempotencyKeyUpdate) SetBody(b []byte) *IdempotencyKeyUpdate {
+	iku.mutation.SetBody(b)
+	return iku
+}
+
+// ClearBody clears the value of the "body" field.
+func (iku *IdempotencyKeyUpdate) ClearBody() *IdempotencyKeyUpdate {
+	iku.mutation.ClearBody()
+	return iku
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (iku *IdempotencyKeyUpdate) SetCreatedAt(t time.Time) *IdempotencyKeyUpdate {
+	iku.mutation.SetCreatedAt(t)
+	return iku
+}
+
+// SetExpiresAt sets the "expiresAt" field.
+func (iku *IdempotencyKeyUpdate) SetExpiresAt(t time.Time) *IdempotencyKeyUpdate {
+	iku.mutation.SetExpiresAt(t)
+	return iku
+}
+
+// SetReservedAt sets the "reservedAt" field.
+func (iku *IdempotencyKeyUpdate) SetReservedAt(t time.Time) *IdempotencyKeyUpdate {
+	iku.mutation.SetReservedAt(t)
+	return iku
+}
+
+// SetNillableReservedAt sets the "reservedAt" field if the given value is not nil.
+func (iku *IdempotencyKeyUpdate) SetNillableReservedAt(t *time.Time) *IdempotencyKeyUpdate {
+	if t != nil {
+		iku.SetReservedAt(*t)
+	}
+	return iku
+}
+
+// ClearReservedAt clears the value of the "reservedAt" field.
+func (iku *IdempotencyKeyUpdate) ClearReservedAt() *IdempotencyKeyUpdate {
+	iku.mutation.ClearReservedAt()
+	return iku
+}
+
+// SetReservation sets the "reservation" field.
+func (iku *IdempotencyKeyUpdate) SetReservation(s string) *IdempotencyKeyUpdate {
+	iku.mutation.SetReservation(s)
+	return iku
+}
+
+// SetNillableReservation sets the "reservation" field if the given value is not nil.
+func (iku *IdempotencyKeyUpdate) SetNillableReservation(s *string) *IdempotencyKeyUpdate {
+	if s != nil {
+		iku.SetReservation(*s)
+	}
+	return iku
+}
+
+// ClearReservation clears the value of the "reservation" field.
+func (iku *IdempotencyKeyUpdate) ClearReservation() *IdempotencyKeyUpdate {
+	iku.mutation.ClearReservation()
+	return iku
+}
+
+// Mutation returns the IdempotencyKeyMutation object of the builder.
+func (iku *IdempotencyKeyUpdate) Mutation() *IdempotencyKeyMutation {
+	return iku.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iku *IdempotencyKeyUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(iku.hooks) == 0 {
+		affected, err = iku.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IdempotencyKeyMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			iku.mutation = mutation
+			affected, err = iku.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(iku.hooks) - 1; i >= 0; i-- {
+			if iku.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = iku.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, iku.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iku *IdempotencyKeyUpdate) SaveX(ctx context.Context) int {
+	affected, err := iku.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iku *IdempotencyKeyUpdate) Exec(ctx context.Context) error {
+	_, err := iku.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iku *IdempotencyKeyUpdate) ExecX(ctx context.Context) {
+	if err := iku.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (iku *IdempotencyKeyUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   idempotencykey.Table,
+			Columns: idempotencykey.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: idempotencykey.FieldID,
+			},
+		},
+	}
+	if ps := iku.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iku.mutation.ClientName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldClientName,
+		})
+	}
+	if value, ok := iku.mutation.Key(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldKey,
+		})
+	}
+	if value, ok := iku.mutation.RequestHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldRequestHash,
+		})
+	}
+	if value, ok := iku.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if value, ok := iku.mutation.AddedStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if iku.mutation.StatusCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if value, ok := iku.mutation.Header(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: idempotencykey.FieldHeader,
+		})
+	}
+	if iku.mutation.HeaderCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: idempotencykey.FieldHeader,
+		})
+	}
+	if value, ok := iku.mutation.Body(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: idempotencykey.FieldBody,
+		})
+	}
+	if iku.mutation.BodyCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: idempotencykey.FieldBody,
+		})
+	}
+	if value, ok := iku.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldCreatedAt,
+		})
+	}
+	if value, ok := iku.mutation.ExpiresAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldExpiresAt,
+		})
+	}
+	if value, ok := iku.mutation.ReservedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldReservedAt,
+		})
+	}
+	if iku.mutation.ReservedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: idempotencykey.FieldReservedAt,
+		})
+	}
+	if value, ok := iku.mutation.Reservation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldReservation,
+		})
+	}
+	if iku.mutation.ReservationCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: idempotencykey.FieldReservation,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iku.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{idempotencykey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// IdempotencyKeyUpdateOne is the builder for updating a single IdempotencyKey entity.
+type IdempotencyKeyUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *IdempotencyKeyMutation
+}
+
+// SetClientName sets the "clientName" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetClientName(s string) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetClientName(s)
+	return ikuo
+}
+
+// SetKey sets the "key" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetKey(s string) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetKey(s)
+	return ikuo
+}
+
+// SetRequestHash sets the "requestHash" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetRequestHash(s string) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetRequestHash(s)
+	return ikuo
+}
+
+// SetStatus sets the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetStatus(i int) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ResetStatus()
+	ikuo.mutation.SetStatus(i)
+	return ikuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ikuo *IdempotencyKeyUpdateOne) SetNillableStatus(i *int) *IdempotencyKeyUpdateOne {
+	if i != nil {
+		ikuo.SetStatus(*i)
+	}
+	return ikuo
+}
+
+// AddStatus adds i to the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) AddStatus(i int) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.AddStatus(i)
+	return ikuo
+}
+
+// ClearStatus clears the value of the "status" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearStatus() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearStatus()
+	return ikuo
+}
+
+// SetHeader sets the "header" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetHeader(b []byte) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetHeader(b)
+	return ikuo
+}
+
+// ClearHeader clears the value of the "header" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearHeader() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearHeader()
+	return ikuo
+}
+
+// SetBody sets the "body" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetBody(b []byte) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetBody(b)
+	return ikuo
+}
+
+// ClearBody clears the value of the "body" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearBody() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearBody()
+	return ikuo
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetCreatedAt(t time.Time) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetCreatedAt(t)
+	return ikuo
+}
+
+// SetExpiresAt sets the "expiresAt" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetExpiresAt(t time.Time) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetExpiresAt(t)
+	return ikuo
+}
+
+// SetReservedAt sets the "reservedAt" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetReservedAt(t time.Time) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetReservedAt(t)
+	return ikuo
+}
+
+// SetNillableReservedAt sets the "reservedAt" field if the given value is not nil.
+func (ikuo *IdempotencyKeyUpdateOne) SetNillableReservedAt(t *time.Time) *IdempotencyKeyUpdateOne {
+	if t != nil {
+		ikuo.SetReservedAt(*t)
+	}
+	return ikuo
+}
+
+// ClearReservedAt clears the value of the "reservedAt" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearReservedAt() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearReservedAt()
+	return ikuo
+}
+
+// SetReservation sets the "reservation" field.
+func (ikuo *IdempotencyKeyUpdateOne) SetReservation(s string) *IdempotencyKeyUpdateOne {
+	ikuo.mutation.SetReservation(s)
+	return ikuo
+}
+
+// SetNillableReservation sets the "reservation" field if the given value is not nil.
+func (ikuo *IdempotencyKeyUpdateOne) SetNillableReservation(s *string) *IdempotencyKeyUpdateOne {
+	if s != nil {
+		ikuo.SetReservation(*s)
+	}
+	return ikuo
+}
+
+// ClearReservation clears the value of the "reservation" field.
+func (ikuo *IdempotencyKeyUpdateOne) ClearReservation() *IdempotencyKeyUpdateOne {
+	ikuo.mutation.ClearReservation()
+	return ikuo
+}
+
+// Mutation returns the IdempotencyKeyMutation object of the builder.
+func (ikuo *IdempotencyKeyUpdateOne) Mutation() *IdempotencyKeyMutation {
+	return ikuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ikuo *IdempotencyKeyUpdateOne) Select(field string, fields ...string) *IdempotencyKeyUpdateOne {
+	ikuo.fields = append([]string{field}, fields...)
+	return ikuo
+}
+
+// Save executes the query and returns the updated IdempotencyKey entity.
+func (ikuo *IdempotencyKeyUpdateOne) Save(ctx context.Context) (*IdempotencyKey, error) {
+	var (
+		err  error
+		node *IdempotencyKey
+	)
+	if len(ikuo.hooks) == 0 {
+		node, err = ikuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*IdempotencyKeyMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			ikuo.mutation = mutation
+			node, err = ikuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(ikuo.hooks) - 1; i >= 0; i-- {
+			if ikuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = ikuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, ikuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*IdempotencyKey)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from IdempotencyKeyMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ikuo *IdempotencyKeyUpdateOne) SaveX(ctx context.Context) *IdempotencyKey {
+	node, err := ikuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ikuo *IdempotencyKeyUpdateOne) Exec(ctx context.Context) error {
+	_, err := ikuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ikuo *IdempotencyKeyUpdateOne) ExecX(ctx context.Context) {
+	if err := ikuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (ikuo *IdempotencyKeyUpdateOne) sqlSave(ctx context.Context) (_node *IdempotencyKey, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   idempotencykey.Table,
+			Columns: idempotencykey.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: idempotencykey.FieldID,
+			},
+		},
+	}
+	id, ok := ikuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "IdempotencyKey.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ikuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, idempotencykey.FieldID)
+		for _, f := range fields {
+			if !idempotencykey.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != idempotencykey.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ikuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ikuo.mutation.ClientName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldClientName,
+		})
+	}
+	if value, ok := ikuo.mutation.Key(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldKey,
+		})
+	}
+	if value, ok := ikuo.mutation.RequestHash(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldRequestHash,
+		})
+	}
+	if value, ok := ikuo.mutation.Status(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if value, ok := ikuo.mutation.AddedStatus(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if ikuo.mutation.StatusCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Column: idempotencykey.FieldStatus,
+		})
+	}
+	if value, ok := ikuo.mutation.Header(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: idempotencykey.FieldHeader,
+		})
+	}
+	if ikuo.mutation.HeaderCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: idempotencykey.FieldHeader,
+		})
+	}
+	if value, ok := ikuo.mutation.Body(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: idempotencykey.FieldBody,
+		})
+	}
+	if ikuo.mutation.BodyCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: idempotencykey.FieldBody,
+		})
+	}
+	if value, ok := ikuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldCreatedAt,
+		})
+	}
+	if value, ok := ikuo.mutation.ExpiresAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldExpiresAt,
+		})
+	}
+	if value, ok := ikuo.mutation.ReservedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: idempotencykey.FieldReservedAt,
+		})
+	}
+	if ikuo.mutation.ReservedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: idempotencykey.FieldReservedAt,
+		})
+	}
+	if value, ok := ikuo.mutation.Reservation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: idempotencykey.FieldReservation,
+		})
+	}
+	if ikuo.mutation.ReservationCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: idempotencykey.FieldReservation,
+		})
+	}
+	_node = &IdempotencyKey{config: ikuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ikuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{idempotencykey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
@@ -1,8 +1,10 @@
 package ent
 
+// Membership describes a group that a user is a member of. The $ref
+// of the group is not stored, as it depends on the base URL of the
+// server, and is computed when the SCIM resource is built
 type Membership struct {
 	Value   string `json:"value"`
-	Ref     string `json:"$ref"`
 	Type    string `json:"type"`
 	Display string `json:"display"`
 }
@@ -33,6 +33,43 @@ var (
 			},
 		},
 	}
+	// AuditEntriesColumns holds the columns for the "audit_entries" table.
+	AuditEntriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "timestamp", Type: field.TypeTime, SchemaType: map[string]string{"mysql": "datetime(6)"}},
+		{Name: "client_name", Type: field.TypeString},
+		{Name: "operation", Type: field.TypeString},
+		{Name: "resource_type", Type: field.TypeString, Nullable: true},
+		{Name: "resource_id", Type: field.TypeString, Nullable: true},
+		{Name: "attributes", Type: field.TypeJSON, Nullable: true},
+		{Name: "status", Type: field.TypeInt},
+		{Name: "outcome", Type: field.TypeString},
+		{Name: "prev_hash", Type: field.TypeString, Unique: true},
+		{Name: "hash", Type: field.TypeString, Unique: true},
+	}
+	// AuditEntriesTable holds the schema information for the "audit_entries" table.
+	AuditEntriesTable = &schema.Table{
+		Name:       "audit_entries",
+		Columns:    AuditEntriesColumns,
+		PrimaryKey: []*schema.Column{AuditEntriesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditentry_resource_type_resource_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEntriesColumns[4], AuditEntriesColumns[5]},
+			},
+			{
+				Name:    "auditentry_client_name",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEntriesColumns[2]},
+			},
+			{
+				Name:    "auditentry_timestamp",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEntriesColumns[1]},
+			},
+		},
+	}
 	// EmailsColumns holds the columns for the "emails" table.
 	EmailsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -82,6 +119,11 @@ var (
 	// GroupsColumns holds the columns for the "groups" table.
 	GroupsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
+		{Name: "normalized_display_name", Type: field.TypeString, Nullable: true},
+		{Name: "external_id_client", Type: field.TypeString, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "deleted_by", Type: field.TypeString, Nullable: true},
+		{Name: "deleted_memberships", Type: field.TypeBytes, Nullable: true},
 		{Name: "display_name", Type: field.TypeString, Nullable: true},
 		{Name: "external_id", Type: field.TypeString, Nullable: true},
 		{Name: "etag", Type: field.TypeString, Nullable: true},
@@ -91,6 +133,23 @@ var (
 		Name:       "groups",
 		Columns:    GroupsColumns,
 		PrimaryKey: []*schema.Column{GroupsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "group_external_id",
+				Unique:  false,
+				Columns: []*schema.Column{GroupsColumns[7]},
+			},
+			{
+				Name:    "group_external_id_client_external_id",
+				Unique:  false,
+				Columns: []*schema.Column{GroupsColumns[2], GroupsColumns[7]},
+			},
+			{
+				Name:    "group_deleted_at",
+				Unique:  false,
+				Columns: []*schema.Column{GroupsColumns[3]},
+			},
+		},
 	}
 	// ImSsColumns holds the columns for the "im_ss" table.
 	ImSsColumns = []*schema.Column{
@@ -115,6 +174,38 @@ var (
 			},
 		},
 	}
+	// IdempotencyKeysColumns holds the columns for the "idempotency_keys" table.
+	IdempotencyKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "client_name", Type: field.TypeString},
+		{Name: "key", Type: field.TypeString},
+		{Name: "request_hash", Type: field.TypeString},
+		{Name: "status", Type: field.TypeInt, Nullable: true},
+		{Name: "header", Type: field.TypeBytes, Nullable: true},
+		{Name: "body", Type: field.TypeBytes, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "reserved_at", Type: field.TypeTime, Nullable: true},
+		{Name: "reservation", Type: field.TypeString, Nullable: true},
+	}
+	// IdempotencyKeysTable holds the schema information for the "idempotency_keys" table.
+	IdempotencyKeysTable = &schema.Table{
+		Name:       "idempotency_keys",
+		Columns:    IdempotencyKeysColumns,
+		PrimaryKey: []*schema.Column{IdempotencyKeysColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "idempotencykey_client_name_key",
+				Unique:  true,
+				Columns: []*schema.Column{IdempotencyKeysColumns[1], IdempotencyKeysColumns[2]},
+			},
+			{
+				Name:    "idempotencykey_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{IdempotencyKeysColumns[8]},
+			},
+		},
+	}
 	// MembersColumns holds the columns for the "members" table.
 	MembersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -209,6 +300,36 @@ var (
 			},
 		},
 	}
+	// ResourceVersionsColumns holds the columns for the "resource_versions" table.
+	ResourceVersionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "resource_type", Type: field.TypeString},
+		{Name: "resource_id", Type: field.TypeUUID},
+		{Name: "version", Type: field.TypeInt},
+		{Name: "etag", Type: field.TypeString, Nullable: true},
+		{Name: "operation", Type: field.TypeString},
+		{Name: "actor", Type: field.TypeString, Nullable: true},
+		{Name: "data", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+	}
+	// ResourceVersionsTable holds the schema information for the "resource_versions" table.
+	ResourceVersionsTable = &schema.Table{
+		Name:       "resource_versions",
+		Columns:    ResourceVersionsColumns,
+		PrimaryKey: []*schema.Column{ResourceVersionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "resourceversion_resource_type_resource_id_version",
+				Unique:  true,
+				Columns: []*schema.Column{ResourceVersionsColumns[1], ResourceVersionsColumns[2], ResourceVersionsColumns[3]},
+			},
+			{
+				Name:    "resourceversion_resource_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{ResourceVersionsColumns[2], ResourceVersionsColumns[8]},
+			},
+		},
+	}
 	// RolesColumns holds the columns for the "roles" table.
 	RolesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
@@ -235,6 +356,11 @@ var (
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
+		{Name: "normalized_user_name", Type: field.TypeString, Unique: true, Nullable: true},
+		{Name: "external_id_client", Type: field.TypeString, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "deleted_by", Type: field.TypeString, Nullable: true},
+		{Name: "deleted_memberships", Type: field.TypeBytes, Nullable: true},
 		{Name: "active", Type: field.TypeBool, Nullable: true},
 		{Name: "display_name", Type: field.TypeString, Nullable: true},
 		{Name: "external_id", Type: field.TypeString, Nullable: true},
@@ -255,6 +381,23 @@ var (
 		Name:       "users",
 		Columns:    UsersColumns,
 		PrimaryKey: []*schema.Column{UsersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "user_external_id",
+				Unique:  false,
+				Columns: []*schema.Column{UsersColumns[8]},
+			},
+			{
+				Name:    "user_external_id_client_external_id",
+				Unique:  false,
+				Columns: []*schema.Column{UsersColumns[2], UsersColumns[8]},
+			},
+			{
+				Name:    "user_deleted_at",
+				Unique:  false,
+				Columns: []*schema.Column{UsersColumns[3]},
+			},
+		},
 	}
 	// X509certificatesColumns holds the columns for the "x509certificates" table.
 	X509certificatesColumns = []*schema.Column{
@@ -282,14 +425,17 @@ var (
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		AddressesTable,
+		AuditEntriesTable,
 		EmailsTable,
 		EntitlementsTable,
 		GroupsTable,
 		ImSsTable,
+		IdempotencyKeysTable,
 		MembersTable,
 		NamesTable,
 		PhoneNumbersTable,
 		PhotosTable,
+		ResourceVersionsTable,
 		RolesTable,
 		UsersTable,
 		X509certificatesTable,
@@ -7,17 +7,21 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cybozu-go/scim-server/ent/address"
+	"github.com/cybozu-go/scim-server/ent/auditentry"
 	"github.com/cybozu-go/scim-server/ent/email"
 	"github.com/cybozu-go/scim-server/ent/entitlement"
 	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/idempotencykey"
 	"github.com/cybozu-go/scim-server/ent/ims"
 	"github.com/cybozu-go/scim-server/ent/member"
 	"github.com/cybozu-go/scim-server/ent/names"
 	"github.com/cybozu-go/scim-server/ent/phonenumber"
 	"github.com/cybozu-go/scim-server/ent/photo"
 	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
 	"github.com/cybozu-go/scim-server/ent/role"
 	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim-server/ent/x509certificate"
@@ -36,14 +40,17 @@ const (
 
 	// Node types.
 	TypeAddress         = "Address"
+	TypeAuditEntry      = "AuditEntry"
 	TypeEmail           = "Email"
 	TypeEntitlement     = "Entitlement"
 	TypeGroup           = "Group"
 	TypeIMS             = "IMS"
+	TypeIdempotencyKey  = "IdempotencyKey"
 	TypeMember          = "Member"
 	TypeNames           = "Names"
 	TypePhoneNumber     = "PhoneNumber"
 	TypePhoto           = "Photo"
+	TypeResourceVersion = "ResourceVersion"
 	TypeRole            = "Role"
 	TypeUser            = "User"
 	TypeX509Certificate = "X509Certificate"
@@ -822,35 +829,40 @@ func (m *AddressMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Address edge %s", name)
 }
 
-// EmailMutation represents an operation that mutates the Email nodes in the graph.
-type EmailMutation struct {
+// AuditEntryMutation represents an operation that mutates the AuditEntry nodes in the graph.
+type AuditEntryMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *uuid.UUID
-	display       *string
-	primary       *bool
-	_type         *string
-	value         *string
+	id            *int
+	timestamp     *time.Time
+	clientName    *string
+	operation     *string
+	resourceType  *string
+	resourceID    *string
+	attributes    *[]string
+	status        *int
+	addstatus     *int
+	outcome       *string
+	prevHash      *string
+	hash          *string
 	clearedFields map[string]struct{}
-	user          *uuid.UUID
-	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*Email, error)
-	predicates    []predicate.Email
+	oldValue      func(context.Context) (*AuditEntry, error)
+	predicates    []predicate.AuditEntry
 }
 
-var _ ent.Mutation = (*EmailMutation)(nil)
+var _ ent.Mutation = (*AuditEntryMutation)(nil)
 
-// emailOption allows management of the mutation configuration using functional options.
-type emailOption func(*EmailMutation)
+// auditentryOption allows management of the mutation configuration using functional options.
+type auditentryOption func(*AuditEntryMutation)
 
-// newEmailMutation creates new mutation for the Email entity.
-func newEmailMutation(c config, op Op, opts ...emailOption) *EmailMutation {
-	m := &EmailMutation{
+// newAuditEntryMutation creates new mutation for the AuditEntry entity.
+func newAuditEntryMutation(c config, op Op, opts ...auditentryOption) *AuditEntryMutation {
+	m := &AuditEntryMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeEmail,
+		typ:           TypeAuditEntry,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -859,20 +871,20 @@ func newEmailMutation(c config, op Op, opts ...emailOption) *EmailMutation {
 	return m
 }
 
-// withEmailID sets the ID field of the mutation.
-func withEmailID(id uuid.UUID) emailOption {
-	return func(m *EmailMutation) {
+// withAuditEntryID sets the ID field of the mutation.
+func withAuditEntryID(id int) auditentryOption {
+	return func(m *AuditEntryMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Email
+			value *AuditEntry
 		)
-		m.oldValue = func(ctx context.Context) (*Email, error) {
+		m.oldValue = func(ctx context.Context) (*AuditEntry, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Email.Get(ctx, id)
+					value, err = m.Client().AuditEntry.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -881,10 +893,10 @@ func withEmailID(id uuid.UUID) emailOption {
 	}
 }
 
-// withEmail sets the old Email of the mutation.
-func withEmail(node *Email) emailOption {
-	return func(m *EmailMutation) {
-		m.oldValue = func(context.Context) (*Email, error) {
+// withAuditEntry sets the old AuditEntry of the mutation.
+func withAuditEntry(node *AuditEntry) auditentryOption {
+	return func(m *AuditEntryMutation) {
+		m.oldValue = func(context.Context) (*AuditEntry, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -893,7 +905,7 @@ func withEmail(node *Email) emailOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m EmailMutation) Client() *Client {
+func (m AuditEntryMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -901,7 +913,7 @@ func (m EmailMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m EmailMutation) Tx() (*Tx, error) {
+func (m AuditEntryMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -910,15 +922,9 @@ func (m EmailMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Email entities.
-func (m *EmailMutation) SetID(id uuid.UUID) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *EmailMutation) ID() (id uuid.UUID, exists bool) {
+func (m *AuditEntryMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -929,509 +935,795 @@ func (m *EmailMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *EmailMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *AuditEntryMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []uuid.UUID{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Email.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().AuditEntry.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetDisplay sets the "display" field.
-func (m *EmailMutation) SetDisplay(s string) {
-	m.display = &s
+// SetTimestamp sets the "timestamp" field.
+func (m *AuditEntryMutation) SetTimestamp(t time.Time) {
+	m.timestamp = &t
 }
 
-// Display returns the value of the "display" field in the mutation.
-func (m *EmailMutation) Display() (r string, exists bool) {
-	v := m.display
+// Timestamp returns the value of the "timestamp" field in the mutation.
+func (m *AuditEntryMutation) Timestamp() (r time.Time, exists bool) {
+	v := m.timestamp
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the Email entity.
-// If the Email object wasn't provided to the builder, the object is fetched from the database.
+// OldTimestamp returns the old "timestamp" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EmailMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *AuditEntryMutation) OldTimestamp(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+		return v, errors.New("OldTimestamp is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDisplay requires an ID field in the mutation")
+		return v, errors.New("OldTimestamp requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+		return v, fmt.Errorf("querying old value for OldTimestamp: %w", err)
 	}
-	return oldValue.Display, nil
+	return oldValue.Timestamp, nil
 }
 
-// ClearDisplay clears the value of the "display" field.
-func (m *EmailMutation) ClearDisplay() {
-	m.display = nil
-	m.clearedFields[email.FieldDisplay] = struct{}{}
+// ResetTimestamp resets all changes to the "timestamp" field.
+func (m *AuditEntryMutation) ResetTimestamp() {
+	m.timestamp = nil
 }
 
-// DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *EmailMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[email.FieldDisplay]
-	return ok
+// SetClientName sets the "clientName" field.
+func (m *AuditEntryMutation) SetClientName(s string) {
+	m.clientName = &s
 }
 
-// ResetDisplay resets all changes to the "display" field.
-func (m *EmailMutation) ResetDisplay() {
-	m.display = nil
-	delete(m.clearedFields, email.FieldDisplay)
+// ClientName returns the value of the "clientName" field in the mutation.
+func (m *AuditEntryMutation) ClientName() (r string, exists bool) {
+	v := m.clientName
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// SetPrimary sets the "primary" field.
-func (m *EmailMutation) SetPrimary(b bool) {
-	m.primary = &b
+// OldClientName returns the old "clientName" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldClientName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClientName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClientName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClientName: %w", err)
+	}
+	return oldValue.ClientName, nil
 }
 
-// Primary returns the value of the "primary" field in the mutation.
-func (m *EmailMutation) Primary() (r bool, exists bool) {
-	v := m.primary
+// ResetClientName resets all changes to the "clientName" field.
+func (m *AuditEntryMutation) ResetClientName() {
+	m.clientName = nil
+}
+
+// SetOperation sets the "operation" field.
+func (m *AuditEntryMutation) SetOperation(s string) {
+	m.operation = &s
+}
+
+// Operation returns the value of the "operation" field in the mutation.
+func (m *AuditEntryMutation) Operation() (r string, exists bool) {
+	v := m.operation
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPrimary returns the old "primary" field's value of the Email entity.
-// If the Email object wasn't provided to the builder, the object is fetched from the database.
+// OldOperation returns the old "operation" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EmailMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+func (m *AuditEntryMutation) OldOperation(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
+		return v, errors.New("OldOperation is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPrimary requires an ID field in the mutation")
+		return v, errors.New("OldOperation requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
+		return v, fmt.Errorf("querying old value for OldOperation: %w", err)
 	}
-	return oldValue.Primary, nil
+	return oldValue.Operation, nil
 }
 
-// ClearPrimary clears the value of the "primary" field.
-func (m *EmailMutation) ClearPrimary() {
-	m.primary = nil
-	m.clearedFields[email.FieldPrimary] = struct{}{}
+// ResetOperation resets all changes to the "operation" field.
+func (m *AuditEntryMutation) ResetOperation() {
+	m.operation = nil
 }
 
-// PrimaryCleared returns if the "primary" field was cleared in this mutation.
-func (m *EmailMutation) PrimaryCleared() bool {
-	_, ok := m.clearedFields[email.FieldPrimary]
+// SetResourceType sets the "resourceType" field.
+func (m *AuditEntryMutation) SetResourceType(s string) {
+	m.resourceType = &s
+}
+
+// ResourceType returns the value of the "resourceType" field in the mutation.
+func (m *AuditEntryMutation) ResourceType() (r string, exists bool) {
+	v := m.resourceType
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResourceType returns the old "resourceType" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldResourceType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResourceType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResourceType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResourceType: %w", err)
+	}
+	return oldValue.ResourceType, nil
+}
+
+// ClearResourceType clears the value of the "resourceType" field.
+func (m *AuditEntryMutation) ClearResourceType() {
+	m.resourceType = nil
+	m.clearedFields[auditentry.FieldResourceType] = struct{}{}
+}
+
+// ResourceTypeCleared returns if the "resourceType" field was cleared in this mutation.
+func (m *AuditEntryMutation) ResourceTypeCleared() bool {
+	_, ok := m.clearedFields[auditentry.FieldResourceType]
 	return ok
 }
 
-// ResetPrimary resets all changes to the "primary" field.
-func (m *EmailMutation) ResetPrimary() {
-	m.primary = nil
-	delete(m.clearedFields, email.FieldPrimary)
+// ResetResourceType resets all changes to the "resourceType" field.
+func (m *AuditEntryMutation) ResetResourceType() {
+	m.resourceType = nil
+	delete(m.clearedFields, auditentry.FieldResourceType)
 }
 
-// SetType sets the "type" field.
-func (m *EmailMutation) SetType(s string) {
-	m._type = &s
+// SetResourceID sets the "resourceID" field.
+func (m *AuditEntryMutation) SetResourceID(s string) {
+	m.resourceID = &s
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *EmailMutation) GetType() (r string, exists bool) {
-	v := m._type
+// ResourceID returns the value of the "resourceID" field in the mutation.
+func (m *AuditEntryMutation) ResourceID() (r string, exists bool) {
+	v := m.resourceID
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Email entity.
-// If the Email object wasn't provided to the builder, the object is fetched from the database.
+// OldResourceID returns the old "resourceID" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EmailMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *AuditEntryMutation) OldResourceID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldResourceID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldResourceID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldResourceID: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.ResourceID, nil
 }
 
-// ClearType clears the value of the "type" field.
-func (m *EmailMutation) ClearType() {
-	m._type = nil
-	m.clearedFields[email.FieldType] = struct{}{}
+// ClearResourceID clears the value of the "resourceID" field.
+func (m *AuditEntryMutation) ClearResourceID() {
+	m.resourceID = nil
+	m.clearedFields[auditentry.FieldResourceID] = struct{}{}
 }
 
-// TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *EmailMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[email.FieldType]
+// ResourceIDCleared returns if the "resourceID" field was cleared in this mutation.
+func (m *AuditEntryMutation) ResourceIDCleared() bool {
+	_, ok := m.clearedFields[auditentry.FieldResourceID]
 	return ok
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *EmailMutation) ResetType() {
-	m._type = nil
-	delete(m.clearedFields, email.FieldType)
+// ResetResourceID resets all changes to the "resourceID" field.
+func (m *AuditEntryMutation) ResetResourceID() {
+	m.resourceID = nil
+	delete(m.clearedFields, auditentry.FieldResourceID)
 }
 
-// SetValue sets the "value" field.
-func (m *EmailMutation) SetValue(s string) {
-	m.value = &s
+// SetAttributes sets the "attributes" field.
+func (m *AuditEntryMutation) SetAttributes(s []string) {
+	m.attributes = &s
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *EmailMutation) Value() (r string, exists bool) {
-	v := m.value
+// Attributes returns the value of the "attributes" field in the mutation.
+func (m *AuditEntryMutation) Attributes() (r []string, exists bool) {
+	v := m.attributes
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Email entity.
-// If the Email object wasn't provided to the builder, the object is fetched from the database.
+// OldAttributes returns the old "attributes" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EmailMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *AuditEntryMutation) OldAttributes(ctx context.Context) (v []string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+		return v, errors.New("OldAttributes is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+		return v, errors.New("OldAttributes requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+		return v, fmt.Errorf("querying old value for OldAttributes: %w", err)
 	}
-	return oldValue.Value, nil
+	return oldValue.Attributes, nil
 }
 
-// ResetValue resets all changes to the "value" field.
-func (m *EmailMutation) ResetValue() {
-	m.value = nil
+// ClearAttributes clears the value of the "attributes" field.
+func (m *AuditEntryMutation) ClearAttributes() {
+	m.attributes = nil
+	m.clearedFields[auditentry.FieldAttributes] = struct{}{}
 }
 
-// SetUserID sets the "user" edge to the User entity by id.
-func (m *EmailMutation) SetUserID(id uuid.UUID) {
-	m.user = &id
+// AttributesCleared returns if the "attributes" field was cleared in this mutation.
+func (m *AuditEntryMutation) AttributesCleared() bool {
+	_, ok := m.clearedFields[auditentry.FieldAttributes]
+	return ok
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *EmailMutation) ClearUser() {
-	m.cleareduser = true
+// ResetAttributes resets all changes to the "attributes" field.
+func (m *AuditEntryMutation) ResetAttributes() {
+	m.attributes = nil
+	delete(m.clearedFields, auditentry.FieldAttributes)
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *EmailMutation) UserCleared() bool {
-	return m.cleareduser
+// SetStatus sets the "status" field.
+func (m *AuditEntryMutation) SetStatus(i int) {
+	m.status = &i
+	m.addstatus = nil
 }
 
-// UserID returns the "user" edge ID in the mutation.
-func (m *EmailMutation) UserID() (id uuid.UUID, exists bool) {
-	if m.user != nil {
-		return *m.user, true
+// Status returns the value of the "status" field in the mutation.
+func (m *AuditEntryMutation) Status() (r int, exists bool) {
+	v := m.status
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *EmailMutation) UserIDs() (ids []uuid.UUID) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// OldStatus returns the old "status" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldStatus(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *EmailMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// AddStatus adds i to the "status" field.
+func (m *AuditEntryMutation) AddStatus(i int) {
+	if m.addstatus != nil {
+		*m.addstatus += i
+	} else {
+		m.addstatus = &i
+	}
 }
 
-// Where appends a list predicates to the EmailMutation builder.
-func (m *EmailMutation) Where(ps ...predicate.Email) {
-	m.predicates = append(m.predicates, ps...)
+// AddedStatus returns the value that was added to the "status" field in this mutation.
+func (m *AuditEntryMutation) AddedStatus() (r int, exists bool) {
+	v := m.addstatus
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Op returns the operation name.
-func (m *EmailMutation) Op() Op {
-	return m.op
+// ResetStatus resets all changes to the "status" field.
+func (m *AuditEntryMutation) ResetStatus() {
+	m.status = nil
+	m.addstatus = nil
 }
 
-// Type returns the node type of this mutation (Email).
-func (m *EmailMutation) Type() string {
-	return m.typ
+// SetOutcome sets the "outcome" field.
+func (m *AuditEntryMutation) SetOutcome(s string) {
+	m.outcome = &s
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *EmailMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.display != nil {
-		fields = append(fields, email.FieldDisplay)
+// Outcome returns the value of the "outcome" field in the mutation.
+func (m *AuditEntryMutation) Outcome() (r string, exists bool) {
+	v := m.outcome
+	if v == nil {
+		return
 	}
-	if m.primary != nil {
-		fields = append(fields, email.FieldPrimary)
+	return *v, true
+}
+
+// OldOutcome returns the old "outcome" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldOutcome(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOutcome is only allowed on UpdateOne operations")
 	}
-	if m._type != nil {
-		fields = append(fields, email.FieldType)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOutcome requires an ID field in the mutation")
 	}
-	if m.value != nil {
-		fields = append(fields, email.FieldValue)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOutcome: %w", err)
 	}
-	return fields
+	return oldValue.Outcome, nil
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *EmailMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case email.FieldDisplay:
-		return m.Display()
-	case email.FieldPrimary:
-		return m.Primary()
-	case email.FieldType:
-		return m.GetType()
-	case email.FieldValue:
-		return m.Value()
-	}
-	return nil, false
+// ResetOutcome resets all changes to the "outcome" field.
+func (m *AuditEntryMutation) ResetOutcome() {
+	m.outcome = nil
+}
+
+// SetPrevHash sets the "prevHash" field.
+func (m *AuditEntryMutation) SetPrevHash(s string) {
+	m.prevHash = &s
+}
+
+// PrevHash returns the value of the "prevHash" field in the mutation.
+func (m *AuditEntryMutation) PrevHash() (r string, exists bool) {
+	v := m.prevHash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrevHash returns the old "prevHash" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldPrevHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrevHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrevHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrevHash: %w", err)
+	}
+	return oldValue.PrevHash, nil
+}
+
+// ResetPrevHash resets all changes to the "prevHash" field.
+func (m *AuditEntryMutation) ResetPrevHash() {
+	m.prevHash = nil
+}
+
+// SetHash sets the "hash" field.
+func (m *AuditEntryMutation) SetHash(s string) {
+	m.hash = &s
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *AuditEntryMutation) Hash() (r string, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the AuditEntry entity.
+// If the AuditEntry object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditEntryMutation) OldHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *AuditEntryMutation) ResetHash() {
+	m.hash = nil
+}
+
+// Where appends a list predicates to the AuditEntryMutation builder.
+func (m *AuditEntryMutation) Where(ps ...predicate.AuditEntry) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *AuditEntryMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (AuditEntry).
+func (m *AuditEntryMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *AuditEntryMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.timestamp != nil {
+		fields = append(fields, auditentry.FieldTimestamp)
+	}
+	if m.clientName != nil {
+		fields = append(fields, auditentry.FieldClientName)
+	}
+	if m.operation != nil {
+		fields = append(fields, auditentry.FieldOperation)
+	}
+	if m.resourceType != nil {
+		fields = append(fields, auditentry.FieldResourceType)
+	}
+	if m.resourceID != nil {
+		fields = append(fields, auditentry.FieldResourceID)
+	}
+	if m.attributes != nil {
+		fields = append(fields, auditentry.FieldAttributes)
+	}
+	if m.status != nil {
+		fields = append(fields, auditentry.FieldStatus)
+	}
+	if m.outcome != nil {
+		fields = append(fields, auditentry.FieldOutcome)
+	}
+	if m.prevHash != nil {
+		fields = append(fields, auditentry.FieldPrevHash)
+	}
+	if m.hash != nil {
+		fields = append(fields, auditentry.FieldHash)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *AuditEntryMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case auditentry.FieldTimestamp:
+		return m.Timestamp()
+	case auditentry.FieldClientName:
+		return m.ClientName()
+	case auditentry.FieldOperation:
+		return m.Operation()
+	case auditentry.FieldResourceType:
+		return m.ResourceType()
+	case auditentry.FieldResourceID:
+		return m.ResourceID()
+	case auditentry.FieldAttributes:
+		return m.Attributes()
+	case auditentry.FieldStatus:
+		return m.Status()
+	case auditentry.FieldOutcome:
+		return m.Outcome()
+	case auditentry.FieldPrevHash:
+		return m.PrevHash()
+	case auditentry.FieldHash:
+		return m.Hash()
+	}
+	return nil, false
 }
 
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *EmailMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case email.FieldDisplay:
-		return m.OldDisplay(ctx)
-	case email.FieldPrimary:
-		return m.OldPrimary(ctx)
-	case email.FieldType:
-		return m.OldType(ctx)
-	case email.FieldValue:
-		return m.OldValue(ctx)
-	}
-	return nil, fmt.Errorf("unknown Email field %s", name)
+func (m *AuditEntryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case auditentry.FieldTimestamp:
+		return m.OldTimestamp(ctx)
+	case auditentry.FieldClientName:
+		return m.OldClientName(ctx)
+	case auditentry.FieldOperation:
+		return m.OldOperation(ctx)
+	case auditentry.FieldResourceType:
+		return m.OldResourceType(ctx)
+	case auditentry.FieldResourceID:
+		return m.OldResourceID(ctx)
+	case auditentry.FieldAttributes:
+		return m.OldAttributes(ctx)
+	case auditentry.FieldStatus:
+		return m.OldStatus(ctx)
+	case auditentry.FieldOutcome:
+		return m.OldOutcome(ctx)
+	case auditentry.FieldPrevHash:
+		return m.OldPrevHash(ctx)
+	case auditentry.FieldHash:
+		return m.OldHash(ctx)
+	}
+	return nil, fmt.Errorf("unknown AuditEntry field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *EmailMutation) SetField(name string, value ent.Value) error {
+func (m *AuditEntryMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case email.FieldDisplay:
+	case auditentry.FieldTimestamp:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTimestamp(v)
+		return nil
+	case auditentry.FieldClientName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDisplay(v)
+		m.SetClientName(v)
 		return nil
-	case email.FieldPrimary:
-		v, ok := value.(bool)
+	case auditentry.FieldOperation:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPrimary(v)
+		m.SetOperation(v)
 		return nil
-	case email.FieldType:
+	case auditentry.FieldResourceType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetType(v)
+		m.SetResourceType(v)
 		return nil
-	case email.FieldValue:
+	case auditentry.FieldResourceID:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetResourceID(v)
+		return nil
+	case auditentry.FieldAttributes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttributes(v)
+		return nil
+	case auditentry.FieldStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case auditentry.FieldOutcome:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOutcome(v)
+		return nil
+	case auditentry.FieldPrevHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrevHash(v)
+		return nil
+	case auditentry.FieldHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Email field %s", name)
+	return fmt.Errorf("unknown AuditEntry field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *EmailMutation) AddedFields() []string {
-	return nil
+func (m *AuditEntryMutation) AddedFields() []string {
+	var fields []string
+	if m.addstatus != nil {
+		fields = append(fields, auditentry.FieldStatus)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *EmailMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AuditEntryMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case auditentry.FieldStatus:
+		return m.AddedStatus()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *EmailMutation) AddField(name string, value ent.Value) error {
+func (m *AuditEntryMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case auditentry.FieldStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddStatus(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Email numeric field %s", name)
+	return fmt.Errorf("unknown AuditEntry numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *EmailMutation) ClearedFields() []string {
+func (m *AuditEntryMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(email.FieldDisplay) {
-		fields = append(fields, email.FieldDisplay)
+	if m.FieldCleared(auditentry.FieldResourceType) {
+		fields = append(fields, auditentry.FieldResourceType)
 	}
-	if m.FieldCleared(email.FieldPrimary) {
-		fields = append(fields, email.FieldPrimary)
+	if m.FieldCleared(auditentry.FieldResourceID) {
+		fields = append(fields, auditentry.FieldResourceID)
 	}
-	if m.FieldCleared(email.FieldType) {
-		fields = append(fields, email.FieldType)
+	if m.FieldCleared(auditentry.FieldAttributes) {
+		fields = append(fields, auditentry.FieldAttributes)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *EmailMutation) FieldCleared(name string) bool {
+func (m *AuditEntryMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *EmailMutation) ClearField(name string) error {
+func (m *AuditEntryMutation) ClearField(name string) error {
 	switch name {
-	case email.FieldDisplay:
-		m.ClearDisplay()
+	case auditentry.FieldResourceType:
+		m.ClearResourceType()
 		return nil
-	case email.FieldPrimary:
-		m.ClearPrimary()
+	case auditentry.FieldResourceID:
+		m.ClearResourceID()
 		return nil
-	case email.FieldType:
-		m.ClearType()
+	case auditentry.FieldAttributes:
+		m.ClearAttributes()
 		return nil
 	}
-	return fmt.Errorf("unknown Email nullable field %s", name)
+	return fmt.Errorf("unknown AuditEntry nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *EmailMutation) ResetField(name string) error {
+func (m *AuditEntryMutation) ResetField(name string) error {
 	switch name {
-	case email.FieldDisplay:
-		m.ResetDisplay()
+	case auditentry.FieldTimestamp:
+		m.ResetTimestamp()
 		return nil
-	case email.FieldPrimary:
-		m.ResetPrimary()
+	case auditentry.FieldClientName:
+		m.ResetClientName()
 		return nil
-	case email.FieldType:
-		m.ResetType()
+	case auditentry.FieldOperation:
+		m.ResetOperation()
 		return nil
-	case email.FieldValue:
-		m.ResetValue()
+	case auditentry.FieldResourceType:
+		m.ResetResourceType()
+		return nil
+	case auditentry.FieldResourceID:
+		m.ResetResourceID()
+		return nil
+	case auditentry.FieldAttributes:
+		m.ResetAttributes()
+		return nil
+	case auditentry.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case auditentry.FieldOutcome:
+		m.ResetOutcome()
+		return nil
+	case auditentry.FieldPrevHash:
+		m.ResetPrevHash()
+		return nil
+	case auditentry.FieldHash:
+		m.ResetHash()
 		return nil
 	}
-	return fmt.Errorf("unknown Email field %s", name)
+	return fmt.Errorf("unknown AuditEntry field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *EmailMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.user != nil {
-		edges = append(edges, email.EdgeUser)
-	}
+func (m *AuditEntryMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *EmailMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case email.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *AuditEntryMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *EmailMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *AuditEntryMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *EmailMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	}
+func (m *AuditEntryMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *EmailMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.cleareduser {
-		edges = append(edges, email.EdgeUser)
-	}
+func (m *AuditEntryMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *EmailMutation) EdgeCleared(name string) bool {
-	switch name {
-	case email.EdgeUser:
-		return m.cleareduser
-	}
+func (m *AuditEntryMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *EmailMutation) ClearEdge(name string) error {
-	switch name {
-	case email.EdgeUser:
-		m.ClearUser()
-		return nil
-	}
-	return fmt.Errorf("unknown Email unique edge %s", name)
+func (m *AuditEntryMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown AuditEntry unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *EmailMutation) ResetEdge(name string) error {
-	switch name {
-	case email.EdgeUser:
-		m.ResetUser()
-		return nil
-	}
-	return fmt.Errorf("unknown Email edge %s", name)
+func (m *AuditEntryMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown AuditEntry edge %s", name)
 }
 
-// EntitlementMutation represents an operation that mutates the Entitlement nodes in the graph.
-type EntitlementMutation struct {
+// EmailMutation represents an operation that mutates the Email nodes in the graph.
+type EmailMutation struct {
 	config
 	op            Op
 	typ           string
@@ -1444,21 +1736,21 @@ type EntitlementMutation struct {
 	user          *uuid.UUID
 	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*Entitlement, error)
-	predicates    []predicate.Entitlement
+	oldValue      func(context.Context) (*Email, error)
+	predicates    []predicate.Email
 }
 
-var _ ent.Mutation = (*EntitlementMutation)(nil)
+var _ ent.Mutation = (*EmailMutation)(nil)
 
-// entitlementOption allows management of the mutation configuration using functional options.
-type entitlementOption func(*EntitlementMutation)
+// emailOption allows management of the mutation configuration using functional options.
+type emailOption func(*EmailMutation)
 
-// newEntitlementMutation creates new mutation for the Entitlement entity.
-func newEntitlementMutation(c config, op Op, opts ...entitlementOption) *EntitlementMutation {
-	m := &EntitlementMutation{
+// newEmailMutation creates new mutation for the Email entity.
+func newEmailMutation(c config, op Op, opts ...emailOption) *EmailMutation {
+	m := &EmailMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeEntitlement,
+		typ:           TypeEmail,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1467,20 +1759,20 @@ func newEntitlementMutation(c config, op Op, opts ...entitlementOption) *Entitle
 	return m
 }
 
-// withEntitlementID sets the ID field of the mutation.
-func withEntitlementID(id uuid.UUID) entitlementOption {
-	return func(m *EntitlementMutation) {
+// withEmailID sets the ID field of the mutation.
+func withEmailID(id uuid.UUID) emailOption {
+	return func(m *EmailMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Entitlement
+			value *Email
 		)
-		m.oldValue = func(ctx context.Context) (*Entitlement, error) {
+		m.oldValue = func(ctx context.Context) (*Email, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Entitlement.Get(ctx, id)
+					value, err = m.Client().Email.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1489,10 +1781,10 @@ func withEntitlementID(id uuid.UUID) entitlementOption {
 	}
 }
 
-// withEntitlement sets the old Entitlement of the mutation.
-func withEntitlement(node *Entitlement) entitlementOption {
-	return func(m *EntitlementMutation) {
-		m.oldValue = func(context.Context) (*Entitlement, error) {
+// withEmail sets the old Email of the mutation.
+func withEmail(node *Email) emailOption {
+	return func(m *EmailMutation) {
+		m.oldValue = func(context.Context) (*Email, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1501,7 +1793,7 @@ func withEntitlement(node *Entitlement) entitlementOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m EntitlementMutation) Client() *Client {
+func (m EmailMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1509,7 +1801,7 @@ func (m EntitlementMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m EntitlementMutation) Tx() (*Tx, error) {
+func (m EmailMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1519,14 +1811,14 @@ func (m EntitlementMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Entitlement entities.
-func (m *EntitlementMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Email entities.
+func (m *EmailMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *EntitlementMutation) ID() (id uuid.UUID, exists bool) {
+func (m *EmailMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1537,7 +1829,7 @@ func (m *EntitlementMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *EntitlementMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *EmailMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1546,19 +1838,19 @@ func (m *EntitlementMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Entitlement.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Email.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetDisplay sets the "display" field.
-func (m *EntitlementMutation) SetDisplay(s string) {
+func (m *EmailMutation) SetDisplay(s string) {
 	m.display = &s
 }
 
 // Display returns the value of the "display" field in the mutation.
-func (m *EntitlementMutation) Display() (r string, exists bool) {
+func (m *EmailMutation) Display() (r string, exists bool) {
 	v := m.display
 	if v == nil {
 		return
@@ -1566,10 +1858,10 @@ func (m *EntitlementMutation) Display() (r string, exists bool) {
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the Entitlement entity.
-// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
+// OldDisplay returns the old "display" field's value of the Email entity.
+// If the Email object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EntitlementMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *EmailMutation) OldDisplay(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
 	}
@@ -1584,30 +1876,30 @@ func (m *EntitlementMutation) OldDisplay(ctx context.Context) (v string, err err
 }
 
 // ClearDisplay clears the value of the "display" field.
-func (m *EntitlementMutation) ClearDisplay() {
+func (m *EmailMutation) ClearDisplay() {
 	m.display = nil
-	m.clearedFields[entitlement.FieldDisplay] = struct{}{}
+	m.clearedFields[email.FieldDisplay] = struct{}{}
 }
 
 // DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *EntitlementMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[entitlement.FieldDisplay]
+func (m *EmailMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[email.FieldDisplay]
 	return ok
 }
 
 // ResetDisplay resets all changes to the "display" field.
-func (m *EntitlementMutation) ResetDisplay() {
+func (m *EmailMutation) ResetDisplay() {
 	m.display = nil
-	delete(m.clearedFields, entitlement.FieldDisplay)
+	delete(m.clearedFields, email.FieldDisplay)
 }
 
 // SetPrimary sets the "primary" field.
-func (m *EntitlementMutation) SetPrimary(b bool) {
+func (m *EmailMutation) SetPrimary(b bool) {
 	m.primary = &b
 }
 
 // Primary returns the value of the "primary" field in the mutation.
-func (m *EntitlementMutation) Primary() (r bool, exists bool) {
+func (m *EmailMutation) Primary() (r bool, exists bool) {
 	v := m.primary
 	if v == nil {
 		return
@@ -1615,10 +1907,10 @@ func (m *EntitlementMutation) Primary() (r bool, exists bool) {
 	return *v, true
 }
 
-// OldPrimary returns the old "primary" field's value of the Entitlement entity.
-// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
+// OldPrimary returns the old "primary" field's value of the Email entity.
+// If the Email object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EntitlementMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+func (m *EmailMutation) OldPrimary(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
 	}
@@ -1633,30 +1925,30 @@ func (m *EntitlementMutation) OldPrimary(ctx context.Context) (v bool, err error
 }
 
 // ClearPrimary clears the value of the "primary" field.
-func (m *EntitlementMutation) ClearPrimary() {
+func (m *EmailMutation) ClearPrimary() {
 	m.primary = nil
-	m.clearedFields[entitlement.FieldPrimary] = struct{}{}
+	m.clearedFields[email.FieldPrimary] = struct{}{}
 }
 
 // PrimaryCleared returns if the "primary" field was cleared in this mutation.
-func (m *EntitlementMutation) PrimaryCleared() bool {
-	_, ok := m.clearedFields[entitlement.FieldPrimary]
+func (m *EmailMutation) PrimaryCleared() bool {
+	_, ok := m.clearedFields[email.FieldPrimary]
 	return ok
 }
 
 // ResetPrimary resets all changes to the "primary" field.
-func (m *EntitlementMutation) ResetPrimary() {
+func (m *EmailMutation) ResetPrimary() {
 	m.primary = nil
-	delete(m.clearedFields, entitlement.FieldPrimary)
+	delete(m.clearedFields, email.FieldPrimary)
 }
 
 // SetType sets the "type" field.
-func (m *EntitlementMutation) SetType(s string) {
+func (m *EmailMutation) SetType(s string) {
 	m._type = &s
 }
 
 // GetType returns the value of the "type" field in the mutation.
-func (m *EntitlementMutation) GetType() (r string, exists bool) {
+func (m *EmailMutation) GetType() (r string, exists bool) {
 	v := m._type
 	if v == nil {
 		return
@@ -1664,10 +1956,10 @@ func (m *EntitlementMutation) GetType() (r string, exists bool) {
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Entitlement entity.
-// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the Email entity.
+// If the Email object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EntitlementMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *EmailMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
@@ -1682,30 +1974,30 @@ func (m *EntitlementMutation) OldType(ctx context.Context) (v string, err error)
 }
 
 // ClearType clears the value of the "type" field.
-func (m *EntitlementMutation) ClearType() {
+func (m *EmailMutation) ClearType() {
 	m._type = nil
-	m.clearedFields[entitlement.FieldType] = struct{}{}
+	m.clearedFields[email.FieldType] = struct{}{}
 }
 
 // TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *EntitlementMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[entitlement.FieldType]
+func (m *EmailMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[email.FieldType]
 	return ok
 }
 
 // ResetType resets all changes to the "type" field.
-func (m *EntitlementMutation) ResetType() {
+func (m *EmailMutation) ResetType() {
 	m._type = nil
-	delete(m.clearedFields, entitlement.FieldType)
+	delete(m.clearedFields, email.FieldType)
 }
 
 // SetValue sets the "value" field.
-func (m *EntitlementMutation) SetValue(s string) {
+func (m *EmailMutation) SetValue(s string) {
 	m.value = &s
 }
 
 // Value returns the value of the "value" field in the mutation.
-func (m *EntitlementMutation) Value() (r string, exists bool) {
+func (m *EmailMutation) Value() (r string, exists bool) {
 	v := m.value
 	if v == nil {
 		return
@@ -1713,10 +2005,10 @@ func (m *EntitlementMutation) Value() (r string, exists bool) {
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Entitlement entity.
-// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the Email entity.
+// If the Email object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *EntitlementMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *EmailMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
@@ -1730,41 +2022,28 @@ func (m *EntitlementMutation) OldValue(ctx context.Context) (v string, err error
 	return oldValue.Value, nil
 }
 
-// ClearValue clears the value of the "value" field.
-func (m *EntitlementMutation) ClearValue() {
-	m.value = nil
-	m.clearedFields[entitlement.FieldValue] = struct{}{}
-}
-
-// ValueCleared returns if the "value" field was cleared in this mutation.
-func (m *EntitlementMutation) ValueCleared() bool {
-	_, ok := m.clearedFields[entitlement.FieldValue]
-	return ok
-}
-
 // ResetValue resets all changes to the "value" field.
-func (m *EntitlementMutation) ResetValue() {
+func (m *EmailMutation) ResetValue() {
 	m.value = nil
-	delete(m.clearedFields, entitlement.FieldValue)
 }
 
 // SetUserID sets the "user" edge to the User entity by id.
-func (m *EntitlementMutation) SetUserID(id uuid.UUID) {
+func (m *EmailMutation) SetUserID(id uuid.UUID) {
 	m.user = &id
 }
 
 // ClearUser clears the "user" edge to the User entity.
-func (m *EntitlementMutation) ClearUser() {
+func (m *EmailMutation) ClearUser() {
 	m.cleareduser = true
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *EntitlementMutation) UserCleared() bool {
+func (m *EmailMutation) UserCleared() bool {
 	return m.cleareduser
 }
 
 // UserID returns the "user" edge ID in the mutation.
-func (m *EntitlementMutation) UserID() (id uuid.UUID, exists bool) {
+func (m *EmailMutation) UserID() (id uuid.UUID, exists bool) {
 	if m.user != nil {
 		return *m.user, true
 	}
@@ -1774,7 +2053,7 @@ func (m *EntitlementMutation) UserID() (id uuid.UUID, exists bool) {
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *EntitlementMutation) UserIDs() (ids []uuid.UUID) {
+func (m *EmailMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -1782,42 +2061,42 @@ func (m *EntitlementMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *EntitlementMutation) ResetUser() {
+func (m *EmailMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// Where appends a list predicates to the EntitlementMutation builder.
-func (m *EntitlementMutation) Where(ps ...predicate.Entitlement) {
+// Where appends a list predicates to the EmailMutation builder.
+func (m *EmailMutation) Where(ps ...predicate.Email) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *EntitlementMutation) Op() Op {
+func (m *EmailMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Entitlement).
-func (m *EntitlementMutation) Type() string {
+// Type returns the node type of this mutation (Email).
+func (m *EmailMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *EntitlementMutation) Fields() []string {
+func (m *EmailMutation) Fields() []string {
 	fields := make([]string, 0, 4)
 	if m.display != nil {
-		fields = append(fields, entitlement.FieldDisplay)
+		fields = append(fields, email.FieldDisplay)
 	}
 	if m.primary != nil {
-		fields = append(fields, entitlement.FieldPrimary)
+		fields = append(fields, email.FieldPrimary)
 	}
 	if m._type != nil {
-		fields = append(fields, entitlement.FieldType)
+		fields = append(fields, email.FieldType)
 	}
 	if m.value != nil {
-		fields = append(fields, entitlement.FieldValue)
+		fields = append(fields, email.FieldValue)
 	}
 	return fields
 }
@@ -1825,15 +2104,15 @@ func (m *EntitlementMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *EntitlementMutation) Field(name string) (ent.Value, bool) {
+func (m *EmailMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case entitlement.FieldDisplay:
+	case email.FieldDisplay:
 		return m.Display()
-	case entitlement.FieldPrimary:
+	case email.FieldPrimary:
 		return m.Primary()
-	case entitlement.FieldType:
+	case email.FieldType:
 		return m.GetType()
-	case entitlement.FieldValue:
+	case email.FieldValue:
 		return m.Value()
 	}
 	return nil, false
@@ -1842,47 +2121,47 @@ func (m *EntitlementMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *EntitlementMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *EmailMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case entitlement.FieldDisplay:
+	case email.FieldDisplay:
 		return m.OldDisplay(ctx)
-	case entitlement.FieldPrimary:
+	case email.FieldPrimary:
 		return m.OldPrimary(ctx)
-	case entitlement.FieldType:
+	case email.FieldType:
 		return m.OldType(ctx)
-	case entitlement.FieldValue:
+	case email.FieldValue:
 		return m.OldValue(ctx)
 	}
-	return nil, fmt.Errorf("unknown Entitlement field %s", name)
+	return nil, fmt.Errorf("unknown Email field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *EntitlementMutation) SetField(name string, value ent.Value) error {
+func (m *EmailMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case entitlement.FieldDisplay:
+	case email.FieldDisplay:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetDisplay(v)
 		return nil
-	case entitlement.FieldPrimary:
+	case email.FieldPrimary:
 		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetPrimary(v)
 		return nil
-	case entitlement.FieldType:
+	case email.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetType(v)
 		return nil
-	case entitlement.FieldValue:
+	case email.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -1890,111 +2169,105 @@ func (m *EntitlementMutation) SetField(name string, value ent.Value) error {
 		m.SetValue(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Entitlement field %s", name)
+	return fmt.Errorf("unknown Email field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *EntitlementMutation) AddedFields() []string {
+func (m *EmailMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *EntitlementMutation) AddedField(name string) (ent.Value, bool) {
+func (m *EmailMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *EntitlementMutation) AddField(name string, value ent.Value) error {
+func (m *EmailMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Entitlement numeric field %s", name)
+	return fmt.Errorf("unknown Email numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *EntitlementMutation) ClearedFields() []string {
+func (m *EmailMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(entitlement.FieldDisplay) {
-		fields = append(fields, entitlement.FieldDisplay)
-	}
-	if m.FieldCleared(entitlement.FieldPrimary) {
-		fields = append(fields, entitlement.FieldPrimary)
+	if m.FieldCleared(email.FieldDisplay) {
+		fields = append(fields, email.FieldDisplay)
 	}
-	if m.FieldCleared(entitlement.FieldType) {
-		fields = append(fields, entitlement.FieldType)
+	if m.FieldCleared(email.FieldPrimary) {
+		fields = append(fields, email.FieldPrimary)
 	}
-	if m.FieldCleared(entitlement.FieldValue) {
-		fields = append(fields, entitlement.FieldValue)
+	if m.FieldCleared(email.FieldType) {
+		fields = append(fields, email.FieldType)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *EntitlementMutation) FieldCleared(name string) bool {
+func (m *EmailMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *EntitlementMutation) ClearField(name string) error {
+func (m *EmailMutation) ClearField(name string) error {
 	switch name {
-	case entitlement.FieldDisplay:
+	case email.FieldDisplay:
 		m.ClearDisplay()
 		return nil
-	case entitlement.FieldPrimary:
+	case email.FieldPrimary:
 		m.ClearPrimary()
 		return nil
-	case entitlement.FieldType:
+	case email.FieldType:
 		m.ClearType()
 		return nil
-	case entitlement.FieldValue:
-		m.ClearValue()
-		return nil
 	}
-	return fmt.Errorf("unknown Entitlement nullable field %s", name)
+	return fmt.Errorf("unknown Email nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *EntitlementMutation) ResetField(name string) error {
+func (m *EmailMutation) ResetField(name string) error {
 	switch name {
-	case entitlement.FieldDisplay:
+	case email.FieldDisplay:
 		m.ResetDisplay()
 		return nil
-	case entitlement.FieldPrimary:
+	case email.FieldPrimary:
 		m.ResetPrimary()
 		return nil
-	case entitlement.FieldType:
+	case email.FieldType:
 		m.ResetType()
 		return nil
-	case entitlement.FieldValue:
+	case email.FieldValue:
 		m.ResetValue()
 		return nil
 	}
-	return fmt.Errorf("unknown Entitlement field %s", name)
+	return fmt.Errorf("unknown Email field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *EntitlementMutation) AddedEdges() []string {
+func (m *EmailMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, entitlement.EdgeUser)
+		edges = append(edges, email.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *EntitlementMutation) AddedIDs(name string) []ent.Value {
+func (m *EmailMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case entitlement.EdgeUser:
+	case email.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
@@ -2003,33 +2276,33 @@ func (m *EntitlementMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *EntitlementMutation) RemovedEdges() []string {
+func (m *EmailMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *EntitlementMutation) RemovedIDs(name string) []ent.Value {
+func (m *EmailMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *EntitlementMutation) ClearedEdges() []string {
+func (m *EmailMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, entitlement.EdgeUser)
+		edges = append(edges, email.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *EntitlementMutation) EdgeCleared(name string) bool {
+func (m *EmailMutation) EdgeCleared(name string) bool {
 	switch name {
-	case entitlement.EdgeUser:
+	case email.EdgeUser:
 		return m.cleareduser
 	}
 	return false
@@ -2037,55 +2310,55 @@ func (m *EntitlementMutation) EdgeCleared(name string) bool {
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *EntitlementMutation) ClearEdge(name string) error {
+func (m *EmailMutation) ClearEdge(name string) error {
 	switch name {
-	case entitlement.EdgeUser:
+	case email.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Entitlement unique edge %s", name)
+	return fmt.Errorf("unknown Email unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *EntitlementMutation) ResetEdge(name string) error {
+func (m *EmailMutation) ResetEdge(name string) error {
 	switch name {
-	case entitlement.EdgeUser:
+	case email.EdgeUser:
 		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Entitlement edge %s", name)
+	return fmt.Errorf("unknown Email edge %s", name)
 }
 
-// GroupMutation represents an operation that mutates the Group nodes in the graph.
-type GroupMutation struct {
+// EntitlementMutation represents an operation that mutates the Entitlement nodes in the graph.
+type EntitlementMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *uuid.UUID
-	displayName    *string
-	externalID     *string
-	etag           *string
-	clearedFields  map[string]struct{}
-	members        map[int]struct{}
-	removedmembers map[int]struct{}
-	clearedmembers bool
-	done           bool
-	oldValue       func(context.Context) (*Group, error)
-	predicates     []predicate.Group
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	display       *string
+	primary       *bool
+	_type         *string
+	value         *string
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*Entitlement, error)
+	predicates    []predicate.Entitlement
 }
 
-var _ ent.Mutation = (*GroupMutation)(nil)
+var _ ent.Mutation = (*EntitlementMutation)(nil)
 
-// groupOption allows management of the mutation configuration using functional options.
-type groupOption func(*GroupMutation)
+// entitlementOption allows management of the mutation configuration using functional options.
+type entitlementOption func(*EntitlementMutation)
 
-// newGroupMutation creates new mutation for the Group entity.
-func newGroupMutation(c config, op Op, opts ...groupOption) *GroupMutation {
-	m := &GroupMutation{
+// newEntitlementMutation creates new mutation for the Entitlement entity.
+func newEntitlementMutation(c config, op Op, opts ...entitlementOption) *EntitlementMutation {
+	m := &EntitlementMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeGroup,
+		typ:           TypeEntitlement,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2094,20 +2367,20 @@ func newGroupMutation(c config, op Op, opts ...groupOption) *GroupMutation {
 	return m
 }
 
-// withGroupID sets the ID field of the mutation.
-func withGroupID(id uuid.UUID) groupOption {
-	return func(m *GroupMutation) {
+// withEntitlementID sets the ID field of the mutation.
+func withEntitlementID(id uuid.UUID) entitlementOption {
+	return func(m *EntitlementMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Group
+			value *Entitlement
 		)
-		m.oldValue = func(ctx context.Context) (*Group, error) {
+		m.oldValue = func(ctx context.Context) (*Entitlement, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Group.Get(ctx, id)
+					value, err = m.Client().Entitlement.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2116,10 +2389,10 @@ func withGroupID(id uuid.UUID) groupOption {
 	}
 }
 
-// withGroup sets the old Group of the mutation.
-func withGroup(node *Group) groupOption {
-	return func(m *GroupMutation) {
-		m.oldValue = func(context.Context) (*Group, error) {
+// withEntitlement sets the old Entitlement of the mutation.
+func withEntitlement(node *Entitlement) entitlementOption {
+	return func(m *EntitlementMutation) {
+		m.oldValue = func(context.Context) (*Entitlement, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2128,7 +2401,7 @@ func withGroup(node *Group) groupOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m GroupMutation) Client() *Client {
+func (m EntitlementMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2136,7 +2409,7 @@ func (m GroupMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m GroupMutation) Tx() (*Tx, error) {
+func (m EntitlementMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -2146,14 +2419,14 @@ func (m GroupMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Group entities.
-func (m *GroupMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Entitlement entities.
+func (m *EntitlementMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *GroupMutation) ID() (id uuid.UUID, exists bool) {
+func (m *EntitlementMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2164,7 +2437,7 @@ func (m *GroupMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *GroupMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *EntitlementMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -2173,241 +2446,278 @@ func (m *GroupMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Group.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Entitlement.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetDisplayName sets the "displayName" field.
-func (m *GroupMutation) SetDisplayName(s string) {
-	m.displayName = &s
+// SetDisplay sets the "display" field.
+func (m *EntitlementMutation) SetDisplay(s string) {
+	m.display = &s
 }
 
-// DisplayName returns the value of the "displayName" field in the mutation.
-func (m *GroupMutation) DisplayName() (r string, exists bool) {
-	v := m.displayName
+// Display returns the value of the "display" field in the mutation.
+func (m *EntitlementMutation) Display() (r string, exists bool) {
+	v := m.display
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDisplayName returns the old "displayName" field's value of the Group entity.
-// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// OldDisplay returns the old "display" field's value of the Entitlement entity.
+// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldDisplayName(ctx context.Context) (v string, err error) {
+func (m *EntitlementMutation) OldDisplay(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDisplayName is only allowed on UpdateOne operations")
+		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDisplayName requires an ID field in the mutation")
+		return v, errors.New("OldDisplay requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDisplayName: %w", err)
+		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
 	}
-	return oldValue.DisplayName, nil
+	return oldValue.Display, nil
 }
 
-// ClearDisplayName clears the value of the "displayName" field.
-func (m *GroupMutation) ClearDisplayName() {
-	m.displayName = nil
-	m.clearedFields[group.FieldDisplayName] = struct{}{}
+// ClearDisplay clears the value of the "display" field.
+func (m *EntitlementMutation) ClearDisplay() {
+	m.display = nil
+	m.clearedFields[entitlement.FieldDisplay] = struct{}{}
 }
 
-// DisplayNameCleared returns if the "displayName" field was cleared in this mutation.
-func (m *GroupMutation) DisplayNameCleared() bool {
-	_, ok := m.clearedFields[group.FieldDisplayName]
+// DisplayCleared returns if the "display" field was cleared in this mutation.
+func (m *EntitlementMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[entitlement.FieldDisplay]
 	return ok
 }
 
-// ResetDisplayName resets all changes to the "displayName" field.
-func (m *GroupMutation) ResetDisplayName() {
-	m.displayName = nil
-	delete(m.clearedFields, group.FieldDisplayName)
+// ResetDisplay resets all changes to the "display" field.
+func (m *EntitlementMutation) ResetDisplay() {
+	m.display = nil
+	delete(m.clearedFields, entitlement.FieldDisplay)
 }
 
-// SetExternalID sets the "externalID" field.
-func (m *GroupMutation) SetExternalID(s string) {
-	m.externalID = &s
+// SetPrimary sets the "primary" field.
+func (m *EntitlementMutation) SetPrimary(b bool) {
+	m.primary = &b
 }
 
-// ExternalID returns the value of the "externalID" field in the mutation.
-func (m *GroupMutation) ExternalID() (r string, exists bool) {
-	v := m.externalID
+// Primary returns the value of the "primary" field in the mutation.
+func (m *EntitlementMutation) Primary() (r bool, exists bool) {
+	v := m.primary
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExternalID returns the old "externalID" field's value of the Group entity.
-// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// OldPrimary returns the old "primary" field's value of the Entitlement entity.
+// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldExternalID(ctx context.Context) (v string, err error) {
+func (m *EntitlementMutation) OldPrimary(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExternalID is only allowed on UpdateOne operations")
+		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExternalID requires an ID field in the mutation")
+		return v, errors.New("OldPrimary requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExternalID: %w", err)
+		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
 	}
-	return oldValue.ExternalID, nil
+	return oldValue.Primary, nil
 }
 
-// ClearExternalID clears the value of the "externalID" field.
-func (m *GroupMutation) ClearExternalID() {
-	m.externalID = nil
-	m.clearedFields[group.FieldExternalID] = struct{}{}
+// ClearPrimary clears the value of the "primary" field.
+func (m *EntitlementMutation) ClearPrimary() {
+	m.primary = nil
+	m.clearedFields[entitlement.FieldPrimary] = struct{}{}
 }
 
-// ExternalIDCleared returns if the "externalID" field was cleared in this mutation.
-func (m *GroupMutation) ExternalIDCleared() bool {
-	_, ok := m.clearedFields[group.FieldExternalID]
+// PrimaryCleared returns if the "primary" field was cleared in this mutation.
+func (m *EntitlementMutation) PrimaryCleared() bool {
+	_, ok := m.clearedFields[entitlement.FieldPrimary]
 	return ok
 }
 
-// ResetExternalID resets all changes to the "externalID" field.
-func (m *GroupMutation) ResetExternalID() {
-	m.externalID = nil
-	delete(m.clearedFields, group.FieldExternalID)
+// ResetPrimary resets all changes to the "primary" field.
+func (m *EntitlementMutation) ResetPrimary() {
+	m.primary = nil
+	delete(m.clearedFields, entitlement.FieldPrimary)
 }
 
-// SetEtag sets the "etag" field.
-func (m *GroupMutation) SetEtag(s string) {
-	m.etag = &s
+// SetType sets the "type" field.
+func (m *EntitlementMutation) SetType(s string) {
+	m._type = &s
 }
 
-// Etag returns the value of the "etag" field in the mutation.
-func (m *GroupMutation) Etag() (r string, exists bool) {
-	v := m.etag
+// GetType returns the value of the "type" field in the mutation.
+func (m *EntitlementMutation) GetType() (r string, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldEtag returns the old "etag" field's value of the Group entity.
-// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the Entitlement entity.
+// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *GroupMutation) OldEtag(ctx context.Context) (v string, err error) {
+func (m *EntitlementMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldEtag is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldEtag requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldEtag: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.Etag, nil
+	return oldValue.Type, nil
 }
 
-// ClearEtag clears the value of the "etag" field.
-func (m *GroupMutation) ClearEtag() {
-	m.etag = nil
-	m.clearedFields[group.FieldEtag] = struct{}{}
+// ClearType clears the value of the "type" field.
+func (m *EntitlementMutation) ClearType() {
+	m._type = nil
+	m.clearedFields[entitlement.FieldType] = struct{}{}
 }
 
-// EtagCleared returns if the "etag" field was cleared in this mutation.
-func (m *GroupMutation) EtagCleared() bool {
-	_, ok := m.clearedFields[group.FieldEtag]
+// TypeCleared returns if the "type" field was cleared in this mutation.
+func (m *EntitlementMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[entitlement.FieldType]
 	return ok
 }
 
-// ResetEtag resets all changes to the "etag" field.
-func (m *GroupMutation) ResetEtag() {
-	m.etag = nil
-	delete(m.clearedFields, group.FieldEtag)
-}
-
-// AddMemberIDs adds the "members" edge to the Member entity by ids.
-func (m *GroupMutation) AddMemberIDs(ids ...int) {
-	if m.members == nil {
-		m.members = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.members[ids[i]] = struct{}{}
-	}
+// ResetType resets all changes to the "type" field.
+func (m *EntitlementMutation) ResetType() {
+	m._type = nil
+	delete(m.clearedFields, entitlement.FieldType)
 }
 
-// ClearMembers clears the "members" edge to the Member entity.
-func (m *GroupMutation) ClearMembers() {
-	m.clearedmembers = true
+// SetValue sets the "value" field.
+func (m *EntitlementMutation) SetValue(s string) {
+	m.value = &s
 }
 
-// MembersCleared reports if the "members" edge to the Member entity was cleared.
-func (m *GroupMutation) MembersCleared() bool {
-	return m.clearedmembers
+// Value returns the value of the "value" field in the mutation.
+func (m *EntitlementMutation) Value() (r string, exists bool) {
+	v := m.value
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// RemoveMemberIDs removes the "members" edge to the Member entity by IDs.
-func (m *GroupMutation) RemoveMemberIDs(ids ...int) {
-	if m.removedmembers == nil {
-		m.removedmembers = make(map[int]struct{})
+// OldValue returns the old "value" field's value of the Entitlement entity.
+// If the Entitlement object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EntitlementMutation) OldValue(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		delete(m.members, ids[i])
-		m.removedmembers[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValue requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
 	}
+	return oldValue.Value, nil
 }
 
-// RemovedMembers returns the removed IDs of the "members" edge to the Member entity.
-func (m *GroupMutation) RemovedMembersIDs() (ids []int) {
-	for id := range m.removedmembers {
-		ids = append(ids, id)
-	}
+// ClearValue clears the value of the "value" field.
+func (m *EntitlementMutation) ClearValue() {
+	m.value = nil
+	m.clearedFields[entitlement.FieldValue] = struct{}{}
+}
+
+// ValueCleared returns if the "value" field was cleared in this mutation.
+func (m *EntitlementMutation) ValueCleared() bool {
+	_, ok := m.clearedFields[entitlement.FieldValue]
+	return ok
+}
+
+// ResetValue resets all changes to the "value" field.
+func (m *EntitlementMutation) ResetValue() {
+	m.value = nil
+	delete(m.clearedFields, entitlement.FieldValue)
+}
+
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *EntitlementMutation) SetUserID(id uuid.UUID) {
+	m.user = &id
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *EntitlementMutation) ClearUser() {
+	m.cleareduser = true
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *EntitlementMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserID returns the "user" edge ID in the mutation.
+func (m *EntitlementMutation) UserID() (id uuid.UUID, exists bool) {
+	if m.user != nil {
+		return *m.user, true
+	}
 	return
 }
 
-// MembersIDs returns the "members" edge IDs in the mutation.
-func (m *GroupMutation) MembersIDs() (ids []int) {
-	for id := range m.members {
-		ids = append(ids, id)
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *EntitlementMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetMembers resets all changes to the "members" edge.
-func (m *GroupMutation) ResetMembers() {
-	m.members = nil
-	m.clearedmembers = false
-	m.removedmembers = nil
+// ResetUser resets all changes to the "user" edge.
+func (m *EntitlementMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the GroupMutation builder.
-func (m *GroupMutation) Where(ps ...predicate.Group) {
+// Where appends a list predicates to the EntitlementMutation builder.
+func (m *EntitlementMutation) Where(ps ...predicate.Entitlement) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *GroupMutation) Op() Op {
+func (m *EntitlementMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Group).
-func (m *GroupMutation) Type() string {
+// Type returns the node type of this mutation (Entitlement).
+func (m *EntitlementMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *GroupMutation) Fields() []string {
-	fields := make([]string, 0, 3)
-	if m.displayName != nil {
-		fields = append(fields, group.FieldDisplayName)
+func (m *EntitlementMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.display != nil {
+		fields = append(fields, entitlement.FieldDisplay)
 	}
-	if m.externalID != nil {
-		fields = append(fields, group.FieldExternalID)
+	if m.primary != nil {
+		fields = append(fields, entitlement.FieldPrimary)
 	}
-	if m.etag != nil {
-		fields = append(fields, group.FieldEtag)
+	if m._type != nil {
+		fields = append(fields, entitlement.FieldType)
+	}
+	if m.value != nil {
+		fields = append(fields, entitlement.FieldValue)
 	}
 	return fields
 }
@@ -2415,14 +2725,16 @@ func (m *GroupMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *GroupMutation) Field(name string) (ent.Value, bool) {
+func (m *EntitlementMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case group.FieldDisplayName:
-		return m.DisplayName()
-	case group.FieldExternalID:
-		return m.ExternalID()
-	case group.FieldEtag:
-		return m.Etag()
+	case entitlement.FieldDisplay:
+		return m.Display()
+	case entitlement.FieldPrimary:
+		return m.Primary()
+	case entitlement.FieldType:
+		return m.GetType()
+	case entitlement.FieldValue:
+		return m.Value()
 	}
 	return nil, false
 }
@@ -2430,240 +2742,255 @@ func (m *GroupMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *EntitlementMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case group.FieldDisplayName:
-		return m.OldDisplayName(ctx)
-	case group.FieldExternalID:
-		return m.OldExternalID(ctx)
-	case group.FieldEtag:
-		return m.OldEtag(ctx)
+	case entitlement.FieldDisplay:
+		return m.OldDisplay(ctx)
+	case entitlement.FieldPrimary:
+		return m.OldPrimary(ctx)
+	case entitlement.FieldType:
+		return m.OldType(ctx)
+	case entitlement.FieldValue:
+		return m.OldValue(ctx)
 	}
-	return nil, fmt.Errorf("unknown Group field %s", name)
+	return nil, fmt.Errorf("unknown Entitlement field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *GroupMutation) SetField(name string, value ent.Value) error {
+func (m *EntitlementMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case group.FieldDisplayName:
+	case entitlement.FieldDisplay:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDisplayName(v)
+		m.SetDisplay(v)
 		return nil
-	case group.FieldExternalID:
+	case entitlement.FieldPrimary:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrimary(v)
+		return nil
+	case entitlement.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetExternalID(v)
+		m.SetType(v)
 		return nil
-	case group.FieldEtag:
+	case entitlement.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetEtag(v)
+		m.SetValue(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Group field %s", name)
+	return fmt.Errorf("unknown Entitlement field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *GroupMutation) AddedFields() []string {
+func (m *EntitlementMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *GroupMutation) AddedField(name string) (ent.Value, bool) {
+func (m *EntitlementMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *GroupMutation) AddField(name string, value ent.Value) error {
+func (m *EntitlementMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Group numeric field %s", name)
+	return fmt.Errorf("unknown Entitlement numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *GroupMutation) ClearedFields() []string {
+func (m *EntitlementMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(group.FieldDisplayName) {
-		fields = append(fields, group.FieldDisplayName)
+	if m.FieldCleared(entitlement.FieldDisplay) {
+		fields = append(fields, entitlement.FieldDisplay)
 	}
-	if m.FieldCleared(group.FieldExternalID) {
-		fields = append(fields, group.FieldExternalID)
+	if m.FieldCleared(entitlement.FieldPrimary) {
+		fields = append(fields, entitlement.FieldPrimary)
 	}
-	if m.FieldCleared(group.FieldEtag) {
-		fields = append(fields, group.FieldEtag)
+	if m.FieldCleared(entitlement.FieldType) {
+		fields = append(fields, entitlement.FieldType)
+	}
+	if m.FieldCleared(entitlement.FieldValue) {
+		fields = append(fields, entitlement.FieldValue)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *GroupMutation) FieldCleared(name string) bool {
+func (m *EntitlementMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *GroupMutation) ClearField(name string) error {
+func (m *EntitlementMutation) ClearField(name string) error {
 	switch name {
-	case group.FieldDisplayName:
-		m.ClearDisplayName()
+	case entitlement.FieldDisplay:
+		m.ClearDisplay()
 		return nil
-	case group.FieldExternalID:
-		m.ClearExternalID()
+	case entitlement.FieldPrimary:
+		m.ClearPrimary()
 		return nil
-	case group.FieldEtag:
-		m.ClearEtag()
+	case entitlement.FieldType:
+		m.ClearType()
+		return nil
+	case entitlement.FieldValue:
+		m.ClearValue()
 		return nil
 	}
-	return fmt.Errorf("unknown Group nullable field %s", name)
+	return fmt.Errorf("unknown Entitlement nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *GroupMutation) ResetField(name string) error {
+func (m *EntitlementMutation) ResetField(name string) error {
 	switch name {
-	case group.FieldDisplayName:
-		m.ResetDisplayName()
+	case entitlement.FieldDisplay:
+		m.ResetDisplay()
 		return nil
-	case group.FieldExternalID:
-		m.ResetExternalID()
+	case entitlement.FieldPrimary:
+		m.ResetPrimary()
 		return nil
-	case group.FieldEtag:
-		m.ResetEtag()
+	case entitlement.FieldType:
+		m.ResetType()
+		return nil
+	case entitlement.FieldValue:
+		m.ResetValue()
 		return nil
 	}
-	return fmt.Errorf("unknown Group field %s", name)
+	return fmt.Errorf("unknown Entitlement field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *GroupMutation) AddedEdges() []string {
+func (m *EntitlementMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.members != nil {
-		edges = append(edges, group.EdgeMembers)
+	if m.user != nil {
+		edges = append(edges, entitlement.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *GroupMutation) AddedIDs(name string) []ent.Value {
+func (m *EntitlementMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case group.EdgeMembers:
-		ids := make([]ent.Value, 0, len(m.members))
-		for id := range m.members {
-			ids = append(ids, id)
+	case entitlement.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *GroupMutation) RemovedEdges() []string {
+func (m *EntitlementMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.removedmembers != nil {
-		edges = append(edges, group.EdgeMembers)
-	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *GroupMutation) RemovedIDs(name string) []ent.Value {
+func (m *EntitlementMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
-	case group.EdgeMembers:
-		ids := make([]ent.Value, 0, len(m.removedmembers))
-		for id := range m.removedmembers {
-			ids = append(ids, id)
-		}
-		return ids
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *GroupMutation) ClearedEdges() []string {
+func (m *EntitlementMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.clearedmembers {
-		edges = append(edges, group.EdgeMembers)
+	if m.cleareduser {
+		edges = append(edges, entitlement.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *GroupMutation) EdgeCleared(name string) bool {
+func (m *EntitlementMutation) EdgeCleared(name string) bool {
 	switch name {
-	case group.EdgeMembers:
-		return m.clearedmembers
+	case entitlement.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *GroupMutation) ClearEdge(name string) error {
+func (m *EntitlementMutation) ClearEdge(name string) error {
 	switch name {
+	case entitlement.EdgeUser:
+		m.ClearUser()
+		return nil
 	}
-	return fmt.Errorf("unknown Group unique edge %s", name)
+	return fmt.Errorf("unknown Entitlement unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *GroupMutation) ResetEdge(name string) error {
+func (m *EntitlementMutation) ResetEdge(name string) error {
 	switch name {
-	case group.EdgeMembers:
-		m.ResetMembers()
+	case entitlement.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Group edge %s", name)
+	return fmt.Errorf("unknown Entitlement edge %s", name)
 }
 
-// IMSMutation represents an operation that mutates the IMS nodes in the graph.
-type IMSMutation struct {
+// GroupMutation represents an operation that mutates the Group nodes in the graph.
+type GroupMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *uuid.UUID
-	display       *string
-	primary       *bool
-	_type         *string
-	value         *string
-	clearedFields map[string]struct{}
-	user          *uuid.UUID
-	cleareduser   bool
-	done          bool
-	oldValue      func(context.Context) (*IMS, error)
-	predicates    []predicate.IMS
+	op                    Op
+	typ                   string
+	id                    *uuid.UUID
+	normalizedDisplayName *string
+	externalIDClient      *string
+	deletedAt             *time.Time
+	deletedBy             *string
+	deletedMemberships    *[]byte
+	displayName           *string
+	externalID            *string
+	etag                  *string
+	clearedFields         map[string]struct{}
+	members               map[int]struct{}
+	removedmembers        map[int]struct{}
+	clearedmembers        bool
+	done                  bool
+	oldValue              func(context.Context) (*Group, error)
+	predicates            []predicate.Group
 }
 
-var _ ent.Mutation = (*IMSMutation)(nil)
+var _ ent.Mutation = (*GroupMutation)(nil)
 
-// imsOption allows management of the mutation configuration using functional options.
-type imsOption func(*IMSMutation)
+// groupOption allows management of the mutation configuration using functional options.
+type groupOption func(*GroupMutation)
 
-// newIMSMutation creates new mutation for the IMS entity.
-func newIMSMutation(c config, op Op, opts ...imsOption) *IMSMutation {
-	m := &IMSMutation{
+// newGroupMutation creates new mutation for the Group entity.
+func newGroupMutation(c config, op Op, opts ...groupOption) *GroupMutation {
+	m := &GroupMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeIMS,
+		typ:           TypeGroup,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2672,20 +2999,20 @@ func newIMSMutation(c config, op Op, opts ...imsOption) *IMSMutation {
 	return m
 }
 
-// withIMSID sets the ID field of the mutation.
-func withIMSID(id uuid.UUID) imsOption {
-	return func(m *IMSMutation) {
+// withGroupID sets the ID field of the mutation.
+func withGroupID(id uuid.UUID) groupOption {
+	return func(m *GroupMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *IMS
+			value *Group
 		)
-		m.oldValue = func(ctx context.Context) (*IMS, error) {
+		m.oldValue = func(ctx context.Context) (*Group, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().IMS.Get(ctx, id)
+					value, err = m.Client().Group.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2694,10 +3021,10 @@ func withIMSID(id uuid.UUID) imsOption {
 	}
 }
 
-// withIMS sets the old IMS of the mutation.
-func withIMS(node *IMS) imsOption {
-	return func(m *IMSMutation) {
-		m.oldValue = func(context.Context) (*IMS, error) {
+// withGroup sets the old Group of the mutation.
+func withGroup(node *Group) groupOption {
+	return func(m *GroupMutation) {
+		m.oldValue = func(context.Context) (*Group, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2706,7 +3033,7 @@ func withIMS(node *IMS) imsOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m IMSMutation) Client() *Client {
+func (m GroupMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2714,7 +3041,7 @@ func (m IMSMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m IMSMutation) Tx() (*Tx, error) {
+func (m GroupMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -2724,14 +3051,14 @@ func (m IMSMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of IMS entities.
-func (m *IMSMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Group entities.
+func (m *GroupMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *IMSMutation) ID() (id uuid.UUID, exists bool) {
+func (m *GroupMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2742,7 +3069,7 @@ func (m *IMSMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *IMSMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *GroupMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -2751,278 +3078,2743 @@ func (m *IMSMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().IMS.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Group.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetDisplay sets the "display" field.
-func (m *IMSMutation) SetDisplay(s string) {
-	m.display = &s
+// SetNormalizedDisplayName sets the "normalizedDisplayName" field.
+func (m *GroupMutation) SetNormalizedDisplayName(s string) {
+	m.normalizedDisplayName = &s
 }
 
-// Display returns the value of the "display" field in the mutation.
-func (m *IMSMutation) Display() (r string, exists bool) {
-	v := m.display
+// NormalizedDisplayName returns the value of the "normalizedDisplayName" field in the mutation.
+func (m *GroupMutation) NormalizedDisplayName() (r string, exists bool) {
+	v := m.normalizedDisplayName
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the IMS entity.
-// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// OldNormalizedDisplayName returns the old "normalizedDisplayName" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *IMSMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldNormalizedDisplayName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+		return v, errors.New("OldNormalizedDisplayName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDisplay requires an ID field in the mutation")
+		return v, errors.New("OldNormalizedDisplayName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+		return v, fmt.Errorf("querying old value for OldNormalizedDisplayName: %w", err)
 	}
-	return oldValue.Display, nil
+	return oldValue.NormalizedDisplayName, nil
 }
 
-// ClearDisplay clears the value of the "display" field.
-func (m *IMSMutation) ClearDisplay() {
-	m.display = nil
-	m.clearedFields[ims.FieldDisplay] = struct{}{}
+// ClearNormalizedDisplayName clears the value of the "normalizedDisplayName" field.
+func (m *GroupMutation) ClearNormalizedDisplayName() {
+	m.normalizedDisplayName = nil
+	m.clearedFields[group.FieldNormalizedDisplayName] = struct{}{}
 }
 
-// DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *IMSMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[ims.FieldDisplay]
+// NormalizedDisplayNameCleared returns if the "normalizedDisplayName" field was cleared in this mutation.
+func (m *GroupMutation) NormalizedDisplayNameCleared() bool {
+	_, ok := m.clearedFields[group.FieldNormalizedDisplayName]
 	return ok
 }
 
-// ResetDisplay resets all changes to the "display" field.
-func (m *IMSMutation) ResetDisplay() {
-	m.display = nil
-	delete(m.clearedFields, ims.FieldDisplay)
+// ResetNormalizedDisplayName resets all changes to the "normalizedDisplayName" field.
+func (m *GroupMutation) ResetNormalizedDisplayName() {
+	m.normalizedDisplayName = nil
+	delete(m.clearedFields, group.FieldNormalizedDisplayName)
 }
 
-// SetPrimary sets the "primary" field.
-func (m *IMSMutation) SetPrimary(b bool) {
-	m.primary = &b
+// SetExternalIDClient sets the "externalIDClient" field.
+func (m *GroupMutation) SetExternalIDClient(s string) {
+	m.externalIDClient = &s
 }
 
-// Primary returns the value of the "primary" field in the mutation.
-func (m *IMSMutation) Primary() (r bool, exists bool) {
-	v := m.primary
+// ExternalIDClient returns the value of the "externalIDClient" field in the mutation.
+func (m *GroupMutation) ExternalIDClient() (r string, exists bool) {
+	v := m.externalIDClient
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPrimary returns the old "primary" field's value of the IMS entity.
-// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// OldExternalIDClient returns the old "externalIDClient" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *IMSMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+func (m *GroupMutation) OldExternalIDClient(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
+		return v, errors.New("OldExternalIDClient is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPrimary requires an ID field in the mutation")
+		return v, errors.New("OldExternalIDClient requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
+		return v, fmt.Errorf("querying old value for OldExternalIDClient: %w", err)
 	}
-	return oldValue.Primary, nil
+	return oldValue.ExternalIDClient, nil
 }
 
-// ClearPrimary clears the value of the "primary" field.
-func (m *IMSMutation) ClearPrimary() {
-	m.primary = nil
-	m.clearedFields[ims.FieldPrimary] = struct{}{}
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (m *GroupMutation) ClearExternalIDClient() {
+	m.externalIDClient = nil
+	m.clearedFields[group.FieldExternalIDClient] = struct{}{}
 }
 
-// PrimaryCleared returns if the "primary" field was cleared in this mutation.
-func (m *IMSMutation) PrimaryCleared() bool {
-	_, ok := m.clearedFields[ims.FieldPrimary]
+// ExternalIDClientCleared returns if the "externalIDClient" field was cleared in this mutation.
+func (m *GroupMutation) ExternalIDClientCleared() bool {
+	_, ok := m.clearedFields[group.FieldExternalIDClient]
 	return ok
 }
 
-// ResetPrimary resets all changes to the "primary" field.
-func (m *IMSMutation) ResetPrimary() {
-	m.primary = nil
-	delete(m.clearedFields, ims.FieldPrimary)
+// ResetExternalIDClient resets all changes to the "externalIDClient" field.
+func (m *GroupMutation) ResetExternalIDClient() {
+	m.externalIDClient = nil
+	delete(m.clearedFields, group.FieldExternalIDClient)
 }
 
-// SetType sets the "type" field.
-func (m *IMSMutation) SetType(s string) {
-	m._type = &s
+// SetDeletedAt sets the "deletedAt" field.
+func (m *GroupMutation) SetDeletedAt(t time.Time) {
+	m.deletedAt = &t
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *IMSMutation) GetType() (r string, exists bool) {
-	v := m._type
+// DeletedAt returns the value of the "deletedAt" field in the mutation.
+func (m *GroupMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deletedAt
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the IMS entity.
-// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// OldDeletedAt returns the old "deletedAt" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *IMSMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.DeletedAt, nil
 }
 
-// ClearType clears the value of the "type" field.
-func (m *IMSMutation) ClearType() {
-	m._type = nil
-	m.clearedFields[ims.FieldType] = struct{}{}
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (m *GroupMutation) ClearDeletedAt() {
+	m.deletedAt = nil
+	m.clearedFields[group.FieldDeletedAt] = struct{}{}
 }
 
-// TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *IMSMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[ims.FieldType]
+// DeletedAtCleared returns if the "deletedAt" field was cleared in this mutation.
+func (m *GroupMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[group.FieldDeletedAt]
 	return ok
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *IMSMutation) ResetType() {
-	m._type = nil
-	delete(m.clearedFields, ims.FieldType)
+// ResetDeletedAt resets all changes to the "deletedAt" field.
+func (m *GroupMutation) ResetDeletedAt() {
+	m.deletedAt = nil
+	delete(m.clearedFields, group.FieldDeletedAt)
 }
 
-// SetValue sets the "value" field.
-func (m *IMSMutation) SetValue(s string) {
-	m.value = &s
+// SetDeletedBy sets the "deletedBy" field.
+func (m *GroupMutation) SetDeletedBy(s string) {
+	m.deletedBy = &s
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *IMSMutation) Value() (r string, exists bool) {
-	v := m.value
+// DeletedBy returns the value of the "deletedBy" field in the mutation.
+func (m *GroupMutation) DeletedBy() (r string, exists bool) {
+	v := m.deletedBy
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the IMS entity.
-// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// OldDeletedBy returns the old "deletedBy" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *IMSMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *GroupMutation) OldDeletedBy(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeletedBy is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+		return v, errors.New("OldDeletedBy requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeletedBy: %w", err)
 	}
-	return oldValue.Value, nil
+	return oldValue.DeletedBy, nil
 }
 
-// ClearValue clears the value of the "value" field.
-func (m *IMSMutation) ClearValue() {
-	m.value = nil
-	m.clearedFields[ims.FieldValue] = struct{}{}
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (m *GroupMutation) ClearDeletedBy() {
+	m.deletedBy = nil
+	m.clearedFields[group.FieldDeletedBy] = struct{}{}
 }
 
-// ValueCleared returns if the "value" field was cleared in this mutation.
-func (m *IMSMutation) ValueCleared() bool {
-	_, ok := m.clearedFields[ims.FieldValue]
+// DeletedByCleared returns if the "deletedBy" field was cleared in this mutation.
+func (m *GroupMutation) DeletedByCleared() bool {
+	_, ok := m.clearedFields[group.FieldDeletedBy]
 	return ok
 }
 
-// ResetValue resets all changes to the "value" field.
-func (m *IMSMutation) ResetValue() {
-	m.value = nil
-	delete(m.clearedFields, ims.FieldValue)
-}
-
-// SetUserID sets the "user" edge to the User entity by id.
-func (m *IMSMutation) SetUserID(id uuid.UUID) {
-	m.user = &id
+// ResetDeletedBy resets all changes to the "deletedBy" field.
+func (m *GroupMutation) ResetDeletedBy() {
+	m.deletedBy = nil
+	delete(m.clearedFields, group.FieldDeletedBy)
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *IMSMutation) ClearUser() {
-	m.cleareduser = true
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (m *GroupMutation) SetDeletedMemberships(b []byte) {
+	m.deletedMemberships = &b
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *IMSMutation) UserCleared() bool {
-	return m.cleareduser
+// DeletedMemberships returns the value of the "deletedMemberships" field in the mutation.
+func (m *GroupMutation) DeletedMemberships() (r []byte, exists bool) {
+	v := m.deletedMemberships
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// UserID returns the "user" edge ID in the mutation.
-func (m *IMSMutation) UserID() (id uuid.UUID, exists bool) {
-	if m.user != nil {
-		return *m.user, true
+// OldDeletedMemberships returns the old "deletedMemberships" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDeletedMemberships(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedMemberships is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedMemberships requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedMemberships: %w", err)
+	}
+	return oldValue.DeletedMemberships, nil
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *IMSMutation) UserIDs() (ids []uuid.UUID) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (m *GroupMutation) ClearDeletedMemberships() {
+	m.deletedMemberships = nil
+	m.clearedFields[group.FieldDeletedMemberships] = struct{}{}
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *IMSMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// DeletedMembershipsCleared returns if the "deletedMemberships" field was cleared in this mutation.
+func (m *GroupMutation) DeletedMembershipsCleared() bool {
+	_, ok := m.clearedFields[group.FieldDeletedMemberships]
+	return ok
 }
 
-// Where appends a list predicates to the IMSMutation builder.
-func (m *IMSMutation) Where(ps ...predicate.IMS) {
-	m.predicates = append(m.predicates, ps...)
+// ResetDeletedMemberships resets all changes to the "deletedMemberships" field.
+func (m *GroupMutation) ResetDeletedMemberships() {
+	m.deletedMemberships = nil
+	delete(m.clearedFields, group.FieldDeletedMemberships)
 }
 
-// Op returns the operation name.
-func (m *IMSMutation) Op() Op {
-	return m.op
+// SetDisplayName sets the "displayName" field.
+func (m *GroupMutation) SetDisplayName(s string) {
+	m.displayName = &s
 }
 
-// Type returns the node type of this mutation (IMS).
-func (m *IMSMutation) Type() string {
-	return m.typ
+// DisplayName returns the value of the "displayName" field in the mutation.
+func (m *GroupMutation) DisplayName() (r string, exists bool) {
+	v := m.displayName
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *IMSMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.display != nil {
-		fields = append(fields, ims.FieldDisplay)
+// OldDisplayName returns the old "displayName" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldDisplayName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisplayName is only allowed on UpdateOne operations")
 	}
-	if m.primary != nil {
-		fields = append(fields, ims.FieldPrimary)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisplayName requires an ID field in the mutation")
 	}
-	if m._type != nil {
-		fields = append(fields, ims.FieldType)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisplayName: %w", err)
 	}
-	if m.value != nil {
-		fields = append(fields, ims.FieldValue)
+	return oldValue.DisplayName, nil
+}
+
+// ClearDisplayName clears the value of the "displayName" field.
+func (m *GroupMutation) ClearDisplayName() {
+	m.displayName = nil
+	m.clearedFields[group.FieldDisplayName] = struct{}{}
+}
+
+// DisplayNameCleared returns if the "displayName" field was cleared in this mutation.
+func (m *GroupMutation) DisplayNameCleared() bool {
+	_, ok := m.clearedFields[group.FieldDisplayName]
+	return ok
+}
+
+// ResetDisplayName resets all changes to the "displayName" field.
+func (m *GroupMutation) ResetDisplayName() {
+	m.displayName = nil
+	delete(m.clearedFields, group.FieldDisplayName)
+}
+
+// SetExternalID sets the "externalID" field.
+func (m *GroupMutation) SetExternalID(s string) {
+	m.externalID = &s
+}
+
+// ExternalID returns the value of the "externalID" field in the mutation.
+func (m *GroupMutation) ExternalID() (r string, exists bool) {
+	v := m.externalID
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExternalID returns the old "externalID" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldExternalID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExternalID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExternalID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExternalID: %w", err)
+	}
+	return oldValue.ExternalID, nil
+}
+
+// ClearExternalID clears the value of the "externalID" field.
+func (m *GroupMutation) ClearExternalID() {
+	m.externalID = nil
+	m.clearedFields[group.FieldExternalID] = struct{}{}
+}
+
+// ExternalIDCleared returns if the "externalID" field was cleared in this mutation.
+func (m *GroupMutation) ExternalIDCleared() bool {
+	_, ok := m.clearedFields[group.FieldExternalID]
+	return ok
+}
+
+// ResetExternalID resets all changes to the "externalID" field.
+func (m *GroupMutation) ResetExternalID() {
+	m.externalID = nil
+	delete(m.clearedFields, group.FieldExternalID)
+}
+
+// SetEtag sets the "etag" field.
+func (m *GroupMutation) SetEtag(s string) {
+	m.etag = &s
+}
+
+// Etag returns the value of the "etag" field in the mutation.
+func (m *GroupMutation) Etag() (r string, exists bool) {
+	v := m.etag
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEtag returns the old "etag" field's value of the Group entity.
+// If the Group object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *GroupMutation) OldEtag(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEtag is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEtag requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEtag: %w", err)
+	}
+	return oldValue.Etag, nil
+}
+
+// ClearEtag clears the value of the "etag" field.
+func (m *GroupMutation) ClearEtag() {
+	m.etag = nil
+	m.clearedFields[group.FieldEtag] = struct{}{}
+}
+
+// EtagCleared returns if the "etag" field was cleared in this mutation.
+func (m *GroupMutation) EtagCleared() bool {
+	_, ok := m.clearedFields[group.FieldEtag]
+	return ok
+}
+
+// ResetEtag resets all changes to the "etag" field.
+func (m *GroupMutation) ResetEtag() {
+	m.etag = nil
+	delete(m.clearedFields, group.FieldEtag)
+}
+
+// AddMemberIDs adds the "members" edge to the Member entity by ids.
+func (m *GroupMutation) AddMemberIDs(ids ...int) {
+	if m.members == nil {
+		m.members = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.members[ids[i]] = struct{}{}
+	}
+}
+
+// ClearMembers clears the "members" edge to the Member entity.
+func (m *GroupMutation) ClearMembers() {
+	m.clearedmembers = true
+}
+
+// MembersCleared reports if the "members" edge to the Member entity was cleared.
+func (m *GroupMutation) MembersCleared() bool {
+	return m.clearedmembers
+}
+
+// RemoveMemberIDs removes the "members" edge to the Member entity by IDs.
+func (m *GroupMutation) RemoveMemberIDs(ids ...int) {
+	if m.removedmembers == nil {
+		m.removedmembers = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.members, ids[i])
+		m.removedmembers[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedMembers returns the removed IDs of the "members" edge to the Member entity.
+func (m *GroupMutation) RemovedMembersIDs() (ids []int) {
+	for id := range m.removedmembers {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// MembersIDs returns the "members" edge IDs in the mutation.
+func (m *GroupMutation) MembersIDs() (ids []int) {
+	for id := range m.members {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetMembers resets all changes to the "members" edge.
+func (m *GroupMutation) ResetMembers() {
+	m.members = nil
+	m.clearedmembers = false
+	m.removedmembers = nil
+}
+
+// Where appends a list predicates to the GroupMutation builder.
+func (m *GroupMutation) Where(ps ...predicate.Group) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *GroupMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Group).
+func (m *GroupMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *GroupMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.normalizedDisplayName != nil {
+		fields = append(fields, group.FieldNormalizedDisplayName)
+	}
+	if m.externalIDClient != nil {
+		fields = append(fields, group.FieldExternalIDClient)
+	}
+	if m.deletedAt != nil {
+		fields = append(fields, group.FieldDeletedAt)
+	}
+	if m.deletedBy != nil {
+		fields = append(fields, group.FieldDeletedBy)
+	}
+	if m.deletedMemberships != nil {
+		fields = append(fields, group.FieldDeletedMemberships)
+	}
+	if m.displayName != nil {
+		fields = append(fields, group.FieldDisplayName)
+	}
+	if m.externalID != nil {
+		fields = append(fields, group.FieldExternalID)
+	}
+	if m.etag != nil {
+		fields = append(fields, group.FieldEtag)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *GroupMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case group.FieldNormalizedDisplayName:
+		return m.NormalizedDisplayName()
+	case group.FieldExternalIDClient:
+		return m.ExternalIDClient()
+	case group.FieldDeletedAt:
+		return m.DeletedAt()
+	case group.FieldDeletedBy:
+		return m.DeletedBy()
+	case group.FieldDeletedMemberships:
+		return m.DeletedMemberships()
+	case group.FieldDisplayName:
+		return m.DisplayName()
+	case group.FieldExternalID:
+		return m.ExternalID()
+	case group.FieldEtag:
+		return m.Etag()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *GroupMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case group.FieldNormalizedDisplayName:
+		return m.OldNormalizedDisplayName(ctx)
+	case group.FieldExternalIDClient:
+		return m.OldExternalIDClient(ctx)
+	case group.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case group.FieldDeletedBy:
+		return m.OldDeletedBy(ctx)
+	case group.FieldDeletedMemberships:
+		return m.OldDeletedMemberships(ctx)
+	case group.FieldDisplayName:
+		return m.OldDisplayName(ctx)
+	case group.FieldExternalID:
+		return m.OldExternalID(ctx)
+	case group.FieldEtag:
+		return m.OldEtag(ctx)
+	}
+	return nil, fmt.Errorf("unknown Group field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *GroupMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case group.FieldNormalizedDisplayName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNormalizedDisplayName(v)
+		return nil
+	case group.FieldExternalIDClient:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExternalIDClient(v)
+		return nil
+	case group.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case group.FieldDeletedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedBy(v)
+		return nil
+	case group.FieldDeletedMemberships:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedMemberships(v)
+		return nil
+	case group.FieldDisplayName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisplayName(v)
+		return nil
+	case group.FieldExternalID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExternalID(v)
+		return nil
+	case group.FieldEtag:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEtag(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Group field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *GroupMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *GroupMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *GroupMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Group numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *GroupMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(group.FieldNormalizedDisplayName) {
+		fields = append(fields, group.FieldNormalizedDisplayName)
+	}
+	if m.FieldCleared(group.FieldExternalIDClient) {
+		fields = append(fields, group.FieldExternalIDClient)
+	}
+	if m.FieldCleared(group.FieldDeletedAt) {
+		fields = append(fields, group.FieldDeletedAt)
+	}
+	if m.FieldCleared(group.FieldDeletedBy) {
+		fields = append(fields, group.FieldDeletedBy)
+	}
+	if m.FieldCleared(group.FieldDeletedMemberships) {
+		fields = append(fields, group.FieldDeletedMemberships)
+	}
+	if m.FieldCleared(group.FieldDisplayName) {
+		fields = append(fields, group.FieldDisplayName)
+	}
+	if m.FieldCleared(group.FieldExternalID) {
+		fields = append(fields, group.FieldExternalID)
+	}
+	if m.FieldCleared(group.FieldEtag) {
+		fields = append(fields, group.FieldEtag)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *GroupMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *GroupMutation) ClearField(name string) error {
+	switch name {
+	case group.FieldNormalizedDisplayName:
+		m.ClearNormalizedDisplayName()
+		return nil
+	case group.FieldExternalIDClient:
+		m.ClearExternalIDClient()
+		return nil
+	case group.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case group.FieldDeletedBy:
+		m.ClearDeletedBy()
+		return nil
+	case group.FieldDeletedMemberships:
+		m.ClearDeletedMemberships()
+		return nil
+	case group.FieldDisplayName:
+		m.ClearDisplayName()
+		return nil
+	case group.FieldExternalID:
+		m.ClearExternalID()
+		return nil
+	case group.FieldEtag:
+		m.ClearEtag()
+		return nil
+	}
+	return fmt.Errorf("unknown Group nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *GroupMutation) ResetField(name string) error {
+	switch name {
+	case group.FieldNormalizedDisplayName:
+		m.ResetNormalizedDisplayName()
+		return nil
+	case group.FieldExternalIDClient:
+		m.ResetExternalIDClient()
+		return nil
+	case group.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case group.FieldDeletedBy:
+		m.ResetDeletedBy()
+		return nil
+	case group.FieldDeletedMemberships:
+		m.ResetDeletedMemberships()
+		return nil
+	case group.FieldDisplayName:
+		m.ResetDisplayName()
+		return nil
+	case group.FieldExternalID:
+		m.ResetExternalID()
+		return nil
+	case group.FieldEtag:
+		m.ResetEtag()
+		return nil
+	}
+	return fmt.Errorf("unknown Group field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *GroupMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.members != nil {
+		edges = append(edges, group.EdgeMembers)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *GroupMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case group.EdgeMembers:
+		ids := make([]ent.Value, 0, len(m.members))
+		for id := range m.members {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *GroupMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedmembers != nil {
+		edges = append(edges, group.EdgeMembers)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *GroupMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case group.EdgeMembers:
+		ids := make([]ent.Value, 0, len(m.removedmembers))
+		for id := range m.removedmembers {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *GroupMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedmembers {
+		edges = append(edges, group.EdgeMembers)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *GroupMutation) EdgeCleared(name string) bool {
+	switch name {
+	case group.EdgeMembers:
+		return m.clearedmembers
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *GroupMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Group unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *GroupMutation) ResetEdge(name string) error {
+	switch name {
+	case group.EdgeMembers:
+		m.ResetMembers()
+		return nil
+	}
+	return fmt.Errorf("unknown Group edge %s", name)
+}
+
+// IMSMutation represents an operation that mutates the IMS nodes in the graph.
+type IMSMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	display       *string
+	primary       *bool
+	_type         *string
+	value         *string
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*IMS, error)
+	predicates    []predicate.IMS
+}
+
+var _ ent.Mutation = (*IMSMutation)(nil)
+
+// imsOption allows management of the mutation configuration using functional options.
+type imsOption func(*IMSMutation)
+
+// newIMSMutation creates new mutation for the IMS entity.
+func newIMSMutation(c config, op Op, opts ...imsOption) *IMSMutation {
+	m := &IMSMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeIMS,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withIMSID sets the ID field of the mutation.
+func withIMSID(id uuid.UUID) imsOption {
+	return func(m *IMSMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *IMS
+		)
+		m.oldValue = func(ctx context.Context) (*IMS, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().IMS.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withIMS sets the old IMS of the mutation.
+func withIMS(node *IMS) imsOption {
+	return func(m *IMSMutation) {
+		m.oldValue = func(context.Context) (*IMS, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m IMSMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m IMSMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of IMS entities.
+func (m *IMSMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *IMSMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *IMSMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().IMS.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetDisplay sets the "display" field.
+func (m *IMSMutation) SetDisplay(s string) {
+	m.display = &s
+}
+
+// Display returns the value of the "display" field in the mutation.
+func (m *IMSMutation) Display() (r string, exists bool) {
+	v := m.display
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDisplay returns the old "display" field's value of the IMS entity.
+// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IMSMutation) OldDisplay(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisplay requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+	}
+	return oldValue.Display, nil
+}
+
+// ClearDisplay clears the value of the "display" field.
+func (m *IMSMutation) ClearDisplay() {
+	m.display = nil
+	m.clearedFields[ims.FieldDisplay] = struct{}{}
+}
+
+// DisplayCleared returns if the "display" field was cleared in this mutation.
+func (m *IMSMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[ims.FieldDisplay]
+	return ok
+}
+
+// ResetDisplay resets all changes to the "display" field.
+func (m *IMSMutation) ResetDisplay() {
+	m.display = nil
+	delete(m.clearedFields, ims.FieldDisplay)
+}
+
+// SetPrimary sets the "primary" field.
+func (m *IMSMutation) SetPrimary(b bool) {
+	m.primary = &b
+}
+
+// Primary returns the value of the "primary" field in the mutation.
+func (m *IMSMutation) Primary() (r bool, exists bool) {
+	v := m.primary
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrimary returns the old "primary" field's value of the IMS entity.
+// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IMSMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrimary requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
+	}
+	return oldValue.Primary, nil
+}
+
+// ClearPrimary clears the value of the "primary" field.
+func (m *IMSMutation) ClearPrimary() {
+	m.primary = nil
+	m.clearedFields[ims.FieldPrimary] = struct{}{}
+}
+
+// PrimaryCleared returns if the "primary" field was cleared in this mutation.
+func (m *IMSMutation) PrimaryCleared() bool {
+	_, ok := m.clearedFields[ims.FieldPrimary]
+	return ok
+}
+
+// ResetPrimary resets all changes to the "primary" field.
+func (m *IMSMutation) ResetPrimary() {
+	m.primary = nil
+	delete(m.clearedFields, ims.FieldPrimary)
+}
+
+// SetType sets the "type" field.
+func (m *IMSMutation) SetType(s string) {
+	m._type = &s
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *IMSMutation) GetType() (r string, exists bool) {
+	v := m._type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the IMS entity.
+// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IMSMutation) OldType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	}
+	return oldValue.Type, nil
+}
+
+// ClearType clears the value of the "type" field.
+func (m *IMSMutation) ClearType() {
+	m._type = nil
+	m.clearedFields[ims.FieldType] = struct{}{}
+}
+
+// TypeCleared returns if the "type" field was cleared in this mutation.
+func (m *IMSMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[ims.FieldType]
+	return ok
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *IMSMutation) ResetType() {
+	m._type = nil
+	delete(m.clearedFields, ims.FieldType)
+}
+
+// SetValue sets the "value" field.
+func (m *IMSMutation) SetValue(s string) {
+	m.value = &s
+}
+
+// Value returns the value of the "value" field in the mutation.
+func (m *IMSMutation) Value() (r string, exists bool) {
+	v := m.value
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldValue returns the old "value" field's value of the IMS entity.
+// If the IMS object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IMSMutation) OldValue(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValue requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+	}
+	return oldValue.Value, nil
+}
+
+// ClearValue clears the value of the "value" field.
+func (m *IMSMutation) ClearValue() {
+	m.value = nil
+	m.clearedFields[ims.FieldValue] = struct{}{}
+}
+
+// ValueCleared returns if the "value" field was cleared in this mutation.
+func (m *IMSMutation) ValueCleared() bool {
+	_, ok := m.clearedFields[ims.FieldValue]
+	return ok
+}
+
+// ResetValue resets all changes to the "value" field.
+func (m *IMSMutation) ResetValue() {
+	m.value = nil
+	delete(m.clearedFields, ims.FieldValue)
+}
+
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *IMSMutation) SetUserID(id uuid.UUID) {
+	m.user = &id
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *IMSMutation) ClearUser() {
+	m.cleareduser = true
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *IMSMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserID returns the "user" edge ID in the mutation.
+func (m *IMSMutation) UserID() (id uuid.UUID, exists bool) {
+	if m.user != nil {
+		return *m.user, true
+	}
+	return
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *IMSMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *IMSMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the IMSMutation builder.
+func (m *IMSMutation) Where(ps ...predicate.IMS) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *IMSMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (IMS).
+func (m *IMSMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *IMSMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.display != nil {
+		fields = append(fields, ims.FieldDisplay)
+	}
+	if m.primary != nil {
+		fields = append(fields, ims.FieldPrimary)
+	}
+	if m._type != nil {
+		fields = append(fields, ims.FieldType)
+	}
+	if m.value != nil {
+		fields = append(fields, ims.FieldValue)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *IMSMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case ims.FieldDisplay:
+		return m.Display()
+	case ims.FieldPrimary:
+		return m.Primary()
+	case ims.FieldType:
+		return m.GetType()
+	case ims.FieldValue:
+		return m.Value()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *IMSMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case ims.FieldDisplay:
+		return m.OldDisplay(ctx)
+	case ims.FieldPrimary:
+		return m.OldPrimary(ctx)
+	case ims.FieldType:
+		return m.OldType(ctx)
+	case ims.FieldValue:
+		return m.OldValue(ctx)
+	}
+	return nil, fmt.Errorf("unknown IMS field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *IMSMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case ims.FieldDisplay:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisplay(v)
+		return nil
+	case ims.FieldPrimary:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrimary(v)
+		return nil
+	case ims.FieldType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case ims.FieldValue:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValue(v)
+		return nil
+	}
+	return fmt.Errorf("unknown IMS field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *IMSMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *IMSMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *IMSMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown IMS numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *IMSMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(ims.FieldDisplay) {
+		fields = append(fields, ims.FieldDisplay)
+	}
+	if m.FieldCleared(ims.FieldPrimary) {
+		fields = append(fields, ims.FieldPrimary)
+	}
+	if m.FieldCleared(ims.FieldType) {
+		fields = append(fields, ims.FieldType)
+	}
+	if m.FieldCleared(ims.FieldValue) {
+		fields = append(fields, ims.FieldValue)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *IMSMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *IMSMutation) ClearField(name string) error {
+	switch name {
+	case ims.FieldDisplay:
+		m.ClearDisplay()
+		return nil
+	case ims.FieldPrimary:
+		m.ClearPrimary()
+		return nil
+	case ims.FieldType:
+		m.ClearType()
+		return nil
+	case ims.FieldValue:
+		m.ClearValue()
+		return nil
+	}
+	return fmt.Errorf("unknown IMS nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *IMSMutation) ResetField(name string) error {
+	switch name {
+	case ims.FieldDisplay:
+		m.ResetDisplay()
+		return nil
+	case ims.FieldPrimary:
+		m.ResetPrimary()
+		return nil
+	case ims.FieldType:
+		m.ResetType()
+		return nil
+	case ims.FieldValue:
+		m.ResetValue()
+		return nil
+	}
+	return fmt.Errorf("unknown IMS field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *IMSMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, ims.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *IMSMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case ims.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *IMSMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *IMSMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *IMSMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, ims.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *IMSMutation) EdgeCleared(name string) bool {
+	switch name {
+	case ims.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *IMSMutation) ClearEdge(name string) error {
+	switch name {
+	case ims.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown IMS unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *IMSMutation) ResetEdge(name string) error {
+	switch name {
+	case ims.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown IMS edge %s", name)
+}
+
+// IdempotencyKeyMutation represents an operation that mutates the IdempotencyKey nodes in the graph.
+type IdempotencyKeyMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	clientName    *string
+	key           *string
+	requestHash   *string
+	status        *int
+	addstatus     *int
+	header        *[]byte
+	body          *[]byte
+	createdAt     *time.Time
+	expiresAt     *time.Time
+	reservedAt    *time.Time
+	reservation   *string
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*IdempotencyKey, error)
+	predicates    []predicate.IdempotencyKey
+}
+
+var _ ent.Mutation = (*IdempotencyKeyMutation)(nil)
+
+// idempotencykeyOption allows management of the mutation configuration using functional options.
+type idempotencykeyOption func(*IdempotencyKeyMutation)
+
+// newIdempotencyKeyMutation creates new mutation for the IdempotencyKey entity.
+func newIdempotencyKeyMutation(c config, op Op, opts ...idempotencykeyOption) *IdempotencyKeyMutation {
+	m := &IdempotencyKeyMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeIdempotencyKey,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withIdempotencyKeyID sets the ID field of the mutation.
+func withIdempotencyKeyID(id int) idempotencykeyOption {
+	return func(m *IdempotencyKeyMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *IdempotencyKey
+		)
+		m.oldValue = func(ctx context.Context) (*IdempotencyKey, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().IdempotencyKey.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withIdempotencyKey sets the old IdempotencyKey of the mutation.
+func withIdempotencyKey(node *IdempotencyKey) idempotencykeyOption {
+	return func(m *IdempotencyKeyMutation) {
+		m.oldValue = func(context.Context) (*IdempotencyKey, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m IdempotencyKeyMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m IdempotencyKeyMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *IdempotencyKeyMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *IdempotencyKeyMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().IdempotencyKey.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetClientName sets the "clientName" field.
+func (m *IdempotencyKeyMutation) SetClientName(s string) {
+	m.clientName = &s
+}
+
+// ClientName returns the value of the "clientName" field in the mutation.
+func (m *IdempotencyKeyMutation) ClientName() (r string, exists bool) {
+	v := m.clientName
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClientName returns the old "clientName" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldClientName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClientName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClientName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClientName: %w", err)
+	}
+	return oldValue.ClientName, nil
+}
+
+// ResetClientName resets all changes to the "clientName" field.
+func (m *IdempotencyKeyMutation) ResetClientName() {
+	m.clientName = nil
+}
+
+// SetKey sets the "key" field.
+func (m *IdempotencyKeyMutation) SetKey(s string) {
+	m.key = &s
+}
+
+// Key returns the value of the "key" field in the mutation.
+func (m *IdempotencyKeyMutation) Key() (r string, exists bool) {
+	v := m.key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKey returns the old "key" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldKey(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKey: %w", err)
+	}
+	return oldValue.Key, nil
+}
+
+// ResetKey resets all changes to the "key" field.
+func (m *IdempotencyKeyMutation) ResetKey() {
+	m.key = nil
+}
+
+// SetRequestHash sets the "requestHash" field.
+func (m *IdempotencyKeyMutation) SetRequestHash(s string) {
+	m.requestHash = &s
+}
+
+// RequestHash returns the value of the "requestHash" field in the mutation.
+func (m *IdempotencyKeyMutation) RequestHash() (r string, exists bool) {
+	v := m.requestHash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequestHash returns the old "requestHash" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldRequestHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequestHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequestHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequestHash: %w", err)
+	}
+	return oldValue.RequestHash, nil
+}
+
+// ResetRequestHash resets all changes to the "requestHash" field.
+func (m *IdempotencyKeyMutation) ResetRequestHash() {
+	m.requestHash = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *IdempotencyKeyMutation) SetStatus(i int) {
+	m.status = &i
+	m.addstatus = nil
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *IdempotencyKeyMutation) Status() (r int, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldStatus(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// AddStatus adds i to the "status" field.
+func (m *IdempotencyKeyMutation) AddStatus(i int) {
+	if m.addstatus != nil {
+		*m.addstatus += i
+	} else {
+		m.addstatus = &i
+	}
+}
+
+// AddedStatus returns the value that was added to the "status" field in this mutation.
+func (m *IdempotencyKeyMutation) AddedStatus() (r int, exists bool) {
+	v := m.addstatus
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearStatus clears the value of the "status" field.
+func (m *IdempotencyKeyMutation) ClearStatus() {
+	m.status = nil
+	m.addstatus = nil
+	m.clearedFields[idempotencykey.FieldStatus] = struct{}{}
+}
+
+// StatusCleared returns if the "status" field was cleared in this mutation.
+func (m *IdempotencyKeyMutation) StatusCleared() bool {
+	_, ok := m.clearedFields[idempotencykey.FieldStatus]
+	return ok
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *IdempotencyKeyMutation) ResetStatus() {
+	m.status = nil
+	m.addstatus = nil
+	delete(m.clearedFields, idempotencykey.FieldStatus)
+}
+
+// SetHeader sets the "header" field.
+func (m *IdempotencyKeyMutation) SetHeader(b []byte) {
+	m.header = &b
+}
+
+// Header returns the value of the "header" field in the mutation.
+func (m *IdempotencyKeyMutation) Header() (r []byte, exists bool) {
+	v := m.header
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHeader returns the old "header" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldHeader(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHeader is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHeader requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHeader: %w", err)
+	}
+	return oldValue.Header, nil
+}
+
+// ClearHeader clears the value of the "header" field.
+func (m *IdempotencyKeyMutation) ClearHeader() {
+	m.header = nil
+	m.clearedFields[idempotencykey.FieldHeader] = struct{}{}
+}
+
+// HeaderCleared returns if the "header" field was cleared in this mutation.
+func (m *IdempotencyKeyMutation) HeaderCleared() bool {
+	_, ok := m.clearedFields[idempotencykey.FieldHeader]
+	return ok
+}
+
+// ResetHeader resets all changes to the "header" field.
+func (m *IdempotencyKeyMutation) ResetHeader() {
+	m.header = nil
+	delete(m.clearedFields, idempotencykey.FieldHeader)
+}
+
+// SetBody sets the "body" field.
+func (m *IdempotencyKeyMutation) SetBody(b []byte) {
+	m.body = &b
+}
+
+// Body returns the value of the "body" field in the mutation.
+func (m *IdempotencyKeyMutation) Body() (r []byte, exists bool) {
+	v := m.body
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBody returns the old "body" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldBody(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBody is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBody requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBody: %w", err)
+	}
+	return oldValue.Body, nil
+}
+
+// ClearBody clears the value of the "body" field.
+func (m *IdempotencyKeyMutation) ClearBody() {
+	m.body = nil
+	m.clearedFields[idempotencykey.FieldBody] = struct{}{}
+}
+
+// BodyCleared returns if the "body" field was cleared in this mutation.
+func (m *IdempotencyKeyMutation) BodyCleared() bool {
+	_, ok := m.clearedFields[idempotencykey.FieldBody]
+	return ok
+}
+
+// ResetBody resets all changes to the "body" field.
+func (m *IdempotencyKeyMutation) ResetBody() {
+	m.body = nil
+	delete(m.clearedFields, idempotencykey.FieldBody)
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (m *IdempotencyKeyMutation) SetCreatedAt(t time.Time) {
+	m.createdAt = &t
+}
+
+// CreatedAt returns the value of the "createdAt" field in the mutation.
+func (m *IdempotencyKeyMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.createdAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "createdAt" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "createdAt" field.
+func (m *IdempotencyKeyMutation) ResetCreatedAt() {
+	m.createdAt = nil
+}
+
+// SetExpiresAt sets the "expiresAt" field.
+func (m *IdempotencyKeyMutation) SetExpiresAt(t time.Time) {
+	m.expiresAt = &t
+}
+
+// ExpiresAt returns the value of the "expiresAt" field in the mutation.
+func (m *IdempotencyKeyMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expiresAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expiresAt" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ResetExpiresAt resets all changes to the "expiresAt" field.
+func (m *IdempotencyKeyMutation) ResetExpiresAt() {
+	m.expiresAt = nil
+}
+
+// SetReservedAt sets the "reservedAt" field.
+func (m *IdempotencyKeyMutation) SetReservedAt(t time.Time) {
+	m.reservedAt = &t
+}
+
+// ReservedAt returns the value of the "reservedAt" field in the mutation.
+func (m *IdempotencyKeyMutation) ReservedAt() (r time.Time, exists bool) {
+	v := m.reservedAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReservedAt returns the old "reservedAt" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldReservedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReservedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReservedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReservedAt: %w", err)
+	}
+	return oldValue.ReservedAt, nil
+}
+
+// ClearReservedAt clears the value of the "reservedAt" field.
+func (m *IdempotencyKeyMutation) ClearReservedAt() {
+	m.reservedAt = nil
+	m.clearedFields[idempotencykey.FieldReservedAt] = struct{}{}
+}
+
+// ReservedAtCleared returns if the "reservedAt" field was cleared in this mutation.
+func (m *IdempotencyKeyMutation) ReservedAtCleared() bool {
+	_, ok := m.clearedFields[idempotencykey.FieldReservedAt]
+	return ok
+}
+
+// ResetReservedAt resets all changes to the "reservedAt" field.
+func (m *IdempotencyKeyMutation) ResetReservedAt() {
+	m.reservedAt = nil
+	delete(m.clearedFields, idempotencykey.FieldReservedAt)
+}
+
+// SetReservation sets the "reservation" field.
+func (m *IdempotencyKeyMutation) SetReservation(s string) {
+	m.reservation = &s
+}
+
+// Reservation returns the value of the "reservation" field in the mutation.
+func (m *IdempotencyKeyMutation) Reservation() (r string, exists bool) {
+	v := m.reservation
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReservation returns the old "reservation" field's value of the IdempotencyKey entity.
+// If the IdempotencyKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IdempotencyKeyMutation) OldReservation(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReservation is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReservation requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReservation: %w", err)
+	}
+	return oldValue.Reservation, nil
+}
+
+// ClearReservation clears the value of the "reservation" field.
+func (m *IdempotencyKeyMutation) ClearReservation() {
+	m.reservation = nil
+	m.clearedFields[idempotencykey.FieldReservation] = struct{}{}
+}
+
+// ReservationCleared returns if the "reservation" field was cleared in this mutation.
+func (m *IdempotencyKeyMutation) ReservationCleared() bool {
+	_, ok := m.clearedFields[idempotencykey.FieldReservation]
+	return ok
+}
+
+// ResetReservation resets all changes to the "reservation" field.
+func (m *IdempotencyKeyMutation) ResetReservation() {
+	m.reservation = nil
+	delete(m.clearedFields, idempotencykey.FieldReservation)
+}
+
+// Where appends a list predicates to the IdempotencyKeyMutation builder.
+func (m *IdempotencyKeyMutation) Where(ps ...predicate.IdempotencyKey) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *IdempotencyKeyMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (IdempotencyKey).
+func (m *IdempotencyKeyMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *IdempotencyKeyMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.clientName != nil {
+		fields = append(fields, idempotencykey.FieldClientName)
+	}
+	if m.key != nil {
+		fields = append(fields, idempotencykey.FieldKey)
+	}
+	if m.requestHash != nil {
+		fields = append(fields, idempotencykey.FieldRequestHash)
+	}
+	if m.status != nil {
+		fields = append(fields, idempotencykey.FieldStatus)
+	}
+	if m.header != nil {
+		fields = append(fields, idempotencykey.FieldHeader)
+	}
+	if m.body != nil {
+		fields = append(fields, idempotencykey.FieldBody)
+	}
+	if m.createdAt != nil {
+		fields = append(fields, idempotencykey.FieldCreatedAt)
+	}
+	if m.expiresAt != nil {
+		fields = append(fields, idempotencykey.FieldExpiresAt)
+	}
+	if m.reservedAt != nil {
+		fields = append(fields, idempotencykey.FieldReservedAt)
+	}
+	if m.reservation != nil {
+		fields = append(fields, idempotencykey.FieldReservation)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *IdempotencyKeyMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case idempotencykey.FieldClientName:
+		return m.ClientName()
+	case idempotencykey.FieldKey:
+		return m.Key()
+	case idempotencykey.FieldRequestHash:
+		return m.RequestHash()
+	case idempotencykey.FieldStatus:
+		return m.Status()
+	case idempotencykey.FieldHeader:
+		return m.Header()
+	case idempotencykey.FieldBody:
+		return m.Body()
+	case idempotencykey.FieldCreatedAt:
+		return m.CreatedAt()
+	case idempotencykey.FieldExpiresAt:
+		return m.ExpiresAt()
+	case idempotencykey.FieldReservedAt:
+		return m.ReservedAt()
+	case idempotencykey.FieldReservation:
+		return m.Reservation()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *IdempotencyKeyMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case idempotencykey.FieldClientName:
+		return m.OldClientName(ctx)
+	case idempotencykey.FieldKey:
+		return m.OldKey(ctx)
+	case idempotencykey.FieldRequestHash:
+		return m.OldRequestHash(ctx)
+	case idempotencykey.FieldStatus:
+		return m.OldStatus(ctx)
+	case idempotencykey.FieldHeader:
+		return m.OldHeader(ctx)
+	case idempotencykey.FieldBody:
+		return m.OldBody(ctx)
+	case idempotencykey.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case idempotencykey.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case idempotencykey.FieldReservedAt:
+		return m.OldReservedAt(ctx)
+	case idempotencykey.FieldReservation:
+		return m.OldReservation(ctx)
+	}
+	return nil, fmt.Errorf("unknown IdempotencyKey field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *IdempotencyKeyMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case idempotencykey.FieldClientName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClientName(v)
+		return nil
+	case idempotencykey.FieldKey:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKey(v)
+		return nil
+	case idempotencykey.FieldRequestHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequestHash(v)
+		return nil
+	case idempotencykey.FieldStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case idempotencykey.FieldHeader:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHeader(v)
+		return nil
+	case idempotencykey.FieldBody:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBody(v)
+		return nil
+	case idempotencykey.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case idempotencykey.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case idempotencykey.FieldReservedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReservedAt(v)
+		return nil
+	case idempotencykey.FieldReservation:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReservation(v)
+		return nil
+	}
+	return fmt.Errorf("unknown IdempotencyKey field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *IdempotencyKeyMutation) AddedFields() []string {
+	var fields []string
+	if m.addstatus != nil {
+		fields = append(fields, idempotencykey.FieldStatus)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *IdempotencyKeyMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case idempotencykey.FieldStatus:
+		return m.AddedStatus()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *IdempotencyKeyMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case idempotencykey.FieldStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddStatus(v)
+		return nil
+	}
+	return fmt.Errorf("unknown IdempotencyKey numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *IdempotencyKeyMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(idempotencykey.FieldStatus) {
+		fields = append(fields, idempotencykey.FieldStatus)
+	}
+	if m.FieldCleared(idempotencykey.FieldHeader) {
+		fields = append(fields, idempotencykey.FieldHeader)
+	}
+	if m.FieldCleared(idempotencykey.FieldBody) {
+		fields = append(fields, idempotencykey.FieldBody)
+	}
+	if m.FieldCleared(idempotencykey.FieldReservedAt) {
+		fields = append(fields, idempotencykey.FieldReservedAt)
+	}
+	if m.FieldCleared(idempotencykey.FieldReservation) {
+		fields = append(fields, idempotencykey.FieldReservation)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *IdempotencyKeyMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *IdempotencyKeyMutation) ClearField(name string) error {
+	switch name {
+	case idempotencykey.FieldStatus:
+		m.ClearStatus()
+		return nil
+	case idempotencykey.FieldHeader:
+		m.ClearHeader()
+		return nil
+	case idempotencykey.FieldBody:
+		m.ClearBody()
+		return nil
+	case idempotencykey.FieldReservedAt:
+		m.ClearReservedAt()
+		return nil
+	case idempotencykey.FieldReservation:
+		m.ClearReservation()
+		return nil
+	}
+	return fmt.Errorf("unknown IdempotencyKey nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *IdempotencyKeyMutation) ResetField(name string) error {
+	switch name {
+	case idempotencykey.FieldClientName:
+		m.ResetClientName()
+		return nil
+	case idempotencykey.FieldKey:
+		m.ResetKey()
+		return nil
+	case idempotencykey.FieldRequestHash:
+		m.ResetRequestHash()
+		return nil
+	case idempotencykey.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case idempotencykey.FieldHeader:
+		m.ResetHeader()
+		return nil
+	case idempotencykey.FieldBody:
+		m.ResetBody()
+		return nil
+	case idempotencykey.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case idempotencykey.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case idempotencykey.FieldReservedAt:
+		m.ResetReservedAt()
+		return nil
+	case idempotencykey.FieldReservation:
+		m.ResetReservation()
+		return nil
+	}
+	return fmt.Errorf("unknown IdempotencyKey field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *IdempotencyKeyMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *IdempotencyKeyMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *IdempotencyKeyMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *IdempotencyKeyMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *IdempotencyKeyMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *IdempotencyKeyMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *IdempotencyKeyMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown IdempotencyKey unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *IdempotencyKeyMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown IdempotencyKey edge %s", name)
+}
+
+// MemberMutation represents an operation that mutates the Member nodes in the graph.
+type MemberMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	value         *string
+	display       *string
+	_type         *string
+	ref           *string
+	clearedFields map[string]struct{}
+	group         *uuid.UUID
+	clearedgroup  bool
+	done          bool
+	oldValue      func(context.Context) (*Member, error)
+	predicates    []predicate.Member
+}
+
+var _ ent.Mutation = (*MemberMutation)(nil)
+
+// memberOption allows management of the mutation configuration using functional options.
+type memberOption func(*MemberMutation)
+
+// newMemberMutation creates new mutation for the Member entity.
+func newMemberMutation(c config, op Op, opts ...memberOption) *MemberMutation {
+	m := &MemberMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeMember,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withMemberID sets the ID field of the mutation.
+func withMemberID(id int) memberOption {
+	return func(m *MemberMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Member
+		)
+		m.oldValue = func(ctx context.Context) (*Member, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Member.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withMember sets the old Member of the mutation.
+func withMember(node *Member) memberOption {
+	return func(m *MemberMutation) {
+		m.oldValue = func(context.Context) (*Member, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m MemberMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m MemberMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *MemberMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *MemberMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Member.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetValue sets the "value" field.
+func (m *MemberMutation) SetValue(s string) {
+	m.value = &s
+}
+
+// Value returns the value of the "value" field in the mutation.
+func (m *MemberMutation) Value() (r string, exists bool) {
+	v := m.value
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldValue returns the old "value" field's value of the Member entity.
+// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MemberMutation) OldValue(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValue requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+	}
+	return oldValue.Value, nil
+}
+
+// ResetValue resets all changes to the "value" field.
+func (m *MemberMutation) ResetValue() {
+	m.value = nil
+}
+
+// SetDisplay sets the "display" field.
+func (m *MemberMutation) SetDisplay(s string) {
+	m.display = &s
+}
+
+// Display returns the value of the "display" field in the mutation.
+func (m *MemberMutation) Display() (r string, exists bool) {
+	v := m.display
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDisplay returns the old "display" field's value of the Member entity.
+// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MemberMutation) OldDisplay(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisplay requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+	}
+	return oldValue.Display, nil
+}
+
+// ClearDisplay clears the value of the "display" field.
+func (m *MemberMutation) ClearDisplay() {
+	m.display = nil
+	m.clearedFields[member.FieldDisplay] = struct{}{}
+}
+
+// DisplayCleared returns if the "display" field was cleared in this mutation.
+func (m *MemberMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[member.FieldDisplay]
+	return ok
+}
+
+// ResetDisplay resets all changes to the "display" field.
+func (m *MemberMutation) ResetDisplay() {
+	m.display = nil
+	delete(m.clearedFields, member.FieldDisplay)
+}
+
+// SetType sets the "type" field.
+func (m *MemberMutation) SetType(s string) {
+	m._type = &s
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *MemberMutation) GetType() (r string, exists bool) {
+	v := m._type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the Member entity.
+// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MemberMutation) OldType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	}
+	return oldValue.Type, nil
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *MemberMutation) ResetType() {
+	m._type = nil
+}
+
+// SetRef sets the "ref" field.
+func (m *MemberMutation) SetRef(s string) {
+	m.ref = &s
+}
+
+// Ref returns the value of the "ref" field in the mutation.
+func (m *MemberMutation) Ref() (r string, exists bool) {
+	v := m.ref
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRef returns the old "ref" field's value of the Member entity.
+// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MemberMutation) OldRef(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRef is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRef requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRef: %w", err)
+	}
+	return oldValue.Ref, nil
+}
+
+// ClearRef clears the value of the "ref" field.
+func (m *MemberMutation) ClearRef() {
+	m.ref = nil
+	m.clearedFields[member.FieldRef] = struct{}{}
+}
+
+// RefCleared returns if the "ref" field was cleared in this mutation.
+func (m *MemberMutation) RefCleared() bool {
+	_, ok := m.clearedFields[member.FieldRef]
+	return ok
+}
+
+// ResetRef resets all changes to the "ref" field.
+func (m *MemberMutation) ResetRef() {
+	m.ref = nil
+	delete(m.clearedFields, member.FieldRef)
+}
+
+// SetGroupID sets the "group" edge to the Group entity by id.
+func (m *MemberMutation) SetGroupID(id uuid.UUID) {
+	m.group = &id
+}
+
+// ClearGroup clears the "group" edge to the Group entity.
+func (m *MemberMutation) ClearGroup() {
+	m.clearedgroup = true
+}
+
+// GroupCleared reports if the "group" edge to the Group entity was cleared.
+func (m *MemberMutation) GroupCleared() bool {
+	return m.clearedgroup
+}
+
+// GroupID returns the "group" edge ID in the mutation.
+func (m *MemberMutation) GroupID() (id uuid.UUID, exists bool) {
+	if m.group != nil {
+		return *m.group, true
+	}
+	return
+}
+
+// GroupIDs returns the "group" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// GroupID instead. It exists only for internal usage by the builders.
+func (m *MemberMutation) GroupIDs() (ids []uuid.UUID) {
+	if id := m.group; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetGroup resets all changes to the "group" edge.
+func (m *MemberMutation) ResetGroup() {
+	m.group = nil
+	m.clearedgroup = false
+}
+
+// Where appends a list predicates to the MemberMutation builder.
+func (m *MemberMutation) Where(ps ...predicate.Member) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// Op returns the operation name.
+func (m *MemberMutation) Op() Op {
+	return m.op
+}
+
+// Type returns the node type of this mutation (Member).
+func (m *MemberMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *MemberMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.value != nil {
+		fields = append(fields, member.FieldValue)
+	}
+	if m.display != nil {
+		fields = append(fields, member.FieldDisplay)
+	}
+	if m._type != nil {
+		fields = append(fields, member.FieldType)
+	}
+	if m.ref != nil {
+		fields = append(fields, member.FieldRef)
 	}
 	return fields
 }
@@ -3030,16 +5822,16 @@ func (m *IMSMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *IMSMutation) Field(name string) (ent.Value, bool) {
+func (m *MemberMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case ims.FieldDisplay:
+	case member.FieldValue:
+		return m.Value()
+	case member.FieldDisplay:
 		return m.Display()
-	case ims.FieldPrimary:
-		return m.Primary()
-	case ims.FieldType:
+	case member.FieldType:
 		return m.GetType()
-	case ims.FieldValue:
-		return m.Value()
+	case member.FieldRef:
+		return m.Ref()
 	}
 	return nil, false
 }
@@ -3047,160 +5839,148 @@ func (m *IMSMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *IMSMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *MemberMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case ims.FieldDisplay:
+	case member.FieldValue:
+		return m.OldValue(ctx)
+	case member.FieldDisplay:
 		return m.OldDisplay(ctx)
-	case ims.FieldPrimary:
-		return m.OldPrimary(ctx)
-	case ims.FieldType:
+	case member.FieldType:
 		return m.OldType(ctx)
-	case ims.FieldValue:
-		return m.OldValue(ctx)
+	case member.FieldRef:
+		return m.OldRef(ctx)
 	}
-	return nil, fmt.Errorf("unknown IMS field %s", name)
+	return nil, fmt.Errorf("unknown Member field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *IMSMutation) SetField(name string, value ent.Value) error {
+func (m *MemberMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case ims.FieldDisplay:
+	case member.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDisplay(v)
+		m.SetValue(v)
 		return nil
-	case ims.FieldPrimary:
-		v, ok := value.(bool)
+	case member.FieldDisplay:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPrimary(v)
+		m.SetDisplay(v)
 		return nil
-	case ims.FieldType:
+	case member.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetType(v)
 		return nil
-	case ims.FieldValue:
+	case member.FieldRef:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetRef(v)
 		return nil
 	}
-	return fmt.Errorf("unknown IMS field %s", name)
+	return fmt.Errorf("unknown Member field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *IMSMutation) AddedFields() []string {
+func (m *MemberMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *IMSMutation) AddedField(name string) (ent.Value, bool) {
+func (m *MemberMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *IMSMutation) AddField(name string, value ent.Value) error {
+func (m *MemberMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown IMS numeric field %s", name)
+	return fmt.Errorf("unknown Member numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *IMSMutation) ClearedFields() []string {
+func (m *MemberMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(ims.FieldDisplay) {
-		fields = append(fields, ims.FieldDisplay)
-	}
-	if m.FieldCleared(ims.FieldPrimary) {
-		fields = append(fields, ims.FieldPrimary)
-	}
-	if m.FieldCleared(ims.FieldType) {
-		fields = append(fields, ims.FieldType)
+	if m.FieldCleared(member.FieldDisplay) {
+		fields = append(fields, member.FieldDisplay)
 	}
-	if m.FieldCleared(ims.FieldValue) {
-		fields = append(fields, ims.FieldValue)
+	if m.FieldCleared(member.FieldRef) {
+		fields = append(fields, member.FieldRef)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *IMSMutation) FieldCleared(name string) bool {
+func (m *MemberMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *IMSMutation) ClearField(name string) error {
+func (m *MemberMutation) ClearField(name string) error {
 	switch name {
-	case ims.FieldDisplay:
+	case member.FieldDisplay:
 		m.ClearDisplay()
 		return nil
-	case ims.FieldPrimary:
-		m.ClearPrimary()
-		return nil
-	case ims.FieldType:
-		m.ClearType()
-		return nil
-	case ims.FieldValue:
-		m.ClearValue()
+	case member.FieldRef:
+		m.ClearRef()
 		return nil
 	}
-	return fmt.Errorf("unknown IMS nullable field %s", name)
+	return fmt.Errorf("unknown Member nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *IMSMutation) ResetField(name string) error {
+func (m *MemberMutation) ResetField(name string) error {
 	switch name {
-	case ims.FieldDisplay:
-		m.ResetDisplay()
+	case member.FieldValue:
+		m.ResetValue()
 		return nil
-	case ims.FieldPrimary:
-		m.ResetPrimary()
+	case member.FieldDisplay:
+		m.ResetDisplay()
 		return nil
-	case ims.FieldType:
+	case member.FieldType:
 		m.ResetType()
 		return nil
-	case ims.FieldValue:
-		m.ResetValue()
+	case member.FieldRef:
+		m.ResetRef()
 		return nil
 	}
-	return fmt.Errorf("unknown IMS field %s", name)
+	return fmt.Errorf("unknown Member field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *IMSMutation) AddedEdges() []string {
+func (m *MemberMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.user != nil {
-		edges = append(edges, ims.EdgeUser)
+	if m.group != nil {
+		edges = append(edges, member.EdgeGroup)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *IMSMutation) AddedIDs(name string) []ent.Value {
+func (m *MemberMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case ims.EdgeUser:
-		if id := m.user; id != nil {
+	case member.EdgeGroup:
+		if id := m.group; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -3208,89 +5988,91 @@ func (m *IMSMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *IMSMutation) RemovedEdges() []string {
+func (m *MemberMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *IMSMutation) RemovedIDs(name string) []ent.Value {
+func (m *MemberMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *IMSMutation) ClearedEdges() []string {
+func (m *MemberMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.cleareduser {
-		edges = append(edges, ims.EdgeUser)
+	if m.clearedgroup {
+		edges = append(edges, member.EdgeGroup)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *IMSMutation) EdgeCleared(name string) bool {
+func (m *MemberMutation) EdgeCleared(name string) bool {
 	switch name {
-	case ims.EdgeUser:
-		return m.cleareduser
+	case member.EdgeGroup:
+		return m.clearedgroup
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *IMSMutation) ClearEdge(name string) error {
+func (m *MemberMutation) ClearEdge(name string) error {
 	switch name {
-	case ims.EdgeUser:
-		m.ClearUser()
+	case member.EdgeGroup:
+		m.ClearGroup()
 		return nil
 	}
-	return fmt.Errorf("unknown IMS unique edge %s", name)
+	return fmt.Errorf("unknown Member unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *IMSMutation) ResetEdge(name string) error {
+func (m *MemberMutation) ResetEdge(name string) error {
 	switch name {
-	case ims.EdgeUser:
-		m.ResetUser()
+	case member.EdgeGroup:
+		m.ResetGroup()
 		return nil
 	}
-	return fmt.Errorf("unknown IMS edge %s", name)
+	return fmt.Errorf("unknown Member edge %s", name)
 }
 
-// MemberMutation represents an operation that mutates the Member nodes in the graph.
-type MemberMutation struct {
+// NamesMutation represents an operation that mutates the Names nodes in the graph.
+type NamesMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	value         *string
-	display       *string
-	_type         *string
-	ref           *string
-	clearedFields map[string]struct{}
-	group         *uuid.UUID
-	clearedgroup  bool
-	done          bool
-	oldValue      func(context.Context) (*Member, error)
-	predicates    []predicate.Member
+	op              Op
+	typ             string
+	id              *uuid.UUID
+	familyName      *string
+	formatted       *string
+	givenName       *string
+	honorificPrefix *string
+	honorificSuffix *string
+	middleName      *string
+	clearedFields   map[string]struct{}
+	user            *uuid.UUID
+	cleareduser     bool
+	done            bool
+	oldValue        func(context.Context) (*Names, error)
+	predicates      []predicate.Names
 }
 
-var _ ent.Mutation = (*MemberMutation)(nil)
+var _ ent.Mutation = (*NamesMutation)(nil)
 
-// memberOption allows management of the mutation configuration using functional options.
-type memberOption func(*MemberMutation)
+// namesOption allows management of the mutation configuration using functional options.
+type namesOption func(*NamesMutation)
 
-// newMemberMutation creates new mutation for the Member entity.
-func newMemberMutation(c config, op Op, opts ...memberOption) *MemberMutation {
-	m := &MemberMutation{
+// newNamesMutation creates new mutation for the Names entity.
+func newNamesMutation(c config, op Op, opts ...namesOption) *NamesMutation {
+	m := &NamesMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeMember,
+		typ:           TypeNames,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3299,20 +6081,20 @@ func newMemberMutation(c config, op Op, opts ...memberOption) *MemberMutation {
 	return m
 }
 
-// withMemberID sets the ID field of the mutation.
-func withMemberID(id int) memberOption {
-	return func(m *MemberMutation) {
+// withNamesID sets the ID field of the mutation.
+func withNamesID(id uuid.UUID) namesOption {
+	return func(m *NamesMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Member
+			value *Names
 		)
-		m.oldValue = func(ctx context.Context) (*Member, error) {
+		m.oldValue = func(ctx context.Context) (*Names, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Member.Get(ctx, id)
+					value, err = m.Client().Names.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3321,10 +6103,10 @@ func withMemberID(id int) memberOption {
 	}
 }
 
-// withMember sets the old Member of the mutation.
-func withMember(node *Member) memberOption {
-	return func(m *MemberMutation) {
-		m.oldValue = func(context.Context) (*Member, error) {
+// withNames sets the old Names of the mutation.
+func withNames(node *Names) namesOption {
+	return func(m *NamesMutation) {
+		m.oldValue = func(context.Context) (*Names, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3333,291 +6115,427 @@ func withMember(node *Member) memberOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m MemberMutation) Client() *Client {
+func (m NamesMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
 }
 
-// Tx returns an `ent.Tx` for mutations that were executed in transactions;
-// it returns an error otherwise.
-func (m MemberMutation) Tx() (*Tx, error) {
-	if _, ok := m.driver.(*txDriver); !ok {
-		return nil, errors.New("ent: mutation is not running in a transaction")
-	}
-	tx := &Tx{config: m.config}
-	tx.init()
-	return tx, nil
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m NamesMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Names entities.
+func (m *NamesMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *NamesMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *NamesMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Names.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetFamilyName sets the "familyName" field.
+func (m *NamesMutation) SetFamilyName(s string) {
+	m.familyName = &s
+}
+
+// FamilyName returns the value of the "familyName" field in the mutation.
+func (m *NamesMutation) FamilyName() (r string, exists bool) {
+	v := m.familyName
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFamilyName returns the old "familyName" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NamesMutation) OldFamilyName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFamilyName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFamilyName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFamilyName: %w", err)
+	}
+	return oldValue.FamilyName, nil
+}
+
+// ClearFamilyName clears the value of the "familyName" field.
+func (m *NamesMutation) ClearFamilyName() {
+	m.familyName = nil
+	m.clearedFields[names.FieldFamilyName] = struct{}{}
+}
+
+// FamilyNameCleared returns if the "familyName" field was cleared in this mutation.
+func (m *NamesMutation) FamilyNameCleared() bool {
+	_, ok := m.clearedFields[names.FieldFamilyName]
+	return ok
+}
+
+// ResetFamilyName resets all changes to the "familyName" field.
+func (m *NamesMutation) ResetFamilyName() {
+	m.familyName = nil
+	delete(m.clearedFields, names.FieldFamilyName)
+}
+
+// SetFormatted sets the "formatted" field.
+func (m *NamesMutation) SetFormatted(s string) {
+	m.formatted = &s
 }
 
-// ID returns the ID value in the mutation. Note that the ID is only available
-// if it was provided to the builder or after it was returned from the database.
-func (m *MemberMutation) ID() (id int, exists bool) {
-	if m.id == nil {
+// Formatted returns the value of the "formatted" field in the mutation.
+func (m *NamesMutation) Formatted() (r string, exists bool) {
+	v := m.formatted
+	if v == nil {
 		return
 	}
-	return *m.id, true
+	return *v, true
 }
 
-// IDs queries the database and returns the entity ids that match the mutation's predicate.
-// That means, if the mutation is applied within a transaction with an isolation level such
-// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
-// or updated by the mutation.
-func (m *MemberMutation) IDs(ctx context.Context) ([]int, error) {
-	switch {
-	case m.op.Is(OpUpdateOne | OpDeleteOne):
-		id, exists := m.ID()
-		if exists {
-			return []int{id}, nil
-		}
-		fallthrough
-	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Member.Query().Where(m.predicates...).IDs(ctx)
-	default:
-		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+// OldFormatted returns the old "formatted" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NamesMutation) OldFormatted(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFormatted is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFormatted requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFormatted: %w", err)
 	}
+	return oldValue.Formatted, nil
 }
 
-// SetValue sets the "value" field.
-func (m *MemberMutation) SetValue(s string) {
-	m.value = &s
+// ClearFormatted clears the value of the "formatted" field.
+func (m *NamesMutation) ClearFormatted() {
+	m.formatted = nil
+	m.clearedFields[names.FieldFormatted] = struct{}{}
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *MemberMutation) Value() (r string, exists bool) {
-	v := m.value
+// FormattedCleared returns if the "formatted" field was cleared in this mutation.
+func (m *NamesMutation) FormattedCleared() bool {
+	_, ok := m.clearedFields[names.FieldFormatted]
+	return ok
+}
+
+// ResetFormatted resets all changes to the "formatted" field.
+func (m *NamesMutation) ResetFormatted() {
+	m.formatted = nil
+	delete(m.clearedFields, names.FieldFormatted)
+}
+
+// SetGivenName sets the "givenName" field.
+func (m *NamesMutation) SetGivenName(s string) {
+	m.givenName = &s
+}
+
+// GivenName returns the value of the "givenName" field in the mutation.
+func (m *NamesMutation) GivenName() (r string, exists bool) {
+	v := m.givenName
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Member entity.
-// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// OldGivenName returns the old "givenName" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MemberMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *NamesMutation) OldGivenName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+		return v, errors.New("OldGivenName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+		return v, errors.New("OldGivenName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+		return v, fmt.Errorf("querying old value for OldGivenName: %w", err)
 	}
-	return oldValue.Value, nil
+	return oldValue.GivenName, nil
 }
 
-// ResetValue resets all changes to the "value" field.
-func (m *MemberMutation) ResetValue() {
-	m.value = nil
+// ClearGivenName clears the value of the "givenName" field.
+func (m *NamesMutation) ClearGivenName() {
+	m.givenName = nil
+	m.clearedFields[names.FieldGivenName] = struct{}{}
 }
 
-// SetDisplay sets the "display" field.
-func (m *MemberMutation) SetDisplay(s string) {
-	m.display = &s
+// GivenNameCleared returns if the "givenName" field was cleared in this mutation.
+func (m *NamesMutation) GivenNameCleared() bool {
+	_, ok := m.clearedFields[names.FieldGivenName]
+	return ok
 }
 
-// Display returns the value of the "display" field in the mutation.
-func (m *MemberMutation) Display() (r string, exists bool) {
-	v := m.display
+// ResetGivenName resets all changes to the "givenName" field.
+func (m *NamesMutation) ResetGivenName() {
+	m.givenName = nil
+	delete(m.clearedFields, names.FieldGivenName)
+}
+
+// SetHonorificPrefix sets the "honorificPrefix" field.
+func (m *NamesMutation) SetHonorificPrefix(s string) {
+	m.honorificPrefix = &s
+}
+
+// HonorificPrefix returns the value of the "honorificPrefix" field in the mutation.
+func (m *NamesMutation) HonorificPrefix() (r string, exists bool) {
+	v := m.honorificPrefix
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the Member entity.
-// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// OldHonorificPrefix returns the old "honorificPrefix" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MemberMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *NamesMutation) OldHonorificPrefix(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+		return v, errors.New("OldHonorificPrefix is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDisplay requires an ID field in the mutation")
+		return v, errors.New("OldHonorificPrefix requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+		return v, fmt.Errorf("querying old value for OldHonorificPrefix: %w", err)
 	}
-	return oldValue.Display, nil
+	return oldValue.HonorificPrefix, nil
 }
 
-// ClearDisplay clears the value of the "display" field.
-func (m *MemberMutation) ClearDisplay() {
-	m.display = nil
-	m.clearedFields[member.FieldDisplay] = struct{}{}
+// ClearHonorificPrefix clears the value of the "honorificPrefix" field.
+func (m *NamesMutation) ClearHonorificPrefix() {
+	m.honorificPrefix = nil
+	m.clearedFields[names.FieldHonorificPrefix] = struct{}{}
 }
 
-// DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *MemberMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[member.FieldDisplay]
+// HonorificPrefixCleared returns if the "honorificPrefix" field was cleared in this mutation.
+func (m *NamesMutation) HonorificPrefixCleared() bool {
+	_, ok := m.clearedFields[names.FieldHonorificPrefix]
 	return ok
 }
 
-// ResetDisplay resets all changes to the "display" field.
-func (m *MemberMutation) ResetDisplay() {
-	m.display = nil
-	delete(m.clearedFields, member.FieldDisplay)
+// ResetHonorificPrefix resets all changes to the "honorificPrefix" field.
+func (m *NamesMutation) ResetHonorificPrefix() {
+	m.honorificPrefix = nil
+	delete(m.clearedFields, names.FieldHonorificPrefix)
 }
 
-// SetType sets the "type" field.
-func (m *MemberMutation) SetType(s string) {
-	m._type = &s
+// SetHonorificSuffix sets the "honorificSuffix" field.
+func (m *NamesMutation) SetHonorificSuffix(s string) {
+	m.honorificSuffix = &s
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *MemberMutation) GetType() (r string, exists bool) {
-	v := m._type
+// HonorificSuffix returns the value of the "honorificSuffix" field in the mutation.
+func (m *NamesMutation) HonorificSuffix() (r string, exists bool) {
+	v := m.honorificSuffix
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Member entity.
-// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// OldHonorificSuffix returns the old "honorificSuffix" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MemberMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *NamesMutation) OldHonorificSuffix(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldHonorificSuffix is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldHonorificSuffix requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldHonorificSuffix: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.HonorificSuffix, nil
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *MemberMutation) ResetType() {
-	m._type = nil
+// ClearHonorificSuffix clears the value of the "honorificSuffix" field.
+func (m *NamesMutation) ClearHonorificSuffix() {
+	m.honorificSuffix = nil
+	m.clearedFields[names.FieldHonorificSuffix] = struct{}{}
 }
 
-// SetRef sets the "ref" field.
-func (m *MemberMutation) SetRef(s string) {
-	m.ref = &s
+// HonorificSuffixCleared returns if the "honorificSuffix" field was cleared in this mutation.
+func (m *NamesMutation) HonorificSuffixCleared() bool {
+	_, ok := m.clearedFields[names.FieldHonorificSuffix]
+	return ok
 }
 
-// Ref returns the value of the "ref" field in the mutation.
-func (m *MemberMutation) Ref() (r string, exists bool) {
-	v := m.ref
+// ResetHonorificSuffix resets all changes to the "honorificSuffix" field.
+func (m *NamesMutation) ResetHonorificSuffix() {
+	m.honorificSuffix = nil
+	delete(m.clearedFields, names.FieldHonorificSuffix)
+}
+
+// SetMiddleName sets the "middleName" field.
+func (m *NamesMutation) SetMiddleName(s string) {
+	m.middleName = &s
+}
+
+// MiddleName returns the value of the "middleName" field in the mutation.
+func (m *NamesMutation) MiddleName() (r string, exists bool) {
+	v := m.middleName
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRef returns the old "ref" field's value of the Member entity.
-// If the Member object wasn't provided to the builder, the object is fetched from the database.
+// OldMiddleName returns the old "middleName" field's value of the Names entity.
+// If the Names object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MemberMutation) OldRef(ctx context.Context) (v string, err error) {
+func (m *NamesMutation) OldMiddleName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRef is only allowed on UpdateOne operations")
+		return v, errors.New("OldMiddleName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRef requires an ID field in the mutation")
+		return v, errors.New("OldMiddleName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRef: %w", err)
+		return v, fmt.Errorf("querying old value for OldMiddleName: %w", err)
 	}
-	return oldValue.Ref, nil
+	return oldValue.MiddleName, nil
 }
 
-// ClearRef clears the value of the "ref" field.
-func (m *MemberMutation) ClearRef() {
-	m.ref = nil
-	m.clearedFields[member.FieldRef] = struct{}{}
+// ClearMiddleName clears the value of the "middleName" field.
+func (m *NamesMutation) ClearMiddleName() {
+	m.middleName = nil
+	m.clearedFields[names.FieldMiddleName] = struct{}{}
 }
 
-// RefCleared returns if the "ref" field was cleared in this mutation.
-func (m *MemberMutation) RefCleared() bool {
-	_, ok := m.clearedFields[member.FieldRef]
+// MiddleNameCleared returns if the "middleName" field was cleared in this mutation.
+func (m *NamesMutation) MiddleNameCleared() bool {
+	_, ok := m.clearedFields[names.FieldMiddleName]
 	return ok
 }
 
-// ResetRef resets all changes to the "ref" field.
-func (m *MemberMutation) ResetRef() {
-	m.ref = nil
-	delete(m.clearedFields, member.FieldRef)
+// ResetMiddleName resets all changes to the "middleName" field.
+func (m *NamesMutation) ResetMiddleName() {
+	m.middleName = nil
+	delete(m.clearedFields, names.FieldMiddleName)
 }
 
-// SetGroupID sets the "group" edge to the Group entity by id.
-func (m *MemberMutation) SetGroupID(id uuid.UUID) {
-	m.group = &id
+// SetUserID sets the "user" edge to the User entity by id.
+func (m *NamesMutation) SetUserID(id uuid.UUID) {
+	m.user = &id
 }
 
-// ClearGroup clears the "group" edge to the Group entity.
-func (m *MemberMutation) ClearGroup() {
-	m.clearedgroup = true
+// ClearUser clears the "user" edge to the User entity.
+func (m *NamesMutation) ClearUser() {
+	m.cleareduser = true
 }
 
-// GroupCleared reports if the "group" edge to the Group entity was cleared.
-func (m *MemberMutation) GroupCleared() bool {
-	return m.clearedgroup
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *NamesMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
-// GroupID returns the "group" edge ID in the mutation.
-func (m *MemberMutation) GroupID() (id uuid.UUID, exists bool) {
-	if m.group != nil {
-		return *m.group, true
+// UserID returns the "user" edge ID in the mutation.
+func (m *NamesMutation) UserID() (id uuid.UUID, exists bool) {
+	if m.user != nil {
+		return *m.user, true
 	}
 	return
 }
 
-// GroupIDs returns the "group" edge IDs in the mutation.
+// UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// GroupID instead. It exists only for internal usage by the builders.
-func (m *MemberMutation) GroupIDs() (ids []uuid.UUID) {
-	if id := m.group; id != nil {
+// UserID instead. It exists only for internal usage by the builders.
+func (m *NamesMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetGroup resets all changes to the "group" edge.
-func (m *MemberMutation) ResetGroup() {
-	m.group = nil
-	m.clearedgroup = false
+// ResetUser resets all changes to the "user" edge.
+func (m *NamesMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the MemberMutation builder.
-func (m *MemberMutation) Where(ps ...predicate.Member) {
+// Where appends a list predicates to the NamesMutation builder.
+func (m *NamesMutation) Where(ps ...predicate.Names) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *MemberMutation) Op() Op {
+func (m *NamesMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Member).
-func (m *MemberMutation) Type() string {
+// Type returns the node type of this mutation (Names).
+func (m *NamesMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *MemberMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.value != nil {
-		fields = append(fields, member.FieldValue)
+func (m *NamesMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.familyName != nil {
+		fields = append(fields, names.FieldFamilyName)
 	}
-	if m.display != nil {
-		fields = append(fields, member.FieldDisplay)
+	if m.formatted != nil {
+		fields = append(fields, names.FieldFormatted)
 	}
-	if m._type != nil {
-		fields = append(fields, member.FieldType)
+	if m.givenName != nil {
+		fields = append(fields, names.FieldGivenName)
 	}
-	if m.ref != nil {
-		fields = append(fields, member.FieldRef)
+	if m.honorificPrefix != nil {
+		fields = append(fields, names.FieldHonorificPrefix)
+	}
+	if m.honorificSuffix != nil {
+		fields = append(fields, names.FieldHonorificSuffix)
+	}
+	if m.middleName != nil {
+		fields = append(fields, names.FieldMiddleName)
 	}
 	return fields
 }
@@ -3625,16 +6543,20 @@ func (m *MemberMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *MemberMutation) Field(name string) (ent.Value, bool) {
+func (m *NamesMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case member.FieldValue:
-		return m.Value()
-	case member.FieldDisplay:
-		return m.Display()
-	case member.FieldType:
-		return m.GetType()
-	case member.FieldRef:
-		return m.Ref()
+	case names.FieldFamilyName:
+		return m.FamilyName()
+	case names.FieldFormatted:
+		return m.Formatted()
+	case names.FieldGivenName:
+		return m.GivenName()
+	case names.FieldHonorificPrefix:
+		return m.HonorificPrefix()
+	case names.FieldHonorificSuffix:
+		return m.HonorificSuffix()
+	case names.FieldMiddleName:
+		return m.MiddleName()
 	}
 	return nil, false
 }
@@ -3642,148 +6564,196 @@ func (m *MemberMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *MemberMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *NamesMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case member.FieldValue:
-		return m.OldValue(ctx)
-	case member.FieldDisplay:
-		return m.OldDisplay(ctx)
-	case member.FieldType:
-		return m.OldType(ctx)
-	case member.FieldRef:
-		return m.OldRef(ctx)
+	case names.FieldFamilyName:
+		return m.OldFamilyName(ctx)
+	case names.FieldFormatted:
+		return m.OldFormatted(ctx)
+	case names.FieldGivenName:
+		return m.OldGivenName(ctx)
+	case names.FieldHonorificPrefix:
+		return m.OldHonorificPrefix(ctx)
+	case names.FieldHonorificSuffix:
+		return m.OldHonorificSuffix(ctx)
+	case names.FieldMiddleName:
+		return m.OldMiddleName(ctx)
 	}
-	return nil, fmt.Errorf("unknown Member field %s", name)
+	return nil, fmt.Errorf("unknown Names field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MemberMutation) SetField(name string, value ent.Value) error {
+func (m *NamesMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case member.FieldValue:
+	case names.FieldFamilyName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetFamilyName(v)
 		return nil
-	case member.FieldDisplay:
+	case names.FieldFormatted:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDisplay(v)
+		m.SetFormatted(v)
 		return nil
-	case member.FieldType:
+	case names.FieldGivenName:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetType(v)
+		m.SetGivenName(v)
 		return nil
-	case member.FieldRef:
+	case names.FieldHonorificPrefix:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetRef(v)
+		m.SetHonorificPrefix(v)
+		return nil
+	case names.FieldHonorificSuffix:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHonorificSuffix(v)
+		return nil
+	case names.FieldMiddleName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMiddleName(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Member field %s", name)
+	return fmt.Errorf("unknown Names field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *MemberMutation) AddedFields() []string {
+func (m *NamesMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *MemberMutation) AddedField(name string) (ent.Value, bool) {
+func (m *NamesMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MemberMutation) AddField(name string, value ent.Value) error {
+func (m *NamesMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Member numeric field %s", name)
+	return fmt.Errorf("unknown Names numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *MemberMutation) ClearedFields() []string {
+func (m *NamesMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(member.FieldDisplay) {
-		fields = append(fields, member.FieldDisplay)
+	if m.FieldCleared(names.FieldFamilyName) {
+		fields = append(fields, names.FieldFamilyName)
 	}
-	if m.FieldCleared(member.FieldRef) {
-		fields = append(fields, member.FieldRef)
+	if m.FieldCleared(names.FieldFormatted) {
+		fields = append(fields, names.FieldFormatted)
+	}
+	if m.FieldCleared(names.FieldGivenName) {
+		fields = append(fields, names.FieldGivenName)
+	}
+	if m.FieldCleared(names.FieldHonorificPrefix) {
+		fields = append(fields, names.FieldHonorificPrefix)
+	}
+	if m.FieldCleared(names.FieldHonorificSuffix) {
+		fields = append(fields, names.FieldHonorificSuffix)
+	}
+	if m.FieldCleared(names.FieldMiddleName) {
+		fields = append(fields, names.FieldMiddleName)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *MemberMutation) FieldCleared(name string) bool {
+func (m *NamesMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *MemberMutation) ClearField(name string) error {
+func (m *NamesMutation) ClearField(name string) error {
 	switch name {
-	case member.FieldDisplay:
-		m.ClearDisplay()
+	case names.FieldFamilyName:
+		m.ClearFamilyName()
 		return nil
-	case member.FieldRef:
-		m.ClearRef()
+	case names.FieldFormatted:
+		m.ClearFormatted()
+		return nil
+	case names.FieldGivenName:
+		m.ClearGivenName()
+		return nil
+	case names.FieldHonorificPrefix:
+		m.ClearHonorificPrefix()
+		return nil
+	case names.FieldHonorificSuffix:
+		m.ClearHonorificSuffix()
+		return nil
+	case names.FieldMiddleName:
+		m.ClearMiddleName()
 		return nil
 	}
-	return fmt.Errorf("unknown Member nullable field %s", name)
+	return fmt.Errorf("unknown Names nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *MemberMutation) ResetField(name string) error {
+func (m *NamesMutation) ResetField(name string) error {
 	switch name {
-	case member.FieldValue:
-		m.ResetValue()
+	case names.FieldFamilyName:
+		m.ResetFamilyName()
 		return nil
-	case member.FieldDisplay:
-		m.ResetDisplay()
+	case names.FieldFormatted:
+		m.ResetFormatted()
 		return nil
-	case member.FieldType:
-		m.ResetType()
+	case names.FieldGivenName:
+		m.ResetGivenName()
 		return nil
-	case member.FieldRef:
-		m.ResetRef()
+	case names.FieldHonorificPrefix:
+		m.ResetHonorificPrefix()
+		return nil
+	case names.FieldHonorificSuffix:
+		m.ResetHonorificSuffix()
+		return nil
+	case names.FieldMiddleName:
+		m.ResetMiddleName()
 		return nil
 	}
-	return fmt.Errorf("unknown Member field %s", name)
+	return fmt.Errorf("unknown Names field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *MemberMutation) AddedEdges() []string {
+func (m *NamesMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.group != nil {
-		edges = append(edges, member.EdgeGroup)
+	if m.user != nil {
+		edges = append(edges, names.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *MemberMutation) AddedIDs(name string) []ent.Value {
+func (m *NamesMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case member.EdgeGroup:
-		if id := m.group; id != nil {
+	case names.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -3791,91 +6761,89 @@ func (m *MemberMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *MemberMutation) RemovedEdges() []string {
+func (m *NamesMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *MemberMutation) RemovedIDs(name string) []ent.Value {
+func (m *NamesMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *MemberMutation) ClearedEdges() []string {
+func (m *NamesMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.clearedgroup {
-		edges = append(edges, member.EdgeGroup)
+	if m.cleareduser {
+		edges = append(edges, names.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *MemberMutation) EdgeCleared(name string) bool {
+func (m *NamesMutation) EdgeCleared(name string) bool {
 	switch name {
-	case member.EdgeGroup:
-		return m.clearedgroup
+	case names.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *MemberMutation) ClearEdge(name string) error {
+func (m *NamesMutation) ClearEdge(name string) error {
 	switch name {
-	case member.EdgeGroup:
-		m.ClearGroup()
+	case names.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Member unique edge %s", name)
+	return fmt.Errorf("unknown Names unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *MemberMutation) ResetEdge(name string) error {
+func (m *NamesMutation) ResetEdge(name string) error {
 	switch name {
-	case member.EdgeGroup:
-		m.ResetGroup()
+	case names.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Member edge %s", name)
+	return fmt.Errorf("unknown Names edge %s", name)
 }
 
-// NamesMutation represents an operation that mutates the Names nodes in the graph.
-type NamesMutation struct {
+// PhoneNumberMutation represents an operation that mutates the PhoneNumber nodes in the graph.
+type PhoneNumberMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *uuid.UUID
-	familyName      *string
-	formatted       *string
-	givenName       *string
-	honorificPrefix *string
-	honorificSuffix *string
-	middleName      *string
-	clearedFields   map[string]struct{}
-	user            *uuid.UUID
-	cleareduser     bool
-	done            bool
-	oldValue        func(context.Context) (*Names, error)
-	predicates      []predicate.Names
+	op            Op
+	typ           string
+	id            *uuid.UUID
+	display       *string
+	primary       *bool
+	_type         *string
+	value         *string
+	clearedFields map[string]struct{}
+	user          *uuid.UUID
+	cleareduser   bool
+	done          bool
+	oldValue      func(context.Context) (*PhoneNumber, error)
+	predicates    []predicate.PhoneNumber
 }
 
-var _ ent.Mutation = (*NamesMutation)(nil)
+var _ ent.Mutation = (*PhoneNumberMutation)(nil)
 
-// namesOption allows management of the mutation configuration using functional options.
-type namesOption func(*NamesMutation)
+// phonenumberOption allows management of the mutation configuration using functional options.
+type phonenumberOption func(*PhoneNumberMutation)
 
-// newNamesMutation creates new mutation for the Names entity.
-func newNamesMutation(c config, op Op, opts ...namesOption) *NamesMutation {
-	m := &NamesMutation{
+// newPhoneNumberMutation creates new mutation for the PhoneNumber entity.
+func newPhoneNumberMutation(c config, op Op, opts ...phonenumberOption) *PhoneNumberMutation {
+	m := &PhoneNumberMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeNames,
+		typ:           TypePhoneNumber,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -3884,20 +6852,20 @@ func newNamesMutation(c config, op Op, opts ...namesOption) *NamesMutation {
 	return m
 }
 
-// withNamesID sets the ID field of the mutation.
-func withNamesID(id uuid.UUID) namesOption {
-	return func(m *NamesMutation) {
+// withPhoneNumberID sets the ID field of the mutation.
+func withPhoneNumberID(id uuid.UUID) phonenumberOption {
+	return func(m *PhoneNumberMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Names
+			value *PhoneNumber
 		)
-		m.oldValue = func(ctx context.Context) (*Names, error) {
+		m.oldValue = func(ctx context.Context) (*PhoneNumber, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Names.Get(ctx, id)
+					value, err = m.Client().PhoneNumber.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -3906,10 +6874,10 @@ func withNamesID(id uuid.UUID) namesOption {
 	}
 }
 
-// withNames sets the old Names of the mutation.
-func withNames(node *Names) namesOption {
-	return func(m *NamesMutation) {
-		m.oldValue = func(context.Context) (*Names, error) {
+// withPhoneNumber sets the old PhoneNumber of the mutation.
+func withPhoneNumber(node *PhoneNumber) phonenumberOption {
+	return func(m *PhoneNumberMutation) {
+		m.oldValue = func(context.Context) (*PhoneNumber, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -3918,7 +6886,7 @@ func withNames(node *Names) namesOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m NamesMutation) Client() *Client {
+func (m PhoneNumberMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -3926,7 +6894,7 @@ func (m NamesMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m NamesMutation) Tx() (*Tx, error) {
+func (m PhoneNumberMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -3936,14 +6904,14 @@ func (m NamesMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Names entities.
-func (m *NamesMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of PhoneNumber entities.
+func (m *PhoneNumberMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *NamesMutation) ID() (id uuid.UUID, exists bool) {
+func (m *PhoneNumberMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -3954,7 +6922,7 @@ func (m *NamesMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *NamesMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *PhoneNumberMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -3963,323 +6931,225 @@ func (m *NamesMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Names.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().PhoneNumber.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetFamilyName sets the "familyName" field.
-func (m *NamesMutation) SetFamilyName(s string) {
-	m.familyName = &s
-}
-
-// FamilyName returns the value of the "familyName" field in the mutation.
-func (m *NamesMutation) FamilyName() (r string, exists bool) {
-	v := m.familyName
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldFamilyName returns the old "familyName" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldFamilyName(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFamilyName is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFamilyName requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFamilyName: %w", err)
-	}
-	return oldValue.FamilyName, nil
-}
-
-// ClearFamilyName clears the value of the "familyName" field.
-func (m *NamesMutation) ClearFamilyName() {
-	m.familyName = nil
-	m.clearedFields[names.FieldFamilyName] = struct{}{}
-}
-
-// FamilyNameCleared returns if the "familyName" field was cleared in this mutation.
-func (m *NamesMutation) FamilyNameCleared() bool {
-	_, ok := m.clearedFields[names.FieldFamilyName]
-	return ok
-}
-
-// ResetFamilyName resets all changes to the "familyName" field.
-func (m *NamesMutation) ResetFamilyName() {
-	m.familyName = nil
-	delete(m.clearedFields, names.FieldFamilyName)
-}
-
-// SetFormatted sets the "formatted" field.
-func (m *NamesMutation) SetFormatted(s string) {
-	m.formatted = &s
-}
-
-// Formatted returns the value of the "formatted" field in the mutation.
-func (m *NamesMutation) Formatted() (r string, exists bool) {
-	v := m.formatted
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldFormatted returns the old "formatted" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldFormatted(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFormatted is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFormatted requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFormatted: %w", err)
-	}
-	return oldValue.Formatted, nil
-}
-
-// ClearFormatted clears the value of the "formatted" field.
-func (m *NamesMutation) ClearFormatted() {
-	m.formatted = nil
-	m.clearedFields[names.FieldFormatted] = struct{}{}
-}
-
-// FormattedCleared returns if the "formatted" field was cleared in this mutation.
-func (m *NamesMutation) FormattedCleared() bool {
-	_, ok := m.clearedFields[names.FieldFormatted]
-	return ok
-}
-
-// ResetFormatted resets all changes to the "formatted" field.
-func (m *NamesMutation) ResetFormatted() {
-	m.formatted = nil
-	delete(m.clearedFields, names.FieldFormatted)
-}
-
-// SetGivenName sets the "givenName" field.
-func (m *NamesMutation) SetGivenName(s string) {
-	m.givenName = &s
+// SetDisplay sets the "display" field.
+func (m *PhoneNumberMutation) SetDisplay(s string) {
+	m.display = &s
 }
 
-// GivenName returns the value of the "givenName" field in the mutation.
-func (m *NamesMutation) GivenName() (r string, exists bool) {
-	v := m.givenName
+// Display returns the value of the "display" field in the mutation.
+func (m *PhoneNumberMutation) Display() (r string, exists bool) {
+	v := m.display
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGivenName returns the old "givenName" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// OldDisplay returns the old "display" field's value of the PhoneNumber entity.
+// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldGivenName(ctx context.Context) (v string, err error) {
+func (m *PhoneNumberMutation) OldDisplay(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldGivenName is only allowed on UpdateOne operations")
+		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldGivenName requires an ID field in the mutation")
+		return v, errors.New("OldDisplay requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldGivenName: %w", err)
+		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
 	}
-	return oldValue.GivenName, nil
+	return oldValue.Display, nil
 }
 
-// ClearGivenName clears the value of the "givenName" field.
-func (m *NamesMutation) ClearGivenName() {
-	m.givenName = nil
-	m.clearedFields[names.FieldGivenName] = struct{}{}
+// ClearDisplay clears the value of the "display" field.
+func (m *PhoneNumberMutation) ClearDisplay() {
+	m.display = nil
+	m.clearedFields[phonenumber.FieldDisplay] = struct{}{}
 }
 
-// GivenNameCleared returns if the "givenName" field was cleared in this mutation.
-func (m *NamesMutation) GivenNameCleared() bool {
-	_, ok := m.clearedFields[names.FieldGivenName]
+// DisplayCleared returns if the "display" field was cleared in this mutation.
+func (m *PhoneNumberMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[phonenumber.FieldDisplay]
 	return ok
 }
 
-// ResetGivenName resets all changes to the "givenName" field.
-func (m *NamesMutation) ResetGivenName() {
-	m.givenName = nil
-	delete(m.clearedFields, names.FieldGivenName)
+// ResetDisplay resets all changes to the "display" field.
+func (m *PhoneNumberMutation) ResetDisplay() {
+	m.display = nil
+	delete(m.clearedFields, phonenumber.FieldDisplay)
 }
 
-// SetHonorificPrefix sets the "honorificPrefix" field.
-func (m *NamesMutation) SetHonorificPrefix(s string) {
-	m.honorificPrefix = &s
+// SetPrimary sets the "primary" field.
+func (m *PhoneNumberMutation) SetPrimary(b bool) {
+	m.primary = &b
 }
 
-// HonorificPrefix returns the value of the "honorificPrefix" field in the mutation.
-func (m *NamesMutation) HonorificPrefix() (r string, exists bool) {
-	v := m.honorificPrefix
+// Primary returns the value of the "primary" field in the mutation.
+func (m *PhoneNumberMutation) Primary() (r bool, exists bool) {
+	v := m.primary
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldHonorificPrefix returns the old "honorificPrefix" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// OldPrimary returns the old "primary" field's value of the PhoneNumber entity.
+// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldHonorificPrefix(ctx context.Context) (v string, err error) {
+func (m *PhoneNumberMutation) OldPrimary(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldHonorificPrefix is only allowed on UpdateOne operations")
+		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldHonorificPrefix requires an ID field in the mutation")
+		return v, errors.New("OldPrimary requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldHonorificPrefix: %w", err)
+		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
 	}
-	return oldValue.HonorificPrefix, nil
+	return oldValue.Primary, nil
 }
 
-// ClearHonorificPrefix clears the value of the "honorificPrefix" field.
-func (m *NamesMutation) ClearHonorificPrefix() {
-	m.honorificPrefix = nil
-	m.clearedFields[names.FieldHonorificPrefix] = struct{}{}
+// ClearPrimary clears the value of the "primary" field.
+func (m *PhoneNumberMutation) ClearPrimary() {
+	m.primary = nil
+	m.clearedFields[phonenumber.FieldPrimary] = struct{}{}
 }
 
-// HonorificPrefixCleared returns if the "honorificPrefix" field was cleared in this mutation.
-func (m *NamesMutation) HonorificPrefixCleared() bool {
-	_, ok := m.clearedFields[names.FieldHonorificPrefix]
+// PrimaryCleared returns if the "primary" field was cleared in this mutation.
+func (m *PhoneNumberMutation) PrimaryCleared() bool {
+	_, ok := m.clearedFields[phonenumber.FieldPrimary]
 	return ok
 }
 
-// ResetHonorificPrefix resets all changes to the "honorificPrefix" field.
-func (m *NamesMutation) ResetHonorificPrefix() {
-	m.honorificPrefix = nil
-	delete(m.clearedFields, names.FieldHonorificPrefix)
+// ResetPrimary resets all changes to the "primary" field.
+func (m *PhoneNumberMutation) ResetPrimary() {
+	m.primary = nil
+	delete(m.clearedFields, phonenumber.FieldPrimary)
 }
 
-// SetHonorificSuffix sets the "honorificSuffix" field.
-func (m *NamesMutation) SetHonorificSuffix(s string) {
-	m.honorificSuffix = &s
+// SetType sets the "type" field.
+func (m *PhoneNumberMutation) SetType(s string) {
+	m._type = &s
 }
 
-// HonorificSuffix returns the value of the "honorificSuffix" field in the mutation.
-func (m *NamesMutation) HonorificSuffix() (r string, exists bool) {
-	v := m.honorificSuffix
+// GetType returns the value of the "type" field in the mutation.
+func (m *PhoneNumberMutation) GetType() (r string, exists bool) {
+	v := m._type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldHonorificSuffix returns the old "honorificSuffix" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the PhoneNumber entity.
+// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldHonorificSuffix(ctx context.Context) (v string, err error) {
+func (m *PhoneNumberMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldHonorificSuffix is only allowed on UpdateOne operations")
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldHonorificSuffix requires an ID field in the mutation")
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldHonorificSuffix: %w", err)
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	return oldValue.HonorificSuffix, nil
+	return oldValue.Type, nil
 }
 
-// ClearHonorificSuffix clears the value of the "honorificSuffix" field.
-func (m *NamesMutation) ClearHonorificSuffix() {
-	m.honorificSuffix = nil
-	m.clearedFields[names.FieldHonorificSuffix] = struct{}{}
+// ClearType clears the value of the "type" field.
+func (m *PhoneNumberMutation) ClearType() {
+	m._type = nil
+	m.clearedFields[phonenumber.FieldType] = struct{}{}
 }
 
-// HonorificSuffixCleared returns if the "honorificSuffix" field was cleared in this mutation.
-func (m *NamesMutation) HonorificSuffixCleared() bool {
-	_, ok := m.clearedFields[names.FieldHonorificSuffix]
+// TypeCleared returns if the "type" field was cleared in this mutation.
+func (m *PhoneNumberMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[phonenumber.FieldType]
 	return ok
 }
 
-// ResetHonorificSuffix resets all changes to the "honorificSuffix" field.
-func (m *NamesMutation) ResetHonorificSuffix() {
-	m.honorificSuffix = nil
-	delete(m.clearedFields, names.FieldHonorificSuffix)
+// ResetType resets all changes to the "type" field.
+func (m *PhoneNumberMutation) ResetType() {
+	m._type = nil
+	delete(m.clearedFields, phonenumber.FieldType)
 }
 
-// SetMiddleName sets the "middleName" field.
-func (m *NamesMutation) SetMiddleName(s string) {
-	m.middleName = &s
+// SetValue sets the "value" field.
+func (m *PhoneNumberMutation) SetValue(s string) {
+	m.value = &s
 }
 
-// MiddleName returns the value of the "middleName" field in the mutation.
-func (m *NamesMutation) MiddleName() (r string, exists bool) {
-	v := m.middleName
+// Value returns the value of the "value" field in the mutation.
+func (m *PhoneNumberMutation) Value() (r string, exists bool) {
+	v := m.value
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMiddleName returns the old "middleName" field's value of the Names entity.
-// If the Names object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the PhoneNumber entity.
+// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NamesMutation) OldMiddleName(ctx context.Context) (v string, err error) {
+func (m *PhoneNumberMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMiddleName is only allowed on UpdateOne operations")
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMiddleName requires an ID field in the mutation")
+		return v, errors.New("OldValue requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMiddleName: %w", err)
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
 	}
-	return oldValue.MiddleName, nil
+	return oldValue.Value, nil
 }
 
-// ClearMiddleName clears the value of the "middleName" field.
-func (m *NamesMutation) ClearMiddleName() {
-	m.middleName = nil
-	m.clearedFields[names.FieldMiddleName] = struct{}{}
+// ClearValue clears the value of the "value" field.
+func (m *PhoneNumberMutation) ClearValue() {
+	m.value = nil
+	m.clearedFields[phonenumber.FieldValue] = struct{}{}
 }
 
-// MiddleNameCleared returns if the "middleName" field was cleared in this mutation.
-func (m *NamesMutation) MiddleNameCleared() bool {
-	_, ok := m.clearedFields[names.FieldMiddleName]
+// ValueCleared returns if the "value" field was cleared in this mutation.
+func (m *PhoneNumberMutation) ValueCleared() bool {
+	_, ok := m.clearedFields[phonenumber.FieldValue]
 	return ok
 }
 
-// ResetMiddleName resets all changes to the "middleName" field.
-func (m *NamesMutation) ResetMiddleName() {
-	m.middleName = nil
-	delete(m.clearedFields, names.FieldMiddleName)
+// ResetValue resets all changes to the "value" field.
+func (m *PhoneNumberMutation) ResetValue() {
+	m.value = nil
+	delete(m.clearedFields, phonenumber.FieldValue)
 }
 
 // SetUserID sets the "user" edge to the User entity by id.
-func (m *NamesMutation) SetUserID(id uuid.UUID) {
+func (m *PhoneNumberMutation) SetUserID(id uuid.UUID) {
 	m.user = &id
 }
 
 // ClearUser clears the "user" edge to the User entity.
-func (m *NamesMutation) ClearUser() {
+func (m *PhoneNumberMutation) ClearUser() {
 	m.cleareduser = true
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *NamesMutation) UserCleared() bool {
+func (m *PhoneNumberMutation) UserCleared() bool {
 	return m.cleareduser
 }
 
 // UserID returns the "user" edge ID in the mutation.
-func (m *NamesMutation) UserID() (id uuid.UUID, exists bool) {
+func (m *PhoneNumberMutation) UserID() (id uuid.UUID, exists bool) {
 	if m.user != nil {
 		return *m.user, true
 	}
@@ -4289,7 +7159,7 @@ func (m *NamesMutation) UserID() (id uuid.UUID, exists bool) {
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *NamesMutation) UserIDs() (ids []uuid.UUID) {
+func (m *PhoneNumberMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -4297,48 +7167,42 @@ func (m *NamesMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *NamesMutation) ResetUser() {
+func (m *PhoneNumberMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// Where appends a list predicates to the NamesMutation builder.
-func (m *NamesMutation) Where(ps ...predicate.Names) {
+// Where appends a list predicates to the PhoneNumberMutation builder.
+func (m *PhoneNumberMutation) Where(ps ...predicate.PhoneNumber) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *NamesMutation) Op() Op {
+func (m *PhoneNumberMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Names).
-func (m *NamesMutation) Type() string {
+// Type returns the node type of this mutation (PhoneNumber).
+func (m *PhoneNumberMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *NamesMutation) Fields() []string {
-	fields := make([]string, 0, 6)
-	if m.familyName != nil {
-		fields = append(fields, names.FieldFamilyName)
-	}
-	if m.formatted != nil {
-		fields = append(fields, names.FieldFormatted)
-	}
-	if m.givenName != nil {
-		fields = append(fields, names.FieldGivenName)
+func (m *PhoneNumberMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.display != nil {
+		fields = append(fields, phonenumber.FieldDisplay)
 	}
-	if m.honorificPrefix != nil {
-		fields = append(fields, names.FieldHonorificPrefix)
+	if m.primary != nil {
+		fields = append(fields, phonenumber.FieldPrimary)
 	}
-	if m.honorificSuffix != nil {
-		fields = append(fields, names.FieldHonorificSuffix)
+	if m._type != nil {
+		fields = append(fields, phonenumber.FieldType)
 	}
-	if m.middleName != nil {
-		fields = append(fields, names.FieldMiddleName)
+	if m.value != nil {
+		fields = append(fields, phonenumber.FieldValue)
 	}
 	return fields
 }
@@ -4346,20 +7210,16 @@ func (m *NamesMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *NamesMutation) Field(name string) (ent.Value, bool) {
+func (m *PhoneNumberMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case names.FieldFamilyName:
-		return m.FamilyName()
-	case names.FieldFormatted:
-		return m.Formatted()
-	case names.FieldGivenName:
-		return m.GivenName()
-	case names.FieldHonorificPrefix:
-		return m.HonorificPrefix()
-	case names.FieldHonorificSuffix:
-		return m.HonorificSuffix()
-	case names.FieldMiddleName:
-		return m.MiddleName()
+	case phonenumber.FieldDisplay:
+		return m.Display()
+	case phonenumber.FieldPrimary:
+		return m.Primary()
+	case phonenumber.FieldType:
+		return m.GetType()
+	case phonenumber.FieldValue:
+		return m.Value()
 	}
 	return nil, false
 }
@@ -4367,195 +7227,159 @@ func (m *NamesMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *NamesMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *PhoneNumberMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case names.FieldFamilyName:
-		return m.OldFamilyName(ctx)
-	case names.FieldFormatted:
-		return m.OldFormatted(ctx)
-	case names.FieldGivenName:
-		return m.OldGivenName(ctx)
-	case names.FieldHonorificPrefix:
-		return m.OldHonorificPrefix(ctx)
-	case names.FieldHonorificSuffix:
-		return m.OldHonorificSuffix(ctx)
-	case names.FieldMiddleName:
-		return m.OldMiddleName(ctx)
+	case phonenumber.FieldDisplay:
+		return m.OldDisplay(ctx)
+	case phonenumber.FieldPrimary:
+		return m.OldPrimary(ctx)
+	case phonenumber.FieldType:
+		return m.OldType(ctx)
+	case phonenumber.FieldValue:
+		return m.OldValue(ctx)
 	}
-	return nil, fmt.Errorf("unknown Names field %s", name)
+	return nil, fmt.Errorf("unknown PhoneNumber field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *NamesMutation) SetField(name string, value ent.Value) error {
+func (m *PhoneNumberMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case names.FieldFamilyName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFamilyName(v)
-		return nil
-	case names.FieldFormatted:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFormatted(v)
-		return nil
-	case names.FieldGivenName:
+	case phonenumber.FieldDisplay:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetGivenName(v)
+		m.SetDisplay(v)
 		return nil
-	case names.FieldHonorificPrefix:
-		v, ok := value.(string)
+	case phonenumber.FieldPrimary:
+		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetHonorificPrefix(v)
+		m.SetPrimary(v)
 		return nil
-	case names.FieldHonorificSuffix:
+	case phonenumber.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetHonorificSuffix(v)
+		m.SetType(v)
 		return nil
-	case names.FieldMiddleName:
+	case phonenumber.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetMiddleName(v)
+		m.SetValue(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Names field %s", name)
+	return fmt.Errorf("unknown PhoneNumber field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *NamesMutation) AddedFields() []string {
+func (m *PhoneNumberMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *NamesMutation) AddedField(name string) (ent.Value, bool) {
+func (m *PhoneNumberMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *NamesMutation) AddField(name string, value ent.Value) error {
+func (m *PhoneNumberMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Names numeric field %s", name)
+	return fmt.Errorf("unknown PhoneNumber numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *NamesMutation) ClearedFields() []string {
+func (m *PhoneNumberMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(names.FieldFamilyName) {
-		fields = append(fields, names.FieldFamilyName)
-	}
-	if m.FieldCleared(names.FieldFormatted) {
-		fields = append(fields, names.FieldFormatted)
-	}
-	if m.FieldCleared(names.FieldGivenName) {
-		fields = append(fields, names.FieldGivenName)
+	if m.FieldCleared(phonenumber.FieldDisplay) {
+		fields = append(fields, phonenumber.FieldDisplay)
 	}
-	if m.FieldCleared(names.FieldHonorificPrefix) {
-		fields = append(fields, names.FieldHonorificPrefix)
+	if m.FieldCleared(phonenumber.FieldPrimary) {
+		fields = append(fields, phonenumber.FieldPrimary)
 	}
-	if m.FieldCleared(names.FieldHonorificSuffix) {
-		fields = append(fields, names.FieldHonorificSuffix)
+	if m.FieldCleared(phonenumber.FieldType) {
+		fields = append(fields, phonenumber.FieldType)
 	}
-	if m.FieldCleared(names.FieldMiddleName) {
-		fields = append(fields, names.FieldMiddleName)
+	if m.FieldCleared(phonenumber.FieldValue) {
+		fields = append(fields, phonenumber.FieldValue)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *NamesMutation) FieldCleared(name string) bool {
+func (m *PhoneNumberMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *NamesMutation) ClearField(name string) error {
+func (m *PhoneNumberMutation) ClearField(name string) error {
 	switch name {
-	case names.FieldFamilyName:
-		m.ClearFamilyName()
-		return nil
-	case names.FieldFormatted:
-		m.ClearFormatted()
-		return nil
-	case names.FieldGivenName:
-		m.ClearGivenName()
+	case phonenumber.FieldDisplay:
+		m.ClearDisplay()
 		return nil
-	case names.FieldHonorificPrefix:
-		m.ClearHonorificPrefix()
+	case phonenumber.FieldPrimary:
+		m.ClearPrimary()
 		return nil
-	case names.FieldHonorificSuffix:
-		m.ClearHonorificSuffix()
+	case phonenumber.FieldType:
+		m.ClearType()
 		return nil
-	case names.FieldMiddleName:
-		m.ClearMiddleName()
+	case phonenumber.FieldValue:
+		m.ClearValue()
 		return nil
 	}
-	return fmt.Errorf("unknown Names nullable field %s", name)
+	return fmt.Errorf("unknown PhoneNumber nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *NamesMutation) ResetField(name string) error {
+func (m *PhoneNumberMutation) ResetField(name string) error {
 	switch name {
-	case names.FieldFamilyName:
-		m.ResetFamilyName()
-		return nil
-	case names.FieldFormatted:
-		m.ResetFormatted()
-		return nil
-	case names.FieldGivenName:
-		m.ResetGivenName()
+	case phonenumber.FieldDisplay:
+		m.ResetDisplay()
 		return nil
-	case names.FieldHonorificPrefix:
-		m.ResetHonorificPrefix()
+	case phonenumber.FieldPrimary:
+		m.ResetPrimary()
 		return nil
-	case names.FieldHonorificSuffix:
-		m.ResetHonorificSuffix()
+	case phonenumber.FieldType:
+		m.ResetType()
 		return nil
-	case names.FieldMiddleName:
-		m.ResetMiddleName()
+	case phonenumber.FieldValue:
+		m.ResetValue()
 		return nil
 	}
-	return fmt.Errorf("unknown Names field %s", name)
+	return fmt.Errorf("unknown PhoneNumber field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *NamesMutation) AddedEdges() []string {
+func (m *PhoneNumberMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, names.EdgeUser)
+		edges = append(edges, phonenumber.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *NamesMutation) AddedIDs(name string) []ent.Value {
+func (m *PhoneNumberMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case names.EdgeUser:
+	case phonenumber.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
@@ -4564,33 +7388,33 @@ func (m *NamesMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *NamesMutation) RemovedEdges() []string {
+func (m *PhoneNumberMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *NamesMutation) RemovedIDs(name string) []ent.Value {
+func (m *PhoneNumberMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *NamesMutation) ClearedEdges() []string {
+func (m *PhoneNumberMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, names.EdgeUser)
+		edges = append(edges, phonenumber.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *NamesMutation) EdgeCleared(name string) bool {
+func (m *PhoneNumberMutation) EdgeCleared(name string) bool {
 	switch name {
-	case names.EdgeUser:
+	case phonenumber.EdgeUser:
 		return m.cleareduser
 	}
 	return false
@@ -4598,28 +7422,28 @@ func (m *NamesMutation) EdgeCleared(name string) bool {
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *NamesMutation) ClearEdge(name string) error {
+func (m *PhoneNumberMutation) ClearEdge(name string) error {
 	switch name {
-	case names.EdgeUser:
+	case phonenumber.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Names unique edge %s", name)
+	return fmt.Errorf("unknown PhoneNumber unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *NamesMutation) ResetEdge(name string) error {
+func (m *PhoneNumberMutation) ResetEdge(name string) error {
 	switch name {
-	case names.EdgeUser:
+	case phonenumber.EdgeUser:
 		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Names edge %s", name)
+	return fmt.Errorf("unknown PhoneNumber edge %s", name)
 }
 
-// PhoneNumberMutation represents an operation that mutates the PhoneNumber nodes in the graph.
-type PhoneNumberMutation struct {
+// PhotoMutation represents an operation that mutates the Photo nodes in the graph.
+type PhotoMutation struct {
 	config
 	op            Op
 	typ           string
@@ -4632,21 +7456,21 @@ type PhoneNumberMutation struct {
 	user          *uuid.UUID
 	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*PhoneNumber, error)
-	predicates    []predicate.PhoneNumber
+	oldValue      func(context.Context) (*Photo, error)
+	predicates    []predicate.Photo
 }
 
-var _ ent.Mutation = (*PhoneNumberMutation)(nil)
+var _ ent.Mutation = (*PhotoMutation)(nil)
 
-// phonenumberOption allows management of the mutation configuration using functional options.
-type phonenumberOption func(*PhoneNumberMutation)
+// photoOption allows management of the mutation configuration using functional options.
+type photoOption func(*PhotoMutation)
 
-// newPhoneNumberMutation creates new mutation for the PhoneNumber entity.
-func newPhoneNumberMutation(c config, op Op, opts ...phonenumberOption) *PhoneNumberMutation {
-	m := &PhoneNumberMutation{
+// newPhotoMutation creates new mutation for the Photo entity.
+func newPhotoMutation(c config, op Op, opts ...photoOption) *PhotoMutation {
+	m := &PhotoMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePhoneNumber,
+		typ:           TypePhoto,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -4655,20 +7479,20 @@ func newPhoneNumberMutation(c config, op Op, opts ...phonenumberOption) *PhoneNu
 	return m
 }
 
-// withPhoneNumberID sets the ID field of the mutation.
-func withPhoneNumberID(id uuid.UUID) phonenumberOption {
-	return func(m *PhoneNumberMutation) {
+// withPhotoID sets the ID field of the mutation.
+func withPhotoID(id uuid.UUID) photoOption {
+	return func(m *PhotoMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *PhoneNumber
+			value *Photo
 		)
-		m.oldValue = func(ctx context.Context) (*PhoneNumber, error) {
+		m.oldValue = func(ctx context.Context) (*Photo, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().PhoneNumber.Get(ctx, id)
+					value, err = m.Client().Photo.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -4677,10 +7501,10 @@ func withPhoneNumberID(id uuid.UUID) phonenumberOption {
 	}
 }
 
-// withPhoneNumber sets the old PhoneNumber of the mutation.
-func withPhoneNumber(node *PhoneNumber) phonenumberOption {
-	return func(m *PhoneNumberMutation) {
-		m.oldValue = func(context.Context) (*PhoneNumber, error) {
+// withPhoto sets the old Photo of the mutation.
+func withPhoto(node *Photo) photoOption {
+	return func(m *PhotoMutation) {
+		m.oldValue = func(context.Context) (*Photo, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -4689,7 +7513,7 @@ func withPhoneNumber(node *PhoneNumber) phonenumberOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PhoneNumberMutation) Client() *Client {
+func (m PhotoMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -4697,7 +7521,7 @@ func (m PhoneNumberMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PhoneNumberMutation) Tx() (*Tx, error) {
+func (m PhotoMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -4707,14 +7531,14 @@ func (m PhoneNumberMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of PhoneNumber entities.
-func (m *PhoneNumberMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Photo entities.
+func (m *PhotoMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PhoneNumberMutation) ID() (id uuid.UUID, exists bool) {
+func (m *PhotoMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -4725,7 +7549,7 @@ func (m *PhoneNumberMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PhoneNumberMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *PhotoMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -4734,19 +7558,19 @@ func (m *PhoneNumberMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().PhoneNumber.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Photo.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
 // SetDisplay sets the "display" field.
-func (m *PhoneNumberMutation) SetDisplay(s string) {
+func (m *PhotoMutation) SetDisplay(s string) {
 	m.display = &s
 }
 
 // Display returns the value of the "display" field in the mutation.
-func (m *PhoneNumberMutation) Display() (r string, exists bool) {
+func (m *PhotoMutation) Display() (r string, exists bool) {
 	v := m.display
 	if v == nil {
 		return
@@ -4754,10 +7578,10 @@ func (m *PhoneNumberMutation) Display() (r string, exists bool) {
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the PhoneNumber entity.
-// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
+// OldDisplay returns the old "display" field's value of the Photo entity.
+// If the Photo object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhoneNumberMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *PhotoMutation) OldDisplay(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
 	}
@@ -4772,30 +7596,30 @@ func (m *PhoneNumberMutation) OldDisplay(ctx context.Context) (v string, err err
 }
 
 // ClearDisplay clears the value of the "display" field.
-func (m *PhoneNumberMutation) ClearDisplay() {
+func (m *PhotoMutation) ClearDisplay() {
 	m.display = nil
-	m.clearedFields[phonenumber.FieldDisplay] = struct{}{}
+	m.clearedFields[photo.FieldDisplay] = struct{}{}
 }
 
 // DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *PhoneNumberMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[phonenumber.FieldDisplay]
+func (m *PhotoMutation) DisplayCleared() bool {
+	_, ok := m.clearedFields[photo.FieldDisplay]
 	return ok
 }
 
 // ResetDisplay resets all changes to the "display" field.
-func (m *PhoneNumberMutation) ResetDisplay() {
+func (m *PhotoMutation) ResetDisplay() {
 	m.display = nil
-	delete(m.clearedFields, phonenumber.FieldDisplay)
+	delete(m.clearedFields, photo.FieldDisplay)
 }
 
 // SetPrimary sets the "primary" field.
-func (m *PhoneNumberMutation) SetPrimary(b bool) {
+func (m *PhotoMutation) SetPrimary(b bool) {
 	m.primary = &b
 }
 
 // Primary returns the value of the "primary" field in the mutation.
-func (m *PhoneNumberMutation) Primary() (r bool, exists bool) {
+func (m *PhotoMutation) Primary() (r bool, exists bool) {
 	v := m.primary
 	if v == nil {
 		return
@@ -4803,10 +7627,10 @@ func (m *PhoneNumberMutation) Primary() (r bool, exists bool) {
 	return *v, true
 }
 
-// OldPrimary returns the old "primary" field's value of the PhoneNumber entity.
-// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
+// OldPrimary returns the old "primary" field's value of the Photo entity.
+// If the Photo object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhoneNumberMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+func (m *PhotoMutation) OldPrimary(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
 	}
@@ -4821,30 +7645,30 @@ func (m *PhoneNumberMutation) OldPrimary(ctx context.Context) (v bool, err error
 }
 
 // ClearPrimary clears the value of the "primary" field.
-func (m *PhoneNumberMutation) ClearPrimary() {
+func (m *PhotoMutation) ClearPrimary() {
 	m.primary = nil
-	m.clearedFields[phonenumber.FieldPrimary] = struct{}{}
+	m.clearedFields[photo.FieldPrimary] = struct{}{}
 }
 
 // PrimaryCleared returns if the "primary" field was cleared in this mutation.
-func (m *PhoneNumberMutation) PrimaryCleared() bool {
-	_, ok := m.clearedFields[phonenumber.FieldPrimary]
+func (m *PhotoMutation) PrimaryCleared() bool {
+	_, ok := m.clearedFields[photo.FieldPrimary]
 	return ok
 }
 
 // ResetPrimary resets all changes to the "primary" field.
-func (m *PhoneNumberMutation) ResetPrimary() {
+func (m *PhotoMutation) ResetPrimary() {
 	m.primary = nil
-	delete(m.clearedFields, phonenumber.FieldPrimary)
+	delete(m.clearedFields, photo.FieldPrimary)
 }
 
 // SetType sets the "type" field.
-func (m *PhoneNumberMutation) SetType(s string) {
+func (m *PhotoMutation) SetType(s string) {
 	m._type = &s
 }
 
 // GetType returns the value of the "type" field in the mutation.
-func (m *PhoneNumberMutation) GetType() (r string, exists bool) {
+func (m *PhotoMutation) GetType() (r string, exists bool) {
 	v := m._type
 	if v == nil {
 		return
@@ -4852,10 +7676,10 @@ func (m *PhoneNumberMutation) GetType() (r string, exists bool) {
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the PhoneNumber entity.
-// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
+// OldType returns the old "type" field's value of the Photo entity.
+// If the Photo object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhoneNumberMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *PhotoMutation) OldType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
@@ -4870,30 +7694,30 @@ func (m *PhoneNumberMutation) OldType(ctx context.Context) (v string, err error)
 }
 
 // ClearType clears the value of the "type" field.
-func (m *PhoneNumberMutation) ClearType() {
+func (m *PhotoMutation) ClearType() {
 	m._type = nil
-	m.clearedFields[phonenumber.FieldType] = struct{}{}
+	m.clearedFields[photo.FieldType] = struct{}{}
 }
 
 // TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *PhoneNumberMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[phonenumber.FieldType]
+func (m *PhotoMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[photo.FieldType]
 	return ok
 }
 
 // ResetType resets all changes to the "type" field.
-func (m *PhoneNumberMutation) ResetType() {
+func (m *PhotoMutation) ResetType() {
 	m._type = nil
-	delete(m.clearedFields, phonenumber.FieldType)
+	delete(m.clearedFields, photo.FieldType)
 }
 
 // SetValue sets the "value" field.
-func (m *PhoneNumberMutation) SetValue(s string) {
+func (m *PhotoMutation) SetValue(s string) {
 	m.value = &s
 }
 
 // Value returns the value of the "value" field in the mutation.
-func (m *PhoneNumberMutation) Value() (r string, exists bool) {
+func (m *PhotoMutation) Value() (r string, exists bool) {
 	v := m.value
 	if v == nil {
 		return
@@ -4901,10 +7725,10 @@ func (m *PhoneNumberMutation) Value() (r string, exists bool) {
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the PhoneNumber entity.
-// If the PhoneNumber object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the Photo entity.
+// If the Photo object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhoneNumberMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *PhotoMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
@@ -4919,40 +7743,40 @@ func (m *PhoneNumberMutation) OldValue(ctx context.Context) (v string, err error
 }
 
 // ClearValue clears the value of the "value" field.
-func (m *PhoneNumberMutation) ClearValue() {
+func (m *PhotoMutation) ClearValue() {
 	m.value = nil
-	m.clearedFields[phonenumber.FieldValue] = struct{}{}
+	m.clearedFields[photo.FieldValue] = struct{}{}
 }
 
 // ValueCleared returns if the "value" field was cleared in this mutation.
-func (m *PhoneNumberMutation) ValueCleared() bool {
-	_, ok := m.clearedFields[phonenumber.FieldValue]
+func (m *PhotoMutation) ValueCleared() bool {
+	_, ok := m.clearedFields[photo.FieldValue]
 	return ok
 }
 
 // ResetValue resets all changes to the "value" field.
-func (m *PhoneNumberMutation) ResetValue() {
+func (m *PhotoMutation) ResetValue() {
 	m.value = nil
-	delete(m.clearedFields, phonenumber.FieldValue)
+	delete(m.clearedFields, photo.FieldValue)
 }
 
 // SetUserID sets the "user" edge to the User entity by id.
-func (m *PhoneNumberMutation) SetUserID(id uuid.UUID) {
+func (m *PhotoMutation) SetUserID(id uuid.UUID) {
 	m.user = &id
 }
 
 // ClearUser clears the "user" edge to the User entity.
-func (m *PhoneNumberMutation) ClearUser() {
+func (m *PhotoMutation) ClearUser() {
 	m.cleareduser = true
 }
 
 // UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *PhoneNumberMutation) UserCleared() bool {
+func (m *PhotoMutation) UserCleared() bool {
 	return m.cleareduser
 }
 
 // UserID returns the "user" edge ID in the mutation.
-func (m *PhoneNumberMutation) UserID() (id uuid.UUID, exists bool) {
+func (m *PhotoMutation) UserID() (id uuid.UUID, exists bool) {
 	if m.user != nil {
 		return *m.user, true
 	}
@@ -4962,7 +7786,7 @@ func (m *PhoneNumberMutation) UserID() (id uuid.UUID, exists bool) {
 // UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // UserID instead. It exists only for internal usage by the builders.
-func (m *PhoneNumberMutation) UserIDs() (ids []uuid.UUID) {
+func (m *PhotoMutation) UserIDs() (ids []uuid.UUID) {
 	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
@@ -4970,42 +7794,42 @@ func (m *PhoneNumberMutation) UserIDs() (ids []uuid.UUID) {
 }
 
 // ResetUser resets all changes to the "user" edge.
-func (m *PhoneNumberMutation) ResetUser() {
+func (m *PhotoMutation) ResetUser() {
 	m.user = nil
 	m.cleareduser = false
 }
 
-// Where appends a list predicates to the PhoneNumberMutation builder.
-func (m *PhoneNumberMutation) Where(ps ...predicate.PhoneNumber) {
+// Where appends a list predicates to the PhotoMutation builder.
+func (m *PhotoMutation) Where(ps ...predicate.Photo) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *PhoneNumberMutation) Op() Op {
+func (m *PhotoMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (PhoneNumber).
-func (m *PhoneNumberMutation) Type() string {
+// Type returns the node type of this mutation (Photo).
+func (m *PhotoMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PhoneNumberMutation) Fields() []string {
+func (m *PhotoMutation) Fields() []string {
 	fields := make([]string, 0, 4)
 	if m.display != nil {
-		fields = append(fields, phonenumber.FieldDisplay)
+		fields = append(fields, photo.FieldDisplay)
 	}
 	if m.primary != nil {
-		fields = append(fields, phonenumber.FieldPrimary)
+		fields = append(fields, photo.FieldPrimary)
 	}
 	if m._type != nil {
-		fields = append(fields, phonenumber.FieldType)
+		fields = append(fields, photo.FieldType)
 	}
 	if m.value != nil {
-		fields = append(fields, phonenumber.FieldValue)
+		fields = append(fields, photo.FieldValue)
 	}
 	return fields
 }
@@ -5013,15 +7837,15 @@ func (m *PhoneNumberMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PhoneNumberMutation) Field(name string) (ent.Value, bool) {
+func (m *PhotoMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case phonenumber.FieldDisplay:
+	case photo.FieldDisplay:
 		return m.Display()
-	case phonenumber.FieldPrimary:
+	case photo.FieldPrimary:
 		return m.Primary()
-	case phonenumber.FieldType:
+	case photo.FieldType:
 		return m.GetType()
-	case phonenumber.FieldValue:
+	case photo.FieldValue:
 		return m.Value()
 	}
 	return nil, false
@@ -5030,47 +7854,47 @@ func (m *PhoneNumberMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PhoneNumberMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *PhotoMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case phonenumber.FieldDisplay:
+	case photo.FieldDisplay:
 		return m.OldDisplay(ctx)
-	case phonenumber.FieldPrimary:
+	case photo.FieldPrimary:
 		return m.OldPrimary(ctx)
-	case phonenumber.FieldType:
+	case photo.FieldType:
 		return m.OldType(ctx)
-	case phonenumber.FieldValue:
+	case photo.FieldValue:
 		return m.OldValue(ctx)
 	}
-	return nil, fmt.Errorf("unknown PhoneNumber field %s", name)
+	return nil, fmt.Errorf("unknown Photo field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PhoneNumberMutation) SetField(name string, value ent.Value) error {
+func (m *PhotoMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case phonenumber.FieldDisplay:
+	case photo.FieldDisplay:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetDisplay(v)
 		return nil
-	case phonenumber.FieldPrimary:
+	case photo.FieldPrimary:
 		v, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetPrimary(v)
 		return nil
-	case phonenumber.FieldType:
+	case photo.FieldType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetType(v)
 		return nil
-	case phonenumber.FieldValue:
+	case photo.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -5078,111 +7902,111 @@ func (m *PhoneNumberMutation) SetField(name string, value ent.Value) error {
 		m.SetValue(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PhoneNumber field %s", name)
+	return fmt.Errorf("unknown Photo field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PhoneNumberMutation) AddedFields() []string {
+func (m *PhotoMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PhoneNumberMutation) AddedField(name string) (ent.Value, bool) {
+func (m *PhotoMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PhoneNumberMutation) AddField(name string, value ent.Value) error {
+func (m *PhotoMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown PhoneNumber numeric field %s", name)
+	return fmt.Errorf("unknown Photo numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PhoneNumberMutation) ClearedFields() []string {
+func (m *PhotoMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(phonenumber.FieldDisplay) {
-		fields = append(fields, phonenumber.FieldDisplay)
+	if m.FieldCleared(photo.FieldDisplay) {
+		fields = append(fields, photo.FieldDisplay)
 	}
-	if m.FieldCleared(phonenumber.FieldPrimary) {
-		fields = append(fields, phonenumber.FieldPrimary)
+	if m.FieldCleared(photo.FieldPrimary) {
+		fields = append(fields, photo.FieldPrimary)
 	}
-	if m.FieldCleared(phonenumber.FieldType) {
-		fields = append(fields, phonenumber.FieldType)
+	if m.FieldCleared(photo.FieldType) {
+		fields = append(fields, photo.FieldType)
 	}
-	if m.FieldCleared(phonenumber.FieldValue) {
-		fields = append(fields, phonenumber.FieldValue)
+	if m.FieldCleared(photo.FieldValue) {
+		fields = append(fields, photo.FieldValue)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PhoneNumberMutation) FieldCleared(name string) bool {
+func (m *PhotoMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PhoneNumberMutation) ClearField(name string) error {
+func (m *PhotoMutation) ClearField(name string) error {
 	switch name {
-	case phonenumber.FieldDisplay:
+	case photo.FieldDisplay:
 		m.ClearDisplay()
 		return nil
-	case phonenumber.FieldPrimary:
+	case photo.FieldPrimary:
 		m.ClearPrimary()
 		return nil
-	case phonenumber.FieldType:
+	case photo.FieldType:
 		m.ClearType()
 		return nil
-	case phonenumber.FieldValue:
+	case photo.FieldValue:
 		m.ClearValue()
 		return nil
 	}
-	return fmt.Errorf("unknown PhoneNumber nullable field %s", name)
+	return fmt.Errorf("unknown Photo nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PhoneNumberMutation) ResetField(name string) error {
+func (m *PhotoMutation) ResetField(name string) error {
 	switch name {
-	case phonenumber.FieldDisplay:
+	case photo.FieldDisplay:
 		m.ResetDisplay()
 		return nil
-	case phonenumber.FieldPrimary:
+	case photo.FieldPrimary:
 		m.ResetPrimary()
 		return nil
-	case phonenumber.FieldType:
+	case photo.FieldType:
 		m.ResetType()
 		return nil
-	case phonenumber.FieldValue:
+	case photo.FieldValue:
 		m.ResetValue()
 		return nil
 	}
-	return fmt.Errorf("unknown PhoneNumber field %s", name)
+	return fmt.Errorf("unknown Photo field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PhoneNumberMutation) AddedEdges() []string {
+func (m *PhotoMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.user != nil {
-		edges = append(edges, phonenumber.EdgeUser)
+		edges = append(edges, photo.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PhoneNumberMutation) AddedIDs(name string) []ent.Value {
+func (m *PhotoMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case phonenumber.EdgeUser:
+	case photo.EdgeUser:
 		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
@@ -5191,33 +8015,33 @@ func (m *PhoneNumberMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PhoneNumberMutation) RemovedEdges() []string {
+func (m *PhotoMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PhoneNumberMutation) RemovedIDs(name string) []ent.Value {
+func (m *PhotoMutation) RemovedIDs(name string) []ent.Value {
 	switch name {
 	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PhoneNumberMutation) ClearedEdges() []string {
+func (m *PhotoMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
 	if m.cleareduser {
-		edges = append(edges, phonenumber.EdgeUser)
+		edges = append(edges, photo.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PhoneNumberMutation) EdgeCleared(name string) bool {
+func (m *PhotoMutation) EdgeCleared(name string) bool {
 	switch name {
-	case phonenumber.EdgeUser:
+	case photo.EdgeUser:
 		return m.cleareduser
 	}
 	return false
@@ -5225,55 +8049,58 @@ func (m *PhoneNumberMutation) EdgeCleared(name string) bool {
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PhoneNumberMutation) ClearEdge(name string) error {
+func (m *PhotoMutation) ClearEdge(name string) error {
 	switch name {
-	case phonenumber.EdgeUser:
+	case photo.EdgeUser:
 		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown PhoneNumber unique edge %s", name)
+	return fmt.Errorf("unknown Photo unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PhoneNumberMutation) ResetEdge(name string) error {
+func (m *PhotoMutation) ResetEdge(name string) error {
 	switch name {
-	case phonenumber.EdgeUser:
+	case photo.EdgeUser:
 		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown PhoneNumber edge %s", name)
+	return fmt.Errorf("unknown Photo edge %s", name)
 }
 
-// PhotoMutation represents an operation that mutates the Photo nodes in the graph.
-type PhotoMutation struct {
+// ResourceVersionMutation represents an operation that mutates the ResourceVersion nodes in the graph.
+type ResourceVersionMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *uuid.UUID
-	display       *string
-	primary       *bool
-	_type         *string
-	value         *string
+	id            *int
+	resourceType  *string
+	resourceID    *uuid.UUID
+	version       *int
+	addversion    *int
+	etag          *string
+	operation     *string
+	actor         *string
+	data          *[]byte
+	createdAt     *time.Time
 	clearedFields map[string]struct{}
-	user          *uuid.UUID
-	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*Photo, error)
-	predicates    []predicate.Photo
+	oldValue      func(context.Context) (*ResourceVersion, error)
+	predicates    []predicate.ResourceVersion
 }
 
-var _ ent.Mutation = (*PhotoMutation)(nil)
+var _ ent.Mutation = (*ResourceVersionMutation)(nil)
 
-// photoOption allows management of the mutation configuration using functional options.
-type photoOption func(*PhotoMutation)
+// resourceversionOption allows management of the mutation configuration using functional options.
+type resourceversionOption func(*ResourceVersionMutation)
 
-// newPhotoMutation creates new mutation for the Photo entity.
-func newPhotoMutation(c config, op Op, opts ...photoOption) *PhotoMutation {
-	m := &PhotoMutation{
+// newResourceVersionMutation creates new mutation for the ResourceVersion entity.
+func newResourceVersionMutation(c config, op Op, opts ...resourceversionOption) *ResourceVersionMutation {
+	m := &ResourceVersionMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePhoto,
+		typ:           TypeResourceVersion,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -5282,20 +8109,20 @@ func newPhotoMutation(c config, op Op, opts ...photoOption) *PhotoMutation {
 	return m
 }
 
-// withPhotoID sets the ID field of the mutation.
-func withPhotoID(id uuid.UUID) photoOption {
-	return func(m *PhotoMutation) {
+// withResourceVersionID sets the ID field of the mutation.
+func withResourceVersionID(id int) resourceversionOption {
+	return func(m *ResourceVersionMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Photo
+			value *ResourceVersion
 		)
-		m.oldValue = func(ctx context.Context) (*Photo, error) {
+		m.oldValue = func(ctx context.Context) (*ResourceVersion, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Photo.Get(ctx, id)
+					value, err = m.Client().ResourceVersion.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -5304,10 +8131,10 @@ func withPhotoID(id uuid.UUID) photoOption {
 	}
 }
 
-// withPhoto sets the old Photo of the mutation.
-func withPhoto(node *Photo) photoOption {
-	return func(m *PhotoMutation) {
-		m.oldValue = func(context.Context) (*Photo, error) {
+// withResourceVersion sets the old ResourceVersion of the mutation.
+func withResourceVersion(node *ResourceVersion) resourceversionOption {
+	return func(m *ResourceVersionMutation) {
+		m.oldValue = func(context.Context) (*ResourceVersion, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -5316,7 +8143,7 @@ func withPhoto(node *Photo) photoOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PhotoMutation) Client() *Client {
+func (m ResourceVersionMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -5324,7 +8151,7 @@ func (m PhotoMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PhotoMutation) Tx() (*Tx, error) {
+func (m ResourceVersionMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -5333,15 +8160,9 @@ func (m PhotoMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Photo entities.
-func (m *PhotoMutation) SetID(id uuid.UUID) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PhotoMutation) ID() (id uuid.UUID, exists bool) {
+func (m *ResourceVersionMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -5352,287 +8173,398 @@ func (m *PhotoMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PhotoMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *ResourceVersionMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []uuid.UUID{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Photo.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().ResourceVersion.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetDisplay sets the "display" field.
-func (m *PhotoMutation) SetDisplay(s string) {
-	m.display = &s
+// SetResourceType sets the "resourceType" field.
+func (m *ResourceVersionMutation) SetResourceType(s string) {
+	m.resourceType = &s
+}
+
+// ResourceType returns the value of the "resourceType" field in the mutation.
+func (m *ResourceVersionMutation) ResourceType() (r string, exists bool) {
+	v := m.resourceType
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResourceType returns the old "resourceType" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceVersionMutation) OldResourceType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResourceType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResourceType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResourceType: %w", err)
+	}
+	return oldValue.ResourceType, nil
+}
+
+// ResetResourceType resets all changes to the "resourceType" field.
+func (m *ResourceVersionMutation) ResetResourceType() {
+	m.resourceType = nil
+}
+
+// SetResourceID sets the "resourceID" field.
+func (m *ResourceVersionMutation) SetResourceID(u uuid.UUID) {
+	m.resourceID = &u
+}
+
+// ResourceID returns the value of the "resourceID" field in the mutation.
+func (m *ResourceVersionMutation) ResourceID() (r uuid.UUID, exists bool) {
+	v := m.resourceID
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResourceID returns the old "resourceID" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceVersionMutation) OldResourceID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResourceID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResourceID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResourceID: %w", err)
+	}
+	return oldValue.ResourceID, nil
+}
+
+// ResetResourceID resets all changes to the "resourceID" field.
+func (m *ResourceVersionMutation) ResetResourceID() {
+	m.resourceID = nil
+}
+
+// SetVersion sets the "version" field.
+func (m *ResourceVersionMutation) SetVersion(i int) {
+	m.version = &i
+	m.addversion = nil
 }
 
-// Display returns the value of the "display" field in the mutation.
-func (m *PhotoMutation) Display() (r string, exists bool) {
-	v := m.display
+// Version returns the value of the "version" field in the mutation.
+func (m *ResourceVersionMutation) Version() (r int, exists bool) {
+	v := m.version
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDisplay returns the old "display" field's value of the Photo entity.
-// If the Photo object wasn't provided to the builder, the object is fetched from the database.
+// OldVersion returns the old "version" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhotoMutation) OldDisplay(ctx context.Context) (v string, err error) {
+func (m *ResourceVersionMutation) OldVersion(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDisplay is only allowed on UpdateOne operations")
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDisplay requires an ID field in the mutation")
+		return v, errors.New("OldVersion requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDisplay: %w", err)
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
 	}
-	return oldValue.Display, nil
+	return oldValue.Version, nil
 }
 
-// ClearDisplay clears the value of the "display" field.
-func (m *PhotoMutation) ClearDisplay() {
-	m.display = nil
-	m.clearedFields[photo.FieldDisplay] = struct{}{}
+// AddVersion adds i to the "version" field.
+func (m *ResourceVersionMutation) AddVersion(i int) {
+	if m.addversion != nil {
+		*m.addversion += i
+	} else {
+		m.addversion = &i
+	}
 }
 
-// DisplayCleared returns if the "display" field was cleared in this mutation.
-func (m *PhotoMutation) DisplayCleared() bool {
-	_, ok := m.clearedFields[photo.FieldDisplay]
-	return ok
+// AddedVersion returns the value that was added to the "version" field in this mutation.
+func (m *ResourceVersionMutation) AddedVersion() (r int, exists bool) {
+	v := m.addversion
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetDisplay resets all changes to the "display" field.
-func (m *PhotoMutation) ResetDisplay() {
-	m.display = nil
-	delete(m.clearedFields, photo.FieldDisplay)
+// ResetVersion resets all changes to the "version" field.
+func (m *ResourceVersionMutation) ResetVersion() {
+	m.version = nil
+	m.addversion = nil
 }
 
-// SetPrimary sets the "primary" field.
-func (m *PhotoMutation) SetPrimary(b bool) {
-	m.primary = &b
+// SetEtag sets the "etag" field.
+func (m *ResourceVersionMutation) SetEtag(s string) {
+	m.etag = &s
 }
 
-// Primary returns the value of the "primary" field in the mutation.
-func (m *PhotoMutation) Primary() (r bool, exists bool) {
-	v := m.primary
+// Etag returns the value of the "etag" field in the mutation.
+func (m *ResourceVersionMutation) Etag() (r string, exists bool) {
+	v := m.etag
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPrimary returns the old "primary" field's value of the Photo entity.
-// If the Photo object wasn't provided to the builder, the object is fetched from the database.
+// OldEtag returns the old "etag" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhotoMutation) OldPrimary(ctx context.Context) (v bool, err error) {
+func (m *ResourceVersionMutation) OldEtag(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPrimary is only allowed on UpdateOne operations")
+		return v, errors.New("OldEtag is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPrimary requires an ID field in the mutation")
+		return v, errors.New("OldEtag requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPrimary: %w", err)
+		return v, fmt.Errorf("querying old value for OldEtag: %w", err)
 	}
-	return oldValue.Primary, nil
+	return oldValue.Etag, nil
 }
 
-// ClearPrimary clears the value of the "primary" field.
-func (m *PhotoMutation) ClearPrimary() {
-	m.primary = nil
-	m.clearedFields[photo.FieldPrimary] = struct{}{}
+// ClearEtag clears the value of the "etag" field.
+func (m *ResourceVersionMutation) ClearEtag() {
+	m.etag = nil
+	m.clearedFields[resourceversion.FieldEtag] = struct{}{}
 }
 
-// PrimaryCleared returns if the "primary" field was cleared in this mutation.
-func (m *PhotoMutation) PrimaryCleared() bool {
-	_, ok := m.clearedFields[photo.FieldPrimary]
+// EtagCleared returns if the "etag" field was cleared in this mutation.
+func (m *ResourceVersionMutation) EtagCleared() bool {
+	_, ok := m.clearedFields[resourceversion.FieldEtag]
 	return ok
 }
 
-// ResetPrimary resets all changes to the "primary" field.
-func (m *PhotoMutation) ResetPrimary() {
-	m.primary = nil
-	delete(m.clearedFields, photo.FieldPrimary)
+// ResetEtag resets all changes to the "etag" field.
+func (m *ResourceVersionMutation) ResetEtag() {
+	m.etag = nil
+	delete(m.clearedFields, resourceversion.FieldEtag)
 }
 
-// SetType sets the "type" field.
-func (m *PhotoMutation) SetType(s string) {
-	m._type = &s
+// SetOperation sets the "operation" field.
+func (m *ResourceVersionMutation) SetOperation(s string) {
+	m.operation = &s
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *PhotoMutation) GetType() (r string, exists bool) {
-	v := m._type
+// Operation returns the value of the "operation" field in the mutation.
+func (m *ResourceVersionMutation) Operation() (r string, exists bool) {
+	v := m.operation
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Photo entity.
-// If the Photo object wasn't provided to the builder, the object is fetched from the database.
+// OldOperation returns the old "operation" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhotoMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *ResourceVersionMutation) OldOperation(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldOperation is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldOperation requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldOperation: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.Operation, nil
 }
 
-// ClearType clears the value of the "type" field.
-func (m *PhotoMutation) ClearType() {
-	m._type = nil
-	m.clearedFields[photo.FieldType] = struct{}{}
-}
-
-// TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *PhotoMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[photo.FieldType]
-	return ok
-}
-
-// ResetType resets all changes to the "type" field.
-func (m *PhotoMutation) ResetType() {
-	m._type = nil
-	delete(m.clearedFields, photo.FieldType)
+// ResetOperation resets all changes to the "operation" field.
+func (m *ResourceVersionMutation) ResetOperation() {
+	m.operation = nil
 }
 
-// SetValue sets the "value" field.
-func (m *PhotoMutation) SetValue(s string) {
-	m.value = &s
+// SetActor sets the "actor" field.
+func (m *ResourceVersionMutation) SetActor(s string) {
+	m.actor = &s
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *PhotoMutation) Value() (r string, exists bool) {
-	v := m.value
+// Actor returns the value of the "actor" field in the mutation.
+func (m *ResourceVersionMutation) Actor() (r string, exists bool) {
+	v := m.actor
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Photo entity.
-// If the Photo object wasn't provided to the builder, the object is fetched from the database.
+// OldActor returns the old "actor" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PhotoMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *ResourceVersionMutation) OldActor(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+		return v, errors.New("OldActor is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+		return v, errors.New("OldActor requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+		return v, fmt.Errorf("querying old value for OldActor: %w", err)
 	}
-	return oldValue.Value, nil
+	return oldValue.Actor, nil
 }
 
-// ClearValue clears the value of the "value" field.
-func (m *PhotoMutation) ClearValue() {
-	m.value = nil
-	m.clearedFields[photo.FieldValue] = struct{}{}
+// ClearActor clears the value of the "actor" field.
+func (m *ResourceVersionMutation) ClearActor() {
+	m.actor = nil
+	m.clearedFields[resourceversion.FieldActor] = struct{}{}
 }
 
-// ValueCleared returns if the "value" field was cleared in this mutation.
-func (m *PhotoMutation) ValueCleared() bool {
-	_, ok := m.clearedFields[photo.FieldValue]
+// ActorCleared returns if the "actor" field was cleared in this mutation.
+func (m *ResourceVersionMutation) ActorCleared() bool {
+	_, ok := m.clearedFields[resourceversion.FieldActor]
 	return ok
 }
 
-// ResetValue resets all changes to the "value" field.
-func (m *PhotoMutation) ResetValue() {
-	m.value = nil
-	delete(m.clearedFields, photo.FieldValue)
+// ResetActor resets all changes to the "actor" field.
+func (m *ResourceVersionMutation) ResetActor() {
+	m.actor = nil
+	delete(m.clearedFields, resourceversion.FieldActor)
 }
 
-// SetUserID sets the "user" edge to the User entity by id.
-func (m *PhotoMutation) SetUserID(id uuid.UUID) {
-	m.user = &id
+// SetData sets the "data" field.
+func (m *ResourceVersionMutation) SetData(b []byte) {
+	m.data = &b
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *PhotoMutation) ClearUser() {
-	m.cleareduser = true
+// Data returns the value of the "data" field in the mutation.
+func (m *ResourceVersionMutation) Data() (r []byte, exists bool) {
+	v := m.data
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *PhotoMutation) UserCleared() bool {
-	return m.cleareduser
+// OldData returns the old "data" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceVersionMutation) OldData(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldData is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldData requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldData: %w", err)
+	}
+	return oldValue.Data, nil
 }
 
-// UserID returns the "user" edge ID in the mutation.
-func (m *PhotoMutation) UserID() (id uuid.UUID, exists bool) {
-	if m.user != nil {
-		return *m.user, true
+// ResetData resets all changes to the "data" field.
+func (m *ResourceVersionMutation) ResetData() {
+	m.data = nil
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (m *ResourceVersionMutation) SetCreatedAt(t time.Time) {
+	m.createdAt = &t
+}
+
+// CreatedAt returns the value of the "createdAt" field in the mutation.
+func (m *ResourceVersionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.createdAt
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *PhotoMutation) UserIDs() (ids []uuid.UUID) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// OldCreatedAt returns the old "createdAt" field's value of the ResourceVersion entity.
+// If the ResourceVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ResourceVersionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *PhotoMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// ResetCreatedAt resets all changes to the "createdAt" field.
+func (m *ResourceVersionMutation) ResetCreatedAt() {
+	m.createdAt = nil
 }
 
-// Where appends a list predicates to the PhotoMutation builder.
-func (m *PhotoMutation) Where(ps ...predicate.Photo) {
+// Where appends a list predicates to the ResourceVersionMutation builder.
+func (m *ResourceVersionMutation) Where(ps ...predicate.ResourceVersion) {
 	m.predicates = append(m.predicates, ps...)
 }
 
 // Op returns the operation name.
-func (m *PhotoMutation) Op() Op {
+func (m *ResourceVersionMutation) Op() Op {
 	return m.op
 }
 
-// Type returns the node type of this mutation (Photo).
-func (m *PhotoMutation) Type() string {
+// Type returns the node type of this mutation (ResourceVersion).
+func (m *ResourceVersionMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PhotoMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.display != nil {
-		fields = append(fields, photo.FieldDisplay)
+func (m *ResourceVersionMutation) Fields() []string {
+	fields := make([]string, 0, 8)
+	if m.resourceType != nil {
+		fields = append(fields, resourceversion.FieldResourceType)
 	}
-	if m.primary != nil {
-		fields = append(fields, photo.FieldPrimary)
+	if m.resourceID != nil {
+		fields = append(fields, resourceversion.FieldResourceID)
 	}
-	if m._type != nil {
-		fields = append(fields, photo.FieldType)
+	if m.version != nil {
+		fields = append(fields, resourceversion.FieldVersion)
 	}
-	if m.value != nil {
-		fields = append(fields, photo.FieldValue)
+	if m.etag != nil {
+		fields = append(fields, resourceversion.FieldEtag)
+	}
+	if m.operation != nil {
+		fields = append(fields, resourceversion.FieldOperation)
+	}
+	if m.actor != nil {
+		fields = append(fields, resourceversion.FieldActor)
+	}
+	if m.data != nil {
+		fields = append(fields, resourceversion.FieldData)
+	}
+	if m.createdAt != nil {
+		fields = append(fields, resourceversion.FieldCreatedAt)
 	}
 	return fields
 }
@@ -5640,16 +8572,24 @@ func (m *PhotoMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PhotoMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case photo.FieldDisplay:
-		return m.Display()
-	case photo.FieldPrimary:
-		return m.Primary()
-	case photo.FieldType:
-		return m.GetType()
-	case photo.FieldValue:
-		return m.Value()
+func (m *ResourceVersionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case resourceversion.FieldResourceType:
+		return m.ResourceType()
+	case resourceversion.FieldResourceID:
+		return m.ResourceID()
+	case resourceversion.FieldVersion:
+		return m.Version()
+	case resourceversion.FieldEtag:
+		return m.Etag()
+	case resourceversion.FieldOperation:
+		return m.Operation()
+	case resourceversion.FieldActor:
+		return m.Actor()
+	case resourceversion.FieldData:
+		return m.Data()
+	case resourceversion.FieldCreatedAt:
+		return m.CreatedAt()
 	}
 	return nil, false
 }
@@ -5657,219 +8597,242 @@ func (m *PhotoMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PhotoMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case photo.FieldDisplay:
-		return m.OldDisplay(ctx)
-	case photo.FieldPrimary:
-		return m.OldPrimary(ctx)
-	case photo.FieldType:
-		return m.OldType(ctx)
-	case photo.FieldValue:
-		return m.OldValue(ctx)
+func (m *ResourceVersionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case resourceversion.FieldResourceType:
+		return m.OldResourceType(ctx)
+	case resourceversion.FieldResourceID:
+		return m.OldResourceID(ctx)
+	case resourceversion.FieldVersion:
+		return m.OldVersion(ctx)
+	case resourceversion.FieldEtag:
+		return m.OldEtag(ctx)
+	case resourceversion.FieldOperation:
+		return m.OldOperation(ctx)
+	case resourceversion.FieldActor:
+		return m.OldActor(ctx)
+	case resourceversion.FieldData:
+		return m.OldData(ctx)
+	case resourceversion.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Photo field %s", name)
+	return nil, fmt.Errorf("unknown ResourceVersion field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PhotoMutation) SetField(name string, value ent.Value) error {
+func (m *ResourceVersionMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case photo.FieldDisplay:
+	case resourceversion.FieldResourceType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDisplay(v)
+		m.SetResourceType(v)
 		return nil
-	case photo.FieldPrimary:
-		v, ok := value.(bool)
+	case resourceversion.FieldResourceID:
+		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPrimary(v)
+		m.SetResourceID(v)
 		return nil
-	case photo.FieldType:
+	case resourceversion.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case resourceversion.FieldEtag:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetType(v)
+		m.SetEtag(v)
 		return nil
-	case photo.FieldValue:
+	case resourceversion.FieldOperation:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetOperation(v)
+		return nil
+	case resourceversion.FieldActor:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActor(v)
+		return nil
+	case resourceversion.FieldData:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetData(v)
+		return nil
+	case resourceversion.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Photo field %s", name)
+	return fmt.Errorf("unknown ResourceVersion field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PhotoMutation) AddedFields() []string {
-	return nil
+func (m *ResourceVersionMutation) AddedFields() []string {
+	var fields []string
+	if m.addversion != nil {
+		fields = append(fields, resourceversion.FieldVersion)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PhotoMutation) AddedField(name string) (ent.Value, bool) {
+func (m *ResourceVersionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case resourceversion.FieldVersion:
+		return m.AddedVersion()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PhotoMutation) AddField(name string, value ent.Value) error {
+func (m *ResourceVersionMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case resourceversion.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVersion(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Photo numeric field %s", name)
+	return fmt.Errorf("unknown ResourceVersion numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PhotoMutation) ClearedFields() []string {
+func (m *ResourceVersionMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(photo.FieldDisplay) {
-		fields = append(fields, photo.FieldDisplay)
-	}
-	if m.FieldCleared(photo.FieldPrimary) {
-		fields = append(fields, photo.FieldPrimary)
+	if m.FieldCleared(resourceversion.FieldEtag) {
+		fields = append(fields, resourceversion.FieldEtag)
 	}
-	if m.FieldCleared(photo.FieldType) {
-		fields = append(fields, photo.FieldType)
-	}
-	if m.FieldCleared(photo.FieldValue) {
-		fields = append(fields, photo.FieldValue)
+	if m.FieldCleared(resourceversion.FieldActor) {
+		fields = append(fields, resourceversion.FieldActor)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PhotoMutation) FieldCleared(name string) bool {
+func (m *ResourceVersionMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PhotoMutation) ClearField(name string) error {
+func (m *ResourceVersionMutation) ClearField(name string) error {
 	switch name {
-	case photo.FieldDisplay:
-		m.ClearDisplay()
-		return nil
-	case photo.FieldPrimary:
-		m.ClearPrimary()
-		return nil
-	case photo.FieldType:
-		m.ClearType()
+	case resourceversion.FieldEtag:
+		m.ClearEtag()
 		return nil
-	case photo.FieldValue:
-		m.ClearValue()
+	case resourceversion.FieldActor:
+		m.ClearActor()
 		return nil
 	}
-	return fmt.Errorf("unknown Photo nullable field %s", name)
+	return fmt.Errorf("unknown ResourceVersion nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PhotoMutation) ResetField(name string) error {
+func (m *ResourceVersionMutation) ResetField(name string) error {
 	switch name {
-	case photo.FieldDisplay:
-		m.ResetDisplay()
+	case resourceversion.FieldResourceType:
+		m.ResetResourceType()
 		return nil
-	case photo.FieldPrimary:
-		m.ResetPrimary()
+	case resourceversion.FieldResourceID:
+		m.ResetResourceID()
 		return nil
-	case photo.FieldType:
-		m.ResetType()
+	case resourceversion.FieldVersion:
+		m.ResetVersion()
 		return nil
-	case photo.FieldValue:
-		m.ResetValue()
+	case resourceversion.FieldEtag:
+		m.ResetEtag()
+		return nil
+	case resourceversion.FieldOperation:
+		m.ResetOperation()
+		return nil
+	case resourceversion.FieldActor:
+		m.ResetActor()
+		return nil
+	case resourceversion.FieldData:
+		m.ResetData()
+		return nil
+	case resourceversion.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Photo field %s", name)
+	return fmt.Errorf("unknown ResourceVersion field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PhotoMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.user != nil {
-		edges = append(edges, photo.EdgeUser)
-	}
+func (m *ResourceVersionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PhotoMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case photo.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *ResourceVersionMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PhotoMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *ResourceVersionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PhotoMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	}
+func (m *ResourceVersionMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PhotoMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.cleareduser {
-		edges = append(edges, photo.EdgeUser)
-	}
+func (m *ResourceVersionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PhotoMutation) EdgeCleared(name string) bool {
-	switch name {
-	case photo.EdgeUser:
-		return m.cleareduser
-	}
+func (m *ResourceVersionMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PhotoMutation) ClearEdge(name string) error {
-	switch name {
-	case photo.EdgeUser:
-		m.ClearUser()
-		return nil
-	}
-	return fmt.Errorf("unknown Photo unique edge %s", name)
+func (m *ResourceVersionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown ResourceVersion unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PhotoMutation) ResetEdge(name string) error {
-	switch name {
-	case photo.EdgeUser:
-		m.ResetUser()
-		return nil
-	}
-	return fmt.Errorf("unknown Photo edge %s", name)
+func (m *ResourceVersionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown ResourceVersion edge %s", name)
 }
 
 // RoleMutation represents an operation that mutates the Role nodes in the graph.
@@ -6505,6 +9468,11 @@ type UserMutation struct {
 	op                       Op
 	typ                      string
 	id                       *uuid.UUID
+	normalizedUserName       *string
+	externalIDClient         *string
+	deletedAt                *time.Time
+	deletedBy                *string
+	deletedMemberships       *[]byte
 	active                   *bool
 	displayName              *string
 	externalID               *string
@@ -6655,6 +9623,251 @@ func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	}
 }
 
+// SetNormalizedUserName sets the "normalizedUserName" field.
+func (m *UserMutation) SetNormalizedUserName(s string) {
+	m.normalizedUserName = &s
+}
+
+// NormalizedUserName returns the value of the "normalizedUserName" field in the mutation.
+func (m *UserMutation) NormalizedUserName() (r string, exists bool) {
+	v := m.normalizedUserName
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNormalizedUserName returns the old "normalizedUserName" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldNormalizedUserName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNormalizedUserName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNormalizedUserName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNormalizedUserName: %w", err)
+	}
+	return oldValue.NormalizedUserName, nil
+}
+
+// ClearNormalizedUserName clears the value of the "normalizedUserName" field.
+func (m *UserMutation) ClearNormalizedUserName() {
+	m.normalizedUserName = nil
+	m.clearedFields[user.FieldNormalizedUserName] = struct{}{}
+}
+
+// NormalizedUserNameCleared returns if the "normalizedUserName" field was cleared in this mutation.
+func (m *UserMutation) NormalizedUserNameCleared() bool {
+	_, ok := m.clearedFields[user.FieldNormalizedUserName]
+	return ok
+}
+
+// ResetNormalizedUserName resets all changes to the "normalizedUserName" field.
+func (m *UserMutation) ResetNormalizedUserName() {
+	m.normalizedUserName = nil
+	delete(m.clearedFields, user.FieldNormalizedUserName)
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (m *UserMutation) SetExternalIDClient(s string) {
+	m.externalIDClient = &s
+}
+
+// ExternalIDClient returns the value of the "externalIDClient" field in the mutation.
+func (m *UserMutation) ExternalIDClient() (r string, exists bool) {
+	v := m.externalIDClient
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExternalIDClient returns the old "externalIDClient" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldExternalIDClient(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExternalIDClient is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExternalIDClient requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExternalIDClient: %w", err)
+	}
+	return oldValue.ExternalIDClient, nil
+}
+
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (m *UserMutation) ClearExternalIDClient() {
+	m.externalIDClient = nil
+	m.clearedFields[user.FieldExternalIDClient] = struct{}{}
+}
+
+// ExternalIDClientCleared returns if the "externalIDClient" field was cleared in this mutation.
+func (m *UserMutation) ExternalIDClientCleared() bool {
+	_, ok := m.clearedFields[user.FieldExternalIDClient]
+	return ok
+}
+
+// ResetExternalIDClient resets all changes to the "externalIDClient" field.
+func (m *UserMutation) ResetExternalIDClient() {
+	m.externalIDClient = nil
+	delete(m.clearedFields, user.FieldExternalIDClient)
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (m *UserMutation) SetDeletedAt(t time.Time) {
+	m.deletedAt = &t
+}
+
+// DeletedAt returns the value of the "deletedAt" field in the mutation.
+func (m *UserMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deletedAt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedAt returns the old "deletedAt" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (m *UserMutation) ClearDeletedAt() {
+	m.deletedAt = nil
+	m.clearedFields[user.FieldDeletedAt] = struct{}{}
+}
+
+// DeletedAtCleared returns if the "deletedAt" field was cleared in this mutation.
+func (m *UserMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldDeletedAt]
+	return ok
+}
+
+// ResetDeletedAt resets all changes to the "deletedAt" field.
+func (m *UserMutation) ResetDeletedAt() {
+	m.deletedAt = nil
+	delete(m.clearedFields, user.FieldDeletedAt)
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (m *UserMutation) SetDeletedBy(s string) {
+	m.deletedBy = &s
+}
+
+// DeletedBy returns the value of the "deletedBy" field in the mutation.
+func (m *UserMutation) DeletedBy() (r string, exists bool) {
+	v := m.deletedBy
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedBy returns the old "deletedBy" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDeletedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedBy: %w", err)
+	}
+	return oldValue.DeletedBy, nil
+}
+
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (m *UserMutation) ClearDeletedBy() {
+	m.deletedBy = nil
+	m.clearedFields[user.FieldDeletedBy] = struct{}{}
+}
+
+// DeletedByCleared returns if the "deletedBy" field was cleared in this mutation.
+func (m *UserMutation) DeletedByCleared() bool {
+	_, ok := m.clearedFields[user.FieldDeletedBy]
+	return ok
+}
+
+// ResetDeletedBy resets all changes to the "deletedBy" field.
+func (m *UserMutation) ResetDeletedBy() {
+	m.deletedBy = nil
+	delete(m.clearedFields, user.FieldDeletedBy)
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (m *UserMutation) SetDeletedMemberships(b []byte) {
+	m.deletedMemberships = &b
+}
+
+// DeletedMemberships returns the value of the "deletedMemberships" field in the mutation.
+func (m *UserMutation) DeletedMemberships() (r []byte, exists bool) {
+	v := m.deletedMemberships
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedMemberships returns the old "deletedMemberships" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDeletedMemberships(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedMemberships is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedMemberships requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedMemberships: %w", err)
+	}
+	return oldValue.DeletedMemberships, nil
+}
+
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (m *UserMutation) ClearDeletedMemberships() {
+	m.deletedMemberships = nil
+	m.clearedFields[user.FieldDeletedMemberships] = struct{}{}
+}
+
+// DeletedMembershipsCleared returns if the "deletedMemberships" field was cleared in this mutation.
+func (m *UserMutation) DeletedMembershipsCleared() bool {
+	_, ok := m.clearedFields[user.FieldDeletedMemberships]
+	return ok
+}
+
+// ResetDeletedMemberships resets all changes to the "deletedMemberships" field.
+func (m *UserMutation) ResetDeletedMemberships() {
+	m.deletedMemberships = nil
+	delete(m.clearedFields, user.FieldDeletedMemberships)
+}
+
 // SetActive sets the "active" field.
 func (m *UserMutation) SetActive(b bool) {
 	m.active = &b
@@ -7818,7 +11031,22 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 14)
+	fields := make([]string, 0, 19)
+	if m.normalizedUserName != nil {
+		fields = append(fields, user.FieldNormalizedUserName)
+	}
+	if m.externalIDClient != nil {
+		fields = append(fields, user.FieldExternalIDClient)
+	}
+	if m.deletedAt != nil {
+		fields = append(fields, user.FieldDeletedAt)
+	}
+	if m.deletedBy != nil {
+		fields = append(fields, user.FieldDeletedBy)
+	}
+	if m.deletedMemberships != nil {
+		fields = append(fields, user.FieldDeletedMemberships)
+	}
 	if m.active != nil {
 		fields = append(fields, user.FieldActive)
 	}
@@ -7869,6 +11097,16 @@ func (m *UserMutation) Fields() []string {
 // schema.
 func (m *UserMutation) Field(name string) (ent.Value, bool) {
 	switch name {
+	case user.FieldNormalizedUserName:
+		return m.NormalizedUserName()
+	case user.FieldExternalIDClient:
+		return m.ExternalIDClient()
+	case user.FieldDeletedAt:
+		return m.DeletedAt()
+	case user.FieldDeletedBy:
+		return m.DeletedBy()
+	case user.FieldDeletedMemberships:
+		return m.DeletedMemberships()
 	case user.FieldActive:
 		return m.Active()
 	case user.FieldDisplayName:
@@ -7906,6 +11144,16 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 // database failed.
 func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
+	case user.FieldNormalizedUserName:
+		return m.OldNormalizedUserName(ctx)
+	case user.FieldExternalIDClient:
+		return m.OldExternalIDClient(ctx)
+	case user.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case user.FieldDeletedBy:
+		return m.OldDeletedBy(ctx)
+	case user.FieldDeletedMemberships:
+		return m.OldDeletedMemberships(ctx)
 	case user.FieldActive:
 		return m.OldActive(ctx)
 	case user.FieldDisplayName:
@@ -7943,6 +11191,41 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 // type.
 func (m *UserMutation) SetField(name string, value ent.Value) error {
 	switch name {
+	case user.FieldNormalizedUserName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNormalizedUserName(v)
+		return nil
+	case user.FieldExternalIDClient:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExternalIDClient(v)
+		return nil
+	case user.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case user.FieldDeletedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedBy(v)
+		return nil
+	case user.FieldDeletedMemberships:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedMemberships(v)
+		return nil
 	case user.FieldActive:
 		v, ok := value.(bool)
 		if !ok {
@@ -8071,6 +11354,21 @@ func (m *UserMutation) AddField(name string, value ent.Value) error {
 // mutation.
 func (m *UserMutation) ClearedFields() []string {
 	var fields []string
+	if m.FieldCleared(user.FieldNormalizedUserName) {
+		fields = append(fields, user.FieldNormalizedUserName)
+	}
+	if m.FieldCleared(user.FieldExternalIDClient) {
+		fields = append(fields, user.FieldExternalIDClient)
+	}
+	if m.FieldCleared(user.FieldDeletedAt) {
+		fields = append(fields, user.FieldDeletedAt)
+	}
+	if m.FieldCleared(user.FieldDeletedBy) {
+		fields = append(fields, user.FieldDeletedBy)
+	}
+	if m.FieldCleared(user.FieldDeletedMemberships) {
+		fields = append(fields, user.FieldDeletedMemberships)
+	}
 	if m.FieldCleared(user.FieldActive) {
 		fields = append(fields, user.FieldActive)
 	}
@@ -8124,6 +11422,21 @@ func (m *UserMutation) FieldCleared(name string) bool {
 // error if the field is not defined in the schema.
 func (m *UserMutation) ClearField(name string) error {
 	switch name {
+	case user.FieldNormalizedUserName:
+		m.ClearNormalizedUserName()
+		return nil
+	case user.FieldExternalIDClient:
+		m.ClearExternalIDClient()
+		return nil
+	case user.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case user.FieldDeletedBy:
+		m.ClearDeletedBy()
+		return nil
+	case user.FieldDeletedMemberships:
+		m.ClearDeletedMemberships()
+		return nil
 	case user.FieldActive:
 		m.ClearActive()
 		return nil
@@ -8171,6 +11484,21 @@ func (m *UserMutation) ClearField(name string) error {
 // It returns an error if the field is not defined in the schema.
 func (m *UserMutation) ResetField(name string) error {
 	switch name {
+	case user.FieldNormalizedUserName:
+		m.ResetNormalizedUserName()
+		return nil
+	case user.FieldExternalIDClient:
+		m.ResetExternalIDClient()
+		return nil
+	case user.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case user.FieldDeletedBy:
+		m.ResetDeletedBy()
+		return nil
+	case user.FieldDeletedMemberships:
+		m.ResetDeletedMemberships()
+		return nil
 	case user.FieldActive:
 		m.ResetActive()
 		return nil
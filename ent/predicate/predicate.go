@@ -9,6 +9,9 @@ import (
 // Address is the predicate function for address builders.
 type Address func(*sql.Selector)
 
+// AuditEntry is the predicate function for auditentry builders.
+type AuditEntry func(*sql.Selector)
+
 // Email is the predicate function for email builders.
 type Email func(*sql.Selector)
 
@@ -21,6 +24,9 @@ type Group func(*sql.Selector)
 // IMS is the predicate function for ims builders.
 type IMS func(*sql.Selector)
 
+// IdempotencyKey is the predicate function for idempotencykey builders.
+type IdempotencyKey func(*sql.Selector)
+
 // Member is the predicate function for member builders.
 type Member func(*sql.Selector)
 
@@ -33,6 +39,9 @@ type PhoneNumber func(*sql.Selector)
 // Photo is the predicate function for photo builders.
 type Photo func(*sql.Selector)
 
+// ResourceVersion is the predicate function for resourceversion builders.
+type ResourceVersion func(*sql.Selector)
+
 // Role is the predicate function for role builders.
 type Role func(*sql.Selector)
 
@@ -0,0 +1,184 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+	"github.com/google/uuid"
+)
+
+// ResourceVersion is the model entity for the ResourceVersion schema.
+type ResourceVersion struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// ResourceType holds the value of the "resourceType" field.
+	ResourceType string `json:"resourceType,omitempty"`
+	// ResourceID holds the value of the "resourceID" field.
+	ResourceID uuid.UUID `json:"resourceID,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// Etag holds the value of the "etag" field.
+	Etag string `json:"etag,omitempty"`
+	// Operation holds the value of the "operation" field.
+	Operation string `json:"operation,omitempty"`
+	// Actor holds the value of the "actor" field.
+	Actor string `json:"actor,omitempty"`
+	// Data holds the value of the "data" field.
+	Data []byte `json:"data,omitempty"`
+	// CreatedAt holds the value of the "createdAt" field.
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ResourceVersion) scanValues(columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case resourceversion.FieldData:
+			values[i] = new([]byte)
+		case resourceversion.FieldID, resourceversion.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case resourceversion.FieldResourceType, resourceversion.FieldEtag, resourceversion.FieldOperation, resourceversion.FieldActor:
+			values[i] = new(sql.NullString)
+		case resourceversion.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case resourceversion.FieldResourceID:
+			values[i] = new(uuid.UUID)
+		default:
+			return nil, fmt.Errorf("unexpected column %q for type ResourceVersion", columns[i])
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ResourceVersion fields.
+func (rv *ResourceVersion) assignValues(columns []string, values []interface{}) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case resourceversion.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			rv.ID = int(value.Int64)
+		case resourceversion.FieldResourceType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field resourceType", values[i])
+			} else if value.Valid {
+				rv.ResourceType = value.String
+			}
+		case resourceversion.FieldResourceID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field resourceID", values[i])
+			} else if value != nil {
+				rv.ResourceID = *value
+			}
+		case resourceversion.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				rv.Version = int(value.Int64)
+			}
+		case resourceversion.FieldEtag:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field etag", values[i])
+			} else if value.Valid {
+				rv.Etag = value.String
+			}
+		case resourceversion.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				rv.Operation = value.String
+			}
+		case resourceversion.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				rv.Actor = value.String
+			}
+		case resourceversion.FieldData:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value != nil {
+				rv.Data = *value
+			}
+		case resourceversion.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field createdAt", values[i])
+			} else if value.Valid {
+				rv.CreatedAt = value.Time
+			}
+		}
+	}
+	return nil
+}
+
+// Update returns a builder for updating this ResourceVersion.
+// Note that you need to call ResourceVersion.Unwrap() before calling this method if this ResourceVersion
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (rv *ResourceVersion) Update() *ResourceVersionUpdateOne {
+	return (&ResourceVersionClient{config: rv.config}).UpdateOne(rv)
+}
+
+// Unwrap unwraps the ResourceVersion entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (rv *ResourceVersion) Unwrap() *ResourceVersion {
+	_tx, ok := rv.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ResourceVersion is not a transactional entity")
+	}
+	rv.config.driver = _tx.drv
+	return rv
+}
+
+// String implements the fmt.Stringer.
+func (rv *ResourceVersion) String() string {
+	var builder strings.Builder
+	builder.WriteString("ResourceVersion(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", rv.ID))
+	builder.WriteString("resourceType=")
+	builder.WriteString(rv.ResourceType)
+	builder.WriteString(", ")
+	builder.WriteString("resourceID=")
+	builder.WriteString(fmt.Sprintf("%v", rv.ResourceID))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", rv.Version))
+	builder.WriteString(", ")
+	builder.WriteString("etag=")
+	builder.WriteString(rv.Etag)
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(rv.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(rv.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("data=")
+	builder.WriteString(fmt.Sprintf("%v", rv.Data))
+	builder.WriteString(", ")
+	builder.WriteString("createdAt=")
+	builder.WriteString(rv.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ResourceVersions is a parsable slice of ResourceVersion.
+type ResourceVersions []*ResourceVersion
+
+func (rv ResourceVersions) config(cfg config) {
+	for _i := range rv {
+		rv[_i].config = cfg
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by ent, DO NOT EDIT.
+
+package resourceversion
+
+const (
+	// Label holds the string label denoting the resourceversion type in the database.
+	Label = "resource_version"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldResourceType holds the string denoting the resourcetype field in the database.
+	FieldResourceType = "resource_type"
+	// FieldResourceID holds the string denoting the resourceid field in the database.
+	FieldResourceID = "resource_id"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldEtag holds the string denoting the etag field in the database.
+	FieldEtag = "etag"
+	// FieldOperation holds the string denoting the operation field in the database.
+	FieldOperation = "operation"
+	// FieldActor holds the string denoting the actor field in the database.
+	FieldActor = "actor"
+	// FieldData holds the string denoting the data field in the database.
+	FieldData = "data"
+	// FieldCreatedAt holds the string denoting the createdat field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the resourceversion in the database.
+	Table = "resource_versions"
+)
+
+// Columns holds all SQL columns for resourceversion fields.
+var Columns = []string{
+	FieldID,
+	FieldResourceType,
+	FieldResourceID,
+	FieldVersion,
+	FieldEtag,
+	FieldOperation,
+	FieldActor,
+	FieldData,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,946 @@
+// Code generated by ent, DO NOT EDIT.
+
+package resourceversion
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldID), id))
+	})
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldID), id))
+	})
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.In(s.C(FieldID), v...))
+	})
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		v := make([]interface{}, len(ids))
+		for i := range v {
+			v[i] = ids[i]
+		}
+		s.Where(sql.NotIn(s.C(FieldID), v...))
+	})
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldID), id))
+	})
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldID), id))
+	})
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldID), id))
+	})
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldID), id))
+	})
+}
+
+// ResourceType applies equality check predicate on the "resourceType" field. It's identical to ResourceTypeEQ.
+func ResourceType(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceID applies equality check predicate on the "resourceID" field. It's identical to ResourceIDEQ.
+func ResourceID(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceID), v))
+	})
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldVersion), v))
+	})
+}
+
+// Etag applies equality check predicate on the "etag" field. It's identical to EtagEQ.
+func Etag(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEtag), v))
+	})
+}
+
+// Operation applies equality check predicate on the "operation" field. It's identical to OperationEQ.
+func Operation(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOperation), v))
+	})
+}
+
+// Actor applies equality check predicate on the "actor" field. It's identical to ActorEQ.
+func Actor(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActor), v))
+	})
+}
+
+// Data applies equality check predicate on the "data" field. It's identical to DataEQ.
+func Data(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldData), v))
+	})
+}
+
+// CreatedAt applies equality check predicate on the "createdAt" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// ResourceTypeEQ applies the EQ predicate on the "resourceType" field.
+func ResourceTypeEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeNEQ applies the NEQ predicate on the "resourceType" field.
+func ResourceTypeNEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeIn applies the In predicate on the "resourceType" field.
+func ResourceTypeIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldResourceType), v...))
+	})
+}
+
+// ResourceTypeNotIn applies the NotIn predicate on the "resourceType" field.
+func ResourceTypeNotIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldResourceType), v...))
+	})
+}
+
+// ResourceTypeGT applies the GT predicate on the "resourceType" field.
+func ResourceTypeGT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeGTE applies the GTE predicate on the "resourceType" field.
+func ResourceTypeGTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeLT applies the LT predicate on the "resourceType" field.
+func ResourceTypeLT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeLTE applies the LTE predicate on the "resourceType" field.
+func ResourceTypeLTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeContains applies the Contains predicate on the "resourceType" field.
+func ResourceTypeContains(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeHasPrefix applies the HasPrefix predicate on the "resourceType" field.
+func ResourceTypeHasPrefix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeHasSuffix applies the HasSuffix predicate on the "resourceType" field.
+func ResourceTypeHasSuffix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeEqualFold applies the EqualFold predicate on the "resourceType" field.
+func ResourceTypeEqualFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceTypeContainsFold applies the ContainsFold predicate on the "resourceType" field.
+func ResourceTypeContainsFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldResourceType), v))
+	})
+}
+
+// ResourceIDEQ applies the EQ predicate on the "resourceID" field.
+func ResourceIDEQ(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDNEQ applies the NEQ predicate on the "resourceID" field.
+func ResourceIDNEQ(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDIn applies the In predicate on the "resourceID" field.
+func ResourceIDIn(vs ...uuid.UUID) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldResourceID), v...))
+	})
+}
+
+// ResourceIDNotIn applies the NotIn predicate on the "resourceID" field.
+func ResourceIDNotIn(vs ...uuid.UUID) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldResourceID), v...))
+	})
+}
+
+// ResourceIDGT applies the GT predicate on the "resourceID" field.
+func ResourceIDGT(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDGTE applies the GTE predicate on the "resourceID" field.
+func ResourceIDGTE(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDLT applies the LT predicate on the "resourceID" field.
+func ResourceIDLT(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldResourceID), v))
+	})
+}
+
+// ResourceIDLTE applies the LTE predicate on the "resourceID" field.
+func ResourceIDLTE(v uuid.UUID) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldResourceID), v))
+	})
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldVersion), v))
+	})
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldVersion), v))
+	})
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldVersion), v...))
+	})
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldVersion), v...))
+	})
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldVersion), v))
+	})
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldVersion), v))
+	})
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldVersion), v))
+	})
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldVersion), v))
+	})
+}
+
+// EtagEQ applies the EQ predicate on the "etag" field.
+func EtagEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldEtag), v))
+	})
+}
+
+// EtagNEQ applies the NEQ predicate on the "etag" field.
+func EtagNEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldEtag), v))
+	})
+}
+
+// EtagIn applies the In predicate on the "etag" field.
+func EtagIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldEtag), v...))
+	})
+}
+
+// EtagNotIn applies the NotIn predicate on the "etag" field.
+func EtagNotIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldEtag), v...))
+	})
+}
+
+// EtagGT applies the GT predicate on the "etag" field.
+func EtagGT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldEtag), v))
+	})
+}
+
+// EtagGTE applies the GTE predicate on the "etag" field.
+func EtagGTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldEtag), v))
+	})
+}
+
+// EtagLT applies the LT predicate on the "etag" field.
+func EtagLT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldEtag), v))
+	})
+}
+
+// EtagLTE applies the LTE predicate on the "etag" field.
+func EtagLTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldEtag), v))
+	})
+}
+
+// EtagContains applies the Contains predicate on the "etag" field.
+func EtagContains(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldEtag), v))
+	})
+}
+
+// EtagHasPrefix applies the HasPrefix predicate on the "etag" field.
+func EtagHasPrefix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldEtag), v))
+	})
+}
+
+// EtagHasSuffix applies the HasSuffix predicate on the "etag" field.
+func EtagHasSuffix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldEtag), v))
+	})
+}
+
+// EtagIsNil applies the IsNil predicate on the "etag" field.
+func EtagIsNil() predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldEtag)))
+	})
+}
+
+// EtagNotNil applies the NotNil predicate on the "etag" field.
+func EtagNotNil() predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldEtag)))
+	})
+}
+
+// EtagEqualFold applies the EqualFold predicate on the "etag" field.
+func EtagEqualFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldEtag), v))
+	})
+}
+
+// EtagContainsFold applies the ContainsFold predicate on the "etag" field.
+func EtagContainsFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldEtag), v))
+	})
+}
+
+// OperationEQ applies the EQ predicate on the "operation" field.
+func OperationEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldOperation), v))
+	})
+}
+
+// OperationNEQ applies the NEQ predicate on the "operation" field.
+func OperationNEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldOperation), v))
+	})
+}
+
+// OperationIn applies the In predicate on the "operation" field.
+func OperationIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldOperation), v...))
+	})
+}
+
+// OperationNotIn applies the NotIn predicate on the "operation" field.
+func OperationNotIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldOperation), v...))
+	})
+}
+
+// OperationGT applies the GT predicate on the "operation" field.
+func OperationGT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldOperation), v))
+	})
+}
+
+// OperationGTE applies the GTE predicate on the "operation" field.
+func OperationGTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldOperation), v))
+	})
+}
+
+// OperationLT applies the LT predicate on the "operation" field.
+func OperationLT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldOperation), v))
+	})
+}
+
+// OperationLTE applies the LTE predicate on the "operation" field.
+func OperationLTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldOperation), v))
+	})
+}
+
+// OperationContains applies the Contains predicate on the "operation" field.
+func OperationContains(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldOperation), v))
+	})
+}
+
+// OperationHasPrefix applies the HasPrefix predicate on the "operation" field.
+func OperationHasPrefix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldOperation), v))
+	})
+}
+
+// OperationHasSuffix applies the HasSuffix predicate on the "operation" field.
+func OperationHasSuffix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldOperation), v))
+	})
+}
+
+// OperationEqualFold applies the EqualFold predicate on the "operation" field.
+func OperationEqualFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldOperation), v))
+	})
+}
+
+// OperationContainsFold applies the ContainsFold predicate on the "operation" field.
+func OperationContainsFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldOperation), v))
+	})
+}
+
+// ActorEQ applies the EQ predicate on the "actor" field.
+func ActorEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldActor), v))
+	})
+}
+
+// ActorNEQ applies the NEQ predicate on the "actor" field.
+func ActorNEQ(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldActor), v))
+	})
+}
+
+// ActorIn applies the In predicate on the "actor" field.
+func ActorIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldActor), v...))
+	})
+}
+
+// ActorNotIn applies the NotIn predicate on the "actor" field.
+func ActorNotIn(vs ...string) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldActor), v...))
+	})
+}
+
+// ActorGT applies the GT predicate on the "actor" field.
+func ActorGT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldActor), v))
+	})
+}
+
+// ActorGTE applies the GTE predicate on the "actor" field.
+func ActorGTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldActor), v))
+	})
+}
+
+// ActorLT applies the LT predicate on the "actor" field.
+func ActorLT(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldActor), v))
+	})
+}
+
+// ActorLTE applies the LTE predicate on the "actor" field.
+func ActorLTE(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldActor), v))
+	})
+}
+
+// ActorContains applies the Contains predicate on the "actor" field.
+func ActorContains(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.Contains(s.C(FieldActor), v))
+	})
+}
+
+// ActorHasPrefix applies the HasPrefix predicate on the "actor" field.
+func ActorHasPrefix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasPrefix(s.C(FieldActor), v))
+	})
+}
+
+// ActorHasSuffix applies the HasSuffix predicate on the "actor" field.
+func ActorHasSuffix(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.HasSuffix(s.C(FieldActor), v))
+	})
+}
+
+// ActorIsNil applies the IsNil predicate on the "actor" field.
+func ActorIsNil() predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.IsNull(s.C(FieldActor)))
+	})
+}
+
+// ActorNotNil applies the NotNil predicate on the "actor" field.
+func ActorNotNil() predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NotNull(s.C(FieldActor)))
+	})
+}
+
+// ActorEqualFold applies the EqualFold predicate on the "actor" field.
+func ActorEqualFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EqualFold(s.C(FieldActor), v))
+	})
+}
+
+// ActorContainsFold applies the ContainsFold predicate on the "actor" field.
+func ActorContainsFold(v string) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.ContainsFold(s.C(FieldActor), v))
+	})
+}
+
+// DataEQ applies the EQ predicate on the "data" field.
+func DataEQ(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldData), v))
+	})
+}
+
+// DataNEQ applies the NEQ predicate on the "data" field.
+func DataNEQ(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldData), v))
+	})
+}
+
+// DataIn applies the In predicate on the "data" field.
+func DataIn(vs ...[]byte) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldData), v...))
+	})
+}
+
+// DataNotIn applies the NotIn predicate on the "data" field.
+func DataNotIn(vs ...[]byte) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldData), v...))
+	})
+}
+
+// DataGT applies the GT predicate on the "data" field.
+func DataGT(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldData), v))
+	})
+}
+
+// DataGTE applies the GTE predicate on the "data" field.
+func DataGTE(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldData), v))
+	})
+}
+
+// DataLT applies the LT predicate on the "data" field.
+func DataLT(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldData), v))
+	})
+}
+
+// DataLTE applies the LTE predicate on the "data" field.
+func DataLTE(v []byte) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldData), v))
+	})
+}
+
+// CreatedAtEQ applies the EQ predicate on the "createdAt" field.
+func CreatedAtEQ(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.EQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "createdAt" field.
+func CreatedAtNEQ(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.NEQ(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtIn applies the In predicate on the "createdAt" field.
+func CreatedAtIn(vs ...time.Time) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.In(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "createdAt" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ResourceVersion {
+	v := make([]interface{}, len(vs))
+	for i := range v {
+		v[i] = vs[i]
+	}
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		// if not arguments were provided, append the FALSE constants,
+		// since we can't apply "IN ()". This will make this predicate falsy.
+		if len(v) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.NotIn(s.C(FieldCreatedAt), v...))
+	})
+}
+
+// CreatedAtGT applies the GT predicate on the "createdAt" field.
+func CreatedAtGT(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtGTE applies the GTE predicate on the "createdAt" field.
+func CreatedAtGTE(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.GTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLT applies the LT predicate on the "createdAt" field.
+func CreatedAtLT(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LT(s.C(FieldCreatedAt), v))
+	})
+}
+
+// CreatedAtLTE applies the LTE predicate on the "createdAt" field.
+func CreatedAtLTE(v time.Time) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s.Where(sql.LTE(s.C(FieldCreatedAt), v))
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ResourceVersion) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for _, p := range predicates {
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ResourceVersion) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		s1 := s.Clone().SetP(nil)
+		for i, p := range predicates {
+			if i > 0 {
+				s1.Or()
+			}
+			p(s1)
+		}
+		s.Where(s1.P())
+	})
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ResourceVersion) predicate.ResourceVersion {
+	return predicate.ResourceVersion(func(s *sql.Selector) {
+		p(s.Not())
+	})
+}
@@ -0,0 +1,357 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+	"github.com/google/uuid"
+)
+
+// ResourceVersionCreate is the builder for creating a ResourceVersion entity.
+type ResourceVersionCreate struct {
+	config
+	mutation *ResourceVersionMutation
+	hooks    []Hook
+}
+
+// SetResourceType sets the "resourceType" field.
+func (rvc *ResourceVersionCreate) SetResourceType(s string) *ResourceVersionCreate {
+	rvc.mutation.SetResourceType(s)
+	return rvc
+}
+
+// SetResourceID sets the "resourceID" field.
+func (rvc *ResourceVersionCreate) SetResourceID(u uuid.UUID) *ResourceVersionCreate {
+	rvc.mutation.SetResourceID(u)
+	return rvc
+}
+
+// SetVersion sets the "version" field.
+func (rvc *ResourceVersionCreate) SetVersion(i int) *ResourceVersionCreate {
+	rvc.mutation.SetVersion(i)
+	return rvc
+}
+
+// SetEtag sets the "etag" field.
+func (rvc *ResourceVersionCreate) SetEtag(s string) *ResourceVersionCreate {
+	rvc.mutation.SetEtag(s)
+	return rvc
+}
+
+// SetNillableEtag sets the "etag" field if the given value is not nil.
+func (rvc *ResourceVersionCreate) SetNillableEtag(s *string) *ResourceVersionCreate {
+	if s != nil {
+		rvc.SetEtag(*s)
+	}
+	return rvc
+}
+
+// SetOperation sets the "operation" field.
+func (rvc *ResourceVersionCreate) SetOperation(s string) *ResourceVersionCreate {
+	rvc.mutation.SetOperation(s)
+	return rvc
+}
+
+// SetActor sets the "actor" field.
+func (rvc *ResourceVersionCreate) SetActor(s string) *ResourceVersionCreate {
+	rvc.mutation.SetActor(s)
+	return rvc
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (rvc *ResourceVersionCreate) SetNillableActor(s *string) *ResourceVersionCreate {
+	if s != nil {
+		rvc.SetActor(*s)
+	}
+	return rvc
+}
+
+// SetData sets the "data" field.
+func (rvc *ResourceVersionCreate) SetData(b []byte) *ResourceVersionCreate {
+	rvc.mutation.SetData(b)
+	return rvc
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (rvc *ResourceVersionCreate) SetCreatedAt(t time.Time) *ResourceVersionCreate {
+	rvc.mutation.SetCreatedAt(t)
+	return rvc
+}
+
+// Mutation returns the ResourceVersionMutation object of the builder.
+func (rvc *ResourceVersionCreate) Mutation() *ResourceVersionMutation {
+	return rvc.mutation
+}
+
+// Save creates the ResourceVersion in the database.
+func (rvc *ResourceVersionCreate) Save(ctx context.Context) (*ResourceVersion, error) {
+	var (
+		err  error
+		node *ResourceVersion
+	)
+	if len(rvc.hooks) == 0 {
+		if err = rvc.check(); err != nil {
+			return nil, err
+		}
+		node, err = rvc.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ResourceVersionMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if err = rvc.check(); err != nil {
+				return nil, err
+			}
+			rvc.mutation = mutation
+			if node, err = rvc.sqlSave(ctx); err != nil {
+				return nil, err
+			}
+			mutation.id = &node.ID
+			mutation.done = true
+			return node, err
+		})
+		for i := len(rvc.hooks) - 1; i >= 0; i-- {
+			if rvc.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rvc.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, rvc.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*ResourceVersion)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ResourceVersionMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rvc *ResourceVersionCreate) SaveX(ctx context.Context) *ResourceVersion {
+	v, err := rvc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rvc *ResourceVersionCreate) Exec(ctx context.Context) error {
+	_, err := rvc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvc *ResourceVersionCreate) ExecX(ctx context.Context) {
+	if err := rvc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rvc *ResourceVersionCreate) check() error {
+	if _, ok := rvc.mutation.ResourceType(); !ok {
+		return &ValidationError{Name: "resourceType", err: errors.New(`ent: missing required field "ResourceVersion.resourceType"`)}
+	}
+	if _, ok := rvc.mutation.ResourceID(); !ok {
+		return &ValidationError{Name: "resourceID", err: errors.New(`ent: missing required field "ResourceVersion.resourceID"`)}
+	}
+	if _, ok := rvc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "ResourceVersion.version"`)}
+	}
+	if _, ok := rvc.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "ResourceVersion.operation"`)}
+	}
+	if _, ok := rvc.mutation.Data(); !ok {
+		return &ValidationError{Name: "data", err: errors.New(`ent: missing required field "ResourceVersion.data"`)}
+	}
+	if _, ok := rvc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "createdAt", err: errors.New(`ent: missing required field "ResourceVersion.createdAt"`)}
+	}
+	return nil
+}
+
+func (rvc *ResourceVersionCreate) sqlSave(ctx context.Context) (*ResourceVersion, error) {
+	_node, _spec := rvc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rvc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	return _node, nil
+}
+
+func (rvc *ResourceVersionCreate) createSpec() (*ResourceVersion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ResourceVersion{config: rvc.config}
+		_spec = &sqlgraph.CreateSpec{
+			Table: resourceversion.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: resourceversion.FieldID,
+			},
+		}
+	)
+	if value, ok := rvc.mutation.ResourceType(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldResourceType,
+		})
+		_node.ResourceType = value
+	}
+	if value, ok := rvc.mutation.ResourceID(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeUUID,
+			Value:  value,
+			Column: resourceversion.FieldResourceID,
+		})
+		_node.ResourceID = value
+	}
+	if value, ok := rvc.mutation.Version(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: resourceversion.FieldVersion,
+		})
+		_node.Version = value
+	}
+	if value, ok := rvc.mutation.Etag(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldEtag,
+		})
+		_node.Etag = value
+	}
+	if value, ok := rvc.mutation.Operation(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldOperation,
+		})
+		_node.Operation = value
+	}
+	if value, ok := rvc.mutation.Actor(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldActor,
+		})
+		_node.Actor = value
+	}
+	if value, ok := rvc.mutation.Data(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: resourceversion.FieldData,
+		})
+		_node.Data = value
+	}
+	if value, ok := rvc.mutation.CreatedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: resourceversion.FieldCreatedAt,
+		})
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// ResourceVersionCreateBulk is the builder for creating many ResourceVersion entities in bulk.
+type ResourceVersionCreateBulk struct {
+	config
+	builders []*ResourceVersionCreate
+}
+
+// Save creates the ResourceVersion entities in the database.
+func (rvcb *ResourceVersionCreateBulk) Save(ctx context.Context) ([]*ResourceVersion, error) {
+	specs := make([]*sqlgraph.CreateSpec, len(rvcb.builders))
+	nodes := make([]*ResourceVersion, len(rvcb.builders))
+	mutators := make([]Mutator, len(rvcb.builders))
+	for i := range rvcb.builders {
+		func(i int, root context.Context) {
+			builder := rvcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ResourceVersionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				nodes[i], specs[i] = builder.createSpec()
+				var err error
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rvcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rvcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rvcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rvcb *ResourceVersionCreateBulk) SaveX(ctx context.Context) []*ResourceVersion {
+	v, err := rvcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rvcb *ResourceVersionCreateBulk) Exec(ctx context.Context) error {
+	_, err := rvcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvcb *ResourceVersionCreateBulk) ExecX(ctx context.Context) {
+	if err := rvcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,115 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+)
+
+// ResourceVersionDelete is the builder for deleting a ResourceVersion entity.
+type ResourceVersionDelete struct {
+	config
+	hooks    []Hook
+	mutation *ResourceVersionMutation
+}
+
+// Where appends a list predicates to the ResourceVersionDelete builder.
+func (rvd *ResourceVersionDelete) Where(ps ...predicate.ResourceVersion) *ResourceVersionDelete {
+	rvd.mutation.Where(ps...)
+	return rvd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (rvd *ResourceVersionDelete) Exec(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(rvd.hooks) == 0 {
+		affected, err = rvd.sqlExec(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ResourceVersionMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rvd.mutation = mutation
+			affected, err = rvd.sqlExec(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(rvd.hooks) - 1; i >= 0; i-- {
+			if rvd.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rvd.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, rvd.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvd *ResourceVersionDelete) ExecX(ctx context.Context) int {
+	n, err := rvd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (rvd *ResourceVersionDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := &sqlgraph.DeleteSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table: resourceversion.Table,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: resourceversion.FieldID,
+			},
+		},
+	}
+	if ps := rvd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, rvd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	return affected, err
+}
+
+// ResourceVersionDeleteOne is the builder for deleting a single ResourceVersion entity.
+type ResourceVersionDeleteOne struct {
+	rvd *ResourceVersionDelete
+}
+
+// Exec executes the deletion query.
+func (rvdo *ResourceVersionDeleteOne) Exec(ctx context.Context) error {
+	n, err := rvdo.rvd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{resourceversion.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvdo *ResourceVersionDeleteOne) ExecX(ctx context.Context) {
+	rvdo.rvd.ExecX(ctx)
+}
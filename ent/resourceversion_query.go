@@ -0,0 +1,529 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+)
+
+// ResourceVersionQuery is the builder for querying ResourceVersion entities.
+type ResourceVersionQuery struct {
+	config
+	limit      *int
+	offset     *int
+	unique     *bool
+	order      []OrderFunc
+	fields     []string
+	predicates []predicate.ResourceVersion
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ResourceVersionQuery builder.
+func (rvq *ResourceVersionQuery) Where(ps ...predicate.ResourceVersion) *ResourceVersionQuery {
+	rvq.predicates = append(rvq.predicates, ps...)
+	return rvq
+}
+
+// Limit adds a limit step to the query.
+func (rvq *ResourceVersionQuery) Limit(limit int) *ResourceVersionQuery {
+	rvq.limit = &limit
+	return rvq
+}
+
+// Offset adds an offset step to the query.
+func (rvq *ResourceVersionQuery) Offset(offset int) *ResourceVersionQuery {
+	rvq.offset = &offset
+	return rvq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (rvq *ResourceVersionQuery) Unique(unique bool) *ResourceVersionQuery {
+	rvq.unique = &unique
+	return rvq
+}
+
+// Order adds an order step to the query.
+func (rvq *ResourceVersionQuery) Order(o ...OrderFunc) *ResourceVersionQuery {
+	rvq.order = append(rvq.order, o...)
+	return rvq
+}
+
+// First returns the first ResourceVersion entity from the query.
+// Returns a *NotFoundError when no ResourceVersion was found.
+func (rvq *ResourceVersionQuery) First(ctx context.Context) (*ResourceVersion, error) {
+	nodes, err := rvq.Limit(1).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{resourceversion.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) FirstX(ctx context.Context) *ResourceVersion {
+	node, err := rvq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ResourceVersion ID from the query.
+// Returns a *NotFoundError when no ResourceVersion ID was found.
+func (rvq *ResourceVersionQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = rvq.Limit(1).IDs(ctx); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{resourceversion.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) FirstIDX(ctx context.Context) int {
+	id, err := rvq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ResourceVersion entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ResourceVersion entity is found.
+// Returns a *NotFoundError when no ResourceVersion entities are found.
+func (rvq *ResourceVersionQuery) Only(ctx context.Context) (*ResourceVersion, error) {
+	nodes, err := rvq.Limit(2).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{resourceversion.Label}
+	default:
+		return nil, &NotSingularError{resourceversion.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) OnlyX(ctx context.Context) *ResourceVersion {
+	node, err := rvq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ResourceVersion ID in the query.
+// Returns a *NotSingularError when more than one ResourceVersion ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (rvq *ResourceVersionQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = rvq.Limit(2).IDs(ctx); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{resourceversion.Label}
+	default:
+		err = &NotSingularError{resourceversion.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) OnlyIDX(ctx context.Context) int {
+	id, err := rvq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ResourceVersions.
+func (rvq *ResourceVersionQuery) All(ctx context.Context) ([]*ResourceVersion, error) {
+	if err := rvq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	return rvq.sqlAll(ctx)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) AllX(ctx context.Context) []*ResourceVersion {
+	nodes, err := rvq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ResourceVersion IDs.
+func (rvq *ResourceVersionQuery) IDs(ctx context.Context) ([]int, error) {
+	var ids []int
+	if err := rvq.Select(resourceversion.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) IDsX(ctx context.Context) []int {
+	ids, err := rvq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (rvq *ResourceVersionQuery) Count(ctx context.Context) (int, error) {
+	if err := rvq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return rvq.sqlCount(ctx)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) CountX(ctx context.Context) int {
+	count, err := rvq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (rvq *ResourceVersionQuery) Exist(ctx context.Context) (bool, error) {
+	if err := rvq.prepareQuery(ctx); err != nil {
+		return false, err
+	}
+	return rvq.sqlExist(ctx)
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (rvq *ResourceVersionQuery) ExistX(ctx context.Context) bool {
+	exist, err := rvq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ResourceVersionQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (rvq *ResourceVersionQuery) Clone() *ResourceVersionQuery {
+	if rvq == nil {
+		return nil
+	}
+	return &ResourceVersionQuery{
+		config:     rvq.config,
+		limit:      rvq.limit,
+		offset:     rvq.offset,
+		order:      append([]OrderFunc{}, rvq.order...),
+		predicates: append([]predicate.ResourceVersion{}, rvq.predicates...),
+		// clone intermediate query.
+		sql:    rvq.sql.Clone(),
+		path:   rvq.path,
+		unique: rvq.unique,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		ResourceType string `json:"resourceType,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ResourceVersion.Query().
+//		GroupBy(resourceversion.FieldResourceType).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+//
+func (rvq *ResourceVersionQuery) GroupBy(field string, fields ...string) *ResourceVersionGroupBy {
+	grbuild := &ResourceVersionGroupBy{config: rvq.config}
+	grbuild.fields = append([]string{field}, fields...)
+	grbuild.path = func(ctx context.Context) (prev *sql.Selector, err error) {
+		if err := rvq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		return rvq.sqlQuery(ctx), nil
+	}
+	grbuild.label = resourceversion.Label
+	grbuild.flds, grbuild.scan = &grbuild.fields, grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		ResourceType string `json:"resourceType,omitempty"`
+//	}
+//
+//	client.ResourceVersion.Query().
+//		Select(resourceversion.FieldResourceType).
+//		Scan(ctx, &v)
+//
+func (rvq *ResourceVersionQuery) Select(fields ...string) *ResourceVersionSelect {
+	rvq.fields = append(rvq.fields, fields...)
+	selbuild := &ResourceVersionSelect{ResourceVersionQuery: rvq}
+	selbuild.label = resourceversion.Label
+	selbuild.flds, selbuild.scan = &rvq.fields, selbuild.Scan
+	return selbuild
+}
+
+func (rvq *ResourceVersionQuery) prepareQuery(ctx context.Context) error {
+	for _, f := range rvq.fields {
+		if !resourceversion.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if rvq.path != nil {
+		prev, err := rvq.path(ctx)
+		if err != nil {
+			return err
+		}
+		rvq.sql = prev
+	}
+	return nil
+}
+
+func (rvq *ResourceVersionQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ResourceVersion, error) {
+	var (
+		nodes = []*ResourceVersion{}
+		_spec = rvq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]interface{}, error) {
+		return (*ResourceVersion).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []interface{}) error {
+		node := &ResourceVersion{config: rvq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, rvq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+
+	return nodes, nil
+}
+
+func (rvq *ResourceVersionQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := rvq.querySpec()
+	_spec.Node.Columns = rvq.fields
+	if len(rvq.fields) > 0 {
+		_spec.Unique = rvq.unique != nil && *rvq.unique
+	}
+	return sqlgraph.CountNodes(ctx, rvq.driver, _spec)
+}
+
+func (rvq *ResourceVersionQuery) sqlExist(ctx context.Context) (bool, error) {
+	n, err := rvq.sqlCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (rvq *ResourceVersionQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := &sqlgraph.QuerySpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   resourceversion.Table,
+			Columns: resourceversion.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: resourceversion.FieldID,
+			},
+		},
+		From:   rvq.sql,
+		Unique: true,
+	}
+	if unique := rvq.unique; unique != nil {
+		_spec.Unique = *unique
+	}
+	if fields := rvq.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, resourceversion.FieldID)
+		for i := range fields {
+			if fields[i] != resourceversion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := rvq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := rvq.limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := rvq.offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := rvq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (rvq *ResourceVersionQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(rvq.driver.Dialect())
+	t1 := builder.Table(resourceversion.Table)
+	columns := rvq.fields
+	if len(columns) == 0 {
+		columns = resourceversion.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if rvq.sql != nil {
+		selector = rvq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if rvq.unique != nil && *rvq.unique {
+		selector.Distinct()
+	}
+	for _, p := range rvq.predicates {
+		p(selector)
+	}
+	for _, p := range rvq.order {
+		p(selector)
+	}
+	if offset := rvq.offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := rvq.limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ResourceVersionGroupBy is the group-by builder for ResourceVersion entities.
+type ResourceVersionGroupBy struct {
+	config
+	selector
+	fields []string
+	fns    []AggregateFunc
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (rvgb *ResourceVersionGroupBy) Aggregate(fns ...AggregateFunc) *ResourceVersionGroupBy {
+	rvgb.fns = append(rvgb.fns, fns...)
+	return rvgb
+}
+
+// Scan applies the group-by query and scans the result into the given value.
+func (rvgb *ResourceVersionGroupBy) Scan(ctx context.Context, v interface{}) error {
+	query, err := rvgb.path(ctx)
+	if err != nil {
+		return err
+	}
+	rvgb.sql = query
+	return rvgb.sqlScan(ctx, v)
+}
+
+func (rvgb *ResourceVersionGroupBy) sqlScan(ctx context.Context, v interface{}) error {
+	for _, f := range rvgb.fields {
+		if !resourceversion.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("invalid field %q for group-by", f)}
+		}
+	}
+	selector := rvgb.sqlQuery()
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rvgb.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+func (rvgb *ResourceVersionGroupBy) sqlQuery() *sql.Selector {
+	selector := rvgb.sql.Select()
+	aggregation := make([]string, 0, len(rvgb.fns))
+	for _, fn := range rvgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	// If no columns were selected in a custom aggregation function, the default
+	// selection is the fields used for "group-by", and the aggregation functions.
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(rvgb.fields)+len(rvgb.fns))
+		for _, f := range rvgb.fields {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	return selector.GroupBy(selector.Columns(rvgb.fields...)...)
+}
+
+// ResourceVersionSelect is the builder for selecting fields of ResourceVersion entities.
+type ResourceVersionSelect struct {
+	*ResourceVersionQuery
+	selector
+	// intermediate query (i.e. traversal path).
+	sql *sql.Selector
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rvs *ResourceVersionSelect) Scan(ctx context.Context, v interface{}) error {
+	if err := rvs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	rvs.sql = rvs.ResourceVersionQuery.sqlQuery(ctx)
+	return rvs.sqlScan(ctx, v)
+}
+
+func (rvs *ResourceVersionSelect) sqlScan(ctx context.Context, v interface{}) error {
+	rows := &sql.Rows{}
+	query, args := rvs.sql.Query()
+	if err := rvs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
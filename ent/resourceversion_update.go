@@ -0,0 +1,563 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+	"github.com/google/uuid"
+)
+
+// ResourceVersionUpdate is the builder for updating ResourceVersion entities.
+type ResourceVersionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ResourceVersionMutation
+}
+
+// Where appends a list predicates to the ResourceVersionUpdate builder.
+func (rvu *ResourceVersionUpdate) Where(ps ...predicate.ResourceVersion) *ResourceVersionUpdate {
+	rvu.mutation.Where(ps...)
+	return rvu
+}
+
+// SetResourceType sets the "resourceType" field.
+func (rvu *ResourceVersionUpdate) SetResourceType(s string) *ResourceVersionUpdate {
+	rvu.mutation.SetResourceType(s)
+	return rvu
+}
+
+// SetResourceID sets the "resourceID" field.
+func (rvu *ResourceVersionUpdate) SetResourceID(u uuid.UUID) *ResourceVersionUpdate {
+	rvu.mutation.SetResourceID(u)
+	return rvu
+}
+
+// SetVersion sets the "version" field.
+func (rvu *ResourceVersionUpdate) SetVersion(i int) *ResourceVersionUpdate {
+	rvu.mutation.ResetVersion()
+	rvu.mutation.SetVersion(i)
+	return rvu
+}
+
+// AddVersion adds i to the "version" field.
+func (rvu *ResourceVersionUpdate) AddVersion(i int) *ResourceVersionUpdate {
+	rvu.mutation.AddVersion(i)
+	return rvu
+}
+
+// SetEtag sets the "etag" field.
+func (rvu *ResourceVersionUpdate) SetEtag(s string) *ResourceVersionUpdate {
+	rvu.mutation.SetEtag(s)
+	return rvu
+}
+
+// SetNillableEtag sets the "etag" field if the given value is not nil.
+func (rvu *ResourceVersionUpdate) SetNillableEtag(s *string) *ResourceVersionUpdate {
+	if s != nil {
+		rvu.SetEtag(*s)
+	}
+	return rvu
+}
+
+// ClearEtag clears the value of the "etag" field.
+func (rvu *ResourceVersionUpdate) ClearEtag() *ResourceVersionUpdate {
+	rvu.mutation.ClearEtag()
+	return rvu
+}
+
+// SetOperation sets the "operation" field.
+func (rvu *ResourceVersionUpdate) SetOperation(s string) *ResourceVersionUpdate {
+	rvu.mutation.SetOperation(s)
+	return rvu
+}
+
+// SetActor sets the "actor" field.
+func (rvu *ResourceVersionUpdate) SetActor(s string) *ResourceVersionUpdate {
+	rvu.mutation.SetActor(s)
+	return rvu
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (rvu *ResourceVersionUpdate) SetNillableActor(s *string) *ResourceVersionUpdate {
+	if s != nil {
+		rvu.SetActor(*s)
+	}
+	return rvu
+}
+
+// ClearActor clears the value of the "actor" field.
+func (rvu *ResourceVersionUpdate) ClearActor() *ResourceVersionUpdate {
+	rvu.mutation.ClearActor()
+	return rvu
+}
+
+// SetData sets the "data" field.
+func (rvu *ResourceVersionUpdate) SetData(b []byte) *ResourceVersionUpdate {
+	rvu.mutation.SetData(b)
+	return rvu
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (rvu *ResourceVersionUpdate) SetCreatedAt(t time.Time) *ResourceVersionUpdate {
+	rvu.mutation.SetCreatedAt(t)
+	return rvu
+}
+
+// Mutation returns the ResourceVersionMutation object of the builder.
+func (rvu *ResourceVersionUpdate) Mutation() *ResourceVersionMutation {
+	return rvu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (rvu *ResourceVersionUpdate) Save(ctx context.Context) (int, error) {
+	var (
+		err      error
+		affected int
+	)
+	if len(rvu.hooks) == 0 {
+		affected, err = rvu.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ResourceVersionMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rvu.mutation = mutation
+			affected, err = rvu.sqlSave(ctx)
+			mutation.done = true
+			return affected, err
+		})
+		for i := len(rvu.hooks) - 1; i >= 0; i-- {
+			if rvu.hooks[i] == nil {
+				return 0, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rvu.hooks[i](mut)
+		}
+		if _, err := mut.Mutate(ctx, rvu.mutation); err != nil {
+			return 0, err
+		}
+	}
+	return affected, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rvu *ResourceVersionUpdate) SaveX(ctx context.Context) int {
+	affected, err := rvu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (rvu *ResourceVersionUpdate) Exec(ctx context.Context) error {
+	_, err := rvu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvu *ResourceVersionUpdate) ExecX(ctx context.Context) {
+	if err := rvu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rvu *ResourceVersionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   resourceversion.Table,
+			Columns: resourceversion.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: resourceversion.FieldID,
+			},
+		},
+	}
+	if ps := rvu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rvu.mutation.ResourceType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldResourceType,
+		})
+	}
+	if value, ok := rvu.mutation.ResourceID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeUUID,
+			Value:  value,
+			Column: resourceversion.FieldResourceID,
+		})
+	}
+	if value, ok := rvu.mutation.Version(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: resourceversion.FieldVersion,
+		})
+	}
+	if value, ok := rvu.mutation.AddedVersion(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: resourceversion.FieldVersion,
+		})
+	}
+	if value, ok := rvu.mutation.Etag(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldEtag,
+		})
+	}
+	if rvu.mutation.EtagCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: resourceversion.FieldEtag,
+		})
+	}
+	if value, ok := rvu.mutation.Operation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldOperation,
+		})
+	}
+	if value, ok := rvu.mutation.Actor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldActor,
+		})
+	}
+	if rvu.mutation.ActorCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: resourceversion.FieldActor,
+		})
+	}
+	if value, ok := rvu.mutation.Data(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: resourceversion.FieldData,
+		})
+	}
+	if value, ok := rvu.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: resourceversion.FieldCreatedAt,
+		})
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, rvu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{resourceversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	return n, nil
+}
+
+// ResourceVersionUpdateOne is the builder for updating a single ResourceVersion entity.
+type ResourceVersionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ResourceVersionMutation
+}
+
+// SetResourceType sets the "resourceType" field.
+func (rvuo *ResourceVersionUpdateOne) SetResourceType(s string) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetResourceType(s)
+	return rvuo
+}
+
+// SetResourceID sets the "resourceID" field.
+func (rvuo *ResourceVersionUpdateOne) SetResourceID(u uuid.UUID) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetResourceID(u)
+	return rvuo
+}
+
+// SetVersion sets the "version" field.
+func (rvuo *ResourceVersionUpdateOne) SetVersion(i int) *ResourceVersionUpdateOne {
+	rvuo.mutation.ResetVersion()
+	rvuo.mutation.SetVersion(i)
+	return rvuo
+}
+
+// AddVersion adds i to the "version" field.
+func (rvuo *ResourceVersionUpdateOne) AddVersion(i int) *ResourceVersionUpdateOne {
+	rvuo.mutation.AddVersion(i)
+	return rvuo
+}
+
+// SetEtag sets the "etag" field.
+func (rvuo *ResourceVersionUpdateOne) SetEtag(s string) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetEtag(s)
+	return rvuo
+}
+
+// SetNillableEtag sets the "etag" field if the given value is not nil.
+func (rvuo *ResourceVersionUpdateOne) SetNillableEtag(s *string) *ResourceVersionUpdateOne {
+	if s != nil {
+		rvuo.SetEtag(*s)
+	}
+	return rvuo
+}
+
+// ClearEtag clears the value of the "etag" field.
+func (rvuo *ResourceVersionUpdateOne) ClearEtag() *ResourceVersionUpdateOne {
+	rvuo.mutation.ClearEtag()
+	return rvuo
+}
+
+// SetOperation sets the "operation" field.
+func (rvuo *ResourceVersionUpdateOne) SetOperation(s string) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetOperation(s)
+	return rvuo
+}
+
+// SetActor sets the "actor" field.
+func (rvuo *ResourceVersionUpdateOne) SetActor(s string) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetActor(s)
+	return rvuo
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (rvuo *ResourceVersionUpdateOne) SetNillableActor(s *string) *ResourceVersionUpdateOne {
+	if s != nil {
+		rvuo.SetActor(*s)
+	}
+	return rvuo
+}
+
+// ClearActor clears the value of the "actor" field.
+func (rvuo *ResourceVersionUpdateOne) ClearActor() *ResourceVersionUpdateOne {
+	rvuo.mutation.ClearActor()
+	return rvuo
+}
+
+// SetData sets the "data" field.
+func (rvuo *ResourceVersionUpdateOne) SetData(b []byte) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetData(b)
+	return rvuo
+}
+
+// SetCreatedAt sets the "createdAt" field.
+func (rvuo *ResourceVersionUpdateOne) SetCreatedAt(t time.Time) *ResourceVersionUpdateOne {
+	rvuo.mutation.SetCreatedAt(t)
+	return rvuo
+}
+
+// Mutation returns the ResourceVersionMutation object of the builder.
+func (rvuo *ResourceVersionUpdateOne) Mutation() *ResourceVersionMutation {
+	return rvuo.mutation
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (rvuo *ResourceVersionUpdateOne) Select(field string, fields ...string) *ResourceVersionUpdateOne {
+	rvuo.fields = append([]string{field}, fields...)
+	return rvuo
+}
+
+// Save executes the query and returns the updated ResourceVersion entity.
+func (rvuo *ResourceVersionUpdateOne) Save(ctx context.Context) (*ResourceVersion, error) {
+	var (
+		err  error
+		node *ResourceVersion
+	)
+	if len(rvuo.hooks) == 0 {
+		node, err = rvuo.sqlSave(ctx)
+	} else {
+		var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+			mutation, ok := m.(*ResourceVersionMutation)
+			if !ok {
+				return nil, fmt.Errorf("unexpected mutation type %T", m)
+			}
+			rvuo.mutation = mutation
+			node, err = rvuo.sqlSave(ctx)
+			mutation.done = true
+			return node, err
+		})
+		for i := len(rvuo.hooks) - 1; i >= 0; i-- {
+			if rvuo.hooks[i] == nil {
+				return nil, fmt.Errorf("ent: uninitialized hook (forgotten import ent/runtime?)")
+			}
+			mut = rvuo.hooks[i](mut)
+		}
+		v, err := mut.Mutate(ctx, rvuo.mutation)
+		if err != nil {
+			return nil, err
+		}
+		nv, ok := v.(*ResourceVersion)
+		if !ok {
+			return nil, fmt.Errorf("unexpected node type %T returned from ResourceVersionMutation", v)
+		}
+		node = nv
+	}
+	return node, err
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rvuo *ResourceVersionUpdateOne) SaveX(ctx context.Context) *ResourceVersion {
+	node, err := rvuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (rvuo *ResourceVersionUpdateOne) Exec(ctx context.Context) error {
+	_, err := rvuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rvuo *ResourceVersionUpdateOne) ExecX(ctx context.Context) {
+	if err := rvuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (rvuo *ResourceVersionUpdateOne) sqlSave(ctx context.Context) (_node *ResourceVersion, err error) {
+	_spec := &sqlgraph.UpdateSpec{
+		Node: &sqlgraph.NodeSpec{
+			Table:   resourceversion.Table,
+			Columns: resourceversion.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeInt,
+				Column: resourceversion.FieldID,
+			},
+		},
+	}
+	id, ok := rvuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ResourceVersion.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := rvuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, resourceversion.FieldID)
+		for _, f := range fields {
+			if !resourceversion.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != resourceversion.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := rvuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rvuo.mutation.ResourceType(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldResourceType,
+		})
+	}
+	if value, ok := rvuo.mutation.ResourceID(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeUUID,
+			Value:  value,
+			Column: resourceversion.FieldResourceID,
+		})
+	}
+	if value, ok := rvuo.mutation.Version(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: resourceversion.FieldVersion,
+		})
+	}
+	if value, ok := rvuo.mutation.AddedVersion(); ok {
+		_spec.Fields.Add = append(_spec.Fields.Add, &sqlgraph.FieldSpec{
+			Type:   field.TypeInt,
+			Value:  value,
+			Column: resourceversion.FieldVersion,
+		})
+	}
+	if value, ok := rvuo.mutation.Etag(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldEtag,
+		})
+	}
+	if rvuo.mutation.EtagCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: resourceversion.FieldEtag,
+		})
+	}
+	if value, ok := rvuo.mutation.Operation(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldOperation,
+		})
+	}
+	if value, ok := rvuo.mutation.Actor(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: resourceversion.FieldActor,
+		})
+	}
+	if rvuo.mutation.ActorCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: resourceversion.FieldActor,
+		})
+	}
+	if value, ok := rvuo.mutation.Data(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: resourceversion.FieldData,
+		})
+	}
+	if value, ok := rvuo.mutation.CreatedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: resourceversion.FieldCreatedAt,
+		})
+	}
+	_node = &ResourceVersion{config: rvuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, rvuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{resourceversion.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	return _node, nil
+}
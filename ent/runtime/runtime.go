@@ -40,6 +40,8 @@ func init() {
 	entitlementDescID := entitlementFields[0].Descriptor()
 	// entitlement.DefaultID holds the default value on creation for the id field.
 	entitlement.DefaultID = entitlementDescID.Default.(func() uuid.UUID)
+	groupHooks := schema.Group{}.Hooks()
+	group.Hooks[0] = groupHooks[0]
 	groupFields := schema.Group{}.Fields()
 	_ = groupFields
 	// groupDescID is the schema descriptor for id field.
@@ -78,6 +80,8 @@ func init() {
 	roleDescID := roleFields[0].Descriptor()
 	// role.DefaultID holds the default value on creation for the id field.
 	role.DefaultID = roleDescID.Default.(func() uuid.UUID)
+	userHooks := schema.User{}.Hooks()
+	user.Hooks[0] = userHooks[0]
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescPassword is the schema descriptor for password field.
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEntry records a provisioning operation. Entries are chained by
+// including the hash of the previous entry in their own hash, and the
+// uniqueness of prevHash keeps the chain from forking when entries are
+// appended concurrently
+type AuditEntry struct {
+	ent.Schema
+}
+
+func (AuditEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("timestamp").
+			SchemaType(map[string]string{
+				dialect.MySQL: "datetime(6)",
+			}),
+		field.String("clientName"),
+		field.String("operation"),
+		field.String("resourceType").
+			Optional(),
+		field.String("resourceID").
+			Optional(),
+		field.Strings("attributes").
+			Optional(),
+		field.Int("status"),
+		field.String("outcome"),
+		field.String("prevHash").
+			Unique(),
+		field.String("hash").
+			Unique(),
+	}
+}
+
+func (AuditEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("resourceType", "resourceID"),
+		index.Fields("clientName"),
+		index.Fields("timestamp"),
+	}
+}
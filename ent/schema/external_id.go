@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+)
+
+// ExternalIDClient adds a shadow column holding the name of the
+// provisioning client that set the externalId of a resource. As
+// externalId is defined by the provisioning client (RFC7643 Section 3.1),
+// its uniqueness (if enforced) is scoped to this client.
+//
+// externalId is also indexed, as connectors usually look up the
+// resources that they manage by their externalId
+type ExternalIDClient struct {
+	mixin.Schema
+}
+
+func (ExternalIDClient) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("externalIDClient").
+			Optional(),
+	}
+}
+
+func (ExternalIDClient) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("externalID"),
+		index.Fields("externalIDClient", "externalID"),
+	}
+}
@@ -3,6 +3,8 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
 )
 
 // Edges of the Group.
@@ -11,3 +13,30 @@ func (Group) Edges() []ent.Edge {
 		edge.To("members", Member.Type),
 	}
 }
+
+// Mixin of the Group.
+func (Group) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		NormalizedDisplayName{},
+		ExternalIDClient{},
+		SoftDelete{},
+	}
+}
+
+// NormalizedDisplayName adds a shadow column holding the case folded
+// displayName, which is folded in the same way as userName (see
+// NormalizedUserName). Upserts find Groups by this column, and it is
+// unique among the Groups that are not deleted when upserts match
+// Groups by displayName (see setupUniqueIndex in the server package).
+//
+// The value is maintained by the NormalizeDisplayName hook
+type NormalizedDisplayName struct {
+	mixin.Schema
+}
+
+func (NormalizedDisplayName) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("normalizedDisplayName").
+			Optional(),
+	}
+}
@@ -80,3 +80,43 @@ func UploadBlob() ent.Hook {
 	}
 	return hook.On(h, ent.OpCreate|ent.OpUpdate)
 }
+
+func (User) Hooks() []ent.Hook {
+	return []ent.Hook{
+		NormalizeUserName(),
+	}
+}
+
+// NormalizeUserName keeps the normalizedUserName column in sync with
+// userName. See NormalizedUserName
+func NormalizeUserName() ent.Hook {
+	h := func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (ent.Value, error) {
+			if v, ok := m.UserName(); ok {
+				m.SetNormalizedUserName(strings.ToLower(v))
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	return hook.On(h, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
+
+func (Group) Hooks() []ent.Hook {
+	return []ent.Hook{
+		NormalizeDisplayName(),
+	}
+}
+
+// NormalizeDisplayName keeps the normalizedDisplayName column in sync
+// with displayName. See NormalizedDisplayName
+func NormalizeDisplayName() ent.Hook {
+	h := func(next ent.Mutator) ent.Mutator {
+		return hook.GroupFunc(func(ctx context.Context, m *gen.GroupMutation) (ent.Value, error) {
+			if v, ok := m.DisplayName(); ok {
+				m.SetNormalizedDisplayName(strings.ToLower(v))
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	return hook.On(h, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
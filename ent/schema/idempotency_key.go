@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// IdempotencyKey records the response to a request sent with an
+// Idempotency-Key header, so that it can be replayed when the client
+// retries the request. Keys are scoped to the client that sent them.
+//
+// A record without a status is a request that is still being processed,
+// since reservedAt. The request holding the key is identified by the
+// random reservation token, as timestamps lose precision in storage
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+func (IdempotencyKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("clientName"),
+		field.String("key"),
+		field.String("requestHash"),
+		field.Int("status").
+			Optional(),
+		field.Bytes("header").
+			Optional(),
+		field.Bytes("body").
+			Optional(),
+		field.Time("createdAt"),
+		field.Time("expiresAt"),
+		field.Time("reservedAt").
+			Optional(),
+		field.String("reservation").
+			Optional(),
+	}
+}
+
+func (IdempotencyKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("clientName", "key").
+			Unique(),
+		index.Fields("expiresAt"),
+	}
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ResourceVersion is a snapshot of a User or a Group, recorded each time
+// that it is modified. Versions are not linked to the resource, so that
+// they outlive it
+type ResourceVersion struct {
+	ent.Schema
+}
+
+func (ResourceVersion) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("resourceType"),
+		field.UUID("resourceID", uuid.UUID{}),
+		field.Int("version"),
+		field.String("etag").
+			Optional(),
+		field.String("operation"),
+		field.String("actor").
+			Optional(),
+		field.Bytes("data"),
+		field.Time("createdAt"),
+	}
+}
+
+func (ResourceVersion) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("resourceType", "resourceID", "version").
+			Unique(),
+		index.Fields("resourceID", "createdAt"),
+	}
+}
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+)
+
+// SoftDelete adds the columns that turn a deleted resource into a
+// tombstone, which is hidden from reads but can be restored until it is
+// purged. The rows linking the resource to groups (and, for a group, to
+// its members) are moved into deletedMemberships, so that they no longer
+// show up in the resources that remain
+type SoftDelete struct {
+	mixin.Schema
+}
+
+func (SoftDelete) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deletedAt").
+			Optional().
+			Nillable(),
+		field.String("deletedBy").
+			Optional(),
+		field.Bytes("deletedMemberships").
+			Optional(),
+	}
+}
+
+func (SoftDelete) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("deletedAt"),
+	}
+}
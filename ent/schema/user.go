@@ -3,6 +3,8 @@ package schema
 import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
 )
 
 // Edges of the User.
@@ -20,3 +22,30 @@ func (User) Edges() []ent.Edge {
 		edge.To(`x509_certificates`, X509Certificate.Type),
 	}
 }
+
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		NormalizedUserName{},
+		ExternalIDClient{},
+		SoftDelete{},
+	}
+}
+
+// NormalizedUserName adds a shadow column holding the case folded
+// userName. As userName is not case exact (RFC7643 Section 4.1.1),
+// "Alice" and "alice" are the same user, and the uniqueness of
+// userName is enforced on this column instead.
+//
+// The value is maintained by the NormalizeUserName hook
+type NormalizedUserName struct {
+	mixin.Schema
+}
+
+func (NormalizedUserName) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("normalizedUserName").
+			Optional().
+			Unique(),
+	}
+}
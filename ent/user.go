@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/cybozu-go/scim-server/ent/names"
@@ -17,6 +18,16 @@ type User struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID uuid.UUID `json:"id,omitempty"`
+	// NormalizedUserName holds the value of the "normalizedUserName" field.
+	NormalizedUserName string `json:"normalizedUserName,omitempty"`
+	// ExternalIDClient holds the value of the "externalIDClient" field.
+	ExternalIDClient string `json:"externalIDClient,omitempty"`
+	// DeletedAt holds the value of the "deletedAt" field.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// DeletedBy holds the value of the "deletedBy" field.
+	DeletedBy string `json:"deletedBy,omitempty"`
+	// DeletedMemberships holds the value of the "deletedMemberships" field.
+	DeletedMemberships []byte `json:"deletedMemberships,omitempty"`
 	// Active holds the value of the "active" field.
 	Active bool `json:"active,omitempty"`
 	// DisplayName holds the value of the "displayName" field.
@@ -172,10 +183,14 @@ func (*User) scanValues(columns []string) ([]interface{}, error) {
 	values := make([]interface{}, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case user.FieldDeletedMemberships:
+			values[i] = new([]byte)
 		case user.FieldActive:
 			values[i] = new(sql.NullBool)
-		case user.FieldDisplayName, user.FieldExternalID, user.FieldLocale, user.FieldNickName, user.FieldPassword, user.FieldPreferredLanguage, user.FieldProfileURL, user.FieldTimezone, user.FieldTitle, user.FieldUserName, user.FieldUserType, user.FieldEtag:
+		case user.FieldNormalizedUserName, user.FieldExternalIDClient, user.FieldDeletedBy, user.FieldDisplayName, user.FieldExternalID, user.FieldLocale, user.FieldNickName, user.FieldPassword, user.FieldPreferredLanguage, user.FieldProfileURL, user.FieldTimezone, user.FieldTitle, user.FieldUserName, user.FieldUserType, user.FieldEtag:
 			values[i] = new(sql.NullString)
+		case user.FieldDeletedAt:
+			values[i] = new(sql.NullTime)
 		case user.FieldID, user.FieldNames:
 			values[i] = new(uuid.UUID)
 		default:
@@ -199,6 +214,37 @@ func (u *User) assignValues(columns []string, values []interface{}) error {
 			} else if value != nil {
 				u.ID = *value
 			}
+		case user.FieldNormalizedUserName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field normalizedUserName", values[i])
+			} else if value.Valid {
+				u.NormalizedUserName = value.String
+			}
+		case user.FieldExternalIDClient:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field externalIDClient", values[i])
+			} else if value.Valid {
+				u.ExternalIDClient = value.String
+			}
+		case user.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedAt", values[i])
+			} else if value.Valid {
+				u.DeletedAt = new(time.Time)
+				*u.DeletedAt = value.Time
+			}
+		case user.FieldDeletedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedBy", values[i])
+			} else if value.Valid {
+				u.DeletedBy = value.String
+			}
+		case user.FieldDeletedMemberships:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field deletedMemberships", values[i])
+			} else if value != nil {
+				u.DeletedMemberships = *value
+			}
 		case user.FieldActive:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field active", values[i])
@@ -356,6 +402,23 @@ func (u *User) String() string {
 	var builder strings.Builder
 	builder.WriteString("User(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("normalizedUserName=")
+	builder.WriteString(u.NormalizedUserName)
+	builder.WriteString(", ")
+	builder.WriteString("externalIDClient=")
+	builder.WriteString(u.ExternalIDClient)
+	builder.WriteString(", ")
+	if v := u.DeletedAt; v != nil {
+		builder.WriteString("deletedAt=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("deletedBy=")
+	builder.WriteString(u.DeletedBy)
+	builder.WriteString(", ")
+	builder.WriteString("deletedMemberships=")
+	builder.WriteString(fmt.Sprintf("%v", u.DeletedMemberships))
+	builder.WriteString(", ")
 	builder.WriteString("active=")
 	builder.WriteString(fmt.Sprintf("%v", u.Active))
 	builder.WriteString(", ")
@@ -3,6 +3,7 @@
 package user
 
 import (
+	"entgo.io/ent"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,16 @@ const (
 	Label = "user"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldNormalizedUserName holds the string denoting the normalizedusername field in the database.
+	FieldNormalizedUserName = "normalized_user_name"
+	// FieldExternalIDClient holds the string denoting the externalidclient field in the database.
+	FieldExternalIDClient = "external_id_client"
+	// FieldDeletedAt holds the string denoting the deletedat field in the database.
+	FieldDeletedAt = "deleted_at"
+	// FieldDeletedBy holds the string denoting the deletedby field in the database.
+	FieldDeletedBy = "deleted_by"
+	// FieldDeletedMemberships holds the string denoting the deletedmemberships field in the database.
+	FieldDeletedMemberships = "deleted_memberships"
 	// FieldActive holds the string denoting the active field in the database.
 	FieldActive = "active"
 	// FieldDisplayName holds the string denoting the displayname field in the database.
@@ -127,6 +138,11 @@ const (
 // Columns holds all SQL columns for user fields.
 var Columns = []string{
 	FieldID,
+	FieldNormalizedUserName,
+	FieldExternalIDClient,
+	FieldDeletedAt,
+	FieldDeletedBy,
+	FieldDeletedMemberships,
 	FieldActive,
 	FieldDisplayName,
 	FieldExternalID,
@@ -153,7 +169,14 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "github.com/cybozu-go/scim-server/ent/runtime"
+//
 var (
+	Hooks [1]ent.Hook
 	// PasswordValidator is a validator for the "password" field. It is called by the builders before save.
 	PasswordValidator func(string) error
 	// UserNameValidator is a validator for the "userName" field. It is called by the builders before save.
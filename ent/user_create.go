@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -29,6 +30,68 @@ type UserCreate struct {
 	hooks    []Hook
 }
 
+// SetNormalizedUserName sets the "normalizedUserName" field.
+func (uc *UserCreate) SetNormalizedUserName(s string) *UserCreate {
+	uc.mutation.SetNormalizedUserName(s)
+	return uc
+}
+
+// SetNillableNormalizedUserName sets the "normalizedUserName" field if the given value is not nil.
+func (uc *UserCreate) SetNillableNormalizedUserName(s *string) *UserCreate {
+	if s != nil {
+		uc.SetNormalizedUserName(*s)
+	}
+	return uc
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (uc *UserCreate) SetExternalIDClient(s string) *UserCreate {
+	uc.mutation.SetExternalIDClient(s)
+	return uc
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (uc *UserCreate) SetNillableExternalIDClient(s *string) *UserCreate {
+	if s != nil {
+		uc.SetExternalIDClient(*s)
+	}
+	return uc
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (uc *UserCreate) SetDeletedAt(t time.Time) *UserCreate {
+	uc.mutation.SetDeletedAt(t)
+	return uc
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDeletedAt(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetDeletedAt(*t)
+	}
+	return uc
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (uc *UserCreate) SetDeletedBy(s string) *UserCreate {
+	uc.mutation.SetDeletedBy(s)
+	return uc
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDeletedBy(s *string) *UserCreate {
+	if s != nil {
+		uc.SetDeletedBy(*s)
+	}
+	return uc
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (uc *UserCreate) SetDeletedMemberships(b []byte) *UserCreate {
+	uc.mutation.SetDeletedMemberships(b)
+	return uc
+}
+
 // SetActive sets the "active" field.
 func (uc *UserCreate) SetActive(b bool) *UserCreate {
 	uc.mutation.SetActive(b)
@@ -381,7 +444,9 @@ func (uc *UserCreate) Save(ctx context.Context) (*User, error) {
 		err  error
 		node *User
 	)
-	uc.defaults()
+	if err := uc.defaults(); err != nil {
+		return nil, err
+	}
 	if len(uc.hooks) == 0 {
 		if err = uc.check(); err != nil {
 			return nil, err
@@ -446,11 +511,15 @@ func (uc *UserCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (uc *UserCreate) defaults() {
+func (uc *UserCreate) defaults() error {
 	if _, ok := uc.mutation.ID(); !ok {
+		if user.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized user.DefaultID (forgotten import ent/runtime?)")
+		}
 		v := user.DefaultID()
 		uc.mutation.SetID(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -504,6 +573,46 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = &id
 	}
+	if value, ok := uc.mutation.NormalizedUserName(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldNormalizedUserName,
+		})
+		_node.NormalizedUserName = value
+	}
+	if value, ok := uc.mutation.ExternalIDClient(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldExternalIDClient,
+		})
+		_node.ExternalIDClient = value
+	}
+	if value, ok := uc.mutation.DeletedAt(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: user.FieldDeletedAt,
+		})
+		_node.DeletedAt = &value
+	}
+	if value, ok := uc.mutation.DeletedBy(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDeletedBy,
+		})
+		_node.DeletedBy = value
+	}
+	if value, ok := uc.mutation.DeletedMemberships(); ok {
+		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: user.FieldDeletedMemberships,
+		})
+		_node.DeletedMemberships = value
+	}
 	if value, ok := uc.mutation.Active(); ok {
 		_spec.Fields = append(_spec.Fields, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
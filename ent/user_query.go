@@ -45,6 +45,7 @@ type UserQuery struct {
 	withPhoneNumbers     *PhoneNumberQuery
 	withPhotos           *PhotoQuery
 	withX509Certificates *X509CertificateQuery
+	withMemberships      bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -581,12 +582,12 @@ func (uq *UserQuery) WithX509Certificates(opts ...func(*X509CertificateQuery)) *
 // Example:
 //
 //	var v []struct {
-//		Active bool `json:"active,omitempty"`
+//		NormalizedUserName string `json:"normalizedUserName,omitempty"`
 //		Count int `json:"count,omitempty"`
 //	}
 //
 //	client.User.Query().
-//		GroupBy(user.FieldActive).
+//		GroupBy(user.FieldNormalizedUserName).
 //		Aggregate(ent.Count()).
 //		Scan(ctx, &v)
 //
@@ -610,11 +611,11 @@ func (uq *UserQuery) GroupBy(field string, fields ...string) *UserGroupBy {
 // Example:
 //
 //	var v []struct {
-//		Active bool `json:"active,omitempty"`
+//		NormalizedUserName string `json:"normalizedUserName,omitempty"`
 //	}
 //
 //	client.User.Query().
-//		Select(user.FieldActive).
+//		Select(user.FieldNormalizedUserName).
 //		Scan(ctx, &v)
 //
 func (uq *UserQuery) Select(fields ...string) *UserSelect {
@@ -936,10 +937,12 @@ func (uq *UserQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*User, e
 		}
 	}
 
-	c := NewMemberClient(uq.config)
-	for _, node := range nodes {
-		if err := LoadMembership(ctx, c, node); err != nil {
-			return nil, err
+	if uq.withMemberships {
+		c := NewMemberClient(uq.config)
+		for _, node := range nodes {
+			if err := LoadMembership(ctx, c, node); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -1044,7 +1047,8 @@ func (uq *UserQuery) sqlQuery(ctx context.Context) *sql.Selector {
 }
 
 func recursiveLoadMembership(ctx context.Context, c *MemberClient, id string, depth int) ([]*Membership, error) {
-	directs, err := c.Query().Where(member.Value(id)).WithGroup().All(ctx)
+	// rows left without a group by older versions are ignored
+	directs, err := c.Query().Where(member.Value(id), member.HasGroup()).WithGroup().All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to load group membership: %w`, err)
 	}
@@ -1067,7 +1071,6 @@ func recursiveLoadMembership(ctx context.Context, c *MemberClient, id string, de
 		res = append(res, &Membership{
 			Value:   direct.Edges.Group.ID.String(),
 			Display: direct.Edges.Group.DisplayName,
-			Ref:     `https://foobar.com`,
 			Type:    typ,
 		})
 
@@ -1082,6 +1085,13 @@ func recursiveLoadMembership(ctx context.Context, c *MemberClient, id string, de
 	return res, nil
 }
 
+// WithMemberships tells the query-builder to load the groups that the
+// users are directly or indirectly members of into the Groups field.
+func (uq *UserQuery) WithMemberships() *UserQuery {
+	uq.withMemberships = true
+	return uq
+}
+
 func LoadMembership(ctx context.Context, c *MemberClient, node *User) error {
 	groups, err := recursiveLoadMembership(ctx, c, node.ID.String(), 0)
 	if err != nil {
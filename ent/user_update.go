@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -37,6 +38,98 @@ func (uu *UserUpdate) Where(ps ...predicate.User) *UserUpdate {
 	return uu
 }
 
+// SetNormalizedUserName sets the "normalizedUserName" field.
+func (uu *UserUpdate) SetNormalizedUserName(s string) *UserUpdate {
+	uu.mutation.SetNormalizedUserName(s)
+	return uu
+}
+
+// SetNillableNormalizedUserName sets the "normalizedUserName" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableNormalizedUserName(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetNormalizedUserName(*s)
+	}
+	return uu
+}
+
+// ClearNormalizedUserName clears the value of the "normalizedUserName" field.
+func (uu *UserUpdate) ClearNormalizedUserName() *UserUpdate {
+	uu.mutation.ClearNormalizedUserName()
+	return uu
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (uu *UserUpdate) SetExternalIDClient(s string) *UserUpdate {
+	uu.mutation.SetExternalIDClient(s)
+	return uu
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableExternalIDClient(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetExternalIDClient(*s)
+	}
+	return uu
+}
+
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (uu *UserUpdate) ClearExternalIDClient() *UserUpdate {
+	uu.mutation.ClearExternalIDClient()
+	return uu
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (uu *UserUpdate) SetDeletedAt(t time.Time) *UserUpdate {
+	uu.mutation.SetDeletedAt(t)
+	return uu
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDeletedAt(t *time.Time) *UserUpdate {
+	if t != nil {
+		uu.SetDeletedAt(*t)
+	}
+	return uu
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (uu *UserUpdate) ClearDeletedAt() *UserUpdate {
+	uu.mutation.ClearDeletedAt()
+	return uu
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (uu *UserUpdate) SetDeletedBy(s string) *UserUpdate {
+	uu.mutation.SetDeletedBy(s)
+	return uu
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDeletedBy(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetDeletedBy(*s)
+	}
+	return uu
+}
+
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (uu *UserUpdate) ClearDeletedBy() *UserUpdate {
+	uu.mutation.ClearDeletedBy()
+	return uu
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (uu *UserUpdate) SetDeletedMemberships(b []byte) *UserUpdate {
+	uu.mutation.SetDeletedMemberships(b)
+	return uu
+}
+
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (uu *UserUpdate) ClearDeletedMemberships() *UserUpdate {
+	uu.mutation.ClearDeletedMemberships()
+	return uu
+}
+
 // SetActive sets the "active" field.
 func (uu *UserUpdate) SetActive(b bool) *UserUpdate {
 	uu.mutation.SetActive(b)
@@ -714,6 +807,71 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := uu.mutation.NormalizedUserName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldNormalizedUserName,
+		})
+	}
+	if uu.mutation.NormalizedUserNameCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldNormalizedUserName,
+		})
+	}
+	if value, ok := uu.mutation.ExternalIDClient(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldExternalIDClient,
+		})
+	}
+	if uu.mutation.ExternalIDClientCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldExternalIDClient,
+		})
+	}
+	if value, ok := uu.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: user.FieldDeletedAt,
+		})
+	}
+	if uu.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: user.FieldDeletedAt,
+		})
+	}
+	if value, ok := uu.mutation.DeletedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDeletedBy,
+		})
+	}
+	if uu.mutation.DeletedByCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDeletedBy,
+		})
+	}
+	if value, ok := uu.mutation.DeletedMemberships(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: user.FieldDeletedMemberships,
+		})
+	}
+	if uu.mutation.DeletedMembershipsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: user.FieldDeletedMemberships,
+		})
+	}
 	if value, ok := uu.mutation.Active(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
@@ -1376,6 +1534,98 @@ type UserUpdateOne struct {
 	mutation *UserMutation
 }
 
+// SetNormalizedUserName sets the "normalizedUserName" field.
+func (uuo *UserUpdateOne) SetNormalizedUserName(s string) *UserUpdateOne {
+	uuo.mutation.SetNormalizedUserName(s)
+	return uuo
+}
+
+// SetNillableNormalizedUserName sets the "normalizedUserName" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableNormalizedUserName(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetNormalizedUserName(*s)
+	}
+	return uuo
+}
+
+// ClearNormalizedUserName clears the value of the "normalizedUserName" field.
+func (uuo *UserUpdateOne) ClearNormalizedUserName() *UserUpdateOne {
+	uuo.mutation.ClearNormalizedUserName()
+	return uuo
+}
+
+// SetExternalIDClient sets the "externalIDClient" field.
+func (uuo *UserUpdateOne) SetExternalIDClient(s string) *UserUpdateOne {
+	uuo.mutation.SetExternalIDClient(s)
+	return uuo
+}
+
+// SetNillableExternalIDClient sets the "externalIDClient" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableExternalIDClient(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetExternalIDClient(*s)
+	}
+	return uuo
+}
+
+// ClearExternalIDClient clears the value of the "externalIDClient" field.
+func (uuo *UserUpdateOne) ClearExternalIDClient() *UserUpdateOne {
+	uuo.mutation.ClearExternalIDClient()
+	return uuo
+}
+
+// SetDeletedAt sets the "deletedAt" field.
+func (uuo *UserUpdateOne) SetDeletedAt(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetDeletedAt(t)
+	return uuo
+}
+
+// SetNillableDeletedAt sets the "deletedAt" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDeletedAt(t *time.Time) *UserUpdateOne {
+	if t != nil {
+		uuo.SetDeletedAt(*t)
+	}
+	return uuo
+}
+
+// ClearDeletedAt clears the value of the "deletedAt" field.
+func (uuo *UserUpdateOne) ClearDeletedAt() *UserUpdateOne {
+	uuo.mutation.ClearDeletedAt()
+	return uuo
+}
+
+// SetDeletedBy sets the "deletedBy" field.
+func (uuo *UserUpdateOne) SetDeletedBy(s string) *UserUpdateOne {
+	uuo.mutation.SetDeletedBy(s)
+	return uuo
+}
+
+// SetNillableDeletedBy sets the "deletedBy" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDeletedBy(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetDeletedBy(*s)
+	}
+	return uuo
+}
+
+// ClearDeletedBy clears the value of the "deletedBy" field.
+func (uuo *UserUpdateOne) ClearDeletedBy() *UserUpdateOne {
+	uuo.mutation.ClearDeletedBy()
+	return uuo
+}
+
+// SetDeletedMemberships sets the "deletedMemberships" field.
+func (uuo *UserUpdateOne) SetDeletedMemberships(b []byte) *UserUpdateOne {
+	uuo.mutation.SetDeletedMemberships(b)
+	return uuo
+}
+
+// ClearDeletedMemberships clears the value of the "deletedMemberships" field.
+func (uuo *UserUpdateOne) ClearDeletedMemberships() *UserUpdateOne {
+	uuo.mutation.ClearDeletedMemberships()
+	return uuo
+}
+
 // SetActive sets the "active" field.
 func (uuo *UserUpdateOne) SetActive(b bool) *UserUpdateOne {
 	uuo.mutation.SetActive(b)
@@ -2083,6 +2333,71 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 			}
 		}
 	}
+	if value, ok := uuo.mutation.NormalizedUserName(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldNormalizedUserName,
+		})
+	}
+	if uuo.mutation.NormalizedUserNameCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldNormalizedUserName,
+		})
+	}
+	if value, ok := uuo.mutation.ExternalIDClient(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldExternalIDClient,
+		})
+	}
+	if uuo.mutation.ExternalIDClientCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldExternalIDClient,
+		})
+	}
+	if value, ok := uuo.mutation.DeletedAt(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Value:  value,
+			Column: user.FieldDeletedAt,
+		})
+	}
+	if uuo.mutation.DeletedAtCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeTime,
+			Column: user.FieldDeletedAt,
+		})
+	}
+	if value, ok := uuo.mutation.DeletedBy(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Value:  value,
+			Column: user.FieldDeletedBy,
+		})
+	}
+	if uuo.mutation.DeletedByCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeString,
+			Column: user.FieldDeletedBy,
+		})
+	}
+	if value, ok := uuo.mutation.DeletedMemberships(); ok {
+		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Value:  value,
+			Column: user.FieldDeletedMemberships,
+		})
+	}
+	if uuo.mutation.DeletedMembershipsCleared() {
+		_spec.Fields.Clear = append(_spec.Fields.Clear, &sqlgraph.FieldSpec{
+			Type:   field.TypeBytes,
+			Column: user.FieldDeletedMemberships,
+		})
+	}
 	if value, ok := uuo.mutation.Active(); ok {
 		_spec.Fields.Set = append(_spec.Fields.Set, &sqlgraph.FieldSpec{
 			Type:   field.TypeBool,
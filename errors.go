@@ -0,0 +1,118 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim/filter"
+	"github.com/cybozu-go/scim/resource"
+)
+
+// The functions in this file build SCIM errors (RFC7644 Section 3.12),
+// so that clients receive an appropriate HTTP status and scimType
+// instead of a generic 500 Internal Server Error.
+
+func notFound(resourceType, id string) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusNotFound).
+		ScimType(resource.ErrUnknown).
+		Detail(fmt.Sprintf(`%s %q not found`, resourceType, id)).
+		MustBuild()
+}
+
+func invalidFilter(err error) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusBadRequest).
+		ScimType(resource.ErrInvalidFilter).
+		Detail(fmt.Sprintf(`invalid filter: %s`, err)).
+		MustBuild()
+}
+
+func invalidPath(path string, err error) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusBadRequest).
+		ScimType(resource.ErrInvalidPath).
+		Detail(fmt.Sprintf(`invalid path %q: %s`, path, err)).
+		MustBuild()
+}
+
+func invalidValue(err error) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusBadRequest).
+		ScimType(resource.ErrInvalidValue).
+		Detail(err.Error()).
+		MustBuild()
+}
+
+func uniquenessError(err error) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusConflict).
+		ScimType(resource.ErrUniqueness).
+		Detail(fmt.Sprintf(`one or more unique attribute values are already in use: %s`, err)).
+		MustBuild()
+}
+
+func internalError(err error) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusInternalServerError).
+		ScimType(resource.ErrUnknown).
+		Detail(err.Error()).
+		MustBuild()
+}
+
+// validatePatchPath makes sure that the path of a PATCH operation
+// can be parsed, so that malformed paths are reported as such
+func validatePatchPath(op *resource.PatchOperation) error {
+	if op.Path() == "" {
+		return nil
+	}
+	if _, err := filter.Parse(op.Path(), filter.WithPatchExpression(true)); err != nil {
+		return invalidPath(op.Path(), err)
+	}
+	return nil
+}
+
+// asSCIMError extracts the SCIM error from err, if err is (or wraps)
+// an error that has already been converted to a SCIM error
+func asSCIMError(err error) (*resource.Error, bool) {
+	var serr *resource.Error
+	if errors.As(err, &serr) {
+		return serr, true
+	}
+	return nil, false
+}
+
+// convertError converts an arbitrary error into a SCIM error:
+//
+//   - SCIM errors (possibly wrapped) are returned as is
+//   - ent.NotFoundError is mapped to 404
+//   - ent.ConstraintError (e.g. duplicate userName) is mapped to 409 uniqueness
+//   - ent.ValidationError is mapped to 400 invalidValue
+//
+// Anything else is passed to fallback, which is usually internalError.
+func convertError(err error, fallback func(error) error) error {
+	if err == nil {
+		return nil
+	}
+
+	if serr, ok := asSCIMError(err); ok {
+		return serr
+	}
+
+	switch {
+	case ent.IsNotFound(err):
+		return resource.NewErrorBuilder().
+			Status(http.StatusNotFound).
+			ScimType(resource.ErrUnknown).
+			Detail(err.Error()).
+			MustBuild()
+	case ent.IsConstraintError(err):
+		return uniquenessError(err)
+	case ent.IsValidationError(err):
+		return invalidValue(err)
+	default:
+		return fallback(err)
+	}
+}
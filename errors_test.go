@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+
+	var in resource.User
+	decode(t, `{"userName":"alice"}`, &in)
+	u, err := b.CreateUser(ctx, &in)
+	require.NoError(t, err, `CreateUser should succeed`)
+
+	const unknownID = "5b6a1f0e-2a2b-4a8e-9d6e-6b1f3c1d2e3f"
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := b.RetrieveUser(ctx, unknownID, nil, nil)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		_, err = b.RetrieveGroup(ctx, unknownID, nil, nil)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		err = b.DeleteUser(ctx, unknownID)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		err = b.DeleteGroup(ctx, unknownID)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		var patch resource.PatchRequest
+		decode(t, `{"Operations":[{"op":"add","path":"displayName","value":"Alice"}]}`, &patch)
+		_, err = b.PatchUser(ctx, unknownID, &patch)
+		requireSCIMError(t, err, http.StatusNotFound)
+	})
+	t.Run("malformed id", func(t *testing.T) {
+		_, err := b.RetrieveUser(ctx, "not-a-uuid", nil, nil)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		err = b.DeleteGroup(ctx, "not-a-uuid")
+		requireSCIMError(t, err, http.StatusNotFound)
+	})
+	t.Run("uniqueness", func(t *testing.T) {
+		var dup resource.User
+		decode(t, `{"userName":"alice"}`, &dup)
+		_, err := b.CreateUser(ctx, &dup)
+		serr := requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		var req resource.SearchRequest
+		decode(t, `{"filter":"userName eq"}`, &req)
+		_, err := b.SearchUser(ctx, &req)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `scimType should be invalidFilter`)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		var patch resource.PatchRequest
+		decode(t, `{"Operations":[{"op":"add","path":"emails[type eq","value":"x"}]}`, &patch)
+		_, err := b.PatchUser(ctx, u.ID(), &patch)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidPath, serr.ScimType(), `scimType should be invalidPath`)
+	})
+	t.Run("invalid value", func(t *testing.T) {
+		var bad resource.User
+		decode(t, `{"userName":""}`, &bad)
+		_, err := b.CreateUser(ctx, &bad)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidValue, serr.ScimType(), `scimType should be invalidValue`)
+	})
+}
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/filter"
+)
+
+// FilterExplanation describes how a filter is translated into SQL
+// queries. It is meant to help understand slow or surprising searches
+type FilterExplanation struct {
+	Filter    string                 `json:"filter"`
+	AST       interface{}            `json:"ast"`
+	Resources []*ResourceExplanation `json:"resources"`
+}
+
+// ResourceExplanation describes the query run against the resources
+// of a single type
+type ResourceExplanation struct {
+	ResourceType string `json:"resourceType"`
+
+	// Predicates lists the clauses of the filter that were translated,
+	// and Dropped the ones that could not be. Error is set if the
+	// search would be rejected because of the latter, which is the
+	// case unless the Backend is lenient (see WithLenientFilters)
+	Predicates []string        `json:"predicates"`
+	Dropped    []DroppedClause `json:"dropped,omitempty"`
+	Error      string          `json:"error,omitempty"`
+
+	// SQL is the query matching the resources, and QueryPlan the
+	// output of EXPLAIN QUERY PLAN for it (SQLite only)
+	SQL       string        `json:"sql"`
+	Args      []interface{} `json:"args"`
+	QueryPlan []string      `json:"queryPlan,omitempty"`
+}
+
+// DroppedClause is a clause of a filter that could not be translated
+type DroppedClause struct {
+	Clause string `json:"clause"`
+	Reason string `json:"reason"`
+}
+
+// ExplainFilter describes how a filter is translated when searching
+// resources of the given type ("User", "Group", or "" for a search at
+// the root level). The same authorization rules as for searches apply
+func (b *Backend) ExplainFilter(ctx context.Context, resourceType, src string) (*FilterExplanation, error) {
+	var explainUser, explainGroup bool
+	switch resourceType {
+	case "":
+		explainUser = b.canRead(ctx, ScopeUsersRead)
+		explainGroup = b.canRead(ctx, ScopeGroupsRead)
+		if !explainUser && !explainGroup {
+			return nil, b.authorize(ctx, ScopeUsersRead)
+		}
+	case `User`:
+		if err := b.authorize(ctx, ScopeUsersRead); err != nil {
+			return nil, err
+		}
+		explainUser = true
+	case `Group`:
+		if err := b.authorize(ctx, ScopeGroupsRead); err != nil {
+			return nil, err
+		}
+		explainGroup = true
+	default:
+		return nil, invalidValue(fmt.Errorf(`unknown resource type %q`, resourceType))
+	}
+
+	expr, err := filter.Parse(src)
+	if err != nil {
+		return nil, invalidFilter(err)
+	}
+
+	ex := &FilterExplanation{
+		Filter: src,
+		AST:    describeExpr(expr),
+	}
+
+	// Each resource type is compiled on its own, so that a clause
+	// that only fails for one of them does not hide the other
+	if explainUser {
+		v := filterVisitor{
+			uq:      b.db.User.Query(),
+			users:   []userBinder{},
+			policy:  attributePolicyFromContext(ctx),
+			lenient: true,
+		}
+		if err := v.visit(expr); err != nil {
+			return nil, convertError(err, invalidFilter)
+		}
+
+		t := sql.Table(user.Table)
+		sel := sql.Dialect(b.dialect).Select(t.Columns(user.Columns...)...).From(t)
+		preds, err := bindUsers(v.args, v.users)
+		if err != nil {
+			return nil, convertError(err, invalidFilter)
+		}
+		preds = append(preds, user.DeletedAtIsNil())
+		for _, p := range preds {
+			p(sel)
+		}
+		sel.OrderBy(t.C(user.FieldID))
+
+		r, err := b.explainQuery(ctx, `User`, &v, sel)
+		if err != nil {
+			return nil, err
+		}
+		ex.Resources = append(ex.Resources, r)
+	}
+
+	if explainGroup {
+		v := filterVisitor{
+			gq:      b.db.Group.Query(),
+			groups:  []groupBinder{},
+			policy:  attributePolicyFromContext(ctx),
+			lenient: true,
+		}
+		if err := v.visit(expr); err != nil {
+			return nil, convertError(err, invalidFilter)
+		}
+
+		t := sql.Table(group.Table)
+		sel := sql.Dialect(b.dialect).Select(t.Columns(group.Columns...)...).From(t)
+		preds, err := bindGroups(v.args, v.groups)
+		if err != nil {
+			return nil, convertError(err, invalidFilter)
+		}
+		preds = append(preds, group.DeletedAtIsNil())
+		for _, p := range preds {
+			p(sel)
+		}
+		sel.OrderBy(t.C(group.FieldID))
+
+		r, err := b.explainQuery(ctx, `Group`, &v, sel)
+		if err != nil {
+			return nil, err
+		}
+		ex.Resources = append(ex.Resources, r)
+	}
+
+	return ex, nil
+}
+
+func (b *Backend) explainQuery(ctx context.Context, resourceType string, v *filterVisitor, sel *sql.Selector) (*ResourceExplanation, error) {
+	query, args := sel.Query()
+	r := &ResourceExplanation{
+		ResourceType: resourceType,
+		Predicates:   v.compiled,
+		Dropped:      v.dropped,
+		SQL:          query,
+		Args:         args,
+	}
+	if r.Predicates == nil {
+		r.Predicates = []string{}
+	}
+	if r.Args == nil {
+		r.Args = []interface{}{}
+	}
+	if len(v.dropped) > 0 && !b.lenient {
+		r.Error = fmt.Sprintf(`search would be rejected: %s`, v.dropped[0].Reason)
+	}
+
+	if b.dialect == dialect.SQLite {
+		plan, err := b.queryPlan(ctx, query, args)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to explain query: %w`, err))
+		}
+		r.QueryPlan = plan
+	}
+	return r, nil
+}
+
+// queryPlan runs EXPLAIN QUERY PLAN for a SQLite query. Steps are
+// indented according to their depth in the plan
+func (b *Backend) queryPlan(ctx context.Context, query string, args []interface{}) ([]string, error) {
+	rows, err := b.sqldb.QueryContext(ctx, `EXPLAIN QUERY PLAN `+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depths := make(map[int]int)
+	var plan []string
+	for rows.Next() {
+		var id, parent, notused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notused, &detail); err != nil {
+			return nil, err
+		}
+
+		var depth int
+		if parent != 0 {
+			depth = depths[parent] + 1
+		}
+		depths[id] = depth
+		plan = append(plan, strings.Repeat(`  `, depth)+detail)
+	}
+	return plan, rows.Err()
+}
+
+// describeExpr converts a filter expression to a tree of JSON objects
+func describeExpr(expr filter.Expr) interface{} {
+	switch expr := expr.(type) {
+	case filter.PresenceExpr:
+		return map[string]interface{}{
+			`type`:     `presence`,
+			`attr`:     operandString(expr.Attr()),
+			`operator`: expr.Operator(),
+		}
+	case filter.CompareExpr:
+		return map[string]interface{}{
+			`type`:     `comparison`,
+			`attr`:     operandString(expr.LHE()),
+			`operator`: expr.Operator(),
+			`value`:    operandValue(expr.RHE()),
+		}
+	case filter.RegexExpr:
+		return map[string]interface{}{
+			`type`:     `comparison`,
+			`attr`:     operandString(expr.LHE()),
+			`operator`: expr.Operator(),
+			`value`:    operandValue(expr.Value()),
+		}
+	case filter.LogExpr:
+		return map[string]interface{}{
+			`type`:     `logical`,
+			`operator`: expr.Operator(),
+			`left`:     describeExpr(expr.LHE()),
+			`right`:    describeExpr(expr.RHS()),
+		}
+	case filter.ValuePath:
+		return map[string]interface{}{
+			`type`:   `valuePath`,
+			`attr`:   operandString(expr.ParentAttr()),
+			`filter`: describeExpr(expr.SubExpr()),
+		}
+	case filter.ParenExpr:
+		return map[string]interface{}{
+			`type`: `group`,
+			`expr`: describeExpr(expr.SubExpr()),
+		}
+	default:
+		return fmt.Sprintf(`%T`, expr)
+	}
+}
+
+// clauseString converts a filter expression back to its textual form
+func clauseString(expr filter.Expr) string {
+	switch expr := expr.(type) {
+	case filter.PresenceExpr:
+		return fmt.Sprintf(`%s %s`, operandString(expr.Attr()), expr.Operator())
+	case filter.CompareExpr:
+		return fmt.Sprintf(`%s %s %s`, operandString(expr.LHE()), expr.Operator(), literalString(expr.RHE()))
+	case filter.RegexExpr:
+		return fmt.Sprintf(`%s %s %s`, operandString(expr.LHE()), expr.Operator(), literalString(expr.Value()))
+	case filter.LogExpr:
+		return fmt.Sprintf(`%s %s %s`, clauseString(expr.LHE()), expr.Operator(), clauseString(expr.RHS()))
+	case filter.ValuePath:
+		return fmt.Sprintf(`%s[%s]`, operandString(expr.ParentAttr()), clauseString(expr.SubExpr()))
+	case filter.ParenExpr:
+		return fmt.Sprintf(`(%s)`, clauseString(expr.SubExpr()))
+	default:
+		return fmt.Sprintf(`%v`, expr)
+	}
+}
+
+func operandValue(v interface{}) interface{} {
+	if lit, err := exprAttr(v); err == nil {
+		return lit
+	}
+	return fmt.Sprintf(`%v`, v)
+}
+
+func operandString(v interface{}) string {
+	return fmt.Sprintf(`%v`, operandValue(v))
+}
+
+// literalString formats the value in a comparison as in a filter,
+// with strings in double quotes
+func literalString(v interface{}) string {
+	lit := operandValue(v)
+	if s, ok := lit.(string); ok {
+		buf, _ := json.Marshal(s)
+		return string(buf)
+	}
+	return fmt.Sprintf(`%v`, lit)
+}
+
+// ExplainHandler returns an http.Handler serving the result of
+// ExplainFilter as JSON, for GET requests with the "filter" and
+// (optionally) "resourceType" query parameters. It is meant for
+// administrators, and must be wrapped with Authenticate
+func ExplainHandler(b *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set(`Allow`, http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		q := r.URL.Query()
+		ex, err := b.ExplainFilter(r.Context(), q.Get(`resourceType`), q.Get(`filter`))
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+
+		w.Header().Set(`Content-Type`, `application/json`)
+		_ = json.NewEncoder(w).Encode(ex)
+	})
+}
@@ -0,0 +1,90 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainFilter(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+
+	t.Run("compiled and dropped clauses", func(t *testing.T) {
+		ex, err := b.ExplainFilter(ctx, `User`, `userName eq "alice" and name.bogus eq "x"`)
+		require.NoError(t, err, `ExplainFilter should succeed`)
+		require.NotNil(t, ex.AST, `AST should be described`)
+		require.Len(t, ex.Resources, 1, `only Users should be explained`)
+
+		r := ex.Resources[0]
+		require.Equal(t, `User`, r.ResourceType, `resource type should be User`)
+		require.Equal(t, []string{`userName eq "alice"`}, r.Predicates, `translated clauses should be listed`)
+		require.Len(t, r.Dropped, 1, `untranslated clause should be listed`)
+		require.Equal(t, `name.bogus eq "x"`, r.Dropped[0].Clause, `dropped clause should be named`)
+		require.NotEmpty(t, r.Error, `search should be reported as rejected`)
+		require.Contains(t, r.SQL, `users`, `SQL should query the users table`)
+		require.NotEmpty(t, r.Args, `SQL arguments should be returned`)
+		require.NotEmpty(t, r.QueryPlan, `query plan should be returned`)
+
+		lenient := newBackend(t, server.WithLenientFilters(true))
+		ex, err = lenient.ExplainFilter(ctx, `User`, `userName eq "alice" and name.bogus eq "x"`)
+		require.NoError(t, err, `ExplainFilter should succeed`)
+		require.Len(t, ex.Resources[0].Dropped, 1, `untranslated clause should be listed`)
+		require.Empty(t, ex.Resources[0].Error, `search should not be rejected when lenient`)
+	})
+	t.Run("root level", func(t *testing.T) {
+		ex, err := b.ExplainFilter(ctx, ``, `userName eq "alice"`)
+		require.NoError(t, err, `ExplainFilter should succeed`)
+		require.Len(t, ex.Resources, 2, `both resource types should be explained`)
+		require.Equal(t, `User`, ex.Resources[0].ResourceType, `Users should come first`)
+		require.Equal(t, `Group`, ex.Resources[1].ResourceType, `Groups should come second`)
+		require.Contains(t, ex.Resources[1].SQL, `groups`, `SQL should query the groups table`)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := b.ExplainFilter(ctx, `Bogus`, `userName eq "alice"`)
+		requireSCIMError(t, err, http.StatusBadRequest)
+
+		_, err = b.ExplainFilter(ctx, `User`, `userName eq`)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `scimType should be invalidFilter`)
+
+		_, err = b.ExplainFilter(as("reader", server.ScopeGroupsRead), `User`, `userName eq "alice"`)
+		requireSCIMError(t, err, http.StatusForbidden)
+	})
+}
+
+func TestExplainHandler(t *testing.T) {
+	h := server.ExplainHandler(newBackend(t))
+
+	get := func(t *testing.T, method string, q url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/_explain?"+q.Encode(), nil))
+		return w
+	}
+
+	w := get(t, http.MethodGet, url.Values{"resourceType": {"User"}, "filter": {`displayName co "a"`}})
+	require.Equal(t, http.StatusOK, w.Code, `status should be 200`)
+	require.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"), `response should be JSON`)
+	var ex server.FilterExplanation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &ex), `response should be an explanation`)
+	require.Equal(t, `displayName co "a"`, ex.Filter, `filter should be returned`)
+	require.Len(t, ex.Resources, 1, `only Users should be explained`)
+	require.Equal(t, []string{`displayName co "a"`}, ex.Resources[0].Predicates, `translated clauses should be listed`)
+
+	w = get(t, http.MethodGet, url.Values{"filter": {`displayName co`}})
+	require.Equal(t, http.StatusBadRequest, w.Code, `invalid filters should be rejected`)
+
+	w = get(t, http.MethodPost, url.Values{"filter": {`displayName co "a"`}})
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code, `only GET should be allowed`)
+	require.Equal(t, http.MethodGet, w.Header().Get("Allow"), `Allow should list GET`)
+}
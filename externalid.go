@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/hook"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/google/uuid"
+)
+
+// externalId is defined by the provisioning client (RFC7643 Section 3.1),
+// so the name of the client that sets it is recorded along with it (see
+// the ExternalIDClient mixin). Resources created through contexts that
+// do not carry a principal, as well as resources whose externalId was set
+// before the client was recorded, belong to the client named ""
+
+func clientName(ctx context.Context) string {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.Name()
+	}
+	return ""
+}
+
+func setupExternalIDClient(client *ent.Client) {
+	client.User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			if _, ok := m.ExternalID(); ok {
+				m.SetExternalIDClient(clientName(ctx))
+			} else if m.ExternalIDCleared() {
+				m.ClearExternalIDClient()
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+	client.Group.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.GroupFunc(func(ctx context.Context, m *ent.GroupMutation) (ent.Value, error) {
+			if _, ok := m.ExternalID(); ok {
+				m.SetExternalIDClient(clientName(ctx))
+			} else if m.ExternalIDCleared() {
+				m.ClearExternalIDClient()
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+}
+
+// externalIDIndexes back the uniqueness of externalId (see
+// WithExternalIDUniqueness), so that concurrent writes cannot both pass
+// checkUserExternalID or checkGroupExternalID. Resources without a
+// client belong to the client named "", as in userExternalID
+var externalIDIndexes = []uniqueIndex{
+	{
+		name:    `users_external_id_unique`,
+		table:   user.Table,
+		columns: fmt.Sprintf(`COALESCE(%s, ''), %s`, user.FieldExternalIDClient, user.FieldExternalID),
+	},
+	{
+		name:    `groups_external_id_unique`,
+		table:   group.Table,
+		columns: fmt.Sprintf(`COALESCE(%s, ''), %s`, group.FieldExternalIDClient, group.FieldExternalID),
+	},
+}
+
+// userExternalID and groupExternalID match the resources with the given
+// externalId, as set by the client. Deleted resources never match
+func userExternalID(client, externalID string) predicate.User {
+	owner := user.ExternalIDClientEQ(client)
+	if client == "" {
+		owner = user.Or(owner, user.ExternalIDClientIsNil())
+	}
+	return user.And(user.ExternalIDEQ(externalID), owner, user.DeletedAtIsNil())
+}
+
+func groupExternalID(client, externalID string) predicate.Group {
+	owner := group.ExternalIDClientEQ(client)
+	if client == "" {
+		owner = group.Or(owner, group.ExternalIDClientIsNil())
+	}
+	return group.And(group.ExternalIDEQ(externalID), owner, group.DeletedAtIsNil())
+}
+
+// checkUserExternalID makes sure that no other user has the externalId
+// of a User being created or replaced (id is empty when creating).
+// This is only enforced if the Backend is configured to do so
+func (b *Backend) checkUserExternalID(ctx context.Context, in *resource.User, id string) error {
+	if !b.uniqueExternalID || !in.HasExternalID() {
+		return nil
+	}
+
+	q := b.db.User.Query().Where(userExternalID(clientName(ctx), in.ExternalID()))
+	if parsed, err := uuid.Parse(id); err == nil {
+		q.Where(user.IDNEQ(parsed))
+	}
+	exists, err := q.Exist(ctx)
+	if err != nil {
+		return internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+	}
+	if exists {
+		return uniquenessError(fmt.Errorf(`externalId %q is already used by another User`, in.ExternalID()))
+	}
+	return nil
+}
+
+func (b *Backend) checkGroupExternalID(ctx context.Context, in *resource.Group, id string) error {
+	if !b.uniqueExternalID || !in.HasExternalID() {
+		return nil
+	}
+
+	q := b.db.Group.Query().Where(groupExternalID(clientName(ctx), in.ExternalID()))
+	if parsed, err := uuid.Parse(id); err == nil {
+		q.Where(group.IDNEQ(parsed))
+	}
+	exists, err := q.Exist(ctx)
+	if err != nil {
+		return internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+	}
+	if exists {
+		return uniquenessError(fmt.Errorf(`externalId %q is already used by another Group`, in.ExternalID()))
+	}
+	return nil
+}
+
+// RetrieveUserByExternalID returns the User whose externalId was set
+// to the given value by the calling client. The lookup uses the index on
+// externalId, and is meant for connectors that keep track of resources
+// by their own identifiers rather than the ones assigned by the server.
+// If several users match (which is only possible when uniqueness is not
+// enforced, see WithExternalIDUniqueness), a uniqueness error is returned
+func (b *Backend) RetrieveUserByExternalID(ctx context.Context, externalID string, fields []string, excludedFields []string) (*resource.User, error) {
+	if err := b.authorize(ctx, ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	id, err := b.userIDByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	return b.retrieveUser(ctx, id, fields, excludedFields)
+}
+
+// RetrieveGroupByExternalID is the equivalent of RetrieveUserByExternalID
+// for Groups
+func (b *Backend) RetrieveGroupByExternalID(ctx context.Context, externalID string, fields []string, excludedFields []string) (*resource.Group, error) {
+	if err := b.authorize(ctx, ScopeGroupsRead); err != nil {
+		return nil, err
+	}
+	id, err := b.groupIDByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	return b.retrieveGroup(ctx, id, fields, excludedFields)
+}
+
+// userIDByExternalID and groupIDByExternalID resolve the ID of the
+// resource whose externalId was set to the given value by the client.
+// Clients that cannot read externalId cannot resolve it either
+func (b *Backend) userIDByExternalID(ctx context.Context, externalID string) (string, error) {
+	if !attributePolicyFromContext(ctx).CanRead(`User`, resource.UserExternalIDKey) {
+		return "", notFound(`User`, externalID)
+	}
+
+	ids, err := b.db.User.Query().
+		Where(userExternalID(clientName(ctx), externalID)).
+		Limit(2).
+		IDs(ctx)
+	if err != nil {
+		return "", internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+	}
+	switch len(ids) {
+	case 0:
+		return "", notFound(`User`, externalID)
+	case 1:
+		return ids[0].String(), nil
+	default:
+		return "", uniquenessError(fmt.Errorf(`externalId %q is used by several Users`, externalID))
+	}
+}
+
+func (b *Backend) groupIDByExternalID(ctx context.Context, externalID string) (string, error) {
+	if !attributePolicyFromContext(ctx).CanRead(`Group`, resource.GroupExternalIDKey) {
+		return "", notFound(`Group`, externalID)
+	}
+
+	ids, err := b.db.Group.Query().
+		Where(groupExternalID(clientName(ctx), externalID)).
+		Limit(2).
+		IDs(ctx)
+	if err != nil {
+		return "", internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+	}
+	switch len(ids) {
+	case 0:
+		return "", notFound(`Group`, externalID)
+	case 1:
+		return ids[0].String(), nil
+	default:
+		return "", uniquenessError(fmt.Errorf(`externalId %q is used by several Groups`, externalID))
+	}
+}
@@ -0,0 +1,81 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalID(t *testing.T) {
+	// createUser creates a user on behalf of a client
+	createUser := func(t *testing.T, b *server.Backend, ctx context.Context, payload string) (*resource.User, error) {
+		t.Helper()
+
+		var in resource.User
+		decode(t, payload, &in)
+		return b.CreateUser(ctx, &in)
+	}
+	hr := as("hr", server.ScopeUsersRead, server.ScopeUsersWrite)
+	sales := as("sales", server.ScopeUsersRead, server.ScopeUsersWrite)
+
+	t.Run("lookup", func(t *testing.T) {
+		b := newBackend(t)
+		u, err := createUser(t, b, hr, `{"userName":"alice","externalId":"e-1"}`)
+		require.NoError(t, err, `CreateUser should succeed`)
+
+		got, err := b.RetrieveUserByExternalID(hr, "e-1", nil, nil)
+		require.NoError(t, err, `RetrieveUserByExternalID should succeed`)
+		require.Equal(t, u.ID(), got.ID(), `the user should be found`)
+
+		_, err = b.RetrieveUserByExternalID(sales, "e-1", nil, nil)
+		requireSCIMError(t, err, http.StatusNotFound)
+
+		// without uniqueness, a client may use an externalId twice
+		_, err = createUser(t, b, hr, `{"userName":"bob","externalId":"e-1"}`)
+		require.NoError(t, err, `CreateUser should succeed`)
+		_, err = b.RetrieveUserByExternalID(hr, "e-1", nil, nil)
+		serr := requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+	})
+	t.Run("uniqueness", func(t *testing.T) {
+		b := newBackend(t, server.WithExternalIDUniqueness(true), server.WithSoftDelete(time.Hour))
+		alice, err := createUser(t, b, hr, `{"userName":"alice","externalId":"e-1"}`)
+		require.NoError(t, err, `CreateUser should succeed`)
+
+		_, err = createUser(t, b, hr, `{"userName":"bob","externalId":"e-1"}`)
+		serr := requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+
+		_, err = createUser(t, b, sales, `{"userName":"bob","externalId":"e-1"}`)
+		require.NoError(t, err, `externalId should be unique per client only`)
+
+		// deleted users do not hold their externalId, but cannot be
+		// restored while another user holds it
+		require.NoError(t, b.DeleteUser(hr, alice.ID()), `DeleteUser should succeed`)
+		_, err = createUser(t, b, hr, `{"userName":"carol","externalId":"e-1"}`)
+		require.NoError(t, err, `externalId of deleted users should be available`)
+		_, err = b.RestoreUser(hr, alice.ID())
+		serr = requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+	})
+	t.Run("unique index", func(t *testing.T) {
+		connspec := "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared&_fk=1"
+		b, err := server.New(connspec)
+		require.NoError(t, err, `server.New should succeed`)
+		defer b.Close()
+		for _, name := range []string{"alice", "bob"} {
+			_, err := createUser(t, b, hr, `{"userName":"`+name+`","externalId":"e-1"}`)
+			require.NoError(t, err, `CreateUser should succeed`)
+		}
+
+		// the index backing uniqueness cannot be created over duplicates
+		_, err = server.New(connspec, server.WithExternalIDUniqueness(true))
+		require.Error(t, err, `server.New should fail`)
+	})
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cybozu-go/scim-server/ent/predicate"
+)
+
+// compiledFilter is a filter translated into predicates, which can be
+// bound to the literal values of any filter with the same template
+// (see filterTemplate)
+type compiledFilter struct {
+	users  []userBinder
+	groups []groupBinder
+
+	// attrs lists the attributes read by the filter, which are checked
+	// against the attribute policy of each client using it
+	attrs []string
+}
+
+func (f *compiledFilter) bind(args []interface{}) ([]predicate.User, []predicate.Group, error) {
+	users, err := bindUsers(args, f.users)
+	if err != nil {
+		return nil, nil, err
+	}
+	groups, err := bindGroups(args, f.groups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return users, groups, nil
+}
+
+// readable reports if the client is allowed to read all the attributes
+// used in the filter
+func (f *compiledFilter) readable(policy *AttributePolicy) bool {
+	for _, name := range f.attrs {
+		if (f.users != nil && !policy.CanRead(`User`, name)) || (f.groups != nil && !policy.CanRead(`Group`, name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTemplate replaces the literal values of a filter (strings,
+// numbers and booleans) with placeholders, and returns them in the order
+// in which they appear. Filters with the same template only differ by
+// their values, such as
+//
+//	userName eq "alice"
+//	userName eq "bob"
+//
+// The placeholders record the type of the values, as it determines how
+// the filter is compiled. The boolean return value is false if the
+// filter cannot be tokenized, in which case it is left to the parser
+func filterTemplate(src string) (string, []interface{}, bool) {
+	// NUL is used in placeholders, and never appears in valid filters
+	if strings.IndexByte(src, 0) > -1 {
+		return "", nil, false
+	}
+
+	var tmpl strings.Builder
+	var args []interface{}
+	for i := 0; i < len(src); {
+		switch c := src[i]; c {
+		case '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				return "", nil, false
+			}
+
+			var s string
+			if err := json.Unmarshal([]byte(src[i:j+1]), &s); err != nil {
+				return "", nil, false
+			}
+			args = append(args, s)
+			tmpl.WriteString("\x00s")
+			i = j + 1
+		case ' ', '\t', '\r', '\n', '(', ')', '[', ']':
+			tmpl.WriteByte(c)
+			i++
+		default:
+			j := i
+			for ; j < len(src) && !strings.ContainsRune(" \t\r\n()[]\"", rune(src[j])); j++ {
+			}
+			word := src[i:j]
+			i = j
+
+			switch {
+			case word == `true` || word == `false`:
+				args = append(args, word == `true`)
+				tmpl.WriteString("\x00b")
+			case isNumber(word):
+				if n, err := strconv.ParseInt(word, 10, 64); err == nil {
+					args = append(args, n)
+					tmpl.WriteString("\x00i")
+				} else if f, err := strconv.ParseFloat(word, 64); err == nil {
+					args = append(args, f)
+					tmpl.WriteString("\x00f")
+				} else {
+					return "", nil, false
+				}
+			default:
+				tmpl.WriteString(word)
+			}
+		}
+	}
+	return tmpl.String(), args, true
+}
+
+// isNumber reports if a word of a filter is a number rather than an
+// attribute name or an operator, which start with a letter
+func isNumber(word string) bool {
+	if strings.HasPrefix(word, `-`) || strings.HasPrefix(word, `+`) {
+		word = word[1:]
+	}
+	return word != "" && word[0] >= '0' && word[0] <= '9'
+}
+
+// sameArgs reports if the literal values found by filterTemplate are
+// the ones seen by the parser, which is required to cache the compiled
+// filter under its template
+func sameArgs(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if x, ok := numberValue(a[i]); ok {
+			if y, ok := numberValue(b[i]); !ok || x != y {
+				return false
+			}
+			continue
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterCacheStats reports the usage of the cache of compiled filters
+type FilterCacheStats struct {
+	Capacity  int    `json:"capacity"`
+	Size      int    `json:"size"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+}
+
+// HitRate returns the ratio of lookups that found a compiled filter
+func (s FilterCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// filterCache is a LRU cache of compiled filters, keyed by their
+// template and the resource types that they apply to. A nil cache
+// never finds anything
+type filterCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  *list.List // most recently used first
+	index    map[string]*list.Element
+	counts   FilterCacheStats
+}
+
+type filterCacheEntry struct {
+	key    string
+	filter *compiledFilter
+}
+
+func newFilterCache(capacity int) *filterCache {
+	if capacity <= 0 {
+		return nil
+	}
+	return &filterCache{
+		capacity: capacity,
+		entries:  list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func filterCacheKey(tmpl string, users, groups bool) string {
+	var buf strings.Builder
+	buf.WriteString(tmpl)
+	buf.WriteByte(0)
+	if users {
+		buf.WriteByte('u')
+	}
+	if groups {
+		buf.WriteByte('g')
+	}
+	return buf.String()
+}
+
+func (c *filterCache) get(key string) (*compiledFilter, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.index[key]
+	if !ok {
+		c.counts.Misses++
+		return nil, false
+	}
+	c.counts.Hits++
+	c.entries.MoveToFront(e)
+	return e.Value.(*filterCacheEntry).filter, true
+}
+
+func (c *filterCache) add(key string, f *compiledFilter) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.index[key]; ok {
+		e.Value.(*filterCacheEntry).filter = f
+		c.entries.MoveToFront(e)
+		return
+	}
+
+	c.index[key] = c.entries.PushFront(&filterCacheEntry{key: key, filter: f})
+	for c.entries.Len() > c.capacity {
+		e := c.entries.Back()
+		c.entries.Remove(e)
+		delete(c.index, e.Value.(*filterCacheEntry).key)
+		c.counts.Evictions++
+	}
+}
+
+func (c *filterCache) stats() FilterCacheStats {
+	if c == nil {
+		return FilterCacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.counts
+	stats.Capacity = c.capacity
+	stats.Size = c.entries.Len()
+	return stats
+}
+
+// FilterCacheStats returns the usage of the cache of compiled filters
+// (see WithFilterCacheSize)
+func (b *Backend) FilterCacheStats() FilterCacheStats {
+	return b.filters.stats()
+}
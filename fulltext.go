@@ -0,0 +1,408 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/email"
+	"github.com/cybozu-go/scim-server/ent/hook"
+	"github.com/cybozu-go/scim-server/ent/names"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/google/uuid"
+)
+
+// Full-text search over Users is backed by an FTS5 table, which is only
+// available if go-sqlite3 is built with the "sqlite_fts5" build tag.
+// The table is rebuilt when the Backend is created, and kept in sync
+// by hooks on the User, Names and Email entities.
+const fullTextTable = `users_fts`
+
+// fullTextColumns maps the attributes covered by full-text search to
+// the columns of the table, which are listed in this order
+var fullTextColumns = []struct {
+	attr   string
+	column string
+	weight float64 // for bm25(), matches on userName rank first
+}{
+	{resource.UserUserNameKey, `user_name`, 10},
+	{resource.UserDisplayNameKey, `display_name`, 5},
+	{resource.UserNameKey, `name`, 3},
+	{resource.UserNickNameKey, `nick_name`, 3},
+	{resource.UserEmailsKey, `emails`, 1},
+}
+
+// fullTextBatchSize limits the number of users indexed by a single query
+const fullTextBatchSize = 500
+
+func setupFullTextSearch(ctx context.Context, dialectName string, client *ent.Client) error {
+	if dialectName != dialect.SQLite {
+		return fmt.Errorf(`full-text search requires SQLite (dialect is %q)`, dialectName)
+	}
+
+	columns := make([]string, 0, len(fullTextColumns)+1)
+	for _, c := range fullTextColumns {
+		columns = append(columns, c.column)
+	}
+	columns = append(columns, `user_id UNINDEXED`)
+	stmt := fmt.Sprintf(`CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(%s, tokenize = 'unicode61 remove_diacritics 2')`, fullTextTable, strings.Join(columns, `, `))
+	if _, err := client.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf(`failed to create full-text index (go-sqlite3 must be built with the sqlite_fts5 tag): %w`, err)
+	}
+
+	if _, err := client.ExecContext(ctx, `DELETE FROM `+fullTextTable); err != nil {
+		return fmt.Errorf(`failed to clear full-text index: %w`, err)
+	}
+	ids, err := client.User.Query().IDs(ctx)
+	if err != nil {
+		return fmt.Errorf(`failed to list users: %w`, err)
+	}
+	if err := indexUsers(ctx, client, ids); err != nil {
+		return err
+	}
+
+	client.User.Use(userFullTextHook)
+	client.Names.Use(namesFullTextHook)
+	client.Email.Use(emailFullTextHook)
+	return nil
+}
+
+// indexUsers updates the full-text index for the given users. Users
+// that do not exist anymore (or were deleted) are removed from the
+// index. The client must be the one used by the mutation that triggered
+// the update, so that the index is updated in the same transaction
+func indexUsers(ctx context.Context, client *ent.Client, ids []uuid.UUID) error {
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > fullTextBatchSize {
+			batch = batch[:fullTextBatchSize]
+		}
+		ids = ids[len(batch):]
+
+		users, err := client.User.Query().
+			Where(user.IDIn(batch...), user.DeletedAtIsNil()).
+			WithName().
+			WithEmails().
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf(`failed to load users to index: %w`, err)
+		}
+
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id.String()
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat(`?, `, len(batch)), `, `)
+		if _, err := client.ExecContext(ctx, `DELETE FROM `+fullTextTable+` WHERE user_id IN (`+placeholders+`)`, args...); err != nil {
+			return fmt.Errorf(`failed to update full-text index: %w`, err)
+		}
+
+		for _, u := range users {
+			var name []string
+			if n := u.Edges.Name; n != nil {
+				name = append(name, n.Formatted, n.HonorificPrefix, n.GivenName, n.MiddleName, n.FamilyName, n.HonorificSuffix)
+			}
+			var emails []string
+			for _, e := range u.Edges.Emails {
+				emails = append(emails, e.Value)
+			}
+
+			_, err := client.ExecContext(ctx,
+				`INSERT INTO `+fullTextTable+` (user_name, display_name, name, nick_name, emails, user_id) VALUES (?, ?, ?, ?, ?, ?)`,
+				u.UserName, u.DisplayName, strings.Join(name, ` `), u.NickName, strings.Join(emails, ` `), u.ID.String(),
+			)
+			if err != nil {
+				return fmt.Errorf(`failed to update full-text index: %w`, err)
+			}
+		}
+	}
+	return nil
+}
+
+func userFullTextHook(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+		var ids []uuid.UUID
+		if !m.Op().Is(ent.OpCreate) {
+			var err error
+			ids, err = m.IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := v.(*ent.User); ok && m.Op().Is(ent.OpCreate) {
+			ids = append(ids, u.ID)
+		}
+		return v, indexUsers(ctx, m.Client(), ids)
+	})
+}
+
+func namesFullTextHook(next ent.Mutator) ent.Mutator {
+	return hook.NamesFunc(func(ctx context.Context, m *ent.NamesMutation) (ent.Value, error) {
+		// the names may be moved from one user to another
+		owners := m.UserIDs()
+		if !m.Op().Is(ent.OpCreate) {
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			previous, err := m.Client().Names.Query().Where(names.IDIn(ids...)).QueryUser().IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			owners = append(owners, previous...)
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		return v, indexUsers(ctx, m.Client(), owners)
+	})
+}
+
+func emailFullTextHook(next ent.Mutator) ent.Mutator {
+	return hook.EmailFunc(func(ctx context.Context, m *ent.EmailMutation) (ent.Value, error) {
+		owners := m.UserIDs()
+		if !m.Op().Is(ent.OpCreate) {
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			previous, err := m.Client().Email.Query().Where(email.IDIn(ids...)).QueryUser().IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			owners = append(owners, previous...)
+		}
+
+		v, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		return v, indexUsers(ctx, m.Client(), owners)
+	})
+}
+
+// FullTextQuery is a full-text search over Users
+type FullTextQuery struct {
+	// Text is the text to search. Users match if all the words of the
+	// text are found (as prefixes of words) in their userName,
+	// displayName, name, nickName or email addresses
+	Text string
+
+	// Attributes and ExcludedAttributes select the attributes to
+	// return, as in searches
+	Attributes         []string
+	ExcludedAttributes []string
+
+	// StartIndex is the 1-based index of the first result to return,
+	// and Count the maximum number of results (negative for the default)
+	StartIndex int
+	Count      int
+}
+
+// SearchUsersFullText finds the users matching a text, ranked by
+// relevance. It requires full-text search to be enabled with
+// WithFullTextSearch. Attributes that the client is not allowed to
+// read are not searched
+func (b *Backend) SearchUsersFullText(ctx context.Context, q *FullTextQuery) (*resource.ListResponse, error) {
+	if err := b.authorize(ctx, ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	if !b.fullText {
+		return nil, notImplemented(`full-text search`)
+	}
+
+	policy := attributePolicyFromContext(ctx)
+	var columns []string
+	for _, c := range fullTextColumns {
+		if policy.CanRead(`User`, c.attr) {
+			columns = append(columns, c.column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, resource.NewErrorBuilder().
+			Status(http.StatusForbidden).
+			ScimType(resource.ErrUnknown).
+			Detail(`client is not allowed to read any of the attributes covered by full-text search`).
+			MustBuild()
+	}
+
+	match := fullTextMatch(q.Text, columns)
+	if match == "" {
+		return nil, invalidValue(fmt.Errorf(`full-text search requires some text`))
+	}
+
+	// The matching users are counted and loaded in the same transaction,
+	// so that totalResults agrees with the page
+	tx, err := b.db.Tx(ctx)
+	if err != nil {
+		return nil, internalError(fmt.Errorf(`failed to start transaction: %w`, err))
+	}
+	db := tx.Client()
+
+	var total int
+	rows, err := db.QueryContext(ctx, `SELECT COUNT(*) FROM `+fullTextTable+` WHERE `+fullTextTable+` MATCH ?`, match)
+	if err != nil {
+		return nil, rollbackTx(tx, internalError(fmt.Errorf(`failed to count users: %w`, err)))
+	}
+	for rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			rows.Close()
+			return nil, rollbackTx(tx, internalError(fmt.Errorf(`failed to count users: %w`, err)))
+		}
+	}
+	rows.Close()
+
+	startIndex := 1
+	if q.StartIndex > 1 {
+		startIndex = q.StartIndex
+	}
+	count := q.Count
+	if b.maxResults > 0 && (count < 0 || count > b.maxResults) {
+		count = b.maxResults
+	}
+	offset, limit := pageWindow(startIndex-1, count, total)
+
+	var ids []uuid.UUID
+	if limit > 0 {
+		weights := make([]string, len(fullTextColumns))
+		for i, c := range fullTextColumns {
+			weights[i] = strconv.FormatFloat(c.weight, 'f', -1, 64)
+		}
+		rows, err := db.QueryContext(ctx,
+			`SELECT user_id FROM `+fullTextTable+` WHERE `+fullTextTable+` MATCH ? ORDER BY bm25(`+fullTextTable+`, `+strings.Join(weights, `, `)+`), user_id LIMIT ? OFFSET ?`,
+			match, limit, offset,
+		)
+		if err != nil {
+			return nil, rollbackTx(tx, internalError(fmt.Errorf(`failed to search users: %w`, err)))
+		}
+		for rows.Next() {
+			var s string
+			if err := rows.Scan(&s); err != nil {
+				rows.Close()
+				return nil, rollbackTx(tx, internalError(fmt.Errorf(`failed to search users: %w`, err)))
+			}
+			id, err := uuid.Parse(s)
+			if err != nil {
+				rows.Close()
+				return nil, rollbackTx(tx, internalError(fmt.Errorf(`invalid user ID in full-text index: %w`, err)))
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+	}
+
+	userQuery := db.User.Query().Where(user.IDIn(ids...))
+	userLoadEntFields(userQuery, q.Attributes, readExclusions(ctx, `User`, q.ExcludedAttributes))
+	users, err := userQuery.All(ctx)
+	if err != nil {
+		return nil, rollbackTx(tx, internalError(fmt.Errorf(`failed to execute query: %w`, err)))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, internalError(fmt.Errorf(`failed to commit transaction: %w`, err))
+	}
+
+	// results are returned in the order of the index
+	byID := make(map[uuid.UUID]*ent.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+	baseURL := b.baseURLFor(ctx)
+	list := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		u, ok := byID[id]
+		if !ok {
+			continue
+		}
+		scrubUser(ctx, u)
+		r, err := UserResourceFromEnt(baseURL, u)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to convert internal data to SCIM resource: %w`, err))
+		}
+		list = append(list, r)
+	}
+
+	var builder resource.Builder
+	return builder.ListResponse().
+		TotalResults(total).
+		StartIndex(startIndex).
+		ItemsPerPage(len(list)).
+		Resources(list...).
+		Build()
+}
+
+// fullTextMatch builds the FTS5 query matching the users whose columns
+// contain words starting with each of the words of text
+func fullTextMatch(text string, columns []string) string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return ""
+	}
+
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"*`
+	}
+	return `{` + strings.Join(columns, ` `) + `} : (` + strings.Join(terms, ` AND `) + `)`
+}
+
+// FullTextSearchHandler returns an http.Handler serving the result of
+// SearchUsersFullText for GET requests. The text is given by the "q"
+// query parameter, and "attributes", "excludedAttributes", "startIndex"
+// and "count" are interpreted as in searches. It must be wrapped with
+// Authenticate
+func FullTextSearchHandler(b *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set(`Allow`, http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		params := r.URL.Query()
+		q := FullTextQuery{
+			Text:       params.Get(`q`),
+			StartIndex: 1,
+			Count:      -1,
+		}
+		if v := params.Get(`attributes`); v != "" {
+			q.Attributes = strings.Split(v, `,`)
+		}
+		if v := params.Get(`excludedAttributes`); v != "" {
+			q.ExcludedAttributes = strings.Split(v, `,`)
+		}
+		for name, dst := range map[string]*int{`startIndex`: &q.StartIndex, `count`: &q.Count} {
+			v := params.Get(name)
+			if v == "" {
+				continue
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, invalidValue(fmt.Errorf(`invalid %s: %w`, name, err)))
+				return
+			}
+			*dst = n
+		}
+
+		list, err := b.SearchUsersFullText(r.Context(), &q)
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+
+		w.Header().Set(`Content-Type`, `application/scim+json`)
+		_ = json.NewEncoder(w).Encode(list)
+	})
+}
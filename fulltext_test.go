@@ -0,0 +1,109 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullTextSearch(t *testing.T) {
+	b := newBackend(t, server.WithFullTextSearch(true))
+	ctx := context.Background()
+	ids := createUsers(t, b,
+		`{"userName":"jsmith","displayName":"John Smith","emails":[{"value":"tanaka@example.com"}]}`,
+		`{"userName":"tanaka","displayName":"Taro Tanaka"}`,
+		`{"userName":"hsato","displayName":"Hanako Sato","name":{"familyName":"Tanaka","givenName":"Hanako"}}`,
+		`{"userName":"mtakahashi","nickName":"Tana"}`,
+	)
+
+	// search returns the userNames of the results, in order
+	search := func(t *testing.T, q *server.FullTextQuery) (*resource.ListResponse, []string) {
+		t.Helper()
+
+		res, err := b.SearchUsersFullText(ctx, q)
+		require.NoError(t, err, `SearchUsersFullText(%q) should succeed`, q.Text)
+		names := []string{}
+		for _, r := range res.Resources() {
+			names = append(names, r.(*resource.User).UserName())
+		}
+		return res, names
+	}
+
+	t.Run("ranking", func(t *testing.T) {
+		res, names := search(t, &server.FullTextQuery{Text: "tanaka", Count: -1})
+		require.Equal(t, 3, res.TotalResults(), `totalResults should count all matches`)
+		require.Equal(t, "tanaka", names[0], `matches on userName should rank first`)
+		require.Equal(t, "jsmith", names[2], `matches on emails should rank last`)
+
+		_, names = search(t, &server.FullTextQuery{Text: "tana", Count: -1})
+		require.Len(t, names, 4, `words should match as prefixes`)
+
+		_, names = search(t, &server.FullTextQuery{Text: "HANAKO tanaka", Count: -1})
+		require.Equal(t, []string{"hsato"}, names, `all the words should match`)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		_, all := search(t, &server.FullTextQuery{Text: "tanaka", Count: -1})
+		res, names := search(t, &server.FullTextQuery{Text: "tanaka", StartIndex: 2, Count: 1})
+		require.Equal(t, 3, res.TotalResults(), `totalResults should count all matches`)
+		require.Equal(t, 2, res.StartIndex(), `startIndex should be reported`)
+		require.Equal(t, all[1:2], names, `the requested page should be returned`)
+	})
+	t.Run("projection", func(t *testing.T) {
+		res, _ := search(t, &server.FullTextQuery{Text: "taro", Attributes: []string{"userName"}, Count: -1})
+		require.Len(t, res.Resources(), 1, `one user should match`)
+		u := res.Resources()[0].(*resource.User)
+		require.Equal(t, "tanaka", u.UserName(), `requested attribute should be returned`)
+		require.Equal(t, "", u.DisplayName(), `other attributes should not be returned`)
+	})
+	t.Run("index updates", func(t *testing.T) {
+		var in resource.User
+		decode(t, `{"userName":"mtakahashi","emails":[{"value":"mt@example.org"}]}`, &in)
+		_, err := b.ReplaceUser(ctx, ids["mtakahashi"], &in)
+		require.NoError(t, err, `ReplaceUser should succeed`)
+
+		_, names := search(t, &server.FullTextQuery{Text: "mt@example", Count: -1})
+		require.Equal(t, []string{"mtakahashi"}, names, `new values should be indexed`)
+		_, names = search(t, &server.FullTextQuery{Text: "tana", Count: -1})
+		require.NotContains(t, names, "mtakahashi", `old values should not be indexed`)
+
+		require.NoError(t, b.DeleteUser(ctx, ids["jsmith"]), `DeleteUser should succeed`)
+		_, names = search(t, &server.FullTextQuery{Text: "smith", Count: -1})
+		require.Empty(t, names, `deleted users should not be indexed`)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := b.SearchUsersFullText(ctx, &server.FullTextQuery{Text: " ", Count: -1})
+		requireSCIMError(t, err, http.StatusBadRequest)
+
+		_, err = b.SearchUsersFullText(as("reader", server.ScopeGroupsRead), &server.FullTextQuery{Text: "tanaka", Count: -1})
+		requireSCIMError(t, err, http.StatusForbidden)
+
+		_, err = newBackend(t).SearchUsersFullText(ctx, &server.FullTextQuery{Text: "tanaka", Count: -1})
+		requireSCIMError(t, err, http.StatusNotImplemented)
+	})
+	t.Run("handler", func(t *testing.T) {
+		h := server.FullTextSearchHandler(b)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/_fulltext?q=taro&attributes=userName", nil))
+		require.Equal(t, http.StatusOK, w.Code, `status should be 200`)
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), `response should be JSON`)
+		require.EqualValues(t, 1, res["totalResults"], `one user should match`)
+
+		for _, target := range []string{"/_fulltext?q=taro&count=many", "/_fulltext"} {
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+			require.Equal(t, http.StatusBadRequest, w.Code, `%s: status should be 400`, target)
+			require.Equal(t, "application/scim+json", w.Header().Get("Content-Type"), `%s: response should be a SCIM error`, target)
+		}
+	})
+}
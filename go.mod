@@ -7,11 +7,13 @@ require (
 	github.com/cybozu-go/scim v0.0.0-20220817234410-c780d6348be2
 	github.com/google/uuid v1.3.0
 	github.com/lestrrat-go/dataurl v0.0.0-20220721131304-b60017625013
+	github.com/lestrrat-go/option v1.0.0
 	github.com/lestrrat-go/rungroup v0.0.0-20220304094823-8e9bd0a89f18
 	github.com/mattn/go-sqlite3 v1.14.13
 	github.com/stretchr/testify v1.8.0
 	gocloud.dev v0.25.0
 	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -26,7 +28,6 @@ require (
 	github.com/googleapis/gax-go/v2 v2.2.0 // indirect
 	github.com/hashicorp/hcl/v2 v2.10.0 // indirect
 	github.com/lestrrat-go/mux v0.0.0-20220525044338-e2775b70cf3d // indirect
-	github.com/lestrrat-go/option v1.0.0 // indirect
 	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/zclconf/go-cty v1.8.0 // indirect
@@ -40,5 +41,4 @@ require (
 	google.golang.org/genproto v0.0.0-20220401170504-314d38edb7de // indirect
 	google.golang.org/grpc v1.45.0 // indirect
 	google.golang.org/protobuf v1.28.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,18 +1,35 @@
 package server
 
 import (
+	"entgo.io/ent/dialect/sql"
 	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/member"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim/resource"
 )
 
-func MemberResourceFromEnt(in *ent.Member) (*resource.GroupMember, error) {
+func MemberResourceFromEnt(baseURL string, in *ent.Member) (*resource.GroupMember, error) {
+	ref := userLocation(baseURL, in.Value)
+	if in.Type == `Group` {
+		ref = groupLocation(baseURL, in.Value)
+	}
 	return resource.NewGroupMemberBuilder().
 		Value(in.Value).
-		Ref(userLocation(in.Value)).
+		Ref(ref).
 		Type(in.Type).
 		Build()
 }
 
+// userHasGroups returns the predicate matching users that are
+// members of at least one group
+func userHasGroups() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		t := sql.Table(member.Table)
+		s.Where(sql.In(s.C(user.FieldID), sql.Select(t.C(member.FieldValue)).From(t)))
+	})
+}
+
 func groupResourceFromEntHelper(in *ent.Group, builder *resource.GroupBuilder) error {
 	/*
 		members := make([]*resource.GroupMember, 0, len(in.Edges.Users)+len(in.Edges.Children))
@@ -20,20 +20,9 @@ import (
 )
 
 func groupLoadEntFields(q *ent.GroupQuery, scimFields, excludedFields []string) {
-	fields := make(map[string]struct{})
-	if len(scimFields) == 0 {
-		scimFields = []string{resource.GroupDisplayNameKey, resource.GroupExternalIDKey, resource.GroupIDKey, resource.GroupMembersKey}
-	}
-
-	for _, name := range scimFields {
-		fields[name] = struct{}{}
-	}
-
-	for _, name := range excludedFields {
-		delete(fields, name)
-	}
+	fields := projectAttributes(`Group`, scimFields, excludedFields)
 	selectNames := make([]string, 0, len(fields))
-	for f := range fields {
+	for _, f := range fields {
 		switch f {
 		case resource.GroupDisplayNameKey:
 			selectNames = append(selectNames, group.FieldDisplayName)
@@ -50,18 +39,18 @@ func groupLoadEntFields(q *ent.GroupQuery, scimFields, excludedFields []string)
 	q.Select(selectNames...)
 }
 
-func groupLocation(id string) string {
-	return "https://foobar.com/scim/v2/Groups/" + id
+func groupLocation(baseURL, id string) string {
+	return baseURL + "/Groups/" + id
 }
 
-func GroupResourceFromEnt(in *ent.Group) (*resource.Group, error) {
+func GroupResourceFromEnt(baseURL string, in *ent.Group) (*resource.Group, error) {
 	var b resource.Builder
 
 	builder := b.Group()
 
 	meta, err := b.Meta().
 		ResourceType("Group").
-		Location(groupLocation(in.ID.String())).
+		Location(groupLocation(baseURL, in.ID.String())).
 		Version(in.Etag).
 		Build()
 	if err != nil {
@@ -74,7 +63,7 @@ func GroupResourceFromEnt(in *ent.Group) (*resource.Group, error) {
 	if el := len(in.Edges.Members); el > 0 {
 		list := make([]*resource.GroupMember, 0, el)
 		for _, ine := range in.Edges.Members {
-			r, err := MemberResourceFromEnt(ine)
+			r, err := MemberResourceFromEnt(baseURL, ine)
 			if err != nil {
 				return nil, fmt.Errorf("failed to build members information for Group")
 			}
@@ -117,22 +106,83 @@ func groupStartsWithPredicate(q *ent.GroupQuery, scimField string, val interface
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.GroupDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case resource.GroupMemberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(member.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.GroupMemberRefKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(member.FieldRef), v, caseExact))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(member.FieldType), v, caseExact))
+			})), nil
+		case resource.GroupMemberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(member.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.GroupMetaKey:
+		p, err := metaPredicate(`Group`, scimField, matchStartsWith, val)
+		if err != nil {
+			return nil, err
+		}
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(p)
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
@@ -148,22 +198,83 @@ func groupEndsWithPredicate(q *ent.GroupQuery, scimField string, val interface{}
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.GroupDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case resource.GroupMemberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(member.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.GroupMemberRefKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(member.FieldRef), v, caseExact))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(member.FieldType), v, caseExact))
+			})), nil
+		case resource.GroupMemberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(member.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.GroupMetaKey:
+		p, err := metaPredicate(`Group`, scimField, matchEndsWith, val)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(p)
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
@@ -179,22 +290,83 @@ func groupContainsPredicate(q *ent.GroupQuery, scimField string, val interface{}
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.GroupDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case resource.GroupMemberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(member.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.GroupMemberRefKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(member.FieldRef), v, caseExact))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(member.FieldType), v, caseExact))
+			})), nil
+		case resource.GroupMemberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(member.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.GroupMetaKey:
+		p, err := metaPredicate(`Group`, scimField, matchContains, val)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(p)
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
@@ -210,36 +382,225 @@ func groupEqualsPredicate(q *ent.GroupQuery, scimField string, val interface{})
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.GroupDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case resource.GroupMemberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(member.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.GroupMemberRefKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(member.FieldRef), v, caseExact))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(member.FieldType), v, caseExact))
+			})), nil
+		case resource.GroupMemberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(member.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.GroupMetaKey:
+		p, err := metaPredicate(`Group`, scimField, matchEqual, val)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(p)
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid filter field specification")
+	}
+}
+
+func groupComparePredicate(q *ent.GroupQuery, scimField string, m stringMatch, val interface{}) (predicate.Group, error) {
+	_ = q
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+	_ = subfield // TODO: remove later
+	switch field {
+	case resource.GroupDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.GroupIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := GroupEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`Group`, scimField)
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case resource.GroupMemberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(member.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.GroupMemberRefKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(member.FieldRef), v, caseExact))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(member.FieldType), v, caseExact))
+			})), nil
+		case resource.GroupMemberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`Group`, scimField)
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(member.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.GroupMetaKey:
+		p, err := metaPredicate(`Group`, scimField, m, val)
+		if err != nil {
+			return nil, err
+		}
 		return predicate.Group(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(p)
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
-func groupPresencePredicate(scimField string) predicate.Group {
-	switch scimField {
+func groupPresencePredicate(scimField string) (predicate.Group, error) {
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+
+	switch field {
 	case resource.GroupDisplayNameKey:
-		return group.And(group.DisplayNameNotNil(), group.DisplayNameNEQ(""))
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(isPresent(s.C(group.FieldDisplayName)))
+		}), nil
 	case resource.GroupExternalIDKey:
-		return group.And(group.ExternalIDNotNil(), group.ExternalIDNEQ(""))
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(isPresent(s.C(group.FieldExternalID)))
+		}), nil
+	case resource.GroupIDKey:
+		return predicate.Group(func(s *sql.Selector) {
+			s.Where(isPresent(s.C(group.FieldID)))
+		}), nil
+	case resource.GroupMembersKey:
+		switch subfield {
+		case "":
+			return group.HasMembers(), nil
+		case resource.GroupMemberDisplayKey:
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(member.FieldDisplay)))
+			})), nil
+		case resource.GroupMemberRefKey:
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(member.FieldRef)))
+			})), nil
+		case resource.GroupMemberTypeKey:
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(member.FieldType)))
+			})), nil
+		case resource.GroupMemberValueKey:
+			return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(member.FieldValue)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	default:
-		return nil
+		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
@@ -279,14 +640,14 @@ func (b *Backend) createMember(ctx context.Context, resources ...*resource.Group
 
 			parsedUUID, err := uuid.Parse(in.Value())
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse ID in \"value\" field: %w", err)
+				return nil, invalidValue(fmt.Errorf("failed to parse ID in \"value\" field: %w", err))
 			}
-			if ok, _ := b.db.User.Query().Where(user.ID(parsedUUID)).Exist(ctx); ok {
+			if ok, _ := b.db.User.Query().Where(user.ID(parsedUUID), user.DeletedAtIsNil()).Exist(ctx); ok {
 				createCall.SetType("User")
-			} else if ok, _ := b.db.Group.Query().Where(group.ID(parsedUUID)).Exist(ctx); ok {
+			} else if ok, _ := b.db.Group.Query().Where(group.ID(parsedUUID), group.DeletedAtIsNil()).Exist(ctx); ok {
 				createCall.SetType("Group")
 			} else {
-				return nil, fmt.Errorf("could not determine resource type (User/Group) from provided ID")
+				return nil, invalidValue(fmt.Errorf("could not determine resource type (User/Group) from provided ID"))
 			}
 		}
 		if in.HasRef() {
@@ -297,8 +658,43 @@ func (b *Backend) createMember(ctx context.Context, resources ...*resource.Group
 	return list, nil
 }
 
-func (b *Backend) CreateGroup(ctx context.Context, in *resource.Group) (*resource.Group, error) {
+func (b *Backend) CreateGroup(ctx context.Context, in *resource.Group) (_ *resource.Group, err error) {
+	// Failures are recorded in the audit log with their SCIM error
+	ctx, audited := b.auditFailures(ctx, AuditCreate, `Group`, "", in)
+	defer audited(&err)
+	// Errors not explicitly converted to SCIM errors are mapped here
+	defer func() { err = convertError(err, internalError) }()
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return nil, err
+	}
+	if b.shouldUpsert(ctx) {
+		res, _, err := b.UpsertGroup(ctx, in)
+		return res, err
+	}
+	if err := checkWritable(ctx, `Group`, in); err != nil {
+		return nil, err
+	}
+	if err := b.validateGroup(ctx, in, ""); err != nil {
+		return nil, err
+	}
+
+	// The Group, its first version and its audit entry are saved in a single transaction
+	var res *resource.Group
+	err = b.inTx(ctx, func(b *Backend) (err error) {
+		res, err = b.createGroup(ctx, in)
+		if err != nil {
+			return err
+		}
+		return b.recordAudit(ctx, b.db, AuditCreate, `Group`, res.ID(), in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+func (b *Backend) createGroup(ctx context.Context, in *resource.Group) (*resource.Group, error) {
 	createCall := b.db.Group.Create()
 	if in.HasDisplayName() {
 		createCall.SetDisplayName(in.DisplayName())
@@ -339,34 +735,95 @@ func (b *Backend) CreateGroup(ctx context.Context, in *resource.Group) (*resourc
 		return nil, fmt.Errorf("failed to save etag: %w", err)
 	}
 	rs.Etag = etag
-	return GroupResourceFromEnt(rs)
+
+	if err := b.recordGroupVersion(ctx, b.db, rs.ID, VersionCreated); err != nil {
+		return nil, err
+	}
+	users, err := memberUsers(ctx, b.db, rs.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.recordMemberVersions(ctx, b.db, users); err != nil {
+		return nil, err
+	}
+
+	scrubGroup(ctx, rs)
+	return GroupResourceFromEnt(b.baseURLFor(ctx), rs)
 }
 
-func (b *Backend) ReplaceGroup(ctx context.Context, id string, in *resource.Group) (*resource.Group, error) {
+func (b *Backend) ReplaceGroup(ctx context.Context, id string, in *resource.Group) (_ *resource.Group, err error) {
+	// Failures are recorded in the audit log with their SCIM error
+	ctx, audited := b.auditFailures(ctx, AuditReplace, `Group`, id, in)
+	defer audited(&err)
+	// Errors not explicitly converted to SCIM errors are mapped here
+	defer func() { err = convertError(err, internalError) }()
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return nil, err
+	}
+	if err := checkWritable(ctx, `Group`, in); err != nil {
+		return nil, err
+	}
+	if err := b.validateGroup(ctx, in, id); err != nil {
+		return nil, err
+	}
+
+	// The Group, its new version and its audit entry are saved in a single transaction
+	var res *resource.Group
+	err = b.inTx(ctx, func(b *Backend) (err error) {
+		res, err = b.replaceGroup(ctx, id, in)
+		if err != nil {
+			return err
+		}
+		return b.recordAudit(ctx, b.db, AuditReplace, `Group`, res.ID(), in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+func (b *Backend) replaceGroup(ctx context.Context, id string, in *resource.Group) (*resource.Group, error) {
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ID: %w", err)
+		return nil, notFound("Group", id)
 	}
 
-	r, err := b.db.Group.Query().Where(group.ID(parsedUUID)).Only(ctx)
+	r, err := b.db.Group.Query().Where(group.ID(parsedUUID), group.DeletedAtIsNil()).Only(ctx)
 	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, notFound("Group", id)
+		}
 		return nil, fmt.Errorf("failed to retrieve resource for replacing: %w", err)
 	}
 
+	before, err := memberUsers(ctx, b.db, parsedUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	preserved := preservedAttributes(ctx, `Group`, in)
 	replaceCall := r.Update()
 
-	replaceCall.ClearDisplayName()
+	if !preserved.Has(resource.GroupDisplayNameKey) {
+		replaceCall.ClearDisplayName()
+	}
 	if in.HasDisplayName() {
 		replaceCall.SetDisplayName(in.DisplayName())
 	}
 
-	replaceCall.ClearExternalID()
+	if !preserved.Has(resource.GroupExternalIDKey) {
+		replaceCall.ClearExternalID()
+	}
 	if in.HasExternalID() {
 		replaceCall.SetExternalID(in.ExternalID())
 	}
 
-	replaceCall.ClearMembers()
+	if !preserved.Has(resource.GroupMembersKey) {
+		if _, err := b.db.Member.Delete().Where(member.HasGroupWith(group.ID(parsedUUID))).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to delete members: %w", err)
+		}
+	}
 	var membersCreateCalls []*ent.MemberCreate
 	if in.HasMembers() {
 		calls, err := b.createMember(ctx, in.Members()...)
@@ -405,7 +862,19 @@ func (b *Backend) ReplaceGroup(ctx context.Context, id string, in *resource.Grou
 	}
 	r2.Etag = etag
 
-	return GroupResourceFromEnt(r2)
+	if err := b.recordGroupVersion(ctx, b.db, r2.ID, VersionReplaced); err != nil {
+		return nil, err
+	}
+	after, err := memberUsers(ctx, b.db, r2.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.recordMemberVersions(ctx, b.db, append(before, after...)); err != nil {
+		return nil, err
+	}
+
+	scrubGroup(ctx, r2)
+	return GroupResourceFromEnt(b.baseURLFor(ctx), r2)
 }
 
 func (b *Backend) patchAddGroup(ctx context.Context, parent *ent.Group, op *resource.PatchOperation) error {
@@ -0,0 +1,546 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/member"
+	"github.com/cybozu-go/scim-server/ent/resourceversion"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/google/uuid"
+)
+
+// Every modification of a User or a Group records a snapshot of the
+// resource, as returned by UserResourceFromEnt or GroupResourceFromEnt
+// (without the attributes that are never returned, such as password).
+// Snapshots are kept when the resource is deleted, so that its history
+// can be audited afterwards. Users are also recorded when their group
+// memberships change, as their "groups" attribute is derived from the
+// members of groups.
+//
+// The snapshots are filtered by the attribute policy of the client
+// reading them, like the resources themselves
+
+// VersionOperation is the kind of modification recorded by a version
+type VersionOperation string
+
+const (
+	VersionCreated  VersionOperation = `create`
+	VersionReplaced VersionOperation = `replace`
+	VersionPatched  VersionOperation = `patch`
+	VersionDeleted  VersionOperation = `delete`
+	VersionRestored VersionOperation = `restore`
+)
+
+// ResourceVersion is a snapshot of a User or a Group. The snapshot
+// recorded when a resource is deleted is its last state
+type ResourceVersion struct {
+	ResourceType string           `json:"resourceType"`
+	ID           string           `json:"id"`
+	Version      int              `json:"version"`
+	ETag         string           `json:"etag,omitempty"`
+	Operation    VersionOperation `json:"operation"`
+	Actor        string           `json:"actor,omitempty"`
+	Timestamp    time.Time        `json:"timestamp"`
+
+	// Resource is the SCIM representation of the resource. It is
+	// omitted when listing versions
+	Resource json.RawMessage `json:"resource,omitempty"`
+}
+
+// AttributeChange is a difference between two versions of a resource.
+// Complex attributes are compared sub-attribute by sub-attribute, and
+// multi-valued attributes as a whole. From (or To) is omitted when the
+// attribute is added (or removed)
+type AttributeChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// VersionDiff lists the changes between two versions of a resource,
+// ignoring meta
+type VersionDiff struct {
+	ResourceType string            `json:"resourceType"`
+	ID           string            `json:"id"`
+	From         int               `json:"from"`
+	To           int               `json:"to"`
+	Changes      []AttributeChange `json:"changes"`
+}
+
+// recordUserVersion records the current state of the User, using client
+// so that the version is part of the transaction making the change.
+// client must be bound to a transaction (see recordVersion)
+func (b *Backend) recordUserVersion(ctx context.Context, client *ent.Client, id uuid.UUID, op VersionOperation) error {
+	return b.recordUserSnapshot(ctx, client, id, op, false)
+}
+
+// recordMemberVersions records the current state of the Users that are
+// members (directly or not) of groups that were modified, as their
+// "groups" attribute may have changed. Users whose state is the same as
+// their last version are skipped
+func (b *Backend) recordMemberVersions(ctx context.Context, client *ent.Client, users []uuid.UUID) error {
+	for _, id := range users {
+		err := b.recordUserSnapshot(ctx, client, id, VersionPatched, true)
+		if err != nil && !ent.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Backend) recordUserSnapshot(ctx context.Context, client *ent.Client, id uuid.UUID, op VersionOperation, changedOnly bool) error {
+	q := client.User.Query().Where(user.ID(id))
+	userLoadEntFields(q, nil, nil)
+	u, err := q.Only(ctx)
+	if err != nil {
+		return fmt.Errorf(`failed to load user for history: %w`, err)
+	}
+	r, err := UserResourceFromEnt(b.baseURLFor(ctx), u)
+	if err != nil {
+		return fmt.Errorf(`failed to convert user for history: %w`, err)
+	}
+	return b.recordVersion(ctx, client, `User`, id, u.Etag, op, r, changedOnly)
+}
+
+func (b *Backend) recordGroupVersion(ctx context.Context, client *ent.Client, id uuid.UUID, op VersionOperation) error {
+	q := client.Group.Query().Where(group.ID(id))
+	groupLoadEntFields(q, nil, nil)
+	g, err := q.Only(ctx)
+	if err != nil {
+		return fmt.Errorf(`failed to load group for history: %w`, err)
+	}
+	r, err := GroupResourceFromEnt(b.baseURLFor(ctx), g)
+	if err != nil {
+		return fmt.Errorf(`failed to convert group for history: %w`, err)
+	}
+	return b.recordVersion(ctx, client, `Group`, id, g.Etag, op, r, false)
+}
+
+// maxVersionAttempts is the number of times that recording a version is
+// attempted when concurrent transactions take the next version number
+const maxVersionAttempts = 5
+
+// recordVersion records the snapshot v as the next version of the
+// resource. If changedOnly is set, nothing is recorded when v is the
+// same as the last version.
+//
+// The next version number is taken in a savepoint, so that if another
+// transaction takes it first, the conflicting insertion can be undone
+// (without aborting the transaction on PostgreSQL) and retried with the
+// following number. client must therefore be bound to a transaction
+func (b *Backend) recordVersion(ctx context.Context, client *ent.Client, resourceType string, id uuid.UUID, etag string, op VersionOperation, v interface{}, changedOnly bool) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf(`failed to encode %s for history: %w`, resourceType, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		last, err := client.ResourceVersion.Query().
+			Where(
+				resourceversion.ResourceTypeEQ(resourceType),
+				resourceversion.ResourceIDEQ(id),
+			).
+			Order(ent.Desc(resourceversion.FieldVersion)).
+			First(ctx)
+		version := 1
+		switch {
+		case err == nil:
+			if changedOnly && bytes.Equal(last.Data, data) {
+				return nil
+			}
+			version = last.Version + 1
+		case !ent.IsNotFound(err):
+			return fmt.Errorf(`failed to look up last version of %s: %w`, resourceType, err)
+		}
+
+		if _, err := client.ExecContext(ctx, `SAVEPOINT record_version`); err != nil {
+			return fmt.Errorf(`failed to create savepoint: %w`, err)
+		}
+		err = client.ResourceVersion.Create().
+			SetResourceType(resourceType).
+			SetResourceID(id).
+			SetVersion(version).
+			SetEtag(etag).
+			SetOperation(string(op)).
+			SetActor(clientName(ctx)).
+			SetData(data).
+			SetCreatedAt(b.clock.Now()).
+			Exec(ctx)
+		if err == nil {
+			if _, err := client.ExecContext(ctx, `RELEASE SAVEPOINT record_version`); err != nil {
+				return fmt.Errorf(`failed to release savepoint: %w`, err)
+			}
+			return nil
+		}
+		if _, rerr := client.ExecContext(ctx, `ROLLBACK TO SAVEPOINT record_version`); rerr != nil {
+			return fmt.Errorf(`failed to roll back to savepoint: %s (original error = %w)`, rerr, err)
+		}
+		if !ent.IsConstraintError(err) || attempt == maxVersionAttempts {
+			return fmt.Errorf(`failed to record version %d of %s: %w`, version, resourceType, err)
+		}
+	}
+}
+
+// memberUsers returns the Users that are members of the Groups, directly
+// or through nested groups
+func memberUsers(ctx context.Context, client *ent.Client, groups ...uuid.UUID) ([]uuid.UUID, error) {
+	var users uuidSet
+	seen := make(map[uuid.UUID]struct{}, len(groups))
+	for len(groups) > 0 {
+		id := groups[0]
+		groups = groups[1:]
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		rows, err := client.Member.Query().
+			Where(member.HasGroupWith(group.ID(id))).
+			Select(member.FieldValue, member.FieldType).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to load group members: %w`, err)
+		}
+		for _, row := range rows {
+			value, err := uuid.Parse(row.Value)
+			if err != nil {
+				continue
+			}
+			if row.Type == `Group` {
+				groups = append(groups, value)
+			} else {
+				users.Add(value)
+			}
+		}
+	}
+	return users.List(), nil
+}
+
+// authorizeHistory checks that the client may read the history of the
+// resource type
+func (b *Backend) authorizeHistory(ctx context.Context, resourceType string) error {
+	switch resourceType {
+	case `User`:
+		return b.authorize(ctx, ScopeUsersRead)
+	case `Group`:
+		return b.authorize(ctx, ScopeGroupsRead)
+	default:
+		return invalidValue(fmt.Errorf(`unknown resource type %q`, resourceType))
+	}
+}
+
+func (b *Backend) versionsQuery(resourceType, id string) (*ent.ResourceVersionQuery, bool) {
+	parsedUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, false
+	}
+	return b.db.ResourceVersion.Query().
+		Where(
+			resourceversion.ResourceTypeEQ(resourceType),
+			resourceversion.ResourceIDEQ(parsedUUID),
+		), true
+}
+
+// ListVersions lists the versions of a User or a Group, oldest first.
+// The snapshots themselves are not included (see RetrieveVersion)
+func (b *Backend) ListVersions(ctx context.Context, resourceType, id string) ([]*ResourceVersion, error) {
+	if err := b.authorizeHistory(ctx, resourceType); err != nil {
+		return nil, err
+	}
+
+	q, ok := b.versionsQuery(resourceType, id)
+	if !ok {
+		return nil, notFound(resourceType, id)
+	}
+	rows, err := q.
+		Order(ent.Asc(resourceversion.FieldVersion)).
+		Select(
+			resourceversion.FieldResourceType,
+			resourceversion.FieldResourceID,
+			resourceversion.FieldVersion,
+			resourceversion.FieldEtag,
+			resourceversion.FieldOperation,
+			resourceversion.FieldActor,
+			resourceversion.FieldCreatedAt,
+		).
+		All(ctx)
+	if err != nil {
+		return nil, internalError(fmt.Errorf(`failed to list versions: %w`, err))
+	}
+	if len(rows) == 0 {
+		return nil, notFound(resourceType, id)
+	}
+
+	list := make([]*ResourceVersion, len(rows))
+	for i, row := range rows {
+		list[i] = versionFromEnt(row)
+	}
+	return list, nil
+}
+
+// RetrieveVersion returns the given version of a User or a Group
+func (b *Backend) RetrieveVersion(ctx context.Context, resourceType, id string, version int) (*ResourceVersion, error) {
+	if err := b.authorizeHistory(ctx, resourceType); err != nil {
+		return nil, err
+	}
+
+	q, ok := b.versionsQuery(resourceType, id)
+	if !ok {
+		return nil, notFound(resourceType, id)
+	}
+	return b.loadVersion(ctx, q.Where(resourceversion.VersionEQ(version)), resourceType, id)
+}
+
+// RetrieveVersionByETag returns the most recent version of a User or a
+// Group that had the given etag
+func (b *Backend) RetrieveVersionByETag(ctx context.Context, resourceType, id, etag string) (*ResourceVersion, error) {
+	if err := b.authorizeHistory(ctx, resourceType); err != nil {
+		return nil, err
+	}
+
+	q, ok := b.versionsQuery(resourceType, id)
+	if !ok {
+		return nil, notFound(resourceType, id)
+	}
+	return b.loadVersion(ctx, q.
+		Where(
+			resourceversion.EtagEQ(etag),
+			resourceversion.OperationNEQ(string(VersionDeleted)),
+		).
+		Order(ent.Desc(resourceversion.FieldVersion)), resourceType, id)
+}
+
+// RetrieveVersionAt returns the version of a User or a Group that was
+// current at the given time. It fails with a not found error if the
+// resource did not exist at that time
+func (b *Backend) RetrieveVersionAt(ctx context.Context, resourceType, id string, t time.Time) (*ResourceVersion, error) {
+	if err := b.authorizeHistory(ctx, resourceType); err != nil {
+		return nil, err
+	}
+
+	q, ok := b.versionsQuery(resourceType, id)
+	if !ok {
+		return nil, notFound(resourceType, id)
+	}
+	v, err := b.loadVersion(ctx, q.
+		Where(resourceversion.CreatedAtLTE(t)).
+		Order(ent.Desc(resourceversion.FieldVersion)), resourceType, id)
+	if err != nil {
+		return nil, err
+	}
+	if v.Operation == VersionDeleted {
+		return nil, notFound(resourceType, id)
+	}
+	return v, nil
+}
+
+func (b *Backend) loadVersion(ctx context.Context, q *ent.ResourceVersionQuery, resourceType, id string) (*ResourceVersion, error) {
+	row, err := q.First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, notFound(resourceType, id)
+		}
+		return nil, internalError(fmt.Errorf(`failed to retrieve version: %w`, err))
+	}
+
+	v := versionFromEnt(row)
+	attrs, err := readableSnapshot(ctx, resourceType, row.Data)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	v.Resource, err = json.Marshal(attrs)
+	if err != nil {
+		return nil, internalError(fmt.Errorf(`failed to encode version: %w`, err))
+	}
+	return v, nil
+}
+
+// DiffVersions compares two versions of a User or a Group
+func (b *Backend) DiffVersions(ctx context.Context, resourceType, id string, from, to int) (*VersionDiff, error) {
+	if err := b.authorizeHistory(ctx, resourceType); err != nil {
+		return nil, err
+	}
+
+	q, ok := b.versionsQuery(resourceType, id)
+	if !ok {
+		return nil, notFound(resourceType, id)
+	}
+	rows, err := q.
+		Where(resourceversion.VersionIn(from, to)).
+		All(ctx)
+	if err != nil {
+		return nil, internalError(fmt.Errorf(`failed to retrieve versions: %w`, err))
+	}
+
+	snapshots := make(map[int]map[string]interface{}, 2)
+	for _, row := range rows {
+		attrs, err := readableSnapshot(ctx, resourceType, row.Data)
+		if err != nil {
+			return nil, internalError(err)
+		}
+		delete(attrs, `meta`)
+		snapshots[row.Version] = attrs
+	}
+	for _, version := range []int{from, to} {
+		if _, ok := snapshots[version]; !ok {
+			return nil, notFound(resourceType+` version`, strconv.Itoa(version))
+		}
+	}
+
+	diff := &VersionDiff{
+		ResourceType: resourceType,
+		ID:           id,
+		From:         from,
+		To:           to,
+		Changes:      []AttributeChange{},
+	}
+	diffAttributes(``, snapshots[from], snapshots[to], &diff.Changes)
+	return diff, nil
+}
+
+func diffAttributes(prefix string, from, to map[string]interface{}, changes *[]AttributeChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + `.` + k
+		}
+
+		a, b := from[k], to[k]
+		if am, ok := a.(map[string]interface{}); ok {
+			if bm, ok := b.(map[string]interface{}); ok {
+				diffAttributes(path, am, bm, changes)
+				continue
+			}
+		}
+		if !reflect.DeepEqual(a, b) {
+			*changes = append(*changes, AttributeChange{Path: path, From: a, To: b})
+		}
+	}
+}
+
+// readableSnapshot decodes a snapshot, without the attributes that the
+// client is not allowed to read
+func readableSnapshot(ctx context.Context, resourceType string, data []byte) (map[string]interface{}, error) {
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, fmt.Errorf(`failed to decode version: %w`, err)
+	}
+	policy := attributePolicyFromContext(ctx)
+	for k := range attrs {
+		if !policy.CanRead(resourceType, k) {
+			delete(attrs, k)
+		}
+	}
+	return attrs, nil
+}
+
+func versionFromEnt(row *ent.ResourceVersion) *ResourceVersion {
+	return &ResourceVersion{
+		ResourceType: row.ResourceType,
+		ID:           row.ResourceID.String(),
+		Version:      row.Version,
+		ETag:         row.Etag,
+		Operation:    VersionOperation(row.Operation),
+		Actor:        row.Actor,
+		Timestamp:    row.CreatedAt,
+	}
+}
+
+// HistoryHandler returns an http.Handler serving the history of Users
+// and Groups for GET requests. It must be wrapped with Authenticate, and
+// expects paths relative to its mount point, such as "/Users/{id}":
+//
+//	/Users/{id}                lists the versions (see ListVersions)
+//	/Users/{id}?version=3      returns a version (see RetrieveVersion)
+//	/Users/{id}?etag=W/"..."   see RetrieveVersionByETag
+//	/Users/{id}?at=2024-03-01T00:00:00Z
+//	                           see RetrieveVersionAt (RFC3339 timestamp)
+//	/Users/{id}?from=1&to=3    compares versions (see DiffVersions)
+func HistoryHandler(b *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set(`Allow`, http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var resourceType string
+		path := strings.Trim(r.URL.Path, `/`)
+		switch {
+		case strings.HasPrefix(path, `Users/`):
+			resourceType = `User`
+		case strings.HasPrefix(path, `Groups/`):
+			resourceType = `Group`
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		id := path[strings.IndexByte(path, '/')+1:]
+
+		params := r.URL.Query()
+		intParam := func(name string) (int, error) {
+			n, err := strconv.Atoi(params.Get(name))
+			if err != nil {
+				return 0, invalidValue(fmt.Errorf(`invalid %s: %w`, name, err))
+			}
+			return n, nil
+		}
+
+		var v interface{}
+		var err error
+		switch {
+		case params.Has(`version`):
+			var version int
+			if version, err = intParam(`version`); err == nil {
+				v, err = b.RetrieveVersion(r.Context(), resourceType, id, version)
+			}
+		case params.Has(`etag`):
+			v, err = b.RetrieveVersionByETag(r.Context(), resourceType, id, params.Get(`etag`))
+		case params.Has(`at`):
+			var t time.Time
+			if t, err = time.Parse(time.RFC3339, params.Get(`at`)); err != nil {
+				err = invalidValue(fmt.Errorf(`invalid at: %w`, err))
+			} else {
+				v, err = b.RetrieveVersionAt(r.Context(), resourceType, id, t)
+			}
+		case params.Has(`from`) || params.Has(`to`):
+			var from, to int
+			if from, err = intParam(`from`); err == nil {
+				if to, err = intParam(`to`); err == nil {
+					v, err = b.DiffVersions(r.Context(), resourceType, id, from, to)
+				}
+			}
+		default:
+			v, err = b.ListVersions(r.Context(), resourceType, id)
+		}
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+
+		w.Header().Set(`Content-Type`, `application/json`)
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
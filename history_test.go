@@ -0,0 +1,132 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+
+	// operations lists the operations recorded in the versions of a
+	// resource, oldest first
+	operations := func(t *testing.T, b *server.Backend, resourceType, id string) []server.VersionOperation {
+		t.Helper()
+
+		versions, err := b.ListVersions(ctx, resourceType, id)
+		require.NoError(t, err, `ListVersions should succeed`)
+		ops := make([]server.VersionOperation, len(versions))
+		for i, v := range versions {
+			require.Equal(t, i+1, v.Version, `versions should be numbered in sequence`)
+			ops[i] = v.Operation
+		}
+		return ops
+	}
+	// lastGroups returns the "groups" attribute of the last version of
+	// a User, as "direct:id" or "indirect:id"
+	lastGroups := func(t *testing.T, b *server.Backend, id string) []string {
+		t.Helper()
+
+		versions, err := b.ListVersions(ctx, `User`, id)
+		require.NoError(t, err, `ListVersions should succeed`)
+		v, err := b.RetrieveVersion(ctx, `User`, id, versions[len(versions)-1].Version)
+		require.NoError(t, err, `RetrieveVersion should succeed`)
+		var u struct {
+			Groups []struct {
+				Value string `json:"value"`
+				Type  string `json:"type"`
+			} `json:"groups"`
+		}
+		require.NoError(t, json.Unmarshal(v.Resource, &u), `snapshot should be a User`)
+		groups := []string{}
+		for _, g := range u.Groups {
+			groups = append(groups, g.Type+":"+g.Value)
+		}
+		return groups
+	}
+	createGroup := func(t *testing.T, b *server.Backend, payload string) *resource.Group {
+		t.Helper()
+
+		var in resource.Group
+		decode(t, payload, &in)
+		g, err := b.CreateGroup(ctx, &in)
+		require.NoError(t, err, `CreateGroup should succeed`)
+		return g
+	}
+
+	t.Run("create and replace", func(t *testing.T) {
+		b := newBackend(t)
+		ids := createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`)
+
+		var in resource.User
+		decode(t, `{"userName":"alice","title":"Engineer"}`, &in)
+		_, err := b.ReplaceUser(ctx, ids["alice"], &in)
+		require.NoError(t, err, `ReplaceUser should succeed`)
+		require.Equal(t, []server.VersionOperation{server.VersionCreated, server.VersionReplaced}, operations(t, b, `User`, ids["alice"]), `versions should be recorded`)
+
+		// failed replacements do not record versions
+		decode(t, `{"userName":"bob"}`, &in)
+		_, err = b.ReplaceUser(ctx, ids["alice"], &in)
+		requireSCIMError(t, err, http.StatusConflict)
+		require.Len(t, operations(t, b, `User`, ids["alice"]), 2, `no version should be recorded`)
+	})
+	t.Run("group members", func(t *testing.T) {
+		b := newBackend(t)
+		ids := createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`, `{"userName":"carol"}`)
+
+		staff := createGroup(t, b, `{"displayName":"staff","members":[{"value":"`+ids["alice"]+`"}]}`)
+		require.Equal(t, []string{"direct:" + staff.ID()}, lastGroups(t, b, ids["alice"]), `members should be recorded on creation`)
+		require.Equal(t, []server.VersionOperation{server.VersionCreated}, operations(t, b, `User`, ids["bob"]), `other users should not be recorded`)
+
+		var patch resource.PatchRequest
+		decode(t, `{"Operations":[{"op":"add","path":"members","value":{"value":"`+ids["bob"]+`"}}]}`, &patch)
+		_, err := b.PatchGroup(ctx, staff.ID(), &patch)
+		require.NoError(t, err, `PatchGroup should succeed`)
+		require.Equal(t, []string{"direct:" + staff.ID()}, lastGroups(t, b, ids["bob"]), `added members should be recorded`)
+		require.Len(t, operations(t, b, `User`, ids["alice"]), 2, `unchanged members should not be recorded again`)
+
+		all := createGroup(t, b, `{"displayName":"all","members":[{"value":"`+staff.ID()+`","type":"Group"}]}`)
+		require.Equal(t, []string{"direct:" + staff.ID(), "indirect:" + all.ID()}, lastGroups(t, b, ids["alice"]), `members of nested groups should be recorded`)
+
+		var in resource.Group
+		decode(t, `{"displayName":"staff","members":[{"value":"`+ids["carol"]+`"}]}`, &in)
+		_, err = b.ReplaceGroup(ctx, staff.ID(), &in)
+		require.NoError(t, err, `ReplaceGroup should succeed`)
+		require.Empty(t, lastGroups(t, b, ids["alice"]), `removed members should be recorded`)
+		require.Equal(t, []string{"direct:" + staff.ID(), "indirect:" + all.ID()}, lastGroups(t, b, ids["carol"]), `added members should be recorded`)
+
+		u, err := b.RetrieveUser(ctx, ids["alice"], nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Empty(t, u.Groups(), `replaced members should not belong to the group`)
+
+		require.NoError(t, b.DeleteGroup(ctx, all.ID()), `DeleteGroup should succeed`)
+		require.Equal(t, []string{"direct:" + staff.ID()}, lastGroups(t, b, ids["carol"]), `members of deleted groups should be recorded`)
+	})
+	t.Run("delete group", func(t *testing.T) {
+		b := newBackend(t)
+		ids := createUsers(t, b, `{"userName":"alice"}`)
+		eng := createGroup(t, b, `{"displayName":"eng","members":[{"value":"`+ids["alice"]+`"}]}`)
+		all := createGroup(t, b, `{"displayName":"all","members":[{"value":"`+eng.ID()+`","type":"Group"}]}`)
+
+		require.NoError(t, b.DeleteGroup(ctx, eng.ID()), `DeleteGroup should succeed`)
+		u, err := b.RetrieveUser(ctx, ids["alice"], nil, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Empty(t, u.Groups(), `members of deleted groups should not belong to them`)
+		require.Empty(t, lastGroups(t, b, ids["alice"]), `members of deleted groups should be recorded`)
+
+		g, err := b.RetrieveGroup(ctx, all.ID(), nil, nil)
+		require.NoError(t, err, `RetrieveGroup should succeed`)
+		require.Empty(t, g.Members(), `deleted groups should be removed from their groups`)
+		require.Equal(t, []server.VersionOperation{server.VersionCreated, server.VersionPatched}, operations(t, b, `Group`, all.ID()), `groups of deleted groups should be recorded`)
+
+		require.NoError(t, b.DeleteUser(ctx, ids["alice"]), `DeleteUser should succeed`)
+		ops := operations(t, b, `User`, ids["alice"])
+		require.Equal(t, server.VersionDeleted, ops[len(ops)-1], `deleted users should be recorded`)
+	})
+}
@@ -0,0 +1,289 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/idempotencykey"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/google/uuid"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the key chosen
+	// by the client to identify a request that it may retry (see
+	// Idempotency)
+	IdempotencyKeyHeader = `Idempotency-Key`
+
+	// IdempotentReplayedHeader is set to "true" on responses replayed
+	// from a previous request with the same key
+	IdempotentReplayedHeader = `Idempotent-Replayed`
+
+	maxIdempotencyKeyLength = 255
+)
+
+func idempotencyConflict(detail string) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusConflict).
+		ScimType(resource.ErrUnknown).
+		Detail(detail).
+		MustBuild()
+}
+
+// Idempotency wraps an http.Handler (usually the SCIM server handler) so
+// that POST requests, i.e. creations and bulk submissions, can be safely
+// retried by clients. The first response to a request carrying an
+// IdempotencyKeyHeader is stored in the database, and replayed to the
+// requests with the same key and payload until the key expires (see
+// WithIdempotencyKeyTTL). Reusing a key for a different payload, or
+// while the first request is still being processed, fails with 409,
+// unless that request has held the key for longer than the lease (see
+// WithIdempotencyKeyLease). Expired keys are deleted by
+// PurgeIdempotencyKeys.
+//
+// Keys are scoped to the authenticated client, so the handler must be
+// wrapped by Authenticate. Server errors are not stored, so that
+// requests that failed for transient reasons can be retried
+func Idempotency(b *Backend, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeError(w, http.StatusBadRequest, invalidValue(fmt.Errorf(`%s must be at most %d bytes long`, IdempotencyKeyHeader, maxIdempotencyKeyLength)))
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, invalidValue(fmt.Errorf(`failed to read request body: %w`, err)))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		hash := requestHash(r, body)
+		rec, reservation, err := b.reserveIdempotencyKey(ctx, key, hash)
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+		if rec != nil {
+			replayResponse(w, rec)
+			return
+		}
+
+		rw := &idempotencyRecorder{ResponseWriter: w}
+		stored := false
+		defer func() {
+			if !stored {
+				b.releaseIdempotencyKey(ctx, key, reservation)
+			}
+		}()
+		h.ServeHTTP(rw, r)
+
+		if rw.status == 0 || rw.status >= http.StatusInternalServerError {
+			return
+		}
+		if err := b.storeIdempotentResponse(ctx, key, reservation, rw); err != nil {
+			b.logger.Printf(`failed to store response for idempotency key %q: %s`, key, err)
+			return
+		}
+		stored = true
+	})
+}
+
+// requestHash identifies the payload of a request, which must be the
+// same for every request using an idempotency key
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// reserveIdempotencyKey records that a request with the given key is
+// being processed, and returns the token identifying the reservation
+// (see releaseIdempotencyKey and storeIdempotentResponse). If the key
+// was already used, the response to the first request is returned
+// instead. Expired keys and reservations held for longer than the lease
+// (see WithIdempotencyKeyLease), e.g. by a server that crashed while
+// processing the request, are taken over
+func (b *Backend) reserveIdempotencyKey(ctx context.Context, key, hash string) (*ent.IdempotencyKey, string, error) {
+	now := b.clock.Now()
+	client := clientName(ctx)
+	reservation := uuid.NewString()
+
+	err := b.db.IdempotencyKey.Create().
+		SetClientName(client).
+		SetKey(key).
+		SetRequestHash(hash).
+		SetCreatedAt(now).
+		SetExpiresAt(now.Add(b.idempotencyTTL)).
+		SetReservedAt(now).
+		SetReservation(reservation).
+		Exec(ctx)
+	if err == nil {
+		return nil, reservation, nil
+	}
+	if !ent.IsConstraintError(err) {
+		return nil, "", internalError(fmt.Errorf(`failed to record idempotency key: %w`, err))
+	}
+
+	rec, err := b.db.IdempotencyKey.Query().
+		Where(
+			idempotencykey.ClientNameEQ(client),
+			idempotencykey.KeyEQ(key),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, "", internalError(fmt.Errorf(`failed to look up idempotency key: %w`, err))
+	}
+
+	inProgress := idempotencyConflict(fmt.Sprintf(`a request with %s %q is still being processed`, IdempotencyKeyHeader, key))
+	switch {
+	case !rec.ExpiresAt.After(now):
+		// expired keys are left to PurgeIdempotencyKeys, and are
+		// reused as if they did not exist
+		n, err := b.db.IdempotencyKey.Update().
+			Where(
+				idempotencykey.IDEQ(rec.ID),
+				idempotencykey.ExpiresAtLTE(now),
+			).
+			SetRequestHash(hash).
+			ClearStatus().
+			ClearHeader().
+			ClearBody().
+			SetCreatedAt(now).
+			SetExpiresAt(now.Add(b.idempotencyTTL)).
+			SetReservedAt(now).
+			SetReservation(reservation).
+			Save(ctx)
+		if err != nil {
+			return nil, "", internalError(fmt.Errorf(`failed to record idempotency key: %w`, err))
+		}
+		if n == 0 {
+			return nil, "", inProgress
+		}
+		return nil, reservation, nil
+	case rec.RequestHash != hash:
+		return nil, "", idempotencyConflict(fmt.Sprintf(`%s %q was already used for a different request`, IdempotencyKeyHeader, key))
+	case rec.Status == 0:
+		cutoff := now.Add(-b.idempotencyLease)
+		if rec.ReservedAt.After(cutoff) {
+			return nil, "", inProgress
+		}
+		n, err := b.db.IdempotencyKey.Update().
+			Where(
+				idempotencykey.IDEQ(rec.ID),
+				idempotencykey.StatusIsNil(),
+				idempotencykey.Or(
+					idempotencykey.ReservedAtIsNil(),
+					idempotencykey.ReservedAtLTE(cutoff),
+				),
+			).
+			SetReservedAt(now).
+			SetReservation(reservation).
+			Save(ctx)
+		if err != nil {
+			return nil, "", internalError(fmt.Errorf(`failed to record idempotency key: %w`, err))
+		}
+		if n == 0 {
+			return nil, "", inProgress
+		}
+		return nil, reservation, nil
+	}
+	return rec, "", nil
+}
+
+// releaseIdempotencyKey forgets a request whose response was not stored,
+// so that the client may retry it. Nothing is done if the reservation
+// was taken over by another request
+func (b *Backend) releaseIdempotencyKey(ctx context.Context, key, reservation string) {
+	_, err := b.db.IdempotencyKey.Delete().
+		Where(
+			idempotencykey.ClientNameEQ(clientName(ctx)),
+			idempotencykey.KeyEQ(key),
+			idempotencykey.StatusIsNil(),
+			idempotencykey.ReservationEQ(reservation),
+		).
+		Exec(context.Background())
+	if err != nil {
+		b.logger.Printf(`failed to release idempotency key %q: %s`, key, err)
+	}
+}
+
+func (b *Backend) storeIdempotentResponse(ctx context.Context, key, reservation string, rw *idempotencyRecorder) error {
+	header, err := json.Marshal(rw.header)
+	if err != nil {
+		return fmt.Errorf(`failed to encode response header: %w`, err)
+	}
+	return b.db.IdempotencyKey.Update().
+		Where(
+			idempotencykey.ClientNameEQ(clientName(ctx)),
+			idempotencykey.KeyEQ(key),
+			idempotencykey.StatusIsNil(),
+			idempotencykey.ReservationEQ(reservation),
+		).
+		SetStatus(rw.status).
+		SetHeader(header).
+		SetBody(rw.body.Bytes()).
+		Exec(context.Background())
+}
+
+// PurgeIdempotencyKeys deletes the idempotency keys that have expired
+// (see WithIdempotencyKeyTTL), and returns the number of deleted keys
+func (b *Backend) PurgeIdempotencyKeys(ctx context.Context) (int, error) {
+	n, err := b.db.IdempotencyKey.Delete().
+		Where(idempotencykey.ExpiresAtLTE(b.clock.Now())).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(`failed to purge expired idempotency keys: %w`, err)
+	}
+	return n, nil
+}
+
+func replayResponse(w http.ResponseWriter, rec *ent.IdempotencyKey) {
+	var header http.Header
+	if err := json.Unmarshal(rec.Header, &header); err == nil {
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+	}
+	w.Header().Set(IdempotentReplayedHeader, `true`)
+	w.WriteHeader(rec.Status)
+	_, _ = w.Write(rec.Body)
+}
+
+// idempotencyRecorder records the response written to the client
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	header http.Header
+	body   bytes.Buffer
+}
+
+func (w *idempotencyRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = w.Header().Clone()
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *idempotencyRecorder) Write(buf []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(buf)
+	return w.ResponseWriter.Write(buf)
+}
@@ -0,0 +1,155 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency(t *testing.T) {
+	now := time.Date(2022, 4, 1, 9, 0, 0, 0, time.UTC)
+	b := newBackend(t,
+		server.WithIdempotencyKeyTTL(time.Hour),
+		server.WithIdempotencyKeyLease(time.Minute),
+		server.WithClock(server.ClockFunc(func() time.Time { return now })),
+	)
+	hr := as("hr", server.ScopeUsersWrite)
+
+	// the handler numbers the requests that reach it, and runs nested
+	// retries while it processes the request (if any)
+	var calls int
+	var nested func()
+	status := http.StatusCreated
+	h := server.Idempotency(b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if f := nested; f != nil {
+			nested = nil
+			f()
+		}
+		w.Header().Set("Content-Type", "application/scim+json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"n":%d}`, calls)
+	}))
+	post := func(ctx context.Context, key, payload string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/Users", strings.NewReader(payload)).WithContext(ctx)
+		r.Header.Set(server.IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		w := post(hr, "k-1", `{"userName":"alice"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `status should be 201`)
+		first := w.Body.String()
+
+		w = post(hr, "k-1", `{"userName":"alice"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `status should be replayed`)
+		require.Equal(t, first, w.Body.String(), `body should be replayed`)
+		require.Equal(t, "true", w.Header().Get(server.IdempotentReplayedHeader), `response should be marked as replayed`)
+		require.Equal(t, "application/scim+json", w.Header().Get("Content-Type"), `header should be replayed`)
+
+		w = post(hr, "k-1", `{"userName":"bob"}`)
+		require.Equal(t, http.StatusConflict, w.Code, `keys should not be reused for different payloads`)
+
+		// keys are scoped to each client
+		calls = 0
+		w = post(as("sales", server.ScopeUsersWrite), "k-1", `{"userName":"alice"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `status should be 201`)
+		require.Equal(t, 1, calls, `request should be processed`)
+	})
+	t.Run("server errors", func(t *testing.T) {
+		calls = 0
+		status = http.StatusServiceUnavailable
+		w := post(hr, "k-2", `{"userName":"carol"}`)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code, `status should be 503`)
+
+		status = http.StatusCreated
+		w = post(hr, "k-2", `{"userName":"carol"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `request should be retried`)
+		require.Equal(t, 2, calls, `request should be processed again`)
+	})
+	t.Run("in progress", func(t *testing.T) {
+		var retry *httptest.ResponseRecorder
+		nested = func() { retry = post(hr, "k-3", `{"userName":"dave"}`) }
+		w := post(hr, "k-3", `{"userName":"dave"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `status should be 201`)
+		require.Equal(t, http.StatusConflict, retry.Code, `retries should fail while the request is processed`)
+	})
+	t.Run("stale reservation", func(t *testing.T) {
+		calls = 0
+		var retry *httptest.ResponseRecorder
+		nested = func() {
+			now = now.Add(2 * time.Minute)
+			retry = post(hr, "k-4", `{"userName":"erin"}`)
+		}
+		w := post(hr, "k-4", `{"userName":"erin"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `status should be 201`)
+		require.Equal(t, http.StatusCreated, retry.Code, `retries should take stale reservations over`)
+		require.Equal(t, 2, calls, `retry should be processed`)
+
+		// the response of the request that lost its reservation is
+		// not stored
+		w = post(hr, "k-4", `{"userName":"erin"}`)
+		require.Equal(t, "true", w.Header().Get(server.IdempotentReplayedHeader), `response should be replayed`)
+		require.Equal(t, retry.Body.String(), w.Body.String(), `response to the retry should be replayed`)
+	})
+	t.Run("expiration", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+
+		// expired keys are reused without being purged first
+		calls = 0
+		w := post(hr, "k-1", `{"userName":"bob"}`)
+		require.Equal(t, http.StatusCreated, w.Code, `expired keys should be reused`)
+		require.Equal(t, 1, calls, `request should be processed`)
+		require.Empty(t, w.Header().Get(server.IdempotentReplayedHeader), `response should not be replayed`)
+
+		// k-2, k-3 and k-4 of hr, and k-1 of sales
+		n, err := b.PurgeIdempotencyKeys(context.Background())
+		require.NoError(t, err, `PurgeIdempotencyKeys should succeed`)
+		require.Equal(t, 4, n, `expired keys should be purged`)
+
+		w = post(hr, "k-1", `{"userName":"bob"}`)
+		require.Equal(t, "true", w.Header().Get(server.IdempotentReplayedHeader), `keys that did not expire should be kept`)
+	})
+	t.Run("clock precision", func(t *testing.T) {
+		// some databases store timestamps with less than nanosecond
+		// precision, which must not prevent reservations from being
+		// matched
+		tick := time.Date(2022, 4, 1, 9, 0, 0, 123456789, time.UTC)
+		b := newBackend(t, server.WithClock(server.ClockFunc(func() time.Time {
+			tick = tick.Add(time.Nanosecond)
+			return tick
+		})))
+		calls := 0
+		h := server.Idempotency(b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			status := http.StatusCreated
+			if calls == 1 {
+				status = http.StatusServiceUnavailable
+			}
+			w.WriteHeader(status)
+		}))
+		post := func() *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodPost, "/Users", strings.NewReader(`{"userName":"alice"}`)).WithContext(hr)
+			r.Header.Set(server.IdempotencyKeyHeader, "k-1")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			return w
+		}
+
+		require.Equal(t, http.StatusServiceUnavailable, post().Code, `status should be 503`)
+		require.Equal(t, http.StatusCreated, post().Code, `released keys should be reused`)
+		w := post()
+		require.Equal(t, http.StatusCreated, w.Code, `status should be replayed`)
+		require.Equal(t, "true", w.Header().Get(server.IdempotentReplayedHeader), `stored responses should be replayed`)
+		require.Equal(t, 2, calls, `replayed requests should not be processed`)
+	})
+}
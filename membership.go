@@ -0,0 +1,151 @@
+package server
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/member"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+)
+
+// The "groups" attribute of a User is not stored anywhere: it is derived
+// from the members of all groups. Filters on it are evaluated against
+// the memberships table below, which lists the groups that each user
+// (or group) belongs to, either directly or through nested groups.
+const (
+	membershipsTable        = `memberships`
+	membershipsMemberColumn = `member_id`
+	membershipsGroupColumn  = `group_id`
+	membershipsTypeColumn   = `type`
+)
+
+// The values of the "type" sub-attribute of the "groups" attribute of
+// a User (RFC7643 Section 4.1.2)
+const (
+	membershipDirect   = `direct`
+	membershipIndirect = `indirect`
+)
+
+// writeMemberships writes the recursive common table expression that
+// computes the memberships table. UNION (as opposed to UNION ALL)
+// discards duplicate rows, so the recursion terminates even if the
+// groups are nested in a cycle
+func writeMemberships(b *sql.Builder) {
+	m := sql.Table(member.Table)
+	t := sql.Table(membershipsTable)
+
+	b.WriteString(`WITH RECURSIVE `).Ident(membershipsTable)
+	b.Nested(func(b *sql.Builder) {
+		b.IdentComma(membershipsMemberColumn, membershipsGroupColumn, membershipsTypeColumn)
+	})
+	b.WriteString(` AS `)
+	b.Nested(func(b *sql.Builder) {
+		b.WriteString(`SELECT `).
+			IdentComma(m.C(member.FieldValue), m.C(member.GroupColumn)).
+			WriteString(fmt.Sprintf(`, '%s' FROM `, membershipDirect)).
+			Ident(member.Table)
+		b.WriteString(` UNION SELECT `).
+			IdentComma(t.C(membershipsMemberColumn), m.C(member.GroupColumn)).
+			WriteString(fmt.Sprintf(`, '%s' FROM `, membershipIndirect)).
+			Ident(membershipsTable).
+			WriteString(` JOIN `).Ident(member.Table).
+			WriteString(` ON `).Ident(m.C(member.FieldValue)).
+			WriteString(` = `).Ident(t.C(membershipsGroupColumn))
+	})
+}
+
+// userInGroups returns the predicate matching users with at least
+// one membership that satisfies cond
+func userInGroups(cond *sql.Predicate) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		t := sql.Table(membershipsTable)
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.Ident(s.C(user.FieldID)).WriteString(` IN `)
+			b.Nested(func(b *sql.Builder) {
+				writeMemberships(b)
+				b.WriteString(` SELECT `).Ident(t.C(membershipsMemberColumn)).
+					WriteString(` FROM `).Ident(membershipsTable).
+					WriteString(` WHERE `).Join(cond)
+			})
+		}))
+	})
+}
+
+// membershipCondition builds the condition on the memberships table
+// for filters such as `groups.value eq "..."` or `groups.type eq "direct"`.
+// "value" is the ID of the group, "display" is its displayName, and
+// "type" tells direct memberships from the ones through nested groups
+func membershipCondition(scimField string, m stringMatch, val interface{}) (*sql.Predicate, error) {
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := filterString(scimField, val)
+	if err != nil {
+		return nil, err
+	}
+	caseExact := isCaseExact(`User`, scimField)
+
+	t := sql.Table(membershipsTable)
+	switch subfield {
+	case resource.GroupMemberValueKey:
+		return matchString(m, t.C(membershipsGroupColumn), v, caseExact), nil
+	case resource.GroupMemberTypeKey:
+		return matchString(m, t.C(membershipsTypeColumn), v, caseExact), nil
+	case resource.GroupMemberDisplayKey:
+		g := sql.Table(group.Table)
+		return sql.In(t.C(membershipsGroupColumn),
+			sql.Select(g.C(group.FieldID)).
+				From(g).
+				Where(matchString(m, g.C(group.FieldDisplayName), v, caseExact)),
+		), nil
+	default:
+		return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+	}
+}
+
+// userGroupsPredicate returns the predicate for filters on the
+// sub-attributes of the "groups" attribute of a User. Nested groups
+// are taken into account: use a value path such as
+// `groups[value eq "..." and type eq "direct"]` to only match
+// direct memberships
+func userGroupsPredicate(scimField string, m stringMatch, val interface{}) (predicate.User, error) {
+	cond, err := membershipCondition(scimField, m, val)
+	if err != nil {
+		return nil, err
+	}
+	return userInGroups(cond), nil
+}
+
+// memberCondition builds the condition on the members of a group
+// for the filters in value paths such as `members[type eq "User"]`
+func memberCondition(scimField string, m stringMatch, val interface{}) (*sql.Predicate, error) {
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+
+	var column string
+	switch subfield {
+	case resource.GroupMemberDisplayKey:
+		column = member.FieldDisplay
+	case resource.GroupMemberRefKey:
+		column = member.FieldRef
+	case resource.GroupMemberTypeKey:
+		column = member.FieldType
+	case resource.GroupMemberValueKey:
+		column = member.FieldValue
+	default:
+		return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+	}
+
+	v, err := filterString(scimField, val)
+	if err != nil {
+		return nil, err
+	}
+	return matchString(m, sql.Table(member.Table).C(column), v, isCaseExact(`Group`, scimField)), nil
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// mutualTLSSchemeType is the authentication scheme type advertised in
+// the ServiceProviderConfig for clients authenticating with TLS
+// client certificates. RFC7643 does not define a value for this, so
+// we use a non-standard one
+const mutualTLSSchemeType = `mtls`
+
+// ClientCertificates is an Authenticator that maps verified TLS client
+// certificates to principals. Certificates can be identified by their
+// subject distinguished name, one of their subject alternative names
+// (DNS names, email addresses or URIs), or the SHA-256 fingerprint of
+// their SubjectPublicKeyInfo.
+//
+// Only certificates that have been verified by the TLS stack are
+// considered, so the http.Server must be configured with
+// tls.RequireAndVerifyClientCert or tls.VerifyClientCertIfGiven.
+type ClientCertificates struct {
+	subjects     map[string]*Principal
+	sans         map[string]*Principal
+	fingerprints map[string]*Principal
+}
+
+func NewClientCertificates() *ClientCertificates {
+	return &ClientCertificates{
+		subjects:     make(map[string]*Principal),
+		sans:         make(map[string]*Principal),
+		fingerprints: make(map[string]*Principal),
+	}
+}
+
+// MapSubject maps a certificate subject, as formatted by
+// pkix.Name.String() (e.g. "CN=hr-connector,O=Example"), to a principal
+func (c *ClientCertificates) MapSubject(dn string, p *Principal) *ClientCertificates {
+	c.subjects[dn] = p
+	return c
+}
+
+// MapSAN maps a subject alternative name to a principal. The name is
+// compared against the DNS names, email addresses and URIs in the certificate
+func (c *ClientCertificates) MapSAN(name string, p *Principal) *ClientCertificates {
+	c.sans[strings.ToLower(name)] = p
+	return c
+}
+
+// MapFingerprint maps the hex encoded SHA-256 fingerprint of a certificate's
+// SubjectPublicKeyInfo to a principal. Colons separating each byte are allowed.
+// Pinning the public key allows the certificate to be renewed without
+// changing the mapping, as long as the key stays the same
+func (c *ClientCertificates) MapFingerprint(fingerprint string, p *Principal) *ClientCertificates {
+	c.fingerprints[normalizeFingerprint(fingerprint)] = p
+	return c
+}
+
+func normalizeFingerprint(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, `:`, ``))
+}
+
+// SPKIFingerprint computes the fingerprint of a certificate, in the format
+// expected by MapFingerprint
+func SPKIFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *ClientCertificates) Authenticate(r *http.Request) (*Principal, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+
+	// Check from the most specific to the least specific identifier
+	if p, ok := c.fingerprints[SPKIFingerprint(cert)]; ok {
+		return p, true
+	}
+
+	if p, ok := c.subjects[cert.Subject.String()]; ok {
+		return p, true
+	}
+
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.URIs))
+	sans = append(sans, cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	for _, name := range sans {
+		if p, ok := c.sans[strings.ToLower(name)]; ok {
+			return p, true
+		}
+	}
+	return nil, false
+}
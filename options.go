@@ -0,0 +1,247 @@
+package server
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/lestrrat-go/option"
+)
+
+// Option configures the Backend created by New. Options for the
+// underlying ent.Client are passed to New as is, or wrapped with
+// WithEntOption when a list of Options is built
+type Option interface {
+	option.Interface
+	backendOption()
+}
+
+type backendOption struct {
+	option.Interface
+}
+
+func (*backendOption) backendOption() {}
+
+func newOption(ident, value interface{}) Option {
+	return &backendOption{option.New(ident, value)}
+}
+
+type identBaseURL struct{}
+type identDialect struct{}
+type identDB struct{}
+type identMaxResults struct{}
+type identPatchSupport struct{}
+type identFilterSupport struct{}
+type identLenientFilters struct{}
+type identFullTextSearch struct{}
+type identFilterCacheSize struct{}
+type identExternalIDUniqueness struct{}
+type identStrictCanonicalValues struct{}
+type identUpsert struct{}
+type identUpsertKey struct{}
+type identIdempotencyKeyTTL struct{}
+type identIdempotencyKeyLease struct{}
+type identSoftDelete struct{}
+type identAudit struct{}
+type identETagSupport struct{}
+type identDocumentationURI struct{}
+type identAuthenticationSchemes struct{}
+type identLogger struct{}
+type identClock struct{}
+type identEntOption struct{}
+
+// Logger is the interface used by the Backend to report diagnostic messages.
+// *log.Logger satisfies this interface
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type nilLogger struct{}
+
+func (nilLogger) Printf(string, ...interface{}) {}
+
+// Clock is the source of the current time for the Backend.
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type dbSpec struct {
+	dialect string
+	db      *sql.DB
+}
+
+// WithEntOption passes options, such as ent.Bucket or ent.PhotoURL,
+// to the underlying ent.Client
+func WithEntOption(options ...ent.Option) Option {
+	return newOption(identEntOption{}, options)
+}
+
+// WithBaseURL specifies the externally visible base URL of the SCIM
+// endpoints, such as "https://scim.example.com/scim/v2". It is used to
+// build meta.location and $ref values. If not specified, these are
+// relative to the root of the server. See also TrustForwardedHeaders
+func WithBaseURL(u string) Option {
+	return newOption(identBaseURL{}, u)
+}
+
+// WithDialect specifies the ent dialect (e.g. dialect.SQLite, dialect.MySQL)
+// used to open connspec. The corresponding database/sql driver must be
+// imported by the caller. The default is dialect.SQLite
+func WithDialect(name string) Option {
+	return newOption(identDialect{}, name)
+}
+
+// WithDB specifies an already opened database handle to use instead of
+// opening connspec. The dialect must match the driver used to open db.
+// The handle is closed when the Backend is closed
+func WithDB(dialect string, db *sql.DB) Option {
+	return newOption(identDB{}, dbSpec{dialect: dialect, db: db})
+}
+
+// WithMaxResults specifies the maximum number of resources returned
+// by a single search. The default is 200
+func WithMaxResults(n int) Option {
+	return newOption(identMaxResults{}, n)
+}
+
+// WithPatchSupport enables or disables PATCH operations. The default is true
+func WithPatchSupport(v bool) Option {
+	return newOption(identPatchSupport{}, v)
+}
+
+// WithFilterSupport enables or disables filtering in searches. The default is true
+func WithFilterSupport(v bool) Option {
+	return newOption(identFilterSupport{}, v)
+}
+
+// WithLenientFilters specifies how clauses of a filter that cannot be
+// translated into a database query (e.g. on attributes that are not
+// stored) are handled. By default the search is rejected with an
+// invalidFilter error naming the clause. If lenient, such clauses are
+// dropped and reported to the logger, which may widen the results
+func WithLenientFilters(v bool) Option {
+	return newOption(identLenientFilters{}, v)
+}
+
+// WithFullTextSearch enables full-text search over the userName,
+// displayName, name, nickName and email addresses of Users (see
+// Backend.SearchUsersFullText). It requires SQLite with the FTS5
+// extension, which go-sqlite3 only includes when built with the
+// sqlite_fts5 tag
+func WithFullTextSearch(v bool) Option {
+	return newOption(identFullTextSearch{}, v)
+}
+
+// WithFilterCacheSize specifies the number of compiled filters kept in
+// memory. Filters that only differ by their literal values, such as
+// `userName eq "alice"` and `userName eq "bob"`, share the same compiled
+// form, so that repeated searches skip parsing and compiling them. The
+// default is 1024, and 0 disables the cache. See Backend.FilterCacheStats
+func WithFilterCacheSize(n int) Option {
+	return newOption(identFilterCacheSize{}, n)
+}
+
+// WithExternalIDUniqueness specifies if externalId must be unique
+// among the Users (and among the Groups) provisioned by each client.
+// If enabled, creating or replacing a resource with an externalId that
+// the client already uses for another resource fails with a uniqueness
+// error. Uniqueness is backed by unique indexes, which requires SQLite
+// or PostgreSQL, and which cannot be created if some client already uses
+// an externalId for several resources. The default is false
+func WithExternalIDUniqueness(v bool) Option {
+	return newOption(identExternalIDUniqueness{}, v)
+}
+
+// WithStrictCanonicalValues specifies if values of attributes with
+// canonical values (e.g. emails.type) must be one of them. RFC7643
+// only suggests these values, so by default other values are accepted
+func WithStrictCanonicalValues(v bool) Option {
+	return newOption(identStrictCanonicalValues{}, v)
+}
+
+// WithUpsert specifies if every creation of a User or Group is handled
+// as an upsert (see Backend.UpsertUser). Otherwise, clients may ask for
+// an upsert with the UpsertRequestHeader (see Upsert). The default is false
+func WithUpsert(v bool) Option {
+	return newOption(identUpsert{}, v)
+}
+
+// WithUpsertKey specifies the attribute used to find the resource
+// replaced by an upsert. An upsert must find at most one resource, so
+// the attribute is unique: UpsertByName makes the displayName of Groups
+// unique (userName always is), and UpsertByExternalID enables
+// WithExternalIDUniqueness. The default is UpsertByName
+func WithUpsertKey(key UpsertKey) Option {
+	return newOption(identUpsertKey{}, key)
+}
+
+// WithIdempotencyKeyTTL specifies how long the responses to requests
+// sent with an IdempotencyKeyHeader are kept for replay (see
+// Idempotency). The default is 24 hours
+func WithIdempotencyKeyTTL(d time.Duration) Option {
+	return newOption(identIdempotencyKeyTTL{}, d)
+}
+
+// WithIdempotencyKeyLease specifies how long a request sent with an
+// IdempotencyKeyHeader holds its key while it is being processed. Once
+// the lease has passed, e.g. because the server processing the request
+// crashed, a retry takes the key over. The default is 1 minute
+func WithIdempotencyKeyLease(d time.Duration) Option {
+	return newOption(identIdempotencyKeyLease{}, d)
+}
+
+// WithSoftDelete keeps deleted Users and Groups as tombstones for the
+// given retention period, during which they can be restored (see
+// Backend.RestoreUser). Tombstones are hidden from reads and searches,
+// and are permanently deleted by Backend.PurgeDeleted once the retention
+// period has passed. The default is 0, which deletes resources
+// immediately
+func WithSoftDelete(retention time.Duration) Option {
+	return newOption(identSoftDelete{}, retention)
+}
+
+// WithAudit specifies if the operations creating, replacing, patching,
+// deleting, restoring and upserting Users and Groups are recorded in the
+// audit log (see AuditEntries), whether they succeed or not. Operations
+// fail if their entry cannot be recorded. The default is false
+func WithAudit(v bool) Option {
+	return newOption(identAudit{}, v)
+}
+
+// WithETagSupport specifies if ETags are advertised in the
+// ServiceProviderConfig. The default is true
+func WithETagSupport(v bool) Option {
+	return newOption(identETagSupport{}, v)
+}
+
+// WithDocumentationURI specifies the URI of the documentation
+// advertised in the ServiceProviderConfig
+func WithDocumentationURI(u string) Option {
+	return newOption(identDocumentationURI{}, u)
+}
+
+// WithAuthenticationSchemes replaces the authentication schemes advertised
+// in the ServiceProviderConfig. By default OAuth bearer tokens and
+// mutual TLS are advertised
+func WithAuthenticationSchemes(schemes ...*resource.AuthenticationScheme) Option {
+	return newOption(identAuthenticationSchemes{}, schemes)
+}
+
+// WithLogger specifies the logger used by the Backend. By default
+// nothing is logged
+func WithLogger(l Logger) Option {
+	return newOption(identLogger{}, l)
+}
+
+// WithClock specifies the source of the current time, which is used
+// for timestamps recorded by the Backend. This is mostly useful for testing
+func WithClock(c Clock) Option {
+	return newOption(identClock{}, c)
+}
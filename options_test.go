@@ -0,0 +1,48 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := server.New("file:TestOptions?mode=memory&cache=shared&_fk=1", server.WithUpsertKey("nickName"))
+		require.Error(t, err, `unknown upsert key should be rejected`)
+
+		_, err = server.New("file:TestOptions?mode=memory&cache=shared&_fk=1", server.WithIdempotencyKeyTTL(0))
+		require.Error(t, err, `non-positive idempotency key TTL should be rejected`)
+
+		_, err = server.New("file:TestOptions?mode=memory&cache=shared&_fk=1", "https://scim.example.com")
+		require.Error(t, err, `values that are not options should be rejected`)
+	})
+	t.Run("max results", func(t *testing.T) {
+		b := newBackend(t, server.WithMaxResults(2))
+		for _, name := range []string{"alice", "bob", "carol"} {
+			var in resource.User
+			decode(t, `{"userName":"`+name+`"}`, &in)
+			_, err := b.CreateUser(context.Background(), &in)
+			require.NoError(t, err, `CreateUser should succeed`)
+		}
+
+		var req resource.SearchRequest
+		decode(t, `{}`, &req)
+		res, err := b.SearchUser(context.Background(), &req)
+		require.NoError(t, err, `SearchUser should succeed`)
+		require.Len(t, res.Resources(), 2, `results should be capped`)
+		require.Equal(t, 3, res.TotalResults(), `total should not be capped`)
+	})
+	t.Run("base URL", func(t *testing.T) {
+		b := newBackend(t, server.WithBaseURL("https://scim.example.com/scim/v2/"))
+
+		var in resource.User
+		decode(t, `{"userName":"alice"}`, &in)
+		u, err := b.CreateUser(context.Background(), &in)
+		require.NoError(t, err, `CreateUser should succeed`)
+		require.Equal(t, "https://scim.example.com/scim/v2/Users/"+u.ID(), u.Meta().Location(), `meta.location should use the base URL`)
+	})
+}
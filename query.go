@@ -1,22 +1,61 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
 
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 	"github.com/cybozu-go/scim-server/ent"
 	"github.com/cybozu-go/scim-server/ent/group"
 	"github.com/cybozu-go/scim-server/ent/predicate"
 	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim/filter"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/cybozu-go/scim/schema"
 )
 
 type filterVisitor struct {
 	uq     *ent.UserQuery
 	gq     *ent.GroupQuery
-	users  []predicate.User
-	groups []predicate.Group
+	users  []userBinder
+	groups []groupBinder
+	policy *AttributePolicy
+
+	// args collects the literal values of the filter as they are
+	// visited, which the binders refer to by position. attrs lists
+	// the attributes that the filter reads, see checkReadable
+	args  []interface{}
+	attrs []string
+
+	// lenient drops the clauses that cannot be compiled instead of
+	// failing. compiled and dropped record the clauses of the filter
+	// as they are visited, see ExplainFilter
+	lenient  bool
+	compiled []string
+	dropped  []DroppedClause
+
+	// substituted records the clauses that could only be translated for
+	// one of the resource types, and match no resources of the other
+	substituted []string
 }
 
+// userBinder and groupBinder build the predicate of a clause from the
+// literal values of a filter. Binders only depend on the position of
+// the values, so that a filter can be compiled once and then bound to
+// the values of other filters that only differ by their literals
+// (see filterCache)
+type userBinder func(args []interface{}) (predicate.User, error)
+type groupBinder func(args []interface{}) (predicate.Group, error)
+
+// condBinder is the equivalent of userBinder and groupBinder for the
+// conditions of value path filters
+type condBinder func(args []interface{}) (*sql.Predicate, error)
+
 // visit visits the filter expression AST and collects ent-predicates.
 //
 // during the traversal, we build predicates for multiple resources
@@ -44,25 +83,129 @@ type filterVisitor struct {
 // If the accumulator is nil, we assume that the caller is not interested in accumulating
 // predicates for that specific resource. For example, if v.users = nil and v.groups is NOT nil,
 // then only the predicates for the Group resources are accumulated
+//
+// The predicates are collected as binders (see userBinder), which are
+// built once against the literal values of the filter while visiting,
+// so that clauses that cannot be translated are reported right away
 func (v *filterVisitor) visit(expr filter.Expr) error {
 	switch expr := expr.(type) {
 	case filter.PresenceExpr:
-		return v.visitPresenceExpr(expr)
+		return v.visitClause(expr, func() error { return v.visitPresenceExpr(expr) })
 	case filter.CompareExpr:
-		return v.visitCompareExpr(expr)
+		return v.visitClause(expr, func() error { return v.visitCompareExpr(expr) })
 	case filter.RegexExpr:
-		return v.visitRegexExpr(expr)
+		return v.visitClause(expr, func() error { return v.visitRegexExpr(expr) })
 	case filter.LogExpr: // RENAME ME TO LogicalStatement
 		return v.visitLogExpr(expr)
+	case filter.ValuePath: // before ParenExpr, as value paths also have a SubExpr
+		return v.visitClause(expr, func() error { return v.visitValuePath(expr) })
 	case filter.ParenExpr:
 		return v.visitParenExpr(expr)
-	case filter.ValuePath:
-		return v.visitValuePath(expr)
 	default:
 		return fmt.Errorf(`unhandled statement type: %T`, expr)
 	}
 }
 
+// visitClause compiles a single clause of the filter, such as a
+// comparison or a value path, and records the outcome.
+//
+// When searching Users and Groups at the same time, the clause is
+// compiled for each resource type on its own: a clause that can only
+// be translated for one of them (e.g. `userName eq "..."`) matches no
+// resources of the other. A clause that cannot be translated at all is
+// an error, unless the visitor is lenient, in which case it is dropped
+func (v *filterVisitor) visitClause(expr filter.Expr, compile func() error) error {
+	nusers, ngroups, nargs := len(v.users), len(v.groups), len(v.args)
+
+	var err error
+	if v.users != nil && v.groups != nil {
+		users, groups := v.users, v.groups
+
+		// both passes visit the same literals, but a pass that fails
+		// may stop before reaching all of them
+		v.groups = nil
+		uerr := compile()
+		users, uargs := v.users, v.args
+
+		v.users, v.groups, v.args = nil, groups, v.args[:nargs:nargs]
+		gerr := compile()
+		groups = v.groups
+
+		v.users, v.groups = users, groups
+		if uerr == nil {
+			v.args = uargs
+		}
+		switch {
+		case uerr == nil && gerr != nil && !isRejected(gerr):
+			v.groups = append(v.groups[:ngroups], constGroup(predicate.Group(func(s *sql.Selector) {
+				s.Where(sql.False())
+			})))
+			v.substituted = append(v.substituted, clauseString(expr))
+		case gerr == nil && uerr != nil && !isRejected(uerr):
+			v.users = append(v.users[:nusers], constUser(predicate.User(func(s *sql.Selector) {
+				s.Where(sql.False())
+			})))
+			v.substituted = append(v.substituted, clauseString(expr))
+		case uerr != nil:
+			err = uerr
+		default:
+			err = gerr
+		}
+	} else {
+		err = compile()
+	}
+
+	if err != nil {
+		if !v.lenient || isRejected(err) {
+			return clauseError(expr, err)
+		}
+
+		if v.users != nil {
+			v.users = v.users[:nusers]
+		}
+		if v.groups != nil {
+			v.groups = v.groups[:ngroups]
+		}
+		v.dropped = append(v.dropped, DroppedClause{
+			Clause: clauseString(expr),
+			Reason: err.Error(),
+		})
+		return nil
+	}
+	v.compiled = append(v.compiled, clauseString(expr))
+	return nil
+}
+
+// clauseError reports a clause of a filter that cannot be translated
+func clauseError(expr filter.Expr, err error) error {
+	if serr, ok := asSCIMError(err); ok {
+		return serr
+	}
+	return invalidFilter(fmt.Errorf(`%s: %w`, clauseString(expr), err))
+}
+
+// rejectedClause wraps the error of a clause that filters on an attribute
+// that the client cannot read. Such clauses fail the whole filter: they
+// are never dropped, nor replaced by a predicate that matches nothing
+type rejectedClause struct {
+	err error
+}
+
+func (e rejectedClause) Error() string {
+	return e.err.Error()
+}
+
+func (e rejectedClause) Unwrap() error {
+	return e.err
+}
+
+// isRejected reports if err is caused by a filter on an attribute that
+// cannot be read, see checkReadable
+func isRejected(err error) bool {
+	var rc rejectedClause
+	return errors.As(err, &rc)
+}
+
 func exprAttr(expr interface{}) (interface{}, error) {
 	switch v := expr.(type) {
 	case string:
@@ -78,6 +221,116 @@ func exprAttr(expr interface{}) (interface{}, error) {
 	}
 }
 
+// literal records the literal value on the right hand side of a
+// comparison, and returns its position in v.args
+func (v *filterVisitor) literal(expr interface{}) (int, error) {
+	val, err := exprAttr(expr)
+	if err != nil {
+		return 0, err
+	}
+	v.args = append(v.args, val)
+	return len(v.args) - 1, nil
+}
+
+// checkReadable makes sure that the client is not filtering on attributes
+// that it is not allowed to read, or that are never returned (such as
+// password), as the search results would otherwise reveal their values
+func (v *filterVisitor) checkReadable(attr string) error {
+	name := topLevelAttribute(attr)
+	v.attrs = append(v.attrs, name)
+	if (v.users != nil && !v.policy.CanRead(`User`, name)) || (v.groups != nil && !v.policy.CanRead(`Group`, name)) {
+		return rejectedClause{resource.NewErrorBuilder().
+			Status(http.StatusForbidden).
+			ScimType(resource.ErrInvalidFilter).
+			Detail(fmt.Sprintf(`client is not allowed to filter on attribute %q`, name)).
+			MustBuild()}
+	}
+	if (v.users != nil && isNeverReturned(`User`, name)) || (v.groups != nil && isNeverReturned(`Group`, name)) {
+		return rejectedClause{invalidFilter(fmt.Errorf(`attribute %q cannot be used in filters, as it is never returned`, name))}
+	}
+	return nil
+}
+
+// isNeverReturned reports if the attribute is never returned to clients,
+// according to its returned characteristic
+func isNeverReturned(resourceType, name string) bool {
+	attr, ok := schemaAttribute(resourceType, name)
+	return ok && string(attr.Returned()) == returnedNever
+}
+
+func constUser(pred predicate.User) userBinder {
+	return func([]interface{}) (predicate.User, error) { return pred, nil }
+}
+
+func constGroup(pred predicate.Group) groupBinder {
+	return func([]interface{}) (predicate.Group, error) { return pred, nil }
+}
+
+// bindUsers and bindGroups build the predicates of a list of binders
+func bindUsers(args []interface{}, binders []userBinder) ([]predicate.User, error) {
+	if binders == nil {
+		return nil, nil
+	}
+	preds := make([]predicate.User, len(binders))
+	for i, bind := range binders {
+		pred, err := bind(args)
+		if err != nil {
+			return nil, err
+		}
+		preds[i] = pred
+	}
+	return preds, nil
+}
+
+func bindGroups(args []interface{}, binders []groupBinder) ([]predicate.Group, error) {
+	if binders == nil {
+		return nil, nil
+	}
+	preds := make([]predicate.Group, len(binders))
+	for i, bind := range binders {
+		pred, err := bind(args)
+		if err != nil {
+			return nil, err
+		}
+		preds[i] = pred
+	}
+	return preds, nil
+}
+
+// addComparison records the predicates comparing an attribute against
+// the literal in the given position, after converting it to the type
+// of the attribute. The predicates are built once to check that the
+// comparison can be translated
+func (v *filterVisitor) addComparison(slot int, upath, gpath string, buildUser func(val interface{}) (predicate.User, error), buildGroup func(val interface{}) (predicate.Group, error)) error {
+	if v.users != nil {
+		bind := func(args []interface{}) (predicate.User, error) {
+			val, err := coerceFilterValue(`User`, upath, args[slot])
+			if err != nil {
+				return nil, err
+			}
+			return buildUser(val)
+		}
+		if _, err := bind(v.args); err != nil {
+			return err
+		}
+		v.users = append(v.users, bind)
+	}
+	if v.groups != nil {
+		bind := func(args []interface{}) (predicate.Group, error) {
+			val, err := coerceFilterValue(`Group`, gpath, args[slot])
+			if err != nil {
+				return nil, err
+			}
+			return buildGroup(val)
+		}
+		if _, err := bind(v.args); err != nil {
+			return err
+		}
+		v.groups = append(v.groups, bind)
+	}
+	return nil
+}
+
 func (v *filterVisitor) visitPresenceExpr(expr filter.PresenceExpr) error {
 	attr, err := exprAttr(expr.Attr())
 	sattr, ok := attr.(string)
@@ -87,13 +340,29 @@ func (v *filterVisitor) visitPresenceExpr(expr filter.PresenceExpr) error {
 		}
 		return fmt.Errorf(`left hand side of PresenceExpr is not valid: %w`, err)
 	}
+	if err := v.checkReadable(sattr); err != nil {
+		return err
+	}
+	upath, gpath, err := v.attrPaths(sattr)
+	if err != nil {
+		return err
+	}
 
 	switch expr.Operator() {
 	case filter.PresenceOp:
 		if v.users != nil {
-			if pred := userPresencePredicate(sattr); pred != nil {
-				v.users = append(v.users, pred)
+			pred, err := userPresencePredicate(upath)
+			if err != nil {
+				return err
 			}
+			v.users = append(v.users, constUser(pred))
+		}
+		if v.groups != nil {
+			pred, err := groupPresencePredicate(gpath)
+			if err != nil {
+				return err
+			}
+			v.groups = append(v.groups, constGroup(pred))
 		}
 		return nil
 	default:
@@ -107,137 +376,745 @@ func (v *filterVisitor) visitRegexExpr(expr filter.RegexExpr) error {
 	if err != nil || !ok {
 		return fmt.Errorf(`left hand side of RegexExpr is not valid`)
 	}
+	if err := v.checkReadable(slhe); err != nil {
+		return err
+	}
 
-	rhe, err := exprAttr(expr.Value())
+	slot, err := v.literal(expr.Value())
 	if err != nil {
 		return fmt.Errorf(`right hand side of RegexExpr is not valid: %w`, err)
 	}
-	// convert rhe to string so it can be passed to regexp.QuoteMeta
-	srhe := fmt.Sprintf(`%v`, rhe)
+	upath, gpath, err := v.attrPaths(slhe)
+	if err != nil {
+		return err
+	}
 
+	uq, gq := v.uq, v.gq
 	switch expr.Operator() {
 	case filter.ContainsOp:
-		if v.users != nil {
-			pred, err := userContainsPredicate(v.uq, slhe, srhe)
+		return v.addComparison(slot, upath, gpath,
+			func(val interface{}) (predicate.User, error) { return userContainsPredicate(uq, upath, val) },
+			func(val interface{}) (predicate.Group, error) { return groupContainsPredicate(gq, gpath, val) },
+		)
+	case filter.StartsWithOp:
+		return v.addComparison(slot, upath, gpath,
+			func(val interface{}) (predicate.User, error) { return userStartsWithPredicate(uq, upath, val) },
+			func(val interface{}) (predicate.Group, error) { return groupStartsWithPredicate(gq, gpath, val) },
+		)
+	case filter.EndsWithOp:
+		return v.addComparison(slot, upath, gpath,
+			func(val interface{}) (predicate.User, error) { return userEndsWithPredicate(uq, upath, val) },
+			func(val interface{}) (predicate.Group, error) { return groupEndsWithPredicate(gq, gpath, val) },
+		)
+	default:
+		return fmt.Errorf(`unhandled regexp operator %q`, expr.Operator())
+	}
+}
+
+func (v *filterVisitor) visitCompareExpr(expr filter.CompareExpr) error {
+	lhe, err := exprAttr(expr.LHE())
+	slhe, ok := lhe.(string)
+	if err != nil || !ok {
+		return fmt.Errorf(`left hand side of CompareExpr is not valid`)
+	}
+	if err := v.checkReadable(slhe); err != nil {
+		return err
+	}
+
+	slot, err := v.literal(expr.RHE())
+	if err != nil {
+		return fmt.Errorf(`right hand side of CompareExpr is not valid: %w`, err)
+	}
+	upath, gpath, err := v.attrPaths(slhe)
+	if err != nil {
+		return err
+	}
+
+	uq, gq := v.uq, v.gq
+	switch op := expr.Operator(); op {
+	case filter.EqualOp, "ne":
+		return v.addComparison(slot, upath, gpath,
+			func(val interface{}) (predicate.User, error) {
+				pred, err := userEqualsPredicate(uq, upath, val)
+				if err != nil || op == filter.EqualOp {
+					return pred, err
+				}
+				return user.Not(pred), nil
+			},
+			func(val interface{}) (predicate.Group, error) {
+				pred, err := groupEqualsPredicate(gq, gpath, val)
+				if err != nil || op == filter.EqualOp {
+					return pred, err
+				}
+				return group.Not(pred), nil
+			},
+		)
+	case "gt", "ge", "lt", "le":
+		m, _ := orderingMatch(op)
+		return v.addComparison(slot, upath, gpath,
+			func(val interface{}) (predicate.User, error) {
+				if err := checkOrdering(op, val); err != nil {
+					return nil, err
+				}
+				return userComparePredicate(uq, upath, m, val)
+			},
+			func(val interface{}) (predicate.Group, error) {
+				if err := checkOrdering(op, val); err != nil {
+					return nil, err
+				}
+				return groupComparePredicate(gq, gpath, m, val)
+			},
+		)
+	default:
+		return fmt.Errorf(`unhandled compare operator %q`, op)
+	}
+}
+
+// checkOrdering rejects the comparison of boolean values with the
+// "gt", "ge", "lt" and "le" operators
+func checkOrdering(op string, val interface{}) error {
+	// RFC7644 Section 3.4.2.2: boolean and binary attributes
+	// cannot be compared with these operators
+	if _, ok := val.(bool); ok {
+		return invalidFilter(fmt.Errorf(`operator %q cannot be used with boolean values`, op))
+	}
+	return nil
+}
+
+// attrPaths normalizes the attribute path on the left hand side of a
+// filter for both User and Group (see normalizeAttrPath)
+func (v *filterVisitor) attrPaths(path string) (string, string, error) {
+	var upath, gpath string
+	if v.users != nil {
+		p, err := normalizeAttrPath(`User`, path)
+		if err != nil {
+			return "", "", err
+		}
+		upath = p
+	}
+	if v.groups != nil {
+		p, err := normalizeAttrPath(`Group`, path)
+		if err != nil {
+			return "", "", err
+		}
+		gpath = p
+	}
+	return upath, gpath, nil
+}
+
+// visitLogExpr compiles both operands of a logical expression on their
+// own, then appends their combination to the predicates collected so far
+func (v *filterVisitor) visitLogExpr(expr filter.LogExpr) error {
+	op := expr.Operator()
+	if op != "and" && op != "or" {
+		return fmt.Errorf(`unhandled logical statement operator %q`, op)
+	}
+
+	lusers, lgroups, err := v.visitOperand(expr.LHE())
+	if err != nil {
+		return fmt.Errorf(`failed to parse left hand side of %q statement: %w`, op, err)
+	}
+	rusers, rgroups, err := v.visitOperand(expr.RHS())
+	if err != nil {
+		return fmt.Errorf(`failed to parse right hand side of %q statement: %w`, op, err)
+	}
+
+	// operands may be empty if all their clauses were dropped
+	if users := append(lusers, rusers...); v.users != nil && len(users) > 0 {
+		v.users = append(v.users, func(args []interface{}) (predicate.User, error) {
+			preds, err := bindUsers(args, users)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			v.users = append(v.users, pred)
-		}
-		if v.groups != nil {
-			pred, err := groupContainsPredicate(v.gq, slhe, srhe)
+			if op == "and" {
+				return user.And(preds...), nil
+			}
+			return user.Or(preds...), nil
+		})
+	}
+	if groups := append(lgroups, rgroups...); v.groups != nil && len(groups) > 0 {
+		v.groups = append(v.groups, func(args []interface{}) (predicate.Group, error) {
+			preds, err := bindGroups(args, groups)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			v.groups = append(v.groups, pred)
-		}
-		return nil
-	case filter.StartsWithOp:
-		if v.users != nil {
-			pred, err := userStartsWithPredicate(v.uq, slhe, srhe)
+			if op == "and" {
+				return group.And(preds...), nil
+			}
+			return group.Or(preds...), nil
+		})
+	}
+	return nil
+}
+
+// visitOperand compiles an operand of a logical expression into
+// new lists of predicates
+func (v *filterVisitor) visitOperand(expr filter.Expr) ([]userBinder, []groupBinder, error) {
+	users, groups := v.users, v.groups
+	defer func() {
+		v.users, v.groups = users, groups
+	}()
+
+	if users != nil {
+		v.users = []userBinder{}
+	}
+	if groups != nil {
+		v.groups = []groupBinder{}
+	}
+	if err := v.visit(expr); err != nil {
+		return nil, nil, err
+	}
+	return v.users, v.groups, nil
+}
+
+func (v *filterVisitor) visitParenExpr(expr filter.ParenExpr) error {
+	return v.visit(expr.SubExpr())
+}
+
+func (v *filterVisitor) visitValuePath(expr filter.ValuePath) error {
+	attr, err := exprAttr(expr.ParentAttr())
+	sattr, ok := attr.(string)
+	if err != nil || !ok {
+		return fmt.Errorf(`attribute of ValuePath is not valid`)
+	}
+	if err := v.checkReadable(sattr); err != nil {
+		return err
+	}
+	upath, gpath, err := v.attrPaths(sattr)
+	if err != nil {
+		return err
+	}
+
+	if v.users != nil {
+		var bind userBinder
+		switch upath {
+		case resource.UserGroupsKey:
+			c := valuePathCompiler{v: v, resourceType: `User`, parent: upath, cond: membershipCondition}
+			cond, err := c.compile(expr.SubExpr())
 			if err != nil {
 				return err
 			}
-			v.users = append(v.users, pred)
-		}
-		if v.groups != nil {
-			pred, err := groupStartsWithPredicate(v.gq, slhe, srhe)
+			bind = func(args []interface{}) (predicate.User, error) {
+				p, err := cond(args)
+				if err != nil {
+					return nil, err
+				}
+				return userInGroups(p), nil
+			}
+		default:
+			t, ok := userValueTables[upath]
+			if !ok {
+				return fmt.Errorf(`value path filters on %q are not supported`, upath)
+			}
+			c := valuePathCompiler{v: v, resourceType: `User`, parent: upath, cond: t.condition}
+			cond, err := c.compile(expr.SubExpr())
 			if err != nil {
 				return err
 			}
-			v.groups = append(v.groups, pred)
+			bind = func(args []interface{}) (predicate.User, error) {
+				p, err := cond(args)
+				if err != nil {
+					return nil, err
+				}
+				return t.has(p), nil
+			}
 		}
-		return nil
-	case filter.EndsWithOp:
-		if v.users != nil {
-			pred, err := userEndsWithPredicate(v.uq, slhe, srhe)
+		if _, err := bind(v.args); err != nil {
+			return err
+		}
+		v.users = append(v.users, bind)
+	}
+	if v.groups != nil {
+		var bind groupBinder
+		switch gpath {
+		case resource.GroupMembersKey:
+			c := valuePathCompiler{v: v, resourceType: `Group`, parent: gpath, cond: memberCondition}
+			cond, err := c.compile(expr.SubExpr())
 			if err != nil {
 				return err
 			}
-			v.users = append(v.users, pred)
+			bind = func(args []interface{}) (predicate.Group, error) {
+				p, err := cond(args)
+				if err != nil {
+					return nil, err
+				}
+				return group.HasMembersWith(predicate.Member(func(s *sql.Selector) {
+					s.Where(p)
+				})), nil
+			}
+		default:
+			return fmt.Errorf(`value path filters on %q are not supported`, gpath)
 		}
-		if v.groups != nil {
-			pred, err := groupEndsWithPredicate(v.gq, slhe, srhe)
-			if err != nil {
-				return err
+		if _, err := bind(v.args); err != nil {
+			return err
+		}
+		v.groups = append(v.groups, bind)
+	}
+	return nil
+}
+
+// valuePathCompiler compiles the filter in brackets of a value path
+// into a single SQL condition, using cond to build the condition for
+// each comparison against a sub-attribute of the parent attribute.
+// Literal values are recorded by the visitor v
+type valuePathCompiler struct {
+	v            *filterVisitor
+	resourceType string
+	parent       string
+	cond         func(scimField string, m stringMatch, val interface{}) (*sql.Predicate, error)
+}
+
+func (c *valuePathCompiler) compile(expr filter.Expr) (condBinder, error) {
+	switch expr := expr.(type) {
+	case filter.CompareExpr:
+		scimField, slot, err := c.operands(expr.LHE(), expr.RHE())
+		if err != nil {
+			return nil, err
+		}
+		switch op := expr.Operator(); op {
+		case filter.EqualOp:
+			return c.comparison(scimField, slot, matchEqual, ""), nil
+		case "ne":
+			cond := c.comparison(scimField, slot, matchEqual, "")
+			return func(args []interface{}) (*sql.Predicate, error) {
+				p, err := cond(args)
+				if err != nil {
+					return nil, err
+				}
+				return sql.Not(p), nil
+			}, nil
+		default:
+			m, ok := orderingMatch(op)
+			if !ok {
+				return nil, fmt.Errorf(`unhandled compare operator %q`, op)
 			}
-			v.groups = append(v.groups, pred)
+			return c.comparison(scimField, slot, m, op), nil
 		}
-		return nil
+	case filter.RegexExpr:
+		scimField, slot, err := c.operands(expr.LHE(), expr.Value())
+		if err != nil {
+			return nil, err
+		}
+		switch expr.Operator() {
+		case filter.ContainsOp:
+			return c.comparison(scimField, slot, matchContains, ""), nil
+		case filter.StartsWithOp:
+			return c.comparison(scimField, slot, matchStartsWith, ""), nil
+		case filter.EndsWithOp:
+			return c.comparison(scimField, slot, matchEndsWith, ""), nil
+		default:
+			return nil, fmt.Errorf(`unhandled regexp operator %q`, expr.Operator())
+		}
+	case filter.LogExpr:
+		lhs, err := c.compile(expr.LHE())
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse left hand side of %q statement: %w`, expr.Operator(), err)
+		}
+		rhs, err := c.compile(expr.RHS())
+		if err != nil {
+			return nil, fmt.Errorf(`failed to parse right hand side of %q statement: %w`, expr.Operator(), err)
+		}
+		op := expr.Operator()
+		if op != "and" && op != "or" {
+			return nil, fmt.Errorf(`unhandled logical statement operator %q`, op)
+		}
+		return func(args []interface{}) (*sql.Predicate, error) {
+			l, err := lhs(args)
+			if err != nil {
+				return nil, err
+			}
+			r, err := rhs(args)
+			if err != nil {
+				return nil, err
+			}
+			if op == "and" {
+				return sql.And(l, r), nil
+			}
+			return sql.Or(l, r), nil
+		}, nil
+	case filter.ValuePath:
+		return nil, invalidFilter(fmt.Errorf(`value paths cannot be nested`))
+	case filter.ParenExpr:
+		return c.compile(expr.SubExpr())
 	default:
-		return fmt.Errorf(`unhandled regexp operator %q`, expr.Operator())
+		return nil, fmt.Errorf(`unhandled statement type in value path: %T`, expr)
 	}
 }
 
-func (v *filterVisitor) visitCompareExpr(expr filter.CompareExpr) error {
-	lhe, err := exprAttr(expr.LHE())
-	slhe, ok := lhe.(string)
+// operands returns the full name of the sub-attribute on the left hand
+// side of a comparison (e.g. "members.value"), and the position of the
+// value on the right hand side
+func (c *valuePathCompiler) operands(lhe, rhe interface{}) (string, int, error) {
+	attr, err := exprAttr(lhe)
+	sattr, ok := attr.(string)
 	if err != nil || !ok {
-		return fmt.Errorf(`left hand side of CompareExpr is not valid`)
+		return "", 0, fmt.Errorf(`left hand side of value path filter is not valid`)
+	}
+	scimField, err := normalizeAttrPath(c.resourceType, c.parent+`.`+sattr)
+	if err != nil {
+		return "", 0, err
 	}
 
-	rhe, err := exprAttr(expr.RHE())
+	slot, err := c.v.literal(rhe)
 	if err != nil {
-		return fmt.Errorf(`right hand side of CompareExpr is not valid: %w`, err)
+		return "", 0, fmt.Errorf(`right hand side of value path filter is not valid: %w`, err)
 	}
-	// convert rhe to string so it can be passed to regexp.QuoteMeta
-	srhe := fmt.Sprintf(`%v`, rhe)
+	return scimField, slot, nil
+}
 
-	switch expr.Operator() {
-	case filter.EqualOp:
-		if v.users != nil {
-			pred, err := userEqualsPredicate(v.uq, slhe, srhe)
-			if err != nil {
-				return err
+// comparison returns the condition comparing a sub-attribute against
+// the literal in the given position, converted to the type of the
+// sub-attribute. op is the operator of ordering comparisons, if any
+func (c *valuePathCompiler) comparison(scimField string, slot int, m stringMatch, op string) condBinder {
+	return func(args []interface{}) (*sql.Predicate, error) {
+		val, err := coerceFilterValue(c.resourceType, scimField, args[slot])
+		if err != nil {
+			return nil, err
+		}
+		if op != "" {
+			if err := checkOrdering(op, val); err != nil {
+				return nil, err
 			}
-			v.users = append(v.users, pred)
 		}
-		if v.groups != nil {
-			pred, err := groupEqualsPredicate(v.gq, slhe, srhe)
+		return c.cond(scimField, m, val)
+	}
+}
+
+// stringMatch is the kind of string comparison performed by a filter
+type stringMatch int
+
+const (
+	matchEqual stringMatch = iota
+	matchContains
+	matchStartsWith
+	matchEndsWith
+	matchGreaterThan
+	matchGreaterOrEqual
+	matchLessThan
+	matchLessOrEqual
+)
+
+// orderingMatch returns the stringMatch for the "gt", "ge", "lt" and "le"
+// filter operators
+func orderingMatch(op string) (stringMatch, bool) {
+	switch op {
+	case "gt":
+		return matchGreaterThan, true
+	case "ge":
+		return matchGreaterOrEqual, true
+	case "lt":
+		return matchLessThan, true
+	case "le":
+		return matchLessOrEqual, true
+	default:
+		return 0, false
+	}
+}
+
+// schemaAttribute looks up the definition of an attribute such as
+// "userName" or "emails.value" in the schema of the resource type
+func schemaAttribute(resourceType, scimField string) (*resource.SchemaAttribute, bool) {
+	s, ok := schema.GetByResourceType(resourceType)
+	if !ok {
+		return nil, false
+	}
+
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, false
+	}
+
+	attr, ok := lookupAttribute(s.Attributes(), field)
+	if !ok {
+		return nil, false
+	}
+	if subfield != "" {
+		return lookupAttribute(attr.SubAttributes(), subfield)
+	}
+	return attr, true
+}
+
+// isCaseExact reports whether string comparisons against the attribute
+// are case sensitive, according to its caseExact characteristic. As in
+// RFC7643 Section 2.2, attributes are not case exact unless specified otherwise
+func isCaseExact(resourceType, scimField string) bool {
+	attr, ok := schemaAttribute(resourceType, scimField)
+	if !ok {
+		return false
+	}
+	return attr.CaseExact()
+}
+
+// coerceFilterValue converts a literal in a filter (a string, a boolean
+// or a number) to the type of the attribute that it is compared against:
+//
+//   - string, reference and binary attributes take strings
+//   - boolean attributes take booleans
+//   - integer attributes take integral numbers (int64)
+//   - decimal attributes take numbers (float64)
+//   - dateTime attributes take RFC3339 strings (time.Time)
+//
+// If the attribute is not known, the literal is returned as is.
+func coerceFilterValue(resourceType, scimField string, val interface{}) (interface{}, error) {
+	attr, ok := schemaAttribute(resourceType, scimField)
+	if !ok {
+		return val, nil
+	}
+
+	switch typ := string(attr.Type()); typ {
+	case typeString, typeReference, typeBinary:
+		if s, ok := val.(string); ok {
+			return s, nil
+		}
+	case typeBoolean:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case typeInteger:
+		if f, ok := numberValue(val); ok && f == math.Trunc(f) {
+			return int64(f), nil
+		}
+	case typeDecimal:
+		if f, ok := numberValue(val); ok {
+			return f, nil
+		}
+	case typeDateTime:
+		if s, ok := val.(string); ok {
+			t, err := time.Parse(time.RFC3339, s)
 			if err != nil {
-				return err
+				return nil, invalidFilter(fmt.Errorf(`attribute %q requires an RFC3339 dateTime value: %w`, scimField, err))
 			}
-			v.groups = append(v.groups, pred)
+			return t, nil
 		}
-		return nil
 	default:
-		panic(expr.Operator())
+		return val, nil
 	}
+	return nil, filterTypeMismatch(scimField, string(attr.Type()), val)
 }
 
-func (v *filterVisitor) visitLogExpr(expr filter.LogExpr) error {
-	if err := v.visit(expr.LHE()); err != nil {
-		return fmt.Errorf(`failed to parse left hand side of %q statement: %w`, expr.Operator(), err)
+func numberValue(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
 	}
-	if err := v.visit(expr.RHS()); err != nil {
-		return fmt.Errorf(`failed to parse right hand side of %q statement: %w`, expr.Operator(), err)
+}
+
+func filterTypeMismatch(scimField, expected string, val interface{}) error {
+	return invalidFilter(fmt.Errorf(`attribute %q requires a %s value, got %#v`, scimField, expected, val))
+}
+
+// filterString and filterBool extract the value to compare against
+// string and boolean attributes, respectively
+func filterString(scimField string, val interface{}) (string, error) {
+	s, ok := val.(string)
+	if !ok {
+		return "", filterTypeMismatch(scimField, typeString, val)
 	}
+	return s, nil
+}
 
-	switch expr.Operator() {
-	case "and":
-		if v.users != nil {
-			v.users = []predicate.User{user.And(v.users...)}
+func filterBool(scimField string, val interface{}) (bool, error) {
+	b, ok := val.(bool)
+	if !ok {
+		return false, filterTypeMismatch(scimField, typeBoolean, val)
+	}
+	return b, nil
+}
+
+// matchString builds the SQL predicate comparing the column against value.
+//
+// The LIKE operator is case insensitive in SQLite (and MySQL, depending
+// on the collation) but not in PostgreSQL, so the predicates are built
+// explicitly for each dialect. Case insensitive comparisons leave the
+// folding of both sides to the database, so that the value is folded in
+// the same way as the column: in SQLite, LIKE and the NOCASE collation
+// only fold ASCII letters, whereas strings.ToLower folds any letter
+func matchString(m stringMatch, col, value string, caseExact bool) *sql.Predicate {
+	switch m {
+	case matchEqual:
+		if caseExact {
+			return sql.EQ(col, value)
 		}
-		if v.groups != nil {
-			v.groups = []predicate.Group{group.And(v.groups...)}
+		return sql.P(func(b *sql.Builder) {
+			switch b.Dialect() {
+			case dialect.MySQL:
+				b.Ident(col).WriteString(` COLLATE utf8mb4_general_ci = `).Arg(value)
+			case dialect.Postgres:
+				b.WriteString(`LOWER(`).Ident(col).WriteString(`) = LOWER(`).Arg(value).WriteString(`)`)
+			default: // SQLite
+				b.Ident(col).WriteString(` COLLATE NOCASE = `).Arg(value)
+			}
+		})
+	case matchGreaterThan, matchGreaterOrEqual, matchLessThan, matchLessOrEqual:
+		return orderString(m, col, value, caseExact)
+	}
+
+	var prefix, suffix string
+	switch m {
+	case matchContains:
+		prefix, suffix = `%`, `%`
+	case matchStartsWith:
+		suffix = `%`
+	case matchEndsWith:
+		prefix = `%`
+	}
+
+	return sql.P(func(b *sql.Builder) {
+		switch b.Dialect() {
+		case dialect.MySQL:
+			if caseExact {
+				b.Ident(col).WriteString(` LIKE BINARY `)
+			} else {
+				b.Ident(col).WriteString(` COLLATE utf8mb4_general_ci LIKE `)
+			}
+			b.Arg(prefix + escapeLike(value) + suffix)
+		case dialect.Postgres:
+			if caseExact {
+				b.Ident(col).WriteString(` LIKE `)
+			} else {
+				b.Ident(col).WriteString(` ILIKE `)
+			}
+			b.Arg(prefix + escapeLike(value) + suffix)
+		default: // SQLite
+			if caseExact {
+				// GLOB is case sensitive, but uses a different syntax
+				b.Ident(col).WriteString(` GLOB `)
+				b.Arg(strings.ReplaceAll(prefix, `%`, `*`) + escapeGlob(value) + strings.ReplaceAll(suffix, `%`, `*`))
+			} else {
+				b.Ident(col).WriteString(` LIKE `)
+				b.Arg(prefix + escapeLike(value) + suffix)
+				b.WriteString(` ESCAPE `).Arg(`\`)
+			}
 		}
-	case "or":
-		if v.users != nil {
-			v.users = []predicate.User{user.Or(v.users...)}
+	})
+}
+
+// orderString builds the SQL predicate for the lexicographical
+// comparison of the column against value
+func orderString(m stringMatch, col, value string, caseExact bool) *sql.Predicate {
+	var op sql.Op
+	switch m {
+	case matchGreaterThan:
+		op = sql.OpGT
+	case matchGreaterOrEqual:
+		op = sql.OpGTE
+	case matchLessThan:
+		op = sql.OpLT
+	default:
+		op = sql.OpLTE
+	}
+
+	return sql.P(func(b *sql.Builder) {
+		switch {
+		case caseExact:
+			b.Ident(col).WriteOp(op).Arg(value)
+		case b.Dialect() == dialect.MySQL:
+			b.Ident(col).WriteString(` COLLATE utf8mb4_general_ci`).WriteOp(op).Arg(value)
+		case b.Dialect() == dialect.Postgres:
+			b.WriteString(`LOWER(`).Ident(col).WriteString(`)`).WriteOp(op).WriteString(`LOWER(`).Arg(value).WriteString(`)`)
+		default: // SQLite
+			b.Ident(col).WriteString(` COLLATE NOCASE`).WriteOp(op).Arg(value)
 		}
-		if v.groups != nil {
-			v.groups = []predicate.Group{group.Or(v.groups...)}
+	})
+}
+
+// userNamePredicate builds the predicate for filters on userName. Unless
+// userName is case exact, the value is matched against the case folded
+// shadow column used to enforce its uniqueness (see NormalizedUserName),
+// so that a filter finds the user that would conflict with the value
+func userNamePredicate(scimField string, m stringMatch, val interface{}) (predicate.User, error) {
+	v, err := filterString(scimField, val)
+	if err != nil {
+		return nil, err
+	}
+
+	col, caseExact := user.FieldNormalizedUserName, isCaseExact(`User`, scimField)
+	if caseExact {
+		col = user.FieldUserName
+	} else {
+		v = strings.ToLower(v)
+	}
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(matchString(m, s.C(col), v, true))
+	}), nil
+}
+
+// isPresent builds the SQL predicate for the presence of a string value.
+// RFC7644 Section 3.4.2.2 treats empty strings as not present
+func isPresent(col string) *sql.Predicate {
+	return sql.And(sql.NotNull(col), sql.NEQ(col, ""))
+}
+
+// metaPredicate builds the SQL predicate for filters on the "meta"
+// attribute of a resource type. Only "meta.resourceType" is supported:
+// as each table holds a single resource type, the predicate is either
+// always true or always false, which lets root level searches be
+// restricted to some resource types
+func metaPredicate(resourceType, scimField string, m stringMatch, val interface{}) (*sql.Predicate, error) {
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+
+	switch subfield {
+	case resource.MetaResourceTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
 		}
+		if matchConstant(m, resourceType, v, isCaseExact(resourceType, scimField)) {
+			return sql.Not(sql.False()), nil
+		}
+		return sql.False(), nil
 	default:
-		return fmt.Errorf(`unhandled logical statement operator %q`, expr.Operator())
+		return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 	}
-	return nil
 }
 
-func (v *filterVisitor) visitParenExpr(expr filter.Expr) error {
-	return fmt.Errorf(`unimplemented`)
+// matchConstant performs the string comparison of matchString on
+// a value known in advance instead of a column
+func matchConstant(m stringMatch, s, value string, caseExact bool) bool {
+	if !caseExact {
+		s = strings.ToLower(s)
+		value = strings.ToLower(value)
+	}
+
+	switch m {
+	case matchEqual:
+		return s == value
+	case matchContains:
+		return strings.Contains(s, value)
+	case matchStartsWith:
+		return strings.HasPrefix(s, value)
+	case matchEndsWith:
+		return strings.HasSuffix(s, value)
+	case matchGreaterThan:
+		return s > value
+	case matchGreaterOrEqual:
+		return s >= value
+	case matchLessThan:
+		return s < value
+	case matchLessOrEqual:
+		return s <= value
+	default:
+		return false
+	}
 }
 
-func (v *filterVisitor) visitValuePath(expr filter.ValuePath) error {
-	return fmt.Errorf(`unimplemented`)
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var globEscaper = strings.NewReplacer(`*`, `[*]`, `?`, `[?]`, `[`, `[[]`)
+
+func escapeGlob(s string) string {
+	return globEscaper.Replace(s)
 }
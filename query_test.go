@@ -0,0 +1,451 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+// createUsers creates users from JSON payloads, and returns their IDs
+// keyed by userName
+func createUsers(t *testing.T, b *server.Backend, payloads ...string) map[string]string {
+	t.Helper()
+
+	ids := make(map[string]string, len(payloads))
+	for _, payload := range payloads {
+		var in resource.User
+		decode(t, payload, &in)
+		u, err := b.CreateUser(context.Background(), &in)
+		require.NoError(t, err, `CreateUser should succeed`)
+		ids[u.UserName()] = u.ID()
+	}
+	return ids
+}
+
+// searchUserNames runs a search on Users, and returns the sorted
+// userNames of the results
+func searchUserNames(t *testing.T, b *server.Backend, src string) []string {
+	t.Helper()
+
+	res, err := b.SearchUser(context.Background(), filterRequest(t, src))
+	require.NoError(t, err, `SearchUser(%s) should succeed`, src)
+
+	names := []string{}
+	for _, r := range res.Resources() {
+		u, ok := r.(*resource.User)
+		require.True(t, ok, `results should be Users`)
+		names = append(names, u.UserName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// searchGroupNames runs a search on Groups, and returns the sorted
+// displayNames of the results
+func searchGroupNames(t *testing.T, b *server.Backend, src string) []string {
+	t.Helper()
+
+	res, err := b.SearchGroup(context.Background(), filterRequest(t, src))
+	require.NoError(t, err, `SearchGroup(%s) should succeed`, src)
+
+	names := []string{}
+	for _, r := range res.Resources() {
+		g, ok := r.(*resource.Group)
+		require.True(t, ok, `results should be Groups`)
+		names = append(names, g.DisplayName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCaseInsensitiveMatching(t *testing.T) {
+	b := newBackend(t)
+	createUsers(t, b,
+		`{"userName":"Alice","displayName":"Alice Smith","externalId":"abc"}`,
+		`{"userName":"Ärger","displayName":"Émile Zola","externalId":"ABC"}`,
+		`{"userName":"bob","displayName":"bob"}`,
+	)
+
+	t.Run("userName uniqueness", func(t *testing.T) {
+		for _, name := range []string{"alice", "ALICE", "ärger", "ÄRGER"} {
+			var in resource.User
+			decode(t, `{"userName":"`+name+`"}`, &in)
+			_, err := b.CreateUser(context.Background(), &in)
+			serr := requireSCIMError(t, err, http.StatusConflict)
+			require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `%s: scimType should be uniqueness`, name)
+		}
+	})
+
+	testcases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		// userName agrees with its uniqueness, including non-ASCII letters
+		{Filter: `userName eq "alice"`, Expected: []string{"Alice"}},
+		{Filter: `userName eq "ärger"`, Expected: []string{"Ärger"}},
+		{Filter: `userName eq "ÄRGER"`, Expected: []string{"Ärger"}},
+		{Filter: `userName sw "är"`, Expected: []string{"Ärger"}},
+		{Filter: `userName co "LIC"`, Expected: []string{"Alice"}},
+		{Filter: `userName ew "GER"`, Expected: []string{"Ärger"}},
+		{Filter: `userName gt "B"`, Expected: []string{"bob", "Ärger"}},
+		// other attributes are folded in the same way on both sides
+		{Filter: `displayName eq "alice smith"`, Expected: []string{"Alice"}},
+		{Filter: `displayName eq "Émile Zola"`, Expected: []string{"Ärger"}},
+		{Filter: `displayName eq "ÉMILE ZOLA"`, Expected: []string{"Ärger"}},
+		{Filter: `displayName sw "Ém"`, Expected: []string{"Ärger"}},
+		{Filter: `displayName co "ZOLA"`, Expected: []string{"Ärger"}},
+		{Filter: `displayName ge "BOB"`, Expected: []string{"bob", "Ärger"}},
+		{Filter: `displayName lt "B"`, Expected: []string{"Alice"}},
+		// externalId is case exact
+		{Filter: `externalId eq "abc"`, Expected: []string{"Alice"}},
+		{Filter: `externalId eq "ABC"`, Expected: []string{"Ärger"}},
+		{Filter: `externalId sw "a"`, Expected: []string{"Alice"}},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+	}
+}
+
+func TestTypedFilterValues(t *testing.T) {
+	b := newBackend(t)
+	createUsers(t, b,
+		`{"userName":"alice","active":true,"emails":[{"value":"alice@example.com","type":"work","primary":true}]}`,
+		`{"userName":"bob","active":false,"emails":[{"value":"bob@example.com","type":"work"},{"value":"bob@example.org","type":"home","primary":true}]}`,
+		`{"userName":"carol"}`,
+	)
+
+	testcases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		{Filter: `active eq true`, Expected: []string{"alice"}},
+		{Filter: `active eq false`, Expected: []string{"bob"}},
+		{Filter: `active ne false`, Expected: []string{"alice"}},
+		{Filter: `emails.primary eq true`, Expected: []string{"alice", "bob"}},
+		{Filter: `emails[primary eq true]`, Expected: []string{"alice", "bob"}},
+		{Filter: `emails[type eq "work" and primary eq true]`, Expected: []string{"alice"}},
+		{Filter: `emails[type eq "home" or value sw "ALICE"]`, Expected: []string{"alice", "bob"}},
+		{Filter: `emails[value ew ".org"] and active eq false`, Expected: []string{"bob"}},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+	}
+
+	for _, src := range []string{
+		`active eq "true"`,
+		`active gt true`,
+		`userName eq 1`,
+		`userName eq true`,
+		`emails[primary eq "true"]`,
+		`emails[primary ge false]`,
+		`emails[bogus eq "x"]`,
+		`meta.lastModified gt "yesterday"`,
+	} {
+		_, err := b.SearchUser(context.Background(), filterRequest(t, src))
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `%s: scimType should be invalidFilter`, src)
+	}
+}
+
+func TestPresenceFilters(t *testing.T) {
+	b := newBackend(t)
+	ids := createUsers(t, b,
+		`{"userName":"alice","active":true,"nickName":"al","password":"s3cr3t","name":{"givenName":"Alice"},"emails":[{"value":"alice@example.com","type":"work"}]}`,
+		`{"userName":"bob","nickName":"","name":{"familyName":"Smith"},"roles":[{"value":"admin"}]}`,
+		`{"userName":"carol"}`,
+	)
+
+	var in resource.Group
+	decode(t, `{"displayName":"staff","members":[{"value":"`+ids["alice"]+`"}]}`, &in)
+	_, err := b.CreateGroup(context.Background(), &in)
+	require.NoError(t, err, `CreateGroup should succeed`)
+	decode(t, `{"displayName":"empty","externalId":"e-1"}`, &in)
+	_, err = b.CreateGroup(context.Background(), &in)
+	require.NoError(t, err, `CreateGroup should succeed`)
+
+	testcases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		{Filter: `nickName pr`, Expected: []string{"alice"}},
+		{Filter: `active pr`, Expected: []string{"alice"}},
+		{Filter: `name pr`, Expected: []string{"alice", "bob"}},
+		{Filter: `name.givenName pr`, Expected: []string{"alice"}},
+		{Filter: `emails pr`, Expected: []string{"alice"}},
+		{Filter: `emails.type pr`, Expected: []string{"alice"}},
+		{Filter: `roles pr`, Expected: []string{"bob"}},
+		{Filter: `groups pr`, Expected: []string{"alice"}},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+	}
+
+	require.Equal(t, []string{"staff"}, searchGroupNames(t, b, `members pr`), `members pr should match groups with members`)
+	require.Equal(t, []string{"staff"}, searchGroupNames(t, b, `members.value pr`), `members.value pr should match groups with members`)
+	require.Equal(t, []string{"empty"}, searchGroupNames(t, b, `externalId pr`), `externalId pr should match groups with an externalId`)
+
+	_, err = b.SearchUser(context.Background(), filterRequest(t, `password pr`))
+	serr := requireSCIMError(t, err, http.StatusBadRequest)
+	require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `scimType should be invalidFilter`)
+}
+
+func TestMembershipFilters(t *testing.T) {
+	b := newBackend(t)
+	ids := createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`, `{"userName":"carol"}`)
+
+	createGroup := func(payload string) string {
+		var in resource.Group
+		decode(t, payload, &in)
+		g, err := b.CreateGroup(context.Background(), &in)
+		require.NoError(t, err, `CreateGroup should succeed`)
+		return g.ID()
+	}
+	backend := createGroup(`{"displayName":"Backend","members":[{"value":"` + ids["bob"] + `","type":"User"}]}`)
+	engineering := createGroup(`{"displayName":"Engineering","members":[{"value":"` + ids["alice"] + `","type":"User"},{"value":"` + backend + `","type":"Group"}]}`)
+
+	testcases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		{Filter: `groups.value eq "` + backend + `"`, Expected: []string{"bob"}},
+		{Filter: `groups.value eq "` + engineering + `"`, Expected: []string{"alice", "bob"}},
+		{Filter: `groups[value eq "` + engineering + `" and type eq "direct"]`, Expected: []string{"alice"}},
+		{Filter: `groups[value eq "` + engineering + `" and type eq "indirect"]`, Expected: []string{"bob"}},
+		{Filter: `groups.display eq "engineering"`, Expected: []string{"alice", "bob"}},
+		{Filter: `groups.display sw "Back"`, Expected: []string{"bob"}},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+	}
+
+	require.Equal(t, []string{"Engineering"}, searchGroupNames(t, b, `members.value eq "`+ids["alice"]+`"`), `members.value should match direct members`)
+	require.Equal(t, []string{"Backend"}, searchGroupNames(t, b, `members.value eq "`+ids["bob"]+`"`), `members.value should match direct members`)
+	require.Equal(t, []string{"Engineering"}, searchGroupNames(t, b, `members[type eq "Group" and value eq "`+backend+`"]`), `value path should match nested groups`)
+	require.Equal(t, []string{}, searchGroupNames(t, b, `members.value eq "`+ids["carol"]+`"`), `members.value should not match non-members`)
+}
+
+func TestAttributeNames(t *testing.T) {
+	b := newBackend(t)
+	createUsers(t, b,
+		`{"userName":"alice","name":{"givenName":"Alice","familyName":"Smith"},"emails":[{"value":"alice@example.com","type":"work"}]}`,
+		`{"userName":"bob","name":{"givenName":"Bob"},"emails":[{"value":"bob@example.org","type":"home"}]}`,
+	)
+
+	testcases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		{Filter: `USERNAME eq "alice"`, Expected: []string{"alice"}},
+		{Filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bob"`, Expected: []string{"bob"}},
+		{Filter: `URN:IETF:PARAMS:SCIM:SCHEMAS:CORE:2.0:USER:USERNAME eq "bob"`, Expected: []string{"bob"}},
+		{Filter: `Emails.VALUE sw "alice"`, Expected: []string{"alice"}},
+		{Filter: `EMAILS[TYPE eq "home"]`, Expected: []string{"bob"}},
+		{Filter: `name.givenName eq "alice"`, Expected: []string{"alice"}},
+		{Filter: `NAME.FAMILYNAME sw "sm"`, Expected: []string{"alice"}},
+		{Filter: `urn:ietf:params:scim:schemas:core:2.0:User:name.givenName eq "Bob"`, Expected: []string{"bob"}},
+	}
+	for _, tc := range testcases {
+		require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+	}
+
+	// attributes of other schemas are not attributes of Users
+	for _, src := range []string{
+		`urn:ietf:params:scim:schemas:core:2.0:Group:displayName eq "alice"`,
+		`name.bogus eq "alice"`,
+	} {
+		_, err := b.SearchUser(context.Background(), filterRequest(t, src))
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `%s: scimType should be invalidFilter`, src)
+	}
+}
+
+func TestRootSearch(t *testing.T) {
+	b := newBackend(t)
+	createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`, `{"userName":"carol"}`)
+	for _, name := range []string{"staff", "admins"} {
+		var in resource.Group
+		decode(t, `{"displayName":"`+name+`"}`, &in)
+		_, err := b.CreateGroup(context.Background(), &in)
+		require.NoError(t, err, `CreateGroup should succeed`)
+	}
+
+	// search returns the resource types and IDs of a root level search
+	search := func(t *testing.T, src string) (*resource.ListResponse, []string) {
+		t.Helper()
+
+		var req resource.SearchRequest
+		decode(t, src, &req)
+		res, err := b.Search(context.Background(), &req)
+		require.NoError(t, err, `Search(%s) should succeed`, src)
+
+		list := []string{}
+		for _, r := range res.Resources() {
+			switch r := r.(type) {
+			case *resource.User:
+				list = append(list, "User/"+r.ID())
+			case *resource.Group:
+				list = append(list, "Group/"+r.ID())
+			default:
+				t.Fatalf(`unexpected resource %T`, r)
+			}
+		}
+		return res, list
+	}
+
+	t.Run("resource type filter", func(t *testing.T) {
+		res, list := search(t, `{"filter":"meta.resourceType eq \"User\""}`)
+		require.Equal(t, 3, res.TotalResults(), `totalResults should count users only`)
+		require.Len(t, list, 3, `only users should be returned`)
+		res, list = search(t, `{"filter":"meta.resourceType eq \"Group\""}`)
+		require.Equal(t, 2, res.TotalResults(), `totalResults should count groups only`)
+		require.Len(t, list, 2, `only groups should be returned`)
+		for _, v := range list {
+			require.True(t, strings.HasPrefix(v, "Group/"), `%s should be a group`, v)
+		}
+	})
+
+	t.Run("deterministic pagination", func(t *testing.T) {
+		const filter = `"filter":"meta.resourceType eq \"User\" or meta.resourceType eq \"Group\""`
+		_, all := search(t, `{`+filter+`}`)
+		require.Len(t, all, 5, `all resources should be returned`)
+		_, again := search(t, `{`+filter+`}`)
+		require.Equal(t, all, again, `results should be in the same order across requests`)
+		for i, v := range all {
+			// users come first
+			require.Equal(t, i < 3, strings.HasPrefix(v, "User/"), `%s should be at the right place`, v)
+		}
+
+		var pages []string
+		for _, startIndex := range []int{1, 3, 5} {
+			res, list := search(t, fmt.Sprintf(`{%s,"startIndex":%d,"count":2}`, filter, startIndex))
+			require.Equal(t, 5, res.TotalResults(), `totalResults should count the union`)
+			require.Equal(t, startIndex, res.StartIndex(), `startIndex should be reported`)
+			require.Equal(t, len(list), res.ItemsPerPage(), `itemsPerPage should be the page size`)
+			pages = append(pages, list...)
+		}
+		require.Equal(t, all, pages, `pages should cover the results in order`)
+
+		res, list := search(t, `{`+filter+`,"startIndex":10,"count":2}`)
+		require.Equal(t, 5, res.TotalResults(), `totalResults should count the union`)
+		require.Empty(t, list, `pages beyond the end should be empty`)
+	})
+}
+
+func TestStrictFilters(t *testing.T) {
+	// backend creates a backend holding a few users and groups
+	backend := func(t *testing.T, options ...interface{}) *server.Backend {
+		t.Helper()
+
+		b := newBackend(t, options...)
+		createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob","displayName":"staff"}`)
+		var in resource.Group
+		decode(t, `{"displayName":"staff"}`, &in)
+		_, err := b.CreateGroup(context.Background(), &in)
+		require.NoError(t, err, `CreateGroup should succeed`)
+		return b
+	}
+
+	// at the root level, a clause on an attribute of a single resource
+	// type does not match the other
+	requireRootSearch := func(t *testing.T, b *server.Backend) {
+		t.Helper()
+
+		res, err := b.Search(context.Background(), filterRequest(t, `userName eq "alice"`))
+		require.NoError(t, err, `Search should succeed`)
+		require.Equal(t, 1, res.TotalResults(), `only the user should match`)
+
+		res, err = b.Search(context.Background(), filterRequest(t, `displayName eq "staff"`))
+		require.NoError(t, err, `Search should succeed`)
+		require.Equal(t, 2, res.TotalResults(), `both the user and the group should match`)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		b := backend(t)
+		for _, src := range []string{
+			`name.bogus eq "x"`,
+			`userName eq "alice" and name.bogus eq "x"`,
+			`userName eq "alice" or name.bogus pr`,
+			`emails[bogus eq "x"]`,
+		} {
+			_, err := b.SearchUser(context.Background(), filterRequest(t, src))
+			serr := requireSCIMError(t, err, http.StatusBadRequest)
+			require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `%s: scimType should be invalidFilter`, src)
+			require.Contains(t, serr.Detail(), `bogus`, `%s: error should name the clause`, src)
+		}
+		requireRootSearch(t, b)
+	})
+	t.Run("lenient", func(t *testing.T) {
+		b := backend(t, server.WithLenientFilters(true))
+
+		// clauses that cannot be translated are dropped, and only them
+		testcases := []struct {
+			Filter   string
+			Expected []string
+		}{
+			{Filter: `userName eq "alice" and name.bogus eq "x"`, Expected: []string{"alice"}},
+			{Filter: `name.bogus eq "x" or userName eq "bob"`, Expected: []string{"bob"}},
+			{Filter: `name.bogus eq "x"`, Expected: []string{"alice", "bob"}},
+		}
+		for _, tc := range testcases {
+			require.Equal(t, tc.Expected, searchUserNames(t, b, tc.Filter), `results of %s should match`, tc.Filter)
+		}
+		requireRootSearch(t, b)
+	})
+}
+
+func TestFilterCache(t *testing.T) {
+	b := newBackend(t, server.WithFilterCacheSize(8))
+	createUsers(t, b,
+		`{"userName":"alice","emails":[{"value":"alice@example.com"}]}`,
+		`{"userName":"bob","emails":[{"value":"bob@example.com"}]}`,
+	)
+	var in resource.Group
+	decode(t, `{"displayName":"staff"}`, &in)
+	_, err := b.CreateGroup(context.Background(), &in)
+	require.NoError(t, err, `CreateGroup should succeed`)
+
+	t.Run("filters with the same template", func(t *testing.T) {
+		require.Equal(t, []string{"alice"}, searchUserNames(t, b, `userName eq "alice"`), `first search should match`)
+		require.Equal(t, []string{"bob"}, searchUserNames(t, b, `userName eq "bob"`), `cached filter should be bound to the new value`)
+		require.Equal(t, []string{"bob"}, searchUserNames(t, b, `userName eq "BOB"`), `cached filter should be bound to the new value`)
+
+		stats := b.FilterCacheStats()
+		require.Equal(t, 1, stats.Size, `filters should share the same entry`)
+		require.EqualValues(t, 2, stats.Hits, `later searches should hit the cache`)
+
+		// values of another type are compiled on their own
+		_, err := b.SearchUser(context.Background(), filterRequest(t, `userName eq 1`))
+		requireSCIMError(t, err, http.StatusBadRequest)
+	})
+	t.Run("attribute policy", func(t *testing.T) {
+		require.Equal(t, []string{"alice"}, searchUserNames(t, b, `emails.value eq "alice@example.com"`), `search should match`)
+
+		helpdesk := server.WithPrincipal(context.Background(), server.NewPrincipal("helpdesk", server.ScopeUsersRead).
+			WithAttributePolicy(server.NewAttributePolicy().DenyRead(`User`, "emails")))
+		_, err := b.SearchUser(helpdesk, filterRequest(t, `emails.value eq "bob@example.com"`))
+		requireSCIMError(t, err, http.StatusForbidden)
+	})
+	t.Run("root level", func(t *testing.T) {
+		// clauses that only apply to one of the resource types are not
+		// cached, as whether they apply may depend on their value
+		size := b.FilterCacheStats().Size
+		for _, name := range []string{"alice", "bob", "staff"} {
+			res, err := b.Search(context.Background(), filterRequest(t, `userName eq "`+name+`"`))
+			require.NoError(t, err, `Search should succeed`)
+			require.Equal(t, name != "staff", res.TotalResults() == 1, `%s: only users should match`, name)
+		}
+		require.Equal(t, size, b.FilterCacheStats().Size, `filter should not be cached`)
+
+		res, err := b.Search(context.Background(), filterRequest(t, `displayName eq "staff"`))
+		require.NoError(t, err, `Search should succeed`)
+		require.Equal(t, 1, res.TotalResults(), `the group should match`)
+		require.Equal(t, size+1, b.FilterCacheStats().Size, `filter should be cached`)
+	})
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/cybozu-go/scim/schema"
+)
+
+// projectAttributes computes the list of attributes to load for a resource,
+// given the "attributes" and "excludedAttributes" parameters of a request
+// (RFC7644 Section 3.4.2.5) and the "returned" characteristic of each
+// attribute in the schema (RFC7643 Section 7):
+//
+//   - "never" attributes (e.g. password) are not returned, even when requested
+//   - "always" attributes (e.g. id) are returned, even when excluded
+//   - "request" attributes are only returned when requested
+//   - "default" attributes are returned unless excluded, or unless the
+//     client explicitly requested a different set of attributes
+//
+// Attribute names are matched case insensitively, and sub-attribute
+// paths such as "name.givenName" select their top level attribute.
+func projectAttributes(resourceType string, fields, excludedFields []string) []string {
+	keys := attributeKeys(resourceType)
+
+	returned := make(map[string]string, len(keys))
+	if s, ok := schema.GetByResourceType(resourceType); ok {
+		for _, attr := range s.Attributes() {
+			returned[strings.ToLower(attr.Name())] = string(attr.Returned())
+		}
+	}
+
+	requested := make(attributeSet)
+	for _, name := range fields {
+		requested.Add(topLevelAttribute(name))
+	}
+	excluded := make(attributeSet)
+	for _, name := range excludedFields {
+		excluded.Add(topLevelAttribute(name))
+	}
+
+	list := make([]string, 0, len(keys))
+	for _, key := range keys {
+		switch returned[strings.ToLower(key)] {
+		case returnedNever:
+			continue
+		case returnedAlways:
+		case returnedRequest:
+			if !requested.Has(key) {
+				continue
+			}
+		default:
+			if len(requested) > 0 && !requested.Has(key) {
+				continue
+			}
+			if excluded.Has(key) {
+				continue
+			}
+		}
+		list = append(list, key)
+	}
+	return list
+}
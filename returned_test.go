@@ -0,0 +1,64 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturned(t *testing.T) {
+	b := newBackend(t)
+	ctx := context.Background()
+
+	var in resource.User
+	decode(t, `{"userName":"alice","displayName":"Alice","nickName":"al","password":"s3cr3t"}`, &in)
+	u, err := b.CreateUser(ctx, &in)
+	require.NoError(t, err, `CreateUser should succeed`)
+	require.False(t, u.HasPassword(), `password should never be returned`)
+
+	t.Run("projection", func(t *testing.T) {
+		u, err := b.RetrieveUser(ctx, u.ID(), []string{"displayName", "password"}, nil)
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Equal(t, "Alice", u.DisplayName(), `requested attribute should be returned`)
+		require.Equal(t, "", u.NickName(), `other attributes should not be returned`)
+		require.NotEmpty(t, u.ID(), `id should always be returned`)
+		require.False(t, u.HasPassword(), `password should not be returned even when requested`)
+
+		u, err = b.RetrieveUser(ctx, u.ID(), nil, []string{"nickName", "id"})
+		require.NoError(t, err, `RetrieveUser should succeed`)
+		require.Equal(t, "", u.NickName(), `excluded attribute should not be returned`)
+		require.Equal(t, "Alice", u.DisplayName(), `other attributes should be returned`)
+		require.NotEmpty(t, u.ID(), `id should be returned even when excluded`)
+	})
+	t.Run("filters on never returned attributes", func(t *testing.T) {
+		lenient := newBackend(t, server.WithLenientFilters(true))
+		_, err := lenient.CreateUser(ctx, &in)
+		require.NoError(t, err, `CreateUser should succeed`)
+
+		for _, src := range []string{
+			`password eq "s3cr3t"`,
+			`password sw "s"`,
+			`password co "cr"`,
+			`password ew "t"`,
+			`password gt "a"`,
+			`PASSWORD eq "s3cr3t"`,
+			`urn:ietf:params:scim:schemas:core:2.0:User:password eq "s3cr3t"`,
+			`userName eq "alice" and password sw "s"`,
+		} {
+			req := filterRequest(t, src)
+			_, err := b.SearchUser(ctx, req)
+			serr := requireSCIMError(t, err, http.StatusBadRequest)
+			require.Equal(t, resource.ErrInvalidFilter, serr.ScimType(), `%s: scimType should be invalidFilter`, src)
+
+			_, err = b.Search(ctx, req)
+			requireSCIMError(t, err, http.StatusBadRequest)
+
+			_, err = lenient.SearchUser(ctx, req)
+			requireSCIMError(t, err, http.StatusBadRequest)
+		}
+	})
+}
@@ -6,17 +6,21 @@ package server
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/cybozu-go/scim-server/ent"
 	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/member"
 	"github.com/cybozu-go/scim-server/ent/predicate"
 	"github.com/cybozu-go/scim-server/ent/user"
 	"github.com/cybozu-go/scim/filter"
@@ -30,9 +34,6 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// TODO: remove these when they have been incorporated
-var _ = groupPresencePredicate
-
 var entTrace bool
 
 func init() {
@@ -55,24 +56,164 @@ func splitScimField(s string) (string, string, error) {
 }
 
 type Backend struct {
-	db       *ent.Client
-	spc      *resource.ServiceProviderConfig
-	rts      []*resource.ResourceType
-	etagSalt []byte
+	db               *ent.Client
+	dialect          string
+	sqldb            *sql.DB
+	spc              *resource.ServiceProviderConfig
+	etagSalt         []byte
+	baseURL          string
+	maxResults       int
+	patchable        bool
+	filterable       bool
+	lenient          bool
+	fullText         bool
+	filters          *filterCache
+	uniqueExternalID bool
+	strictCanonical  bool
+	upsertAll        bool
+	upsertKey        UpsertKey
+	idempotencyTTL   time.Duration
+	idempotencyLease time.Duration
+	retention        time.Duration
+	audit            bool
+	logger           Logger
+	clock            Clock
+
+	// tx is set on the copies of the Backend whose queries run in a
+	// transaction, see inTx
+	tx bool
 }
 
-func New(connspec string, options ...ent.Option) (*Backend, error) {
+// New creates a new Backend. The options may be either Backend options
+// (Option, e.g. WithBaseURL) or options for the underlying ent.Client
+// (ent.Option, e.g. ent.Bucket), which may also be wrapped with
+// WithEntOption.
+func New(connspec string, options ...interface{}) (*Backend, error) {
+	var baseURL string
+	dialectName := dialect.SQLite
+	var db *dbSpec
+	maxResults := 200 // TODO: arbitrary value used
+	patchable := true
+	filterable := true
+	lenient := false
+	fullText := false
+	filterCacheSize := 1024
+	uniqueExternalID := false
+	strictCanonical := false
+	upsertAll := false
+	upsertKey := UpsertByName
+	idempotencyTTL := 24 * time.Hour
+	idempotencyLease := time.Minute
+	var retention time.Duration
+	audit := false
+	etag := true
+	documentationURI := `http://example.com/help`
+	var authSchemes []*resource.AuthenticationScheme
+	var logger Logger = nilLogger{}
+	var clock Clock = ClockFunc(time.Now)
+	var entOptions []ent.Option
+	for _, v := range options {
+		var o Option
+		switch v := v.(type) {
+		case ent.Option:
+			entOptions = append(entOptions, v)
+			continue
+		case Option:
+			o = v
+		default:
+			return nil, fmt.Errorf(`invalid option of type %T (must be an Option or an ent.Option)`, v)
+		}
+
+		switch o.Ident() {
+		case identEntOption{}:
+			entOptions = append(entOptions, o.Value().([]ent.Option)...)
+		case identBaseURL{}:
+			baseURL = strings.TrimSuffix(o.Value().(string), `/`)
+		case identDialect{}:
+			dialectName = o.Value().(string)
+		case identDB{}:
+			v := o.Value().(dbSpec)
+			db = &v
+		case identMaxResults{}:
+			maxResults = o.Value().(int)
+		case identPatchSupport{}:
+			patchable = o.Value().(bool)
+		case identFilterSupport{}:
+			filterable = o.Value().(bool)
+		case identLenientFilters{}:
+			lenient = o.Value().(bool)
+		case identFullTextSearch{}:
+			fullText = o.Value().(bool)
+		case identFilterCacheSize{}:
+			filterCacheSize = o.Value().(int)
+		case identExternalIDUniqueness{}:
+			uniqueExternalID = o.Value().(bool)
+		case identStrictCanonicalValues{}:
+			strictCanonical = o.Value().(bool)
+		case identUpsert{}:
+			upsertAll = o.Value().(bool)
+		case identUpsertKey{}:
+			upsertKey = o.Value().(UpsertKey)
+		case identIdempotencyKeyTTL{}:
+			idempotencyTTL = o.Value().(time.Duration)
+		case identIdempotencyKeyLease{}:
+			idempotencyLease = o.Value().(time.Duration)
+		case identSoftDelete{}:
+			retention = o.Value().(time.Duration)
+		case identAudit{}:
+			audit = o.Value().(bool)
+		case identETagSupport{}:
+			etag = o.Value().(bool)
+		case identDocumentationURI{}:
+			documentationURI = o.Value().(string)
+		case identAuthenticationSchemes{}:
+			authSchemes = o.Value().([]*resource.AuthenticationScheme)
+		case identLogger{}:
+			logger = o.Value().(Logger)
+		case identClock{}:
+			clock = o.Value().(Clock)
+		}
+	}
+
+	switch upsertKey {
+	case UpsertByName:
+	case UpsertByExternalID:
+		// upserts must find at most one resource
+		uniqueExternalID = true
+	default:
+		return nil, fmt.Errorf(`invalid upsert key %q`, upsertKey)
+	}
+
+	if idempotencyTTL <= 0 {
+		return nil, fmt.Errorf(`invalid idempotency key TTL %s (must be positive)`, idempotencyTTL)
+	}
+	if idempotencyLease <= 0 {
+		return nil, fmt.Errorf(`invalid idempotency key lease %s (must be positive)`, idempotencyLease)
+	}
+
 	var b resource.Builder
-	spc, err := b.ServiceProviderConfig().
-		AuthenticationSchemes(
+	if authSchemes == nil {
+		authSchemes = []*resource.AuthenticationScheme{
 			b.AuthenticationScheme().
 				Name("OAuth Bearer Token").
 				Description("Authentication scheme using the OAuth Bearer Token Standard").
 				SpecURI("http://www.rfc-editor.org/info/rfc6750").
-				DocumentationURI("http://example.com/help/oauth.html").
+				DocumentationURI(documentationURI + "/oauth.html").
 				Type(resource.OAuthBearerToken).
 				MustBuild(),
-		).
+			b.AuthenticationScheme().
+				Name("Mutual TLS").
+				Description("Authentication scheme using TLS client certificates").
+				SpecURI("https://www.rfc-editor.org/info/rfc8705").
+				DocumentationURI(documentationURI + "/mtls.html").
+				Type(mutualTLSSchemeType).
+				MustBuild(),
+		}
+	}
+
+	spc, err := b.ServiceProviderConfig().
+		DocumentationURI(documentationURI).
+		AuthenticationSchemes(authSchemes...).
 		Bulk(b.BulkSupport().
 			Supported(false).
 			MaxOperations(0).
@@ -80,12 +221,12 @@ func New(connspec string, options ...ent.Option) (*Backend, error) {
 			MustBuild(),
 		).
 		ETag(b.GenericSupport().
-			Supported(true).
+			Supported(etag).
 			MustBuild(),
 		).
 		Filter(b.FilterSupport().
-			Supported(true).
-			MaxResults(200). // TODO: arbitrary value used
+			Supported(filterable).
+			MaxResults(maxResults).
 			MustBuild(),
 		).
 		Sort(b.GenericSupport().
@@ -111,7 +252,7 @@ func New(connspec string, options ...ent.Option) (*Backend, error) {
 		//   "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User" to the
 		//   resource's "schemas" attribute.
 		Patch(b.GenericSupport().
-			Supported(true).
+			Supported(patchable).
 			MustBuild(),
 		).
 		ChangePassword(b.GenericSupport().
@@ -123,10 +264,23 @@ func New(connspec string, options ...ent.Option) (*Backend, error) {
 		return nil, fmt.Errorf(`failed to setup ServiceProviderConfig: %w`, err)
 	}
 
-	client, err := ent.Open(dialect.SQLite, connspec, options...)
-	if err != nil {
-		return nil, fmt.Errorf(`failed to open database: %w`, err)
+	if _, ok := logger.(nilLogger); !ok {
+		entOptions = append(entOptions, ent.Log(func(args ...interface{}) {
+			logger.Printf(`%s`, fmt.Sprint(args...))
+		}))
+	}
+
+	var drv *entsql.Driver
+	if db != nil {
+		dialectName = db.dialect
+		drv = entsql.OpenDB(db.dialect, db.db)
+	} else {
+		drv, err = entsql.Open(dialectName, connspec)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to open database: %w`, err)
+		}
 	}
+	client := ent.NewClient(append(entOptions, ent.Driver(drv))...)
 
 	if entTrace {
 		client = client.Debug()
@@ -136,40 +290,159 @@ func New(connspec string, options ...ent.Option) (*Backend, error) {
 		return nil, fmt.Errorf(`failed to create schema resources: %w`, err)
 	}
 
-	rts := []*resource.ResourceType{
-		b.ResourceType().
-			ID("User").
-			Name("User").
-			Endpoint("/Users").
-			Description("User Account").
-			Schema(resource.UserSchemaURI).
-			SchemaExtensions(
-				b.SchemaExtension().
-					Schema(
-						resource.EnterpriseUserSchemaURI).
-					MustBuild(),
-			).
-			MustBuild(),
-		b.ResourceType().
-			ID("Group").
-			Name("Group").
-			Endpoint("/Groups").
-			Description("Group").
-			Schema(resource.GroupSchemaURI).
-			MustBuild(),
+	if err := normalizeUserNames(context.Background(), client); err != nil {
+		return nil, err
+	}
+	if err := normalizeDisplayNames(context.Background(), client); err != nil {
+		return nil, err
+	}
+
+	setupExternalIDClient(client)
+	for _, idx := range externalIDIndexes {
+		if err := setupUniqueIndex(context.Background(), dialectName, client, idx, uniqueExternalID); err != nil {
+			return nil, err
+		}
+	}
+	// upserts by name must find at most one Group
+	if err := setupUniqueIndex(context.Background(), dialectName, client, groupDisplayNameIndex, upsertKey == UpsertByName); err != nil {
+		return nil, err
+	}
+
+	if fullText {
+		if err := setupFullTextSearch(context.Background(), dialectName, client); err != nil {
+			return nil, err
+		}
 	}
 
 	salt := make([]byte, 0, 256)
 	_, _ = rand.Read(salt)
 
 	return &Backend{
-		db:       client,
-		spc:      spc,
-		rts:      rts,
-		etagSalt: salt,
+		db:               client,
+		dialect:          dialectName,
+		sqldb:            drv.DB(),
+		spc:              spc,
+		etagSalt:         salt,
+		baseURL:          baseURL,
+		maxResults:       maxResults,
+		patchable:        patchable,
+		filterable:       filterable,
+		lenient:          lenient,
+		fullText:         fullText,
+		filters:          newFilterCache(filterCacheSize),
+		uniqueExternalID: uniqueExternalID,
+		strictCanonical:  strictCanonical,
+		upsertAll:        upsertAll,
+		upsertKey:        upsertKey,
+		idempotencyTTL:   idempotencyTTL,
+		idempotencyLease: idempotencyLease,
+		retention:        retention,
+		audit:            audit,
+		logger:           logger,
+		clock:            clock,
 	}, nil
 }
 
+// normalizeUserNames fills in the normalized userName of users created
+// before userName uniqueness became case insensitive. This fails if
+// there are users whose userName only differ by case
+func normalizeUserNames(ctx context.Context, client *ent.Client) error {
+	users, err := client.User.Query().
+		Where(user.NormalizedUserNameIsNil()).
+		Select(user.FieldID, user.FieldUserName).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf(`failed to query users without normalized userName: %w`, err)
+	}
+
+	for _, u := range users {
+		if err := client.User.UpdateOneID(u.ID).SetUserName(u.UserName).Exec(ctx); err != nil {
+			return fmt.Errorf(`failed to normalize userName %q: %w`, u.UserName, err)
+		}
+	}
+	return nil
+}
+
+// normalizeDisplayNames fills in the normalized displayName of groups
+// created before it was recorded
+func normalizeDisplayNames(ctx context.Context, client *ent.Client) error {
+	groups, err := client.Group.Query().
+		Where(group.NormalizedDisplayNameIsNil()).
+		Select(group.FieldID, group.FieldDisplayName).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf(`failed to query groups without normalized displayName: %w`, err)
+	}
+
+	for _, g := range groups {
+		if err := client.Group.UpdateOneID(g.ID).SetDisplayName(g.DisplayName).Exec(ctx); err != nil {
+			return fmt.Errorf(`failed to normalize displayName %q: %w`, g.DisplayName, err)
+		}
+	}
+	return nil
+}
+
+// uniqueIndex is a unique index that only covers the resources that are
+// not deleted. ent cannot declare such indexes, nor create them
+// depending on the options of the Backend, so they are managed by
+// setupUniqueIndex
+type uniqueIndex struct {
+	name    string
+	table   string
+	columns string
+}
+
+// setupUniqueIndex creates the index if enabled is true, and drops it
+// otherwise. Creating it fails if several resources have the same values
+func setupUniqueIndex(ctx context.Context, dialectName string, client *ent.Client, idx uniqueIndex, enabled bool) error {
+	switch dialectName {
+	case dialect.SQLite, dialect.Postgres:
+	default:
+		// partial indexes are required
+		if enabled {
+			return fmt.Errorf(`index %s requires SQLite or PostgreSQL (dialect is %q)`, idx.name, dialectName)
+		}
+		return nil
+	}
+
+	if !enabled {
+		if _, err := client.ExecContext(ctx, `DROP INDEX IF EXISTS `+idx.name); err != nil {
+			return fmt.Errorf(`failed to drop index %s: %w`, idx.name, err)
+		}
+		return nil
+	}
+
+	stmt := fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (%s) WHERE deleted_at IS NULL`, idx.name, idx.table, idx.columns)
+	if _, err := client.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf(`failed to create index %s (are there duplicate values?): %w`, idx.name, err)
+	}
+	return nil
+}
+
+// inTx runs f with a copy of the Backend whose queries run in a
+// transaction, which is committed if f succeeds. If the Backend is
+// already bound to a transaction, f runs in it
+func (b *Backend) inTx(ctx context.Context, f func(*Backend) error) error {
+	if b.tx {
+		return f(b)
+	}
+
+	tx, err := b.db.Tx(ctx)
+	if err != nil {
+		return internalError(fmt.Errorf(`failed to start transaction: %w`, err))
+	}
+	tb := *b
+	tb.db = tx.Client()
+	tb.tx = true
+	if err := f(&tb); err != nil {
+		return rollbackTx(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return convertError(fmt.Errorf(`failed to commit transaction: %w`, err), internalError)
+	}
+	return nil
+}
+
 func (b *Backend) Close() error {
 	return b.db.Close()
 }
@@ -283,52 +556,109 @@ func (b *Backend) generatePassword(in *resource.User) (string, error) {
 }
 
 func (b *Backend) RetrieveUser(ctx context.Context, id string, fields []string, excludedFields []string) (*resource.User, error) {
+	if err := b.authorize(ctx, ScopeUsersRead); err != nil {
+		return nil, err
+	}
+	return b.retrieveUser(ctx, id, fields, excludedFields)
+}
+
+func (b *Backend) retrieveUser(ctx context.Context, id string, fields []string, excludedFields []string) (*resource.User, error) {
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf(`failed to parse ID: %w`, err)
+		return nil, notFound(`User`, id)
 	}
 
 	userQuery := b.db.User.Query().
 		Unique(false).
-		Where(user.IDEQ(parsedUUID))
+		Where(user.IDEQ(parsedUUID), user.DeletedAtIsNil())
 
-	userLoadEntFields(userQuery, fields, excludedFields)
+	userLoadEntFields(userQuery, fields, readExclusions(ctx, `User`, excludedFields))
 
 	u, err := userQuery.
 		Only(ctx)
 	if err != nil {
-		return nil, resource.NewErrorBuilder().
-			Status(http.StatusNotFound).
-			Detail(fmt.Sprintf(`failed to retrieve user: %s`, err)).
-			ScimType(resource.ErrUnknown).
-			MustBuild()
+		if ent.IsNotFound(err) {
+			return nil, notFound(`User`, id)
+		}
+		return nil, internalError(fmt.Errorf(`failed to retrieve user: %w`, err))
 	}
 
-	return UserResourceFromEnt(u)
+	scrubUser(ctx, u)
+	return UserResourceFromEnt(b.baseURLFor(ctx), u)
 }
 
-func (b *Backend) DeleteUser(ctx context.Context, id string) error {
+func (b *Backend) DeleteUser(ctx context.Context, id string) (err error) {
+	ctx, audited := b.auditFailures(ctx, AuditDelete, `User`, id, nil)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return err
+	}
+
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf(`failed to parse ID: %w`, err)
+		return notFound(`User`, id)
 	}
 
-	if err := b.db.User.DeleteOneID(parsedUUID).Exec(ctx); err != nil {
-		return fmt.Errorf(`failed to delete user: %w`, err)
+	if b.retention > 0 {
+		return b.softDeleteUser(ctx, parsedUUID)
 	}
 
-	return nil
+	return b.inTx(ctx, func(b *Backend) error {
+		// the last state of the User is recorded first, as its history
+		// outlives it
+		if err := b.recordUserVersion(ctx, b.db, parsedUUID, VersionDeleted); err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`User`, id)
+			}
+			return internalError(err)
+		}
+
+		// the Member rows linking to the User would otherwise be left
+		// dangling
+		_, groups, err := takeMemberships(ctx, b.db, member.Value(parsedUUID.String()))
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.User.DeleteOneID(parsedUUID).Exec(ctx); err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`User`, id)
+			}
+			return internalError(fmt.Errorf(`failed to delete user: %w`, err))
+		}
+		if err := b.membersChanged(ctx, b.db, parsedUUID, groups); err != nil {
+			return internalError(err)
+		}
+		return b.recordAudit(ctx, b.db, AuditDelete, `User`, id, nil)
+	})
 }
 
 // XXX passing these boolean variables is so ugly
-func (b *Backend) buildWhere(src string, buildUsers, buildGroups bool) ([]predicate.User, []predicate.Group, error) {
+func (b *Backend) buildWhere(ctx context.Context, src string, buildUsers, buildGroups bool) ([]predicate.User, []predicate.Group, error) {
+	policy := attributePolicyFromContext(ctx)
+
+	// Filters that only differ by their literal values are compiled
+	// once, and the compiled filter is bound to the values of each
+	// search. If binding fails, the filter is compiled again so that
+	// the error is reported as usual
+	tmpl, args, cacheable := filterTemplate(src)
+	key := filterCacheKey(tmpl, buildUsers, buildGroups)
+	if cacheable {
+		if f, ok := b.filters.get(key); ok && f.readable(policy) {
+			if users, groups, err := f.bind(args); err == nil {
+				return users, groups, nil
+			}
+		}
+	}
+
 	expr, err := filter.Parse(src)
 	if err != nil {
-		return nil, nil, fmt.Errorf(`failed to parse filter: %w`, err)
+		return nil, nil, invalidFilter(err)
 	}
 
 	var v filterVisitor
 
+	v.policy = policy
 	v.uq = b.db.User.Query()
 	v.gq = b.db.Group.Query()
 
@@ -340,133 +670,305 @@ func (b *Backend) buildWhere(src string, buildUsers, buildGroups bool) ([]predic
 	// we do this by explicitly initializing the storage space
 	// (the []predicate.* fields) with a non-nill value
 	if buildUsers {
-		v.users = []predicate.User{}
+		v.users = []userBinder{}
 	}
 	if buildGroups {
-		v.groups = []predicate.Group{}
+		v.groups = []groupBinder{}
 	}
 
+	v.lenient = b.lenient
 	if err := v.visit(expr); err != nil {
-		return nil, nil, fmt.Errorf(`failed to parse filter expression: %w`, err)
+		return nil, nil, convertError(err, invalidFilter)
+	}
+	for _, d := range v.dropped {
+		b.logger.Printf(`dropped filter clause %s: %s`, d.Clause, d.Reason)
+	}
+
+	f := &compiledFilter{users: v.users, groups: v.groups, attrs: v.attrs}
+	users, groups, err := f.bind(v.args)
+	if err != nil {
+		return nil, nil, convertError(err, invalidFilter)
 	}
 
-	return v.users, v.groups, nil
+	// Filters with dropped or substituted clauses are not cached, as
+	// whether a clause can be translated may depend on its value
+	if cacheable && len(v.dropped) == 0 && len(v.substituted) == 0 && sameArgs(args, v.args) {
+		b.filters.add(key, f)
+	}
+	return users, groups, nil
 }
 
 func (b *Backend) Search(ctx context.Context, in *resource.SearchRequest) (*resource.ListResponse, error) {
-	return b.search(ctx, in, true, true)
+	// Searching at the root level only covers the resource types that
+	// the client is allowed to read
+	searchUser := b.canRead(ctx, ScopeUsersRead)
+	searchGroup := b.canRead(ctx, ScopeGroupsRead)
+	if !searchUser && !searchGroup {
+		return nil, b.authorize(ctx, ScopeUsersRead)
+	}
+	return b.search(ctx, in, searchUser, searchGroup)
 }
 
 func (b *Backend) SearchUser(ctx context.Context, in *resource.SearchRequest) (*resource.ListResponse, error) {
+	if err := b.authorize(ctx, ScopeUsersRead); err != nil {
+		return nil, err
+	}
 	return b.search(ctx, in, true, false)
 }
 
 func (b *Backend) SearchGroup(ctx context.Context, in *resource.SearchRequest) (*resource.ListResponse, error) {
+	if err := b.authorize(ctx, ScopeGroupsRead); err != nil {
+		return nil, err
+	}
 	return b.search(ctx, in, false, true)
 }
 
+// notImplemented builds the error returned for operations that have
+// been disabled when the Backend was created
+func notImplemented(what string) error {
+	return resource.NewErrorBuilder().
+		Status(http.StatusNotImplemented).
+		ScimType(resource.ErrUnknown).
+		Detail(fmt.Sprintf(`%s is not supported by this server`, what)).
+		MustBuild()
+}
+
 func (b *Backend) search(ctx context.Context, in *resource.SearchRequest, searchUser, searchGroup bool) (*resource.ListResponse, error) {
-	userWhere, groupWhere, err := b.buildWhere(in.Filter(), searchUser, searchGroup)
-	if err != nil {
-		return nil, fmt.Errorf(`failed to parse filter: %w`, err)
+	if !b.filterable && in.Filter() != "" {
+		return nil, notImplemented(`filtering`)
 	}
 
-	if in.Filter() != "" && (len(userWhere) == 0 && len(groupWhere) == 0) {
-		var builder resource.Builder
-		return builder.ListResponse().
-			TotalResults(0).
-			Build()
+	var userWhere []predicate.User
+	var groupWhere []predicate.Group
+	var err error
+	if in.Filter() != "" {
+		userWhere, groupWhere, err = b.buildWhere(ctx, in.Filter(), searchUser, searchGroup)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Deleted resources may be kept as tombstones (see WithSoftDelete),
+	// which never match
+	userWhere = append(userWhere, user.DeletedAtIsNil())
+	groupWhere = append(groupWhere, group.DeletedAtIsNil())
+
+	// Results are ordered by resource type (Users, then Groups), and by
+	// id within each type, so that they are the same across requests and
+	// can be paginated. Only the requested page is loaded: it is located
+	// in the union of both types by counting the matching resources first
+	var userTotal, groupTotal int
+	if searchUser {
+		userTotal, err = b.db.User.Query().Where(userWhere...).Count(ctx)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to count users: %w`, err))
+		}
+	}
+	if searchGroup {
+		groupTotal, err = b.db.Group.Query().Where(groupWhere...).Count(ctx)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to count groups: %w`, err))
+		}
 	}
 
-	var listMu sync.Mutex
-	var list []interface{}
+	// RFC7644 Section 3.4.2.4: startIndex is 1-based, and values
+	// less than 1 are interpreted as 1. A negative count means that
+	// the client did not ask for a specific page size
+	startIndex := 1
+	if in.HasStartIndex() && in.StartIndex() > 1 {
+		startIndex = in.StartIndex()
+	}
+	count := -1
+	if in.HasCount() && in.Count() >= 0 {
+		count = in.Count()
+	}
+	if b.maxResults > 0 && (count < 0 || count > b.maxResults) {
+		count = b.maxResults
+	}
+
+	offset := startIndex - 1
+	userOffset, userLimit := pageWindow(offset, count, userTotal)
+	remaining := count
+	if remaining > 0 {
+		remaining -= userLimit
+	}
+	groupOffset, groupLimit := pageWindow(offset-userTotal, remaining, groupTotal)
+
+	baseURL := b.baseURLFor(ctx)
+
+	var users, groups []interface{}
 
 	var g rungroup.Group
-	if searchUser {
+	if searchUser && userLimit != 0 {
 		_ = g.Add(rungroup.ActorFunc(func(ctx context.Context) error {
-			users, err := b.db.User.Query().Where(userWhere...).
-				All(ctx)
+			userQuery := b.db.User.Query().
+				Where(userWhere...).
+				Order(ent.Asc(user.FieldID)).
+				Offset(userOffset).
+				Limit(userLimit)
+			userLoadEntFields(userQuery, in.Attributes(), readExclusions(ctx, `User`, in.ExcludedAttributes()))
+
+			list, err := userQuery.All(ctx)
 			if err != nil {
 				return fmt.Errorf(`failed to execute query: %w`, err)
 			}
 
-			for _, user := range users {
-				r, err := UserResourceFromEnt(user)
+			for _, user := range list {
+				scrubUser(ctx, user)
+				r, err := UserResourceFromEnt(baseURL, user)
 				if err != nil {
 					return fmt.Errorf(`failed to convert internal data to SCIM resource: %w`, err)
 				}
-				listMu.Lock()
-				list = append(list, r)
-				listMu.Unlock()
+				users = append(users, r)
 			}
 			return nil
 		}))
 	}
 
-	if searchGroup {
+	if searchGroup && groupLimit != 0 {
 		_ = g.Add(rungroup.ActorFunc(func(ctx context.Context) error {
-			groups, err := b.db.Group.Query().Where(groupWhere...).
-				All(ctx)
+			groupQuery := b.db.Group.Query().
+				Where(groupWhere...).
+				Order(ent.Asc(group.FieldID)).
+				Offset(groupOffset).
+				Limit(groupLimit)
+			groupLoadEntFields(groupQuery, in.Attributes(), readExclusions(ctx, `Group`, in.ExcludedAttributes()))
+
+			list, err := groupQuery.All(ctx)
 			if err != nil {
 				return fmt.Errorf(`failed to execute query: %w`, err)
 			}
 
-			for _, group := range groups {
-				r, err := GroupResourceFromEnt(group)
+			for _, group := range list {
+				scrubGroup(ctx, group)
+				r, err := GroupResourceFromEnt(baseURL, group)
 				if err != nil {
 					return fmt.Errorf(`failed to convert internal data to SCIM resource: %w`, err)
 				}
-				listMu.Lock()
-				list = append(list, r)
-				listMu.Unlock()
+				groups = append(groups, r)
 			}
 			return nil
 		}))
 	}
 
 	if err := <-g.Run(ctx); err != nil {
-		return nil, err
+		return nil, internalError(err)
 	}
 
+	list := append(users, groups...)
+
 	var builder resource.Builder
 	return builder.ListResponse().
-		TotalResults(len(list)).
+		TotalResults(userTotal + groupTotal).
+		StartIndex(startIndex).
+		ItemsPerPage(len(list)).
 		Resources(list...).
 		Build()
 }
 
+// pageWindow returns the offset and the number of resources to load
+// from a table holding total matching resources, for a page starting
+// at offset and holding at most count resources (any number if count
+// is negative)
+func pageWindow(offset, count, total int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= total {
+		return total, 0
+	}
+	limit := total - offset
+	if count >= 0 && count < limit {
+		limit = count
+	}
+	return offset, limit
+}
+
 func (b *Backend) RetrieveGroup(ctx context.Context, id string, fields []string, excludedFields []string) (*resource.Group, error) {
+	if err := b.authorize(ctx, ScopeGroupsRead); err != nil {
+		return nil, err
+	}
+	return b.retrieveGroup(ctx, id, fields, excludedFields)
+}
+
+func (b *Backend) retrieveGroup(ctx context.Context, id string, fields []string, excludedFields []string) (*resource.Group, error) {
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf(`failed to parse ID: %w`, err)
+		return nil, notFound(`Group`, id)
 	}
 
 	groupQuery := b.db.Group.Query().
 		WithMembers().
-		Where(group.IDEQ(parsedUUID))
+		Where(group.IDEQ(parsedUUID), group.DeletedAtIsNil())
 
-	groupLoadEntFields(groupQuery, fields, excludedFields)
+	groupLoadEntFields(groupQuery, fields, readExclusions(ctx, `Group`, excludedFields))
 
 	g, err := groupQuery.
 		Only(ctx)
 	if err != nil {
-		return nil, fmt.Errorf(`failed to retrieve group %s: %w`, id, err)
+		if ent.IsNotFound(err) {
+			return nil, notFound(`Group`, id)
+		}
+		return nil, internalError(fmt.Errorf(`failed to retrieve group %s: %w`, id, err))
 	}
 
-	return GroupResourceFromEnt(g)
+	scrubGroup(ctx, g)
+	return GroupResourceFromEnt(b.baseURLFor(ctx), g)
 }
 
-func (b *Backend) DeleteGroup(ctx context.Context, id string) error {
+func (b *Backend) DeleteGroup(ctx context.Context, id string) (err error) {
+	ctx, audited := b.auditFailures(ctx, AuditDelete, `Group`, id, nil)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return err
+	}
+
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf(`failed to parse ID: %w`, err)
+		return notFound(`Group`, id)
 	}
 
-	if err := b.db.Group.DeleteOneID(parsedUUID).Exec(ctx); err != nil {
-		return fmt.Errorf(`failed to delete group: %w`, err)
+	if b.retention > 0 {
+		return b.softDeleteGroup(ctx, parsedUUID)
 	}
 
-	return nil
+	return b.inTx(ctx, func(b *Backend) error {
+		// the last state of the Group is recorded first, as its history
+		// outlives it
+		if err := b.recordGroupVersion(ctx, b.db, parsedUUID, VersionDeleted); err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`Group`, id)
+			}
+			return internalError(err)
+		}
+		users, err := memberUsers(ctx, b.db, parsedUUID)
+		if err != nil {
+			return internalError(err)
+		}
+
+		// the Member rows linking to the Group would otherwise be left
+		// dangling
+		_, groups, err := takeMemberships(ctx, b.db, member.Or(
+			member.Value(parsedUUID.String()),
+			member.HasGroupWith(group.ID(parsedUUID)),
+		))
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.Group.DeleteOneID(parsedUUID).Exec(ctx); err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`Group`, id)
+			}
+			return internalError(fmt.Errorf(`failed to delete group: %w`, err))
+		}
+		if err := b.membersChanged(ctx, b.db, parsedUUID, groups); err != nil {
+			return internalError(err)
+		}
+		if err := b.recordMemberVersions(ctx, b.db, users); err != nil {
+			return internalError(err)
+		}
+		return b.recordAudit(ctx, b.db, AuditDelete, `Group`, id, nil)
+	})
 }
 
 func (b *Backend) RetrieveServiceProviderConfig(_ context.Context) (*resource.ServiceProviderConfig, error) {
@@ -474,18 +976,81 @@ func (b *Backend) RetrieveServiceProviderConfig(_ context.Context) (*resource.Se
 	return b.spc, nil
 }
 
-func (b *Backend) RetrieveResourceTypes(_ context.Context) ([]*resource.ResourceType, error) {
-	// TODO: meta?
-	return b.rts, nil
+func (b *Backend) RetrieveResourceTypes(ctx context.Context) ([]*resource.ResourceType, error) {
+	// ResourceTypes are built on every request, as their locations
+	// depend on the base URL of the request
+	baseURL := b.baseURLFor(ctx)
+
+	var rb resource.Builder
+	return []*resource.ResourceType{
+		rb.ResourceType().
+			ID("User").
+			Name("User").
+			Endpoint("/Users").
+			Description("User Account").
+			Schema(resource.UserSchemaURI).
+			SchemaExtensions(
+				rb.SchemaExtension().
+					Schema(
+						resource.EnterpriseUserSchemaURI).
+					MustBuild(),
+			).
+			Meta(rb.Meta().
+				ResourceType("ResourceType").
+				Location(baseURL + "/ResourceTypes/User").
+				MustBuild(),
+			).
+			MustBuild(),
+		rb.ResourceType().
+			ID("Group").
+			Name("Group").
+			Endpoint("/Groups").
+			Description("Group").
+			Schema(resource.GroupSchemaURI).
+			Meta(rb.Meta().
+				ResourceType("ResourceType").
+				Location(baseURL + "/ResourceTypes/Group").
+				MustBuild(),
+			).
+			MustBuild(),
+	}, nil
 }
 
-func (b *Backend) ListSchemas(_ context.Context) (*resource.ListResponse, error) {
+// schemaResource adds the meta attribute to the schemas defined by
+// the resource package, which are shared and do not carry a location
+type schemaResource struct {
+	schema   *resource.Schema
+	location string
+}
+
+func (s schemaResource) MarshalJSON() ([]byte, error) {
+	buf, err := json.Marshal(s.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, err
+	}
+	m[`meta`] = map[string]interface{}{
+		`resourceType`: `Schema`,
+		`location`:     s.location,
+	}
+	return json.Marshal(m)
+}
+
+func (b *Backend) ListSchemas(ctx context.Context) (*resource.ListResponse, error) {
 	schemas := schema.All()
+	baseURL := b.baseURLFor(ctx)
 
 	// Need to convert this to interface{}
 	list := make([]interface{}, len(schemas))
 	for i, s := range schemas {
-		list[i] = s
+		list[i] = schemaResource{
+			schema:   s,
+			location: baseURL + "/Schemas/" + s.ID(),
+		}
 	}
 
 	var builder resource.Builder
@@ -495,10 +1060,13 @@ func (b *Backend) ListSchemas(_ context.Context) (*resource.ListResponse, error)
 		Build()
 }
 
+// RetrieveSchema returns the schema as defined in the resource package.
+// Unlike ListSchemas, the result does not include meta.location, as
+// *resource.Schema has no place to store it
 func (b *Backend) RetrieveSchema(_ context.Context, id string) (*resource.Schema, error) {
 	s, ok := schema.Get(id)
 	if !ok {
-		return nil, fmt.Errorf(`schema %q not found`, id)
+		return nil, notFound(`Schema`, id)
 	}
 	return s, nil
 }
@@ -522,118 +1090,149 @@ func exprStr(expr filter.Expr) (string, error) {
 	return sv, nil
 }
 
-func (b *Backend) PatchUser(ctx context.Context, id string, r *resource.PatchRequest) (*resource.User, error) {
-	tx, err := b.db.Tx(ctx)
-	if err != nil {
-		return nil, fmt.Errorf(`failed to start transaction`)
+func (b *Backend) PatchUser(ctx context.Context, id string, r *resource.PatchRequest) (_ *resource.User, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditPatch, `User`, id, r)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	if !b.patchable {
+		return nil, notImplemented(`PATCH`)
 	}
-	old := b.db
-	b.db = tx.Client()
-	defer func() {
-		b.db = old
-	}()
 
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, rollbackTx(tx, fmt.Errorf(`failed to parse ID: %w`, err))
+		return nil, notFound(`User`, id)
 	}
 
-	userQuery := b.db.User.Query().
-		Where(user.IDEQ(parsedUUID))
-
-	u, err := userQuery.
-		Only(ctx)
-	if err != nil {
-		return nil, rollbackTx(tx, fmt.Errorf(`failed to retrieve user: %w`, err))
-	}
+	var u2 *resource.User
+	err = b.inTx(ctx, func(b *Backend) error {
+		u, err := b.db.User.Query().
+			Where(user.IDEQ(parsedUUID), user.DeletedAtIsNil()).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`User`, id)
+			}
+			return internalError(fmt.Errorf(`failed to retrieve user: %w`, err))
+		}
 
-	retrieve := true
-	for _, op := range r.Operations() {
-		switch op.Op() {
-		case resource.PatchAdd:
-			if err := b.patchAddUser(ctx, u, op); err != nil {
-				return nil, rollbackTx(tx, err)
+		for _, op := range r.Operations() {
+			if err := validatePatchPath(op); err != nil {
+				return err
 			}
-		case resource.PatchRemove:
-			if err := b.patchRemoveUser(ctx, u, op); err != nil {
-				return nil, rollbackTx(tx, err)
+			if err := checkPatchWritable(ctx, `User`, op); err != nil {
+				return err
+			}
+			switch op.Op() {
+			case resource.PatchAdd:
+				if err := b.patchAddUser(ctx, u, op); err != nil {
+					return convertError(err, invalidValue)
+				}
+			case resource.PatchRemove:
+				if err := b.patchRemoveUser(ctx, u, op); err != nil {
+					return convertError(err, invalidValue)
+				}
+			default:
+				return invalidValue(fmt.Errorf(`unsupported patch operation %q`, op.Op()))
 			}
-		default:
-			return nil, rollbackTx(tx, err)
 		}
-	}
 
-	var u2 *resource.User
+		if err := b.recordUserVersion(ctx, b.db, parsedUUID, VersionPatched); err != nil {
+			return internalError(err)
+		}
 
-	if retrieve {
-		// This is silly, but we're going to have to re-load the object
-		u2, err = b.RetrieveUser(ctx, id, nil, nil)
-		if err != nil {
-			return nil, rollbackTx(tx, err)
+		if err := b.recordAudit(ctx, b.db, AuditPatch, `User`, id, r); err != nil {
+			return err
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf(`failed to commit transaction: %w`, err)
+		// This is silly, but we're going to have to re-load the object
+		u2, err = b.retrieveUser(ctx, id, nil, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return u2, nil
 }
 
-func (b *Backend) PatchGroup(ctx context.Context, id string, r *resource.PatchRequest) (*resource.Group, error) {
-	tx, err := b.db.Tx(ctx)
-	if err != nil {
-		return nil, fmt.Errorf(`failed to start transaction`)
+func (b *Backend) PatchGroup(ctx context.Context, id string, r *resource.PatchRequest) (_ *resource.Group, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditPatch, `Group`, id, r)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return nil, err
+	}
+	if !b.patchable {
+		return nil, notImplemented(`PATCH`)
 	}
-	old := b.db
-	b.db = tx.Client()
-	defer func() {
-		b.db = old
-	}()
 
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, rollbackTx(tx, fmt.Errorf(`failed to parse ID: %w`, err))
+		return nil, notFound(`Group`, id)
 	}
 
-	groupQuery := b.db.Group.Query().
-		Where(group.IDEQ(parsedUUID))
+	var g2 *resource.Group
+	err = b.inTx(ctx, func(b *Backend) error {
+		g, err := b.db.Group.Query().
+			Where(group.IDEQ(parsedUUID), group.DeletedAtIsNil()).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`Group`, id)
+			}
+			return internalError(fmt.Errorf(`failed to retrieve group: %w`, err))
+		}
 
-	g, err := groupQuery.
-		Only(ctx)
-	if err != nil {
-		return nil, rollbackTx(tx, fmt.Errorf(`failed to retrieve group: %w`, err))
-	}
+		// the members before and after the patch are affected
+		before, err := memberUsers(ctx, b.db, parsedUUID)
+		if err != nil {
+			return internalError(err)
+		}
 
-	retrieve := true
-	for _, op := range r.Operations() {
-		switch op.Op() {
-		case resource.PatchAdd:
-			if err := b.patchAddGroup(ctx, g, op); err != nil {
-				return nil, rollbackTx(tx, err)
+		for _, op := range r.Operations() {
+			if err := validatePatchPath(op); err != nil {
+				return err
 			}
-		case resource.PatchRemove:
-			if err := b.patchRemoveGroup(ctx, g, op); err != nil {
-				return nil, rollbackTx(tx, err)
+			if err := checkPatchWritable(ctx, `Group`, op); err != nil {
+				return err
+			}
+			switch op.Op() {
+			case resource.PatchAdd:
+				if err := b.patchAddGroup(ctx, g, op); err != nil {
+					return convertError(err, invalidValue)
+				}
+			case resource.PatchRemove:
+				if err := b.patchRemoveGroup(ctx, g, op); err != nil {
+					return convertError(err, invalidValue)
+				}
+			default:
+				return invalidValue(fmt.Errorf(`unsupported patch operation %q`, op.Op()))
 			}
-		default:
-			return nil, rollbackTx(tx, err)
 		}
-	}
 
-	var g2 *resource.Group
-
-	if retrieve {
-		// This is silly, but we're going to have to re-load the object
-		g2, err = b.RetrieveGroup(ctx, id, nil, nil)
+		if err := b.recordGroupVersion(ctx, b.db, parsedUUID, VersionPatched); err != nil {
+			return internalError(err)
+		}
+		after, err := memberUsers(ctx, b.db, parsedUUID)
 		if err != nil {
-			return nil, rollbackTx(tx, err)
+			return internalError(err)
+		}
+		if err := b.recordMemberVersions(ctx, b.db, append(before, after...)); err != nil {
+			return internalError(err)
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf(`failed to commit transaction: %w`, err)
-	}
+		if err := b.recordAudit(ctx, b.db, AuditPatch, `Group`, id, r); err != nil {
+			return err
+		}
 
+		// This is silly, but we're going to have to re-load the object
+		g2, err = b.retrieveGroup(ctx, id, nil, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return g2, nil
 }
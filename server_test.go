@@ -2,12 +2,15 @@ package server_test
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	server "github.com/cybozu-go/scim-server"
 	"github.com/cybozu-go/scim-server/ent"
 	_ "github.com/cybozu-go/scim-server/ent/runtime"
 	"github.com/cybozu-go/scim-server/helper"
+	"github.com/cybozu-go/scim/resource"
 	"github.com/cybozu-go/scim/test"
 	"github.com/stretchr/testify/require"
 	"gocloud.dev/blob"
@@ -30,3 +33,47 @@ func TestServer(t *testing.T) {
 
 	test.RunConformanceTests(t, "scim-server", s)
 }
+
+// newBackend creates a Backend backed by an in-memory database that is
+// private to the test
+func newBackend(t *testing.T, options ...interface{}) *server.Backend {
+	t.Helper()
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	b, err := server.New("file:"+name+"?mode=memory&cache=shared&_fk=1", options...)
+	require.NoError(t, err, `server.New should succeed`)
+	t.Cleanup(func() { _ = b.Close() })
+	return b
+}
+
+// as returns a context authenticated as a client with the given scopes
+func as(name string, scopes ...server.Scope) context.Context {
+	return server.WithPrincipal(context.Background(), server.NewPrincipal(name, scopes...))
+}
+
+// decode decodes a JSON payload, such as a resource or a request
+func decode(t *testing.T, src string, v interface{}) {
+	t.Helper()
+	require.NoError(t, json.Unmarshal([]byte(src), v), `payload should be valid`)
+}
+
+// filterRequest builds a search request with the given filter
+func filterRequest(t *testing.T, src string) *resource.SearchRequest {
+	t.Helper()
+
+	buf, err := json.Marshal(map[string]string{"filter": src})
+	require.NoError(t, err, `json.Marshal should succeed`)
+	var req resource.SearchRequest
+	decode(t, string(buf), &req)
+	return &req
+}
+
+// requireSCIMError checks that err is a SCIM error with the given status
+func requireSCIMError(t *testing.T, err error, status int) *resource.Error {
+	t.Helper()
+
+	var serr *resource.Error
+	require.ErrorAs(t, err, &serr, `error should be a SCIM error`)
+	require.Equal(t, status, serr.Status(), `status should be %d (%s)`, status, serr.Detail())
+	return serr
+}
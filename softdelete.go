@@ -0,0 +1,644 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cybozu-go/scim-server/ent"
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/member"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/google/uuid"
+)
+
+// When soft deletion is enabled (see WithSoftDelete), deleting a User
+// or a Group only marks it as deleted: the resource becomes a tombstone,
+// which is hidden from every read and search, but keeps its id, etag
+// and attributes so that it can be restored. Its userName remains
+// reserved until the tombstone is purged.
+//
+// The rows linking the resource to groups (and, for a group, to its own
+// members) are moved into the tombstone, so that they disappear from the
+// remaining resources, and are put back when the resource is restored
+
+// deletedMembership is a Member row saved in a tombstone
+type deletedMembership struct {
+	Group   uuid.UUID `json:"group"`
+	Value   string    `json:"value"`
+	Display string    `json:"display,omitempty"`
+	Type    string    `json:"type,omitempty"`
+	Ref     string    `json:"ref,omitempty"`
+}
+
+// takeMemberships deletes the Member rows matching the predicate, and
+// returns them encoded for a tombstone, along with the groups that they
+// were deleted from
+func takeMemberships(ctx context.Context, client *ent.Client, pred predicate.Member) ([]byte, []uuid.UUID, error) {
+	rows, err := client.Member.Query().
+		Where(pred).
+		WithGroup().
+		All(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to load group memberships: %w`, err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, nil
+	}
+
+	list := make([]deletedMembership, 0, len(rows))
+	ids := make([]int, len(rows))
+	var groups uuidSet
+	for i, row := range rows {
+		ids[i] = row.ID
+		if row.Edges.Group == nil {
+			continue
+		}
+		groups.Add(row.Edges.Group.ID)
+		list = append(list, deletedMembership{
+			Group:   row.Edges.Group.ID,
+			Value:   row.Value,
+			Display: row.Display,
+			Type:    row.Type,
+			Ref:     row.Ref,
+		})
+	}
+	if _, err := client.Member.Delete().Where(member.IDIn(ids...)).Exec(ctx); err != nil {
+		return nil, nil, fmt.Errorf(`failed to delete group memberships: %w`, err)
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`failed to encode group memberships: %w`, err)
+	}
+	return data, groups.List(), nil
+}
+
+// restoreMemberships puts back the Member rows saved in the tombstone of
+// the resource self, and returns the groups that they were restored to.
+// Rows linking to a resource that was deleted in the meantime are handed
+// over to its tombstone, and rows linking to a resource that does not
+// exist anymore are dropped
+func restoreMemberships(ctx context.Context, client *ent.Client, self uuid.UUID, data []byte) ([]uuid.UUID, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var list []deletedMembership
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf(`failed to decode deleted group memberships: %w`, err)
+	}
+
+	var groups uuidSet
+
+	for _, m := range list {
+		other := m.Group
+		if other == self {
+			id, err := uuid.Parse(m.Value)
+			if err != nil {
+				continue
+			}
+			other = id
+		}
+
+		handedOver, err := handOverMembership(ctx, client, other, m)
+		if err != nil {
+			return nil, err
+		}
+		if handedOver {
+			continue
+		}
+
+		err = client.Member.Create().
+			SetGroupID(m.Group).
+			SetValue(m.Value).
+			SetNillableDisplay(nonEmpty(m.Display)).
+			SetType(m.Type).
+			SetNillableRef(nonEmpty(m.Ref)).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(`failed to restore group membership: %w`, err)
+		}
+		groups.Add(m.Group)
+	}
+	return groups.List(), nil
+}
+
+// membersChanged recomputes the etag of the groups whose members were
+// changed by an operation on another resource, and records their new
+// versions. Groups are skipped if they are deleted, or are the resource
+// self
+func (b *Backend) membersChanged(ctx context.Context, client *ent.Client, self uuid.UUID, groups []uuid.UUID) error {
+	for _, id := range groups {
+		if id == self {
+			continue
+		}
+		g, err := client.Group.Query().
+			Where(group.ID(id), group.DeletedAtIsNil()).
+			WithMembers().
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf(`failed to load group: %w`, err)
+		}
+
+		h := sha256.New()
+		if err := g.ComputeETag(h); err != nil {
+			return fmt.Errorf(`failed to compute etag: %w`, err)
+		}
+		if err := client.Group.UpdateOneID(id).
+			SetEtag(fmt.Sprintf("W/%x", h.Sum(nil))).
+			Exec(ctx); err != nil {
+			return fmt.Errorf(`failed to save etag: %w`, err)
+		}
+		if err := b.recordGroupVersion(ctx, client, id, VersionPatched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uuidSet lists distinct ids in the order they were added
+type uuidSet struct {
+	seen map[uuid.UUID]struct{}
+	list []uuid.UUID
+}
+
+func (s *uuidSet) Add(id uuid.UUID) {
+	if _, ok := s.seen[id]; ok {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[uuid.UUID]struct{})
+	}
+	s.seen[id] = struct{}{}
+	s.list = append(s.list, id)
+}
+
+func (s *uuidSet) List() []uuid.UUID {
+	return s.list
+}
+
+// handOverMembership appends m to the tombstone of the resource id, if
+// it is deleted. The boolean return value is true if m must not be
+// restored, either because it was handed over or because the resource
+// does not exist anymore
+func handOverMembership(ctx context.Context, client *ent.Client, id uuid.UUID, m deletedMembership) (bool, error) {
+	if u, err := client.User.Query().
+		Where(user.ID(id)).
+		Select(user.FieldDeletedAt, user.FieldDeletedMemberships).
+		Only(ctx); err == nil {
+		if u.DeletedAt == nil {
+			return false, nil
+		}
+		data, err := appendMembership(u.DeletedMemberships, m)
+		if err != nil {
+			return false, err
+		}
+		return true, client.User.UpdateOneID(id).SetDeletedMemberships(data).Exec(ctx)
+	} else if !ent.IsNotFound(err) {
+		return false, fmt.Errorf(`failed to look up user: %w`, err)
+	}
+
+	g, err := client.Group.Query().
+		Where(group.ID(id)).
+		Select(group.FieldDeletedAt, group.FieldDeletedMemberships).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf(`failed to look up group: %w`, err)
+	}
+	if g.DeletedAt == nil {
+		return false, nil
+	}
+	data, err := appendMembership(g.DeletedMemberships, m)
+	if err != nil {
+		return false, err
+	}
+	return true, client.Group.UpdateOneID(id).SetDeletedMemberships(data).Exec(ctx)
+}
+
+func appendMembership(data []byte, m deletedMembership) ([]byte, error) {
+	var list []deletedMembership
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf(`failed to decode deleted group memberships: %w`, err)
+		}
+	}
+	return json.Marshal(append(list, m))
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (b *Backend) softDeleteUser(ctx context.Context, id uuid.UUID) error {
+	return b.inTx(ctx, func(b *Backend) error {
+		exists, err := b.db.User.Query().
+			Where(user.ID(id), user.DeletedAtIsNil()).
+			Exist(ctx)
+		if err != nil {
+			return internalError(fmt.Errorf(`failed to look up user: %w`, err))
+		}
+		if !exists {
+			return notFound(`User`, id.String())
+		}
+
+		memberships, groups, err := takeMemberships(ctx, b.db, member.Value(id.String()))
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.User.UpdateOneID(id).
+			SetDeletedAt(b.clock.Now()).
+			SetDeletedBy(clientName(ctx)).
+			SetDeletedMemberships(memberships).
+			Exec(ctx); err != nil {
+			return internalError(fmt.Errorf(`failed to delete user: %w`, err))
+		}
+		if err := b.recordUserVersion(ctx, b.db, id, VersionDeleted); err != nil {
+			return internalError(err)
+		}
+		if err := b.membersChanged(ctx, b.db, id, groups); err != nil {
+			return internalError(err)
+		}
+		return b.recordAudit(ctx, b.db, AuditDelete, `User`, id.String(), nil)
+	})
+}
+
+func (b *Backend) softDeleteGroup(ctx context.Context, id uuid.UUID) error {
+	return b.inTx(ctx, func(b *Backend) error {
+		exists, err := b.db.Group.Query().
+			Where(group.ID(id), group.DeletedAtIsNil()).
+			Exist(ctx)
+		if err != nil {
+			return internalError(fmt.Errorf(`failed to look up group: %w`, err))
+		}
+		if !exists {
+			return notFound(`Group`, id.String())
+		}
+
+		users, err := memberUsers(ctx, b.db, id)
+		if err != nil {
+			return internalError(err)
+		}
+		memberships, groups, err := takeMemberships(ctx, b.db, member.Or(
+			member.Value(id.String()),
+			member.HasGroupWith(group.ID(id)),
+		))
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.Group.UpdateOneID(id).
+			SetDeletedAt(b.clock.Now()).
+			SetDeletedBy(clientName(ctx)).
+			SetDeletedMemberships(memberships).
+			Exec(ctx); err != nil {
+			return internalError(fmt.Errorf(`failed to delete group: %w`, err))
+		}
+		if err := b.recordGroupVersion(ctx, b.db, id, VersionDeleted); err != nil {
+			return internalError(err)
+		}
+		if err := b.membersChanged(ctx, b.db, id, groups); err != nil {
+			return internalError(err)
+		}
+		if err := b.recordMemberVersions(ctx, b.db, users); err != nil {
+			return internalError(err)
+		}
+		return b.recordAudit(ctx, b.db, AuditDelete, `Group`, id.String(), nil)
+	})
+}
+
+// RestoreUser restores a deleted User with its original id, attributes,
+// etag and group memberships. Memberships of groups that were purged in
+// the meantime are lost. It fails with a uniqueness error if the
+// externalId of the User has been reused since (see
+// WithExternalIDUniqueness)
+func (b *Backend) RestoreUser(ctx context.Context, id string) (_ *resource.User, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditRestore, `User`, id, nil)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+
+	parsedUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, notFound(`User`, id)
+	}
+
+	var res *resource.User
+	err = b.inTx(ctx, func(b *Backend) error {
+		u, err := b.db.User.Query().
+			Where(user.ID(parsedUUID), user.DeletedAtNotNil()).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`User`, id)
+			}
+			return internalError(fmt.Errorf(`failed to look up deleted user: %w`, err))
+		}
+
+		if b.uniqueExternalID && u.ExternalID != "" {
+			exists, err := b.db.User.Query().
+				Where(userExternalID(u.ExternalIDClient, u.ExternalID)).
+				Exist(ctx)
+			if err != nil {
+				return internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+			}
+			if exists {
+				return uniquenessError(fmt.Errorf(`externalId %q is already used by another User`, u.ExternalID))
+			}
+		}
+
+		groups, err := restoreMemberships(ctx, b.db, parsedUUID, u.DeletedMemberships)
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.User.UpdateOneID(parsedUUID).
+			ClearDeletedAt().
+			ClearDeletedBy().
+			ClearDeletedMemberships().
+			Exec(ctx); err != nil {
+			return convertError(fmt.Errorf(`failed to restore user: %w`, err), internalError)
+		}
+		if err := b.recordUserVersion(ctx, b.db, parsedUUID, VersionRestored); err != nil {
+			return internalError(err)
+		}
+		if err := b.membersChanged(ctx, b.db, parsedUUID, groups); err != nil {
+			return internalError(err)
+		}
+		if err := b.recordAudit(ctx, b.db, AuditRestore, `User`, id, nil); err != nil {
+			return err
+		}
+
+		res, err = b.retrieveUser(ctx, id, nil, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RestoreGroup is the equivalent of RestoreUser for Groups. The members
+// of the Group are restored as well, except for those that do not exist
+// anymore
+func (b *Backend) RestoreGroup(ctx context.Context, id string) (_ *resource.Group, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditRestore, `Group`, id, nil)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return nil, err
+	}
+
+	parsedUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, notFound(`Group`, id)
+	}
+
+	var res *resource.Group
+	err = b.inTx(ctx, func(b *Backend) error {
+		g, err := b.db.Group.Query().
+			Where(group.ID(parsedUUID), group.DeletedAtNotNil()).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return notFound(`Group`, id)
+			}
+			return internalError(fmt.Errorf(`failed to look up deleted group: %w`, err))
+		}
+
+		if b.uniqueExternalID && g.ExternalID != "" {
+			exists, err := b.db.Group.Query().
+				Where(groupExternalID(g.ExternalIDClient, g.ExternalID)).
+				Exist(ctx)
+			if err != nil {
+				return internalError(fmt.Errorf(`failed to look up externalId: %w`, err))
+			}
+			if exists {
+				return uniquenessError(fmt.Errorf(`externalId %q is already used by another Group`, g.ExternalID))
+			}
+		}
+
+		groups, err := restoreMemberships(ctx, b.db, parsedUUID, g.DeletedMemberships)
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.db.Group.UpdateOneID(parsedUUID).
+			ClearDeletedAt().
+			ClearDeletedBy().
+			ClearDeletedMemberships().
+			Exec(ctx); err != nil {
+			return convertError(fmt.Errorf(`failed to restore group: %w`, err), internalError)
+		}
+		if err := b.recordGroupVersion(ctx, b.db, parsedUUID, VersionRestored); err != nil {
+			return internalError(err)
+		}
+		if err := b.membersChanged(ctx, b.db, parsedUUID, groups); err != nil {
+			return internalError(err)
+		}
+		users, err := memberUsers(ctx, b.db, parsedUUID)
+		if err != nil {
+			return internalError(err)
+		}
+		if err := b.recordMemberVersions(ctx, b.db, users); err != nil {
+			return internalError(err)
+		}
+		if err := b.recordAudit(ctx, b.db, AuditRestore, `Group`, id, nil); err != nil {
+			return err
+		}
+
+		res, err = b.retrieveGroup(ctx, id, nil, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeletedResource describes a tombstone
+type DeletedResource struct {
+	ResourceType string    `json:"resourceType"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name,omitempty"`
+	ExternalID   string    `json:"externalId,omitempty"`
+	DeletedAt    time.Time `json:"deletedAt"`
+	DeletedBy    string    `json:"deletedBy,omitempty"`
+	PurgeAt      time.Time `json:"purgeAt"`
+}
+
+// DeletedResources lists the tombstones of the Users and Groups that
+// the client may read, most recently deleted first. The name is the
+// userName of Users, and the displayName of Groups
+func (b *Backend) DeletedResources(ctx context.Context) ([]*DeletedResource, error) {
+	readUsers := b.canRead(ctx, ScopeUsersRead)
+	readGroups := b.canRead(ctx, ScopeGroupsRead)
+	if !readUsers && !readGroups {
+		return nil, b.authorize(ctx, ScopeUsersRead)
+	}
+
+	var list []*DeletedResource
+	if readUsers {
+		users, err := b.db.User.Query().
+			Where(user.DeletedAtNotNil()).
+			Select(user.FieldID, user.FieldUserName, user.FieldExternalID, user.FieldDeletedAt, user.FieldDeletedBy).
+			All(ctx)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to list deleted users: %w`, err))
+		}
+		for _, u := range users {
+			list = append(list, b.deletedResource(ctx, `User`, u.ID, u.UserName, u.ExternalID, *u.DeletedAt, u.DeletedBy))
+		}
+	}
+	if readGroups {
+		groups, err := b.db.Group.Query().
+			Where(group.DeletedAtNotNil()).
+			Select(group.FieldID, group.FieldDisplayName, group.FieldExternalID, group.FieldDeletedAt, group.FieldDeletedBy).
+			All(ctx)
+		if err != nil {
+			return nil, internalError(fmt.Errorf(`failed to list deleted groups: %w`, err))
+		}
+		for _, g := range groups {
+			list = append(list, b.deletedResource(ctx, `Group`, g.ID, g.DisplayName, g.ExternalID, *g.DeletedAt, g.DeletedBy))
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].DeletedAt.After(list[j].DeletedAt)
+	})
+	return list, nil
+}
+
+func (b *Backend) deletedResource(ctx context.Context, resourceType string, id uuid.UUID, name, externalID string, deletedAt time.Time, deletedBy string) *DeletedResource {
+	policy := attributePolicyFromContext(ctx)
+	nameKey, externalIDKey := resource.UserUserNameKey, resource.UserExternalIDKey
+	if resourceType == `Group` {
+		nameKey, externalIDKey = resource.GroupDisplayNameKey, resource.GroupExternalIDKey
+	}
+	if !policy.CanRead(resourceType, nameKey) {
+		name = ""
+	}
+	if !policy.CanRead(resourceType, externalIDKey) {
+		externalID = ""
+	}
+	return &DeletedResource{
+		ResourceType: resourceType,
+		ID:           id.String(),
+		Name:         name,
+		ExternalID:   externalID,
+		DeletedAt:    deletedAt,
+		DeletedBy:    deletedBy,
+		PurgeAt:      deletedAt.Add(b.retention),
+	}
+}
+
+// PurgeDeleted permanently deletes the tombstones that are older than
+// the retention period, and returns the number of purged resources
+func (b *Backend) PurgeDeleted(ctx context.Context) (int, error) {
+	cutoff := b.clock.Now().Add(-b.retention)
+
+	users, err := b.db.User.Delete().
+		Where(user.DeletedAtLTE(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(`failed to purge deleted users: %w`, err)
+	}
+	groups, err := b.db.Group.Delete().
+		Where(group.DeletedAtLTE(cutoff)).
+		Exec(ctx)
+	if err != nil {
+		return users, fmt.Errorf(`failed to purge deleted groups: %w`, err)
+	}
+	return users + groups, nil
+}
+
+// RunPurger calls PurgeDeleted (if soft deletion is enabled) and
+// PurgeIdempotencyKeys every interval, until ctx is canceled. Errors,
+// including a non-positive interval, are reported to the logger
+func (b *Backend) RunPurger(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		b.logger.Printf(`invalid purge interval %s (must be positive)`, interval)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if b.retention > 0 {
+			n, err := b.PurgeDeleted(ctx)
+			if err != nil {
+				b.logger.Printf(`%s`, err)
+			} else if n > 0 {
+				b.logger.Printf(`purged %d deleted resources`, n)
+			}
+		}
+		n, err := b.PurgeIdempotencyKeys(ctx)
+		if err != nil {
+			b.logger.Printf(`%s`, err)
+		} else if n > 0 {
+			b.logger.Printf(`purged %d expired idempotency keys`, n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeletedResourcesHandler returns an http.Handler to manage tombstones.
+// It must be wrapped with Authenticate, and expects paths relative to
+// its mount point:
+//
+//	GET  /               lists the tombstones (see DeletedResources)
+//	POST /Users/{id}     restores a User (see RestoreUser)
+//	POST /Groups/{id}    restores a Group (see RestoreGroup)
+func DeletedResourcesHandler(b *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(r.URL.Path, `/`)
+
+		var v interface{}
+		var err error
+		switch {
+		case path == "":
+			if r.Method != http.MethodGet {
+				w.Header().Set(`Allow`, http.MethodGet)
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			v, err = b.DeletedResources(r.Context())
+		case r.Method != http.MethodPost:
+			w.Header().Set(`Allow`, http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		case strings.HasPrefix(path, `Users/`):
+			v, err = b.RestoreUser(r.Context(), strings.TrimPrefix(path, `Users/`))
+		case strings.HasPrefix(path, `Groups/`):
+			v, err = b.RestoreGroup(r.Context(), strings.TrimPrefix(path, `Groups/`))
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			writeSCIMError(w, err)
+			return
+		}
+
+		w.Header().Set(`Content-Type`, `application/scim+json`)
+		_ = json.NewEncoder(w).Encode(v)
+	})
+}
@@ -0,0 +1,78 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	ctx := context.Background()
+
+	// createGroup creates a group from a JSON payload
+	createGroup := func(t *testing.T, b *server.Backend, payload string) *resource.Group {
+		t.Helper()
+
+		var in resource.Group
+		decode(t, payload, &in)
+		g, err := b.CreateGroup(ctx, &in)
+		require.NoError(t, err, `CreateGroup should succeed`)
+		return g
+	}
+	// requireLastVersion checks that the last version of the group was
+	// recorded by op, and has the current etag of the group
+	requireLastVersion := func(t *testing.T, b *server.Backend, id string, op server.VersionOperation) *resource.Group {
+		t.Helper()
+
+		g, err := b.RetrieveGroup(ctx, id, nil, nil)
+		require.NoError(t, err, `RetrieveGroup should succeed`)
+		versions, err := b.ListVersions(ctx, `Group`, id)
+		require.NoError(t, err, `ListVersions should succeed`)
+		last := versions[len(versions)-1]
+		require.Equal(t, op, last.Operation, `last version should be recorded by %s`, op)
+		require.Equal(t, g.Meta().Version(), last.ETag, `last version should have the current etag`)
+		return g
+	}
+
+	t.Run("user", func(t *testing.T) {
+		b := newBackend(t, server.WithSoftDelete(time.Hour))
+		ids := createUsers(t, b, `{"userName":"alice"}`, `{"userName":"bob"}`)
+		staff := createGroup(t, b, `{"displayName":"staff","members":[{"value":"`+ids["alice"]+`"},{"value":"`+ids["bob"]+`"}]}`)
+
+		require.NoError(t, b.DeleteUser(ctx, ids["alice"]), `DeleteUser should succeed`)
+		deleted := requireLastVersion(t, b, staff.ID(), server.VersionPatched)
+		require.Len(t, deleted.Members(), 1, `deleted user should be removed from the group`)
+		require.NotEqual(t, staff.Meta().Version(), deleted.Meta().Version(), `etag of the group should change`)
+
+		_, err := b.RestoreUser(ctx, ids["alice"])
+		require.NoError(t, err, `RestoreUser should succeed`)
+		restored := requireLastVersion(t, b, staff.ID(), server.VersionPatched)
+		require.Len(t, restored.Members(), 2, `restored user should be put back in the group`)
+		require.NotEqual(t, deleted.Meta().Version(), restored.Meta().Version(), `etag of the group should change`)
+	})
+	t.Run("group", func(t *testing.T) {
+		b := newBackend(t, server.WithSoftDelete(time.Hour))
+		ids := createUsers(t, b, `{"userName":"alice"}`)
+		eng := createGroup(t, b, `{"displayName":"eng","members":[{"value":"`+ids["alice"]+`"}]}`)
+		all := createGroup(t, b, `{"displayName":"all","members":[{"value":"`+eng.ID()+`","type":"Group"}]}`)
+
+		require.NoError(t, b.DeleteGroup(ctx, eng.ID()), `DeleteGroup should succeed`)
+		deleted := requireLastVersion(t, b, all.ID(), server.VersionPatched)
+		require.Empty(t, deleted.Members(), `deleted group should be removed from the group`)
+		require.NotEqual(t, all.Meta().Version(), deleted.Meta().Version(), `etag of the group should change`)
+
+		restoredEng, err := b.RestoreGroup(ctx, eng.ID())
+		require.NoError(t, err, `RestoreGroup should succeed`)
+		require.Len(t, restoredEng.Members(), 1, `members of the group should be restored`)
+		restored := requireLastVersion(t, b, all.ID(), server.VersionPatched)
+		require.Len(t, restored.Members(), 1, `restored group should be put back in the group`)
+	})
+	t.Run("purger", func(t *testing.T) {
+		b := newBackend(t, server.WithSoftDelete(time.Hour))
+		require.NotPanics(t, func() { b.RunPurger(ctx, 0) }, `non-positive intervals should be rejected`)
+	})
+}
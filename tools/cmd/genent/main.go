@@ -416,48 +416,17 @@ func generateLoadEntFields(dst io.Writer, object *codegen.Object) error {
 	o := codegen.NewOutput(dst)
 
 	o.LL(`func %sLoadEntFields(q *ent.%sQuery, scimFields, excludedFields []string) {`, object.Name(false), object.Name(true))
-	o.L(`fields := make(map[string]struct{})`)
-	o.L(`if len(scimFields) == 0 {`)
-	o.L(`scimFields = []string {`)
-
-	for i, field := range object.Fields() {
-		switch field.Name(false) {
-		case "schemas", "meta": // These are handled separately
-			continue
-		}
-		if field.Bool(`skipCommonFields`) {
-			switch field.Name(false) {
-			case "id", "externalID": // these are only required when they are imported
-				continue
-			}
-		}
-
-		// Theoretically, there cold be any number of fields that
-		// have the "returned" field set to `never` or `request`, but
-		// in practice only password is set to never, and
-		// there are no fields set to request (TODO: check again)
-		if i > 0 {
-			o.R(`,`)
-		}
-		o.R(`resource.%s%sKey`, object.Name(true), field.Name(true))
-	}
-	o.R(`}`)
-	o.L(`}`)
-	o.LL(`for _, name := range scimFields {`)
-	// Theoretically we need to prevent the user from deleting
-	// fields set to "always", but only "id" has this in practice
-	o.L(`fields[name] = struct{}{}`)
-	o.L(`}`)
-
-	o.LL(`for _, name := range excludedFields {`)
-	o.L(`delete(fields, name)`)
-	o.L(`}`)
-
+	// Which attributes are loaded depends on the "returned" characteristic
+	// of each attribute in the schema, which is resolved at runtime
+	o.L("fields := projectAttributes(`%s`, scimFields, excludedFields)", object.Name(true))
 	o.L(`selectNames := make([]string, 0, len(fields))`)
-	o.L(`for f := range fields {`)
+	o.L(`for _, f := range fields {`)
 	o.L(`switch f {`)
 	for _, field := range object.Fields() {
 		if object.Name(true) == `User` && field.Name(true) == `Groups` {
+			// Group memberships are not an edge, but are loaded separately
+			o.L(`case resource.UserGroupsKey:`)
+			o.L(`q.WithMemberships()`)
 			continue
 		}
 		if field.Name(false) == "schemas" {
@@ -501,7 +470,12 @@ func generateResourceFromEnt(dst io.Writer, object *codegen.Object) error {
 	scimRsname := scimResourceName(object)
 	entRsname := resourceName(object)
 
-	o.LL(`func %[1]sResourceFromEnt(in *ent.%[2]s) (*resource.%[1]s, error) {`, scimRsname, entRsname)
+	// Resources with a location need to know the base URL
+	if !object.Bool(`skipCommonFields`) {
+		o.LL(`func %[1]sResourceFromEnt(baseURL string, in *ent.%[2]s) (*resource.%[1]s, error) {`, scimRsname, entRsname)
+	} else {
+		o.LL(`func %[1]sResourceFromEnt(in *ent.%[2]s) (*resource.%[1]s, error) {`, scimRsname, entRsname)
+	}
 	o.L(`var b resource.Builder`)
 
 	o.LL(`builder := b.%s()`, scimRsname)
@@ -509,7 +483,7 @@ func generateResourceFromEnt(dst io.Writer, object *codegen.Object) error {
 	if !object.Bool(`skipCommonFields`) {
 		o.LL(`meta, err := b.Meta().`)
 		o.L(`ResourceType(%q).`, object.Name(true))
-		o.L(`Location(%sLocation(in.ID.String())).`, object.Name(false))
+		o.L(`Location(%sLocation(baseURL, in.ID.String())).`, object.Name(false))
 		o.L(`Version(in.Etag).`)
 		o.L(`Build()`)
 		o.L(`if err != nil {`)
@@ -551,7 +525,12 @@ func generateResourceFromEnt(dst io.Writer, object *codegen.Object) error {
 				o.LL(`if el := len(in.Edges.%s); el > 0 {`, edgeName)
 				o.L(`list := make([]*resource.%s, 0, el)`, scimRsname)
 				o.L(`for _, ine := range in.Edges.%s {`, edgeName)
-				o.L(`r, err := %sResourceFromEnt(ine)`, entRsname)
+				if entRsname == `Member` {
+					// members refer to other resources
+					o.L(`r, err := MemberResourceFromEnt(baseURL, ine)`)
+				} else {
+					o.L(`r, err := %sResourceFromEnt(ine)`, entRsname)
+				}
 				o.L(`if err != nil {`)
 				o.L(`return nil, fmt.Errorf("failed to build %s information for %s")`, field.Name(false), object.Name(true))
 				o.L(`}`)
@@ -574,7 +553,7 @@ func generateResourceFromEnt(dst io.Writer, object *codegen.Object) error {
 		o.L(`var gmb resource.GroupMemberBuilder`)
 		o.L(`gm, err := gmb.Value(m.Value).`)
 		// o.L(`Set("display", m.Display).`) // TODO
-		o.L(`Ref(m.Ref).`)
+		o.L(`Ref(groupLocation(baseURL, m.Value)).`)
 		o.L(`Type(m.Type).`) // direct or indirect
 		o.L(`Build()`)
 		o.L(`if err != nil {`)
@@ -635,9 +614,17 @@ func generateEntFieldFromSCIM(dst io.Writer, object *codegen.Object) error {
 	return nil
 }
 
-func generatePredicate(dst io.Writer, object *codegen.Object, scimMethod, entMethod string) error {
+// generatePredicate generates the function that builds the predicate
+// for the given string comparison (see stringMatch). If match is empty,
+// the comparison is passed as an argument to the generated function
+func generatePredicate(dst io.Writer, object *codegen.Object, scimMethod, match string) error {
 	o := codegen.NewOutput(dst)
-	o.LL(`func %[1]s%[2]sPredicate(q *ent.%[3]sQuery, scimField string, val interface{}) (predicate.%[3]s, error) {`, object.Name(false), scimMethod, object.Name(true))
+	if match == "" {
+		match = `m`
+		o.LL(`func %[1]s%[2]sPredicate(q *ent.%[3]sQuery, scimField string, m stringMatch, val interface{}) (predicate.%[3]s, error) {`, object.Name(false), scimMethod, object.Name(true))
+	} else {
+		o.LL(`func %[1]s%[2]sPredicate(q *ent.%[3]sQuery, scimField string, val interface{}) (predicate.%[3]s, error) {`, object.Name(false), scimMethod, object.Name(true))
+	}
 	o.L(`_ = q`) // in case the predicate doesn't actually need to use the query object
 	// The scim field may either be a flat (simple) field or a nested field.
 	o.L(`field, subfield, err := splitScimField(scimField)`)
@@ -651,13 +638,45 @@ func generatePredicate(dst io.Writer, object *codegen.Object, scimMethod, entMet
 		switch field.Name(false) {
 		case `schemas`:
 			continue
+		case `password`:
+			// password is never returned, so filtering on it would reveal
+			// its value. It is rejected by filterVisitor.checkReadable
+			continue
 		default:
 		}
 
+		if object.Name(true) == `User` && field.Name(true) == `Groups` {
+			// Group memberships are not an edge, see membership.go
+			o.L(`case resource.UserGroupsKey:`)
+			o.L(`return userGroupsPredicate(scimField, %s, val)`, match)
+			continue
+		}
+
+		if object.Name(true) == `User` && field.Name(true) == `UserName` {
+			// userName is matched against its normalized form, see userNamePredicate
+			o.L(`case resource.UserUserNameKey:`)
+			o.L(`return userNamePredicate(scimField, %s, val)`, match)
+			continue
+		}
+
+		if field.Name(true) == `Meta` {
+			// meta is not stored as such, see metaPredicate
+			o.L(`case resource.%sMetaKey:`, object.Name(true))
+			o.L("p, err := metaPredicate(`%s`, scimField, %s, val)", object.Name(true), match)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+			o.L(`return predicate.%s(func(s *sql.Selector) {`, object.Name(true))
+			o.L(`s.Where(p)`)
+			o.L(`}), nil`)
+			continue
+		}
+
 		switch field.Type() {
 		// predicates against a list actually means "... if any of the values match"
-		// so things like `roles.value eq "foo"` means `if any of the role.value is equal to "foo"`
-		case "[]*Role", "[]*Email", "[]*PhoneNumber":
+		// so things like `roles.value eq "foo"` means `if any of the role.value is equal to "foo"`.
+		// The single-valued name is an edge as well, so it is queried the same way
+		case "[]*Role", "[]*Email", "[]*PhoneNumber", "[]*GroupMember", "*Names":
 			o.L(`case resource.%s%sKey:`, object.Name(true), field.Name(true))
 			// It's going to be a relation, so add a query that goes into the separate entity
 			o.L(`switch subfield {`)
@@ -665,38 +684,65 @@ func generatePredicate(dst io.Writer, object *codegen.Object, scimMethod, entMet
 			// TODO don't hardcode
 			// We know at this point that this type is something like []*Foo, so extract the Foo
 			// and get the object definition
-			subObjectName := strings.TrimPrefix(field.Type(), `[]*`)
+			subObjectName := scimResourceName(field)
 			subObject, ok := objectMap[subObjectName]
 			if !ok {
 				return fmt.Errorf(`could not find object %q`, subObjectName)
 			}
+			rsname := resourceName(field)
 			for _, subField := range subObject.Fields() {
-				switch entMethod {
-				case `EQ`:
-					// anything goes
-				default:
-					// only strings allowed
-					if subField.Type() != `string` {
+				subPackage := packageName(rsname)
+				switch subField.Type() {
+				case `string`:
+					o.L(`case resource.%s%sKey:`, subObjectName, subField.Name(true))
+					o.L(`v, err := filterString(scimField, val)`)
+					o.L(`if err != nil {`)
+					o.L(`return nil, err`)
+					o.L(`}`)
+					o.L("caseExact := isCaseExact(`%s`, scimField)", object.Name(true))
+					o.L(`return %s.Has%sWith(predicate.%s(func(s *sql.Selector) {`, object.Name(false), edgeName(field), rsname)
+					o.L(`s.Where(matchString(%s, s.C(%s.Field%s), v, caseExact))`, match, subPackage, entName(subField, true))
+					o.L(`})), nil`)
+				case `bool`:
+					// booleans can only be compared for equality
+					if match != `matchEqual` {
 						continue
 					}
+					o.L(`case resource.%s%sKey:`, subObjectName, subField.Name(true))
+					o.L(`v, err := filterBool(scimField, val)`)
+					o.L(`if err != nil {`)
+					o.L(`return nil, err`)
+					o.L(`}`)
+					o.L(`return %s.Has%sWith(%s.%sEQ(v)), nil`, object.Name(false), edgeName(field), subPackage, entName(subField, true))
 				}
-
-				o.L(`case resource.%s%sKey:`, subObjectName, subField.Name(true))
-				o.L(`//nolint:forcetypeassert`)
-				o.L(`return %s.Has%sWith(%s.%s%s(val.(%s))), nil`, object.Name(false), field.Name(true), strings.ToLower(singularName(field.Name(false))), subField.Name(true), entMethod, subField.Type())
 			}
 			o.L(`default:`)
 			o.L(`return nil, fmt.Errorf("invalid filter specification: invalid subfield for %%q", field)`)
 			o.L(`}`)
 		case "string":
 			o.L(`case resource.%s%sKey:`, object.Name(true), field.Name(true))
+			o.L(`v, err := filterString(scimField, val)`)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
 			// We can't just use ${Field}HasPrefix here, because we're going to
 			// receive the field name as a parameter
 			o.L(`entFieldName := %sEntFieldFromSCIM(scimField)`, object.Name(true))
+			o.L("caseExact := isCaseExact(`%s`, scimField)", object.Name(true))
 			o.L(`return predicate.%[1]s(func(s *sql.Selector) {`, object.Name(true))
-			o.L(`//nolint:forcetypeassert`)
-			o.L(`s.Where(sql.%s(s.C(entFieldName), val.(%s)))`, entMethod, field.Type())
+			o.L(`s.Where(matchString(%s, s.C(entFieldName), v, caseExact))`, match)
 			o.L(`}), nil`)
+		case "bool":
+			// booleans can only be compared for equality
+			if match != `matchEqual` {
+				continue
+			}
+			o.L(`case resource.%s%sKey:`, object.Name(true), field.Name(true))
+			o.L(`v, err := filterBool(scimField, val)`)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+			o.L(`return %s.%sEQ(v), nil`, packageName(object.Name(false)), entName(field, true))
 		}
 	}
 	o.L(`default:`)
@@ -706,28 +752,78 @@ func generatePredicate(dst io.Writer, object *codegen.Object, scimMethod, entMet
 	return nil
 }
 
+// generatePresencePredicate generates the function that builds the
+// predicate for "pr" filters. Simple attributes are present if they are
+// not null (or empty, for strings). Complex and multi-valued attributes
+// are present if there is at least one related entity, optionally with
+// the given sub-attribute present
 func generatePresencePredicate(dst io.Writer, object *codegen.Object) error {
 	o := codegen.NewOutput(dst)
 
-	o.LL(`func %sPresencePredicate(scimField string) predicate.%s {`, object.Name(false), object.Name(true))
-	o.L(`switch scimField {`)
+	pkg := packageName(object.Name(false))
+	o.LL(`func %sPresencePredicate(scimField string) (predicate.%s, error) {`, object.Name(false), object.Name(true))
+	o.L(`field, subfield, err := splitScimField(scimField)`)
+	o.L(`if err != nil {`)
+	o.L(`return nil, err`)
+	o.L(`}`)
+	o.LL(`switch field {`)
 	for _, field := range object.Fields() {
 		switch field.Name(false) {
-		case `schemas`:
+		case `schemas`, `meta`:
+			continue
+		case `password`:
+			// password cannot be filtered on, see generatePredicate
 			continue
 		default:
 		}
-		if field.Type() != "string" {
+
+		ft := field.Type()
+		if !strings.HasPrefix(ft, `[]`) && !strings.HasPrefix(ft, `*`) {
+			o.L(`case resource.%s%sKey:`, object.Name(true), field.Name(true))
+			o.L(`return predicate.%s(func(s *sql.Selector) {`, object.Name(true))
+			if ft == `string` {
+				o.L(`s.Where(isPresent(s.C(%s.Field%s)))`, pkg, entName(field, true))
+			} else {
+				o.L(`s.Where(sql.NotNull(s.C(%s.Field%s)))`, pkg, entName(field, true))
+			}
+			o.L(`}), nil`)
 			continue
 		}
-		if field.IsRequired() {
+
+		if object.Name(true) == `User` && field.Name(true) == `Groups` {
+			// Group memberships are not an edge, see group.go
+			o.L(`case resource.UserGroupsKey:`)
+			o.L(`return userHasGroups(), nil`)
 			continue
 		}
+
+		subObjectName := scimResourceName(field)
+		subObject, ok := objectMap[subObjectName]
+		if !ok {
+			return fmt.Errorf(`could not find object %q`, subObjectName)
+		}
+		rsname := resourceName(field)
+
 		o.L(`case resource.%s%sKey:`, object.Name(true), field.Name(true))
-		o.L(`return %[1]s.And(%[1]s.%[2]sNotNil(), %[1]s.%[2]sNEQ(""))`, packageName(object.Name(false)), field.Name(true))
+		o.L(`switch subfield {`)
+		o.L(`case "":`)
+		o.L(`return %s.Has%s(), nil`, pkg, edgeName(field))
+		for _, subField := range subObject.Fields() {
+			o.L(`case resource.%s%sKey:`, subObjectName, subField.Name(true))
+			o.L(`return %s.Has%sWith(predicate.%s(func(s *sql.Selector) {`, pkg, edgeName(field), rsname)
+			if subField.Type() == `string` {
+				o.L(`s.Where(isPresent(s.C(%s.Field%s)))`, packageName(rsname), entName(subField, true))
+			} else {
+				o.L(`s.Where(sql.NotNull(s.C(%s.Field%s)))`, packageName(rsname), entName(subField, true))
+			}
+			o.L(`})), nil`)
+		}
+		o.L(`default:`)
+		o.L(`return nil, fmt.Errorf("invalid filter specification: invalid subfield for %%q", field)`)
+		o.L(`}`)
 	}
 	o.L(`default:`)
-	o.L(`return nil`)
+	o.L(`return nil, fmt.Errorf("invalid filter field specification")`)
 	o.L(`}`)
 	o.L(`}`)
 	return nil
@@ -885,7 +981,7 @@ func generateUtilities(object *codegen.Object) error {
 		`github.com/cybozu-go/scim/resource`,
 		`github.com/cybozu-go/scim-server/ent`,
 	}
-	for _, pkg := range []string{`predicate`, packageName(lcObject), `address`, `email`, `entitlement`, `group`, `ims`, `member`, `phonenumber`, `photo`, `user`, `role`, `x509certificate`} {
+	for _, pkg := range []string{`predicate`, packageName(lcObject), `address`, `email`, `entitlement`, `group`, `ims`, `member`, `names`, `phonenumber`, `photo`, `user`, `role`, `x509certificate`} {
 		pkgs = append(pkgs, fmt.Sprintf(`github.com/cybozu-go/scim-server/ent/%s`, pkg))
 	}
 	o.LL(`import (`)
@@ -900,10 +996,9 @@ func generateUtilities(object *codegen.Object) error {
 		}
 	}
 
-	// TODO: prefix is hard coded, need to fix
 	if !object.Bool(`skipCommonFields`) {
-		o.LL(`func %sLocation(id string) string {`, object.Name(false))
-		o.L(`return %q+id` /* TODO: FIXME */, fmt.Sprintf(`https://foobar.com/scim/v2/%ss/`, object.Name(true)))
+		o.LL(`func %sLocation(baseURL, id string) string {`, object.Name(false))
+		o.L(`return baseURL + %q + id`, fmt.Sprintf(`/%ss/`, object.Name(true)))
 		o.L(`}`)
 	}
 
@@ -918,15 +1013,16 @@ func generateUtilities(object *codegen.Object) error {
 	switch object.Name(true) {
 	case `User`, `Group`:
 		for _, pred := range []struct {
-			Name   string
-			Method string
+			Name  string
+			Match string
 		}{
-			{Name: `StartsWith`, Method: `HasPrefix`},
-			{Name: `EndsWith`, Method: `HasSuffix`},
-			{Name: `Contains`, Method: `Contains`},
-			{Name: `Equals`, Method: `EQ`},
+			{Name: `StartsWith`, Match: `matchStartsWith`},
+			{Name: `EndsWith`, Match: `matchEndsWith`},
+			{Name: `Contains`, Match: `matchContains`},
+			{Name: `Equals`, Match: `matchEqual`},
+			{Name: `Compare`}, // gt, ge, lt, le
 		} {
-			if err := generatePredicate(&buf, object, pred.Name, pred.Method); err != nil {
+			if err := generatePredicate(&buf, object, pred.Name, pred.Match); err != nil {
 				return fmt.Errorf(`failed to generate predicate for %s: %w`, pred.Name, err)
 			}
 		}
@@ -977,14 +1073,14 @@ func generateUtilities(object *codegen.Object) error {
 					o.L(`} else {`)
 					o.LL(`parsedUUID, err := uuid.Parse(in.Value())`)
 					o.L(`if err != nil {`)
-					o.L(`return nil, fmt.Errorf("failed to parse ID in \"value\" field: %%w", err)`)
+					o.L(`return nil, invalidValue(fmt.Errorf("failed to parse ID in \"value\" field: %%w", err))`)
 					o.L(`}`)
-					o.L(`if ok, _ := b.db.User.Query().Where(user.ID(parsedUUID)).Exist(ctx); ok {`)
+					o.L(`if ok, _ := b.db.User.Query().Where(user.ID(parsedUUID), user.DeletedAtIsNil()).Exist(ctx); ok {`)
 					o.L(`createCall.SetType("User")`)
-					o.L(`} else if ok, _ := b.db.Group.Query().Where(group.ID(parsedUUID)).Exist(ctx); ok {`)
+					o.L(`} else if ok, _ := b.db.Group.Query().Where(group.ID(parsedUUID), group.DeletedAtIsNil()).Exist(ctx); ok {`)
 					o.L(`createCall.SetType("Group")`)
 					o.L(`} else {`)
-					o.L(`return nil, fmt.Errorf("could not determine resource type (User/Group) from provided ID")`)
+					o.L(`return nil, invalidValue(fmt.Errorf("could not determine resource type (User/Group) from provided ID"))`)
 					o.L(`}`)
 				}
 				o.L(`}`)
@@ -1019,19 +1115,54 @@ func generateUtilities(object *codegen.Object) error {
 				optional = append(optional, field)
 			}
 		}
-		o.LL(`func (b *Backend) Create%[1]s(ctx context.Context, in *resource.%[1]s) (*resource.%[1]s, error) {`, object.Name(true))
-		o.LL(`createCall := b.db.%s.Create()`, object.Name(true))
+		o.LL(`func (b *Backend) Create%[1]s(ctx context.Context, in *resource.%[1]s) (_ *resource.%[1]s, err error) {`, object.Name(true))
+		o.L(`// Failures are recorded in the audit log with their SCIM error`)
+		o.L("ctx, audited := b.auditFailures(ctx, AuditCreate, `%s`, \"\", in)", object.Name(true))
+		o.L(`defer audited(&err)`)
+		o.L(`// Errors not explicitly converted to SCIM errors are mapped here`)
+		o.L(`defer func() { err = convertError(err, internalError) }()`)
+		o.L(``)
+		o.L(`if err := b.authorize(ctx, Scope%ssWrite); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L(`if b.shouldUpsert(ctx) {`)
+		o.L(`res, _, err := b.Upsert%s(ctx, in)`, object.Name(true))
+		o.L(`return res, err`)
+		o.L(`}`)
+		o.L("if err := checkWritable(ctx, `%s`, in); err != nil {", object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L(`if err := b.validate%s(ctx, in, ""); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.LL(`// The %s, its first version and its audit entry are saved in a single transaction`, object.Name(true))
+		o.L(`var res *resource.%s`, object.Name(true))
+		o.L(`err = b.inTx(ctx, func(b *Backend) (err error) {`)
+		o.L(`res, err = b.create%s(ctx, in)`, object.Name(true))
+		o.L(`if err != nil {`)
+		o.L(`return err`)
+		o.L(`}`)
+		o.L("return b.recordAudit(ctx, b.db, AuditCreate, `%s`, res.ID(), in)", object.Name(true))
+		o.L(`})`)
+		o.L(`if err != nil {`)
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L(`return res, nil`)
+		o.L(`}`)
+
+		o.LL(`func (b *Backend) create%[1]s(ctx context.Context, in *resource.%[1]s) (*resource.%[1]s, error) {`, object.Name(true))
+		o.L(`createCall := b.db.%s.Create()`, object.Name(true))
 
 		if object.Name(true) == `User` {
 			o.L(`password, err := b.generatePassword(in)`)
 			o.L(`if err != nil {`)
-			o.L(`return nil, fmt.Errorf("failed to process password: %%w", err)`)
+			o.L(`return nil, invalidValue(fmt.Errorf("failed to process password: %%w", err))`)
 			o.L(`}`)
 			o.L(`createCall.SetPassword(password)`)
 		}
 		for _, field := range required {
 			o.L(`if !in.Has%s() {`, field.Name(true))
-			o.L(`return nil, fmt.Errorf("required field %s not found")`, field.JSON())
+			o.L(`return nil, invalidValue(fmt.Errorf("required field %s not found"))`, field.JSON())
 			o.L(`}`)
 			o.L(`createCall.Set%[1]s(in.%[1]s())`, field.Name(true))
 		}
@@ -1091,21 +1222,79 @@ func generateUtilities(object *codegen.Object) error {
 		o.L(`return nil, fmt.Errorf("failed to save etag: %%w", err)`)
 		o.L(`}`)
 		o.L(`rs.Etag = etag`)
-		o.L(`return %sResourceFromEnt(rs)`, object.Name(true))
+		o.LL(`if err := b.record%sVersion(ctx, b.db, rs.ID, VersionCreated); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		if object.Name(true) == `Group` {
+			o.L(`users, err := memberUsers(ctx, b.db, rs.ID)`)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+			o.L(`if err := b.recordMemberVersions(ctx, b.db, users); err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+		}
+		o.LL(`scrub%s(ctx, rs)`, object.Name(true))
+		o.L(`return %sResourceFromEnt(b.baseURLFor(ctx), rs)`, object.Name(true))
+		o.L(`}`)
+
+		o.LL(`func (b *Backend) Replace%[1]s(ctx context.Context, id string, in *resource.%[1]s) (_ *resource.%[1]s, err error) {`, object.Name(true))
+		o.L(`// Failures are recorded in the audit log with their SCIM error`)
+		o.L("ctx, audited := b.auditFailures(ctx, AuditReplace, `%s`, id, in)", object.Name(true))
+		o.L(`defer audited(&err)`)
+		o.L(`// Errors not explicitly converted to SCIM errors are mapped here`)
+		o.L(`defer func() { err = convertError(err, internalError) }()`)
+		o.L(``)
+		o.L(`if err := b.authorize(ctx, Scope%ssWrite); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L("if err := checkWritable(ctx, `%s`, in); err != nil {", object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L(`if err := b.validate%s(ctx, in, id); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.LL(`// The %s, its new version and its audit entry are saved in a single transaction`, object.Name(true))
+		o.L(`var res *resource.%s`, object.Name(true))
+		o.L(`err = b.inTx(ctx, func(b *Backend) (err error) {`)
+		o.L(`res, err = b.replace%s(ctx, id, in)`, object.Name(true))
+		o.L(`if err != nil {`)
+		o.L(`return err`)
+		o.L(`}`)
+		o.L("return b.recordAudit(ctx, b.db, AuditReplace, `%s`, res.ID(), in)", object.Name(true))
+		o.L(`})`)
+		o.L(`if err != nil {`)
+		o.L(`return nil, err`)
+		o.L(`}`)
+		o.L(`return res, nil`)
 		o.L(`}`)
 
-		o.LL(`func (b *Backend) Replace%[1]s(ctx context.Context, id string, in *resource.%[1]s) (*resource.%[1]s, error) {`, object.Name(true))
-		o.LL(`parsedUUID, err := uuid.Parse(id)`)
+		o.LL(`func (b *Backend) replace%[1]s(ctx context.Context, id string, in *resource.%[1]s) (*resource.%[1]s, error) {`, object.Name(true))
+		o.L(`parsedUUID, err := uuid.Parse(id)`)
 		o.L(`if err != nil {`)
-		o.L(`return nil, fmt.Errorf("failed to parse ID: %%w", err)`)
+		o.L(`return nil, notFound(%q, id)`, object.Name(true))
 		o.L(`}`)
-		o.LL(`r, err := b.db.%s.Query().Where(%s.ID(parsedUUID)).Only(ctx)`, object.Name(true), packageName(object.Name(false)))
+		o.LL(`r, err := b.db.%s.Query().Where(%s.ID(parsedUUID), %s.DeletedAtIsNil()).Only(ctx)`, object.Name(true), packageName(object.Name(false)), packageName(object.Name(false)))
 		o.L(`if err != nil {`)
+		o.L(`if ent.IsNotFound(err) {`)
+		o.L(`return nil, notFound(%q, id)`, object.Name(true))
+		o.L(`}`)
 		o.L(`return nil, fmt.Errorf("failed to retrieve resource for replacing: %%w", err)`)
 		o.L(`}`)
+		if object.Name(true) == `Group` {
+			// the members before and after the replacement are affected
+			o.LL(`before, err := memberUsers(ctx, b.db, parsedUUID)`)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+		}
+
+		// Attributes that the client is not allowed to write, as well as
+		// immutable attributes, are left as they are instead of being cleared
+		o.LL("preserved := preservedAttributes(ctx, `%s`, in)", object.Name(true))
 
 		// TODO: THIS IS NOT THE RIGHT IMPLEMENTATION
-		o.LL(`replaceCall := r.Update()`)
+		o.L(`replaceCall := r.Update()`)
 		for _, field := range object.Fields() {
 			attr, ok := resourceSchema.AttributeByName(field.JSON())
 			if !ok {
@@ -1116,9 +1305,22 @@ func generateUtilities(object *codegen.Object) error {
 				continue
 			}
 
-			// UserName cannot be empty
-			if field.Name(true) != `UserName` {
-				o.LL(`replaceCall.Clear%s()`, field.Name(true))
+			switch field.Name(true) {
+			case `UserName`:
+				// UserName cannot be empty
+			case `Members`:
+				// Member rows are deleted rather than unlinked, as they
+				// would otherwise still be listed in the "groups"
+				// attribute of the members
+				o.LL(`if !preserved.Has(resource.%s%sKey) {`, object.Name(true), field.Name(true))
+				o.L(`if _, err := b.db.Member.Delete().Where(member.HasGroupWith(group.ID(parsedUUID))).Exec(ctx); err != nil {`)
+				o.L(`return nil, fmt.Errorf("failed to delete members: %%w", err)`)
+				o.L(`}`)
+				o.L(`}`)
+			default:
+				o.LL(`if !preserved.Has(resource.%s%sKey) {`, object.Name(true), field.Name(true))
+				o.L(`replaceCall.Clear%s()`, field.Name(true))
+				o.L(`}`)
 			}
 
 			if isEdge(object, field) {
@@ -1169,6 +1371,9 @@ func generateUtilities(object *codegen.Object) error {
 		}
 
 		o.LL(`r2, err := b.db.%s.Query().Where(%s.ID(parsedUUID)).`, object.Name(true), packageName(object.Name(false)))
+		if object.Name(true) == `User` {
+			o.L(`WithMemberships().`)
+		}
 		for _, field := range object.Fields() {
 			if !isEdge(object, field) || field.Name(true) == `Names` {
 				continue
@@ -1189,7 +1394,21 @@ func generateUtilities(object *codegen.Object) error {
 		o.L(`}`)
 		o.L(`r2.Etag = etag`)
 
-		o.LL(`return %sResourceFromEnt(r2)`, object.Name(true))
+		o.LL(`if err := b.record%sVersion(ctx, b.db, r2.ID, VersionReplaced); err != nil {`, object.Name(true))
+		o.L(`return nil, err`)
+		o.L(`}`)
+		if object.Name(true) == `Group` {
+			o.L(`after, err := memberUsers(ctx, b.db, r2.ID)`)
+			o.L(`if err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+			o.L(`if err := b.recordMemberVersions(ctx, b.db, append(before, after...)); err != nil {`)
+			o.L(`return nil, err`)
+			o.L(`}`)
+		}
+
+		o.LL(`scrub%s(ctx, r2)`, object.Name(true))
+		o.L(`return %sResourceFromEnt(b.baseURLFor(ctx), r2)`, object.Name(true))
 		o.L(`}`)
 
 		o.LL(`func (b *Backend) patchAdd%[1]s(ctx context.Context, parent *ent.%[1]s, op *resource.PatchOperation) error {`, object.Name(true))
@@ -0,0 +1,331 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cybozu-go/scim-server/ent/group"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/google/uuid"
+)
+
+// UpsertKey is the attribute used to find the resource replaced by an
+// upsert (see Backend.UpsertUser)
+type UpsertKey string
+
+const (
+	// UpsertByName matches Users by userName, and Groups by displayName.
+	// Both are compared case insensitively
+	UpsertByName UpsertKey = `name`
+
+	// UpsertByExternalID matches resources by their externalId, among the
+	// resources provisioned by the same client
+	UpsertByExternalID UpsertKey = `externalId`
+)
+
+const (
+	// UpsertRequestHeader is the request header that turns the creation
+	// of a resource into an upsert, when set to "true" (see Upsert)
+	UpsertRequestHeader = `X-SCIM-Upsert`
+
+	// UpsertResultHeader is the response header telling whether an
+	// upsert "created" or "updated" the resource
+	UpsertResultHeader = `X-SCIM-Upsert-Result`
+)
+
+// upsertState tracks an upsert requested over HTTP. The outcome is
+// recorded by the Backend, and reported by the Upsert middleware
+type upsertState struct {
+	requested bool
+	created   *bool
+}
+
+type identUpsertState struct{}
+type identUpsertInProgress struct{}
+
+func upsertStateFromContext(ctx context.Context) *upsertState {
+	s, _ := ctx.Value(identUpsertState{}).(*upsertState)
+	return s
+}
+
+// shouldUpsert reports if the creation of a resource must be handled as
+// an upsert, either because the Backend is configured to do so or
+// because the client asked for it
+func (b *Backend) shouldUpsert(ctx context.Context) bool {
+	if ctx.Value(identUpsertInProgress{}) != nil {
+		return false
+	}
+	if b.upsertAll {
+		return true
+	}
+	s := upsertStateFromContext(ctx)
+	return s != nil && s.requested
+}
+
+// groupDisplayNameIndex makes the displayName of Groups unique (case
+// insensitively) when upserts match Groups by displayName, as an
+// upsert must find at most one Group
+var groupDisplayNameIndex = uniqueIndex{
+	name:    `groups_display_name_unique`,
+	table:   group.Table,
+	columns: group.FieldNormalizedDisplayName,
+}
+
+// upsertKeyAttribute returns the attribute used to find the resource
+// replaced by an upsert. The client must be allowed to read it, as
+// upserts would otherwise reveal which values are in use
+func (b *Backend) upsertKeyAttribute(ctx context.Context, resourceType string) (string, error) {
+	name := resource.UserExternalIDKey
+	if b.upsertKey != UpsertByExternalID {
+		name = resource.UserUserNameKey
+		if resourceType == `Group` {
+			name = resource.GroupDisplayNameKey
+		}
+	}
+	if !attributePolicyFromContext(ctx).CanRead(resourceType, name) {
+		return "", resource.NewErrorBuilder().
+			Status(http.StatusForbidden).
+			ScimType(resource.ErrUnknown).
+			Detail(fmt.Sprintf(`client is not allowed to upsert %ss by %s, as it cannot read it`, resourceType, name)).
+			MustBuild()
+	}
+	return name, nil
+}
+
+// UpsertUser creates the User, or replaces the existing one with the same
+// natural key (see WithUpsertKey). The boolean return value is true if
+// the User was created.
+//
+// The User is looked up and written in the same transaction, and the
+// natural key is unique, so concurrent upserts of the same User cannot
+// create it twice: the upsert that loses the race finds the User
+// created by the other, and replaces it
+func (b *Backend) UpsertUser(ctx context.Context, in *resource.User) (_ *resource.User, _ bool, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditUpsert, `User`, "", in)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return nil, false, err
+	}
+
+	name, err := b.upsertKeyAttribute(ctx, `User`)
+	if err != nil {
+		return nil, false, err
+	}
+	if (name == resource.UserExternalIDKey && !in.HasExternalID()) || (name == resource.UserUserNameKey && !in.HasUserName()) {
+		return nil, false, invalidValue(fmt.Errorf(`%s is required to upsert a User`, name))
+	}
+
+	outer := upsertStateFromContext(ctx)
+	ctx = context.WithValue(ctx, identUpsertInProgress{}, true)
+
+	for attempt := 0; ; attempt++ {
+		var res *resource.User
+		var created bool
+		err := b.inTx(ctx, func(b *Backend) error {
+			id, err := b.upsertUserID(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			created = id == ""
+			if created {
+				res, err = b.CreateUser(ctx, in)
+			} else {
+				res, err = b.ReplaceUser(ctx, id, in)
+			}
+			return err
+		})
+		// the User was created in the meantime
+		if isConflict(err) && created && attempt == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		if outer != nil {
+			outer.created = &created
+		}
+		return res, created, nil
+	}
+}
+
+// upsertUserID returns the ID of the User matching the natural key of
+// in, or "" if there is none
+func (b *Backend) upsertUserID(ctx context.Context, in *resource.User) (string, error) {
+	var id string
+	var err error
+	switch b.upsertKey {
+	case UpsertByExternalID:
+		id, err = b.userIDByExternalID(ctx, in.ExternalID())
+	default:
+		var ids []uuid.UUID
+		ids, err = b.db.User.Query().
+			Where(user.NormalizedUserNameEQ(strings.ToLower(in.UserName())), user.DeletedAtIsNil()).
+			IDs(ctx)
+		if err == nil && len(ids) > 0 {
+			id = ids[0].String()
+		}
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", convertError(err, func(err error) error {
+			return internalError(fmt.Errorf(`failed to look up user: %w`, err))
+		})
+	}
+	return id, nil
+}
+
+// UpsertGroup is the equivalent of UpsertUser for Groups. When matching
+// by name, displayName is unique among Groups (see groupDisplayNameIndex)
+func (b *Backend) UpsertGroup(ctx context.Context, in *resource.Group) (_ *resource.Group, _ bool, err error) {
+	ctx, audited := b.auditFailures(ctx, AuditUpsert, `Group`, "", in)
+	defer audited(&err)
+
+	if err := b.authorize(ctx, ScopeGroupsWrite); err != nil {
+		return nil, false, err
+	}
+
+	name, err := b.upsertKeyAttribute(ctx, `Group`)
+	if err != nil {
+		return nil, false, err
+	}
+	if (name == resource.GroupExternalIDKey && !in.HasExternalID()) || (name == resource.GroupDisplayNameKey && !in.HasDisplayName()) {
+		return nil, false, invalidValue(fmt.Errorf(`%s is required to upsert a Group`, name))
+	}
+
+	outer := upsertStateFromContext(ctx)
+	ctx = context.WithValue(ctx, identUpsertInProgress{}, true)
+
+	for attempt := 0; ; attempt++ {
+		var res *resource.Group
+		var created bool
+		err := b.inTx(ctx, func(b *Backend) error {
+			id, err := b.upsertGroupID(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			created = id == ""
+			if created {
+				res, err = b.CreateGroup(ctx, in)
+			} else {
+				res, err = b.ReplaceGroup(ctx, id, in)
+			}
+			return err
+		})
+		// the Group was created in the meantime
+		if isConflict(err) && created && attempt == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		if outer != nil {
+			outer.created = &created
+		}
+		return res, created, nil
+	}
+}
+
+// upsertGroupID returns the ID of the Group matching the natural key of
+// in, or "" if there is none
+func (b *Backend) upsertGroupID(ctx context.Context, in *resource.Group) (string, error) {
+	var id string
+	var err error
+	switch b.upsertKey {
+	case UpsertByExternalID:
+		id, err = b.groupIDByExternalID(ctx, in.ExternalID())
+	default:
+		var ids []uuid.UUID
+		ids, err = b.db.Group.Query().
+			Where(group.NormalizedDisplayNameEQ(strings.ToLower(in.DisplayName())), group.DeletedAtIsNil()).
+			IDs(ctx)
+		if err == nil && len(ids) > 0 {
+			id = ids[0].String()
+		}
+	}
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", convertError(err, func(err error) error {
+			return internalError(fmt.Errorf(`failed to look up group: %w`, err))
+		})
+	}
+	return id, nil
+}
+
+func isNotFound(err error) bool {
+	serr, ok := asSCIMError(err)
+	return ok && serr.Status() == http.StatusNotFound
+}
+
+func isConflict(err error) bool {
+	serr, ok := asSCIMError(err)
+	return ok && serr.Status() == http.StatusConflict
+}
+
+// Upsert wraps an http.Handler (usually the SCIM server handler) so that
+// clients may create resources with upsert semantics, by sending the
+// UpsertRequestHeader. It is also required to report the outcome of
+// upserts when the Backend performs them for every creation (see
+// WithUpsert): updates are answered with 200 instead of 201, and the
+// UpsertResultHeader is set to "created" or "updated"
+func Upsert(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		var s upsertState
+		if v := r.Header.Get(UpsertRequestHeader); v != "" {
+			requested, err := strconv.ParseBool(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, invalidValue(fmt.Errorf(`invalid %s header: %w`, UpsertRequestHeader, err)))
+				return
+			}
+			s.requested = requested
+		}
+
+		ctx := context.WithValue(r.Context(), identUpsertState{}, &s)
+		h.ServeHTTP(&upsertResponseWriter{ResponseWriter: w, state: &s}, r.WithContext(ctx))
+	})
+}
+
+type upsertResponseWriter struct {
+	http.ResponseWriter
+	state       *upsertState
+	wroteHeader bool
+}
+
+func (w *upsertResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader && w.state.created != nil {
+		if *w.state.created {
+			w.Header().Set(UpsertResultHeader, `created`)
+		} else {
+			w.Header().Set(UpsertResultHeader, `updated`)
+			if status == http.StatusCreated {
+				status = http.StatusOK
+			}
+		}
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *upsertResponseWriter) Write(buf []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(buf)
+}
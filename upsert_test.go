@@ -0,0 +1,177 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsert(t *testing.T) {
+	ctx := context.Background()
+
+	// upsertUser and upsertGroup upsert a resource from a JSON payload
+	upsertUser := func(t *testing.T, b *server.Backend, ctx context.Context, payload string) (*resource.User, bool, error) {
+		t.Helper()
+
+		var in resource.User
+		decode(t, payload, &in)
+		return b.UpsertUser(ctx, &in)
+	}
+	upsertGroup := func(t *testing.T, b *server.Backend, ctx context.Context, payload string) (*resource.Group, bool, error) {
+		t.Helper()
+
+		var in resource.Group
+		decode(t, payload, &in)
+		return b.UpsertGroup(ctx, &in)
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		b := newBackend(t)
+
+		u, created, err := upsertUser(t, b, ctx, `{"userName":"Ärger","title":"Engineer"}`)
+		require.NoError(t, err, `UpsertUser should succeed`)
+		require.True(t, created, `user should be created`)
+		u2, created, err := upsertUser(t, b, ctx, `{"userName":"ärger","title":"Manager"}`)
+		require.NoError(t, err, `UpsertUser should succeed`)
+		require.False(t, created, `user should be replaced`)
+		require.Equal(t, u.ID(), u2.ID(), `the same user should be replaced`)
+		require.Equal(t, "Manager", u2.Title(), `user should be replaced`)
+
+		g, created, err := upsertGroup(t, b, ctx, `{"displayName":"Équipe"}`)
+		require.NoError(t, err, `UpsertGroup should succeed`)
+		require.True(t, created, `group should be created`)
+		g2, created, err := upsertGroup(t, b, ctx, `{"displayName":"ÉQUIPE","members":[{"value":"`+u.ID()+`"}]}`)
+		require.NoError(t, err, `UpsertGroup should succeed`)
+		require.False(t, created, `group should be replaced`)
+		require.Equal(t, g.ID(), g2.ID(), `the same group should be replaced`)
+		require.Len(t, g2.Members(), 1, `group should be replaced`)
+
+		// upserts must find at most one Group
+		var in resource.Group
+		decode(t, `{"displayName":"équipe"}`, &in)
+		_, err = b.CreateGroup(ctx, &in)
+		serr := requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+
+		_, _, err = upsertGroup(t, b, ctx, `{"externalId":"g-1"}`)
+		requireSCIMError(t, err, http.StatusBadRequest)
+	})
+	t.Run("by externalId", func(t *testing.T) {
+		b := newBackend(t, server.WithUpsertKey(server.UpsertByExternalID))
+		hr := as("hr", server.ScopeUsersRead, server.ScopeUsersWrite)
+		sales := as("sales", server.ScopeUsersRead, server.ScopeUsersWrite)
+
+		u, created, err := upsertUser(t, b, hr, `{"userName":"alice","externalId":"e-1"}`)
+		require.NoError(t, err, `UpsertUser should succeed`)
+		require.True(t, created, `user should be created`)
+		u2, created, err := upsertUser(t, b, hr, `{"userName":"alice.smith","externalId":"e-1"}`)
+		require.NoError(t, err, `UpsertUser should succeed`)
+		require.False(t, created, `user should be replaced`)
+		require.Equal(t, u.ID(), u2.ID(), `the same user should be replaced`)
+		require.Equal(t, "alice.smith", u2.UserName(), `user should be replaced`)
+
+		// externalIds are scoped to each client
+		_, created, err = upsertUser(t, b, sales, `{"userName":"bob","externalId":"e-1"}`)
+		require.NoError(t, err, `UpsertUser should succeed`)
+		require.True(t, created, `user should be created`)
+
+		// upserts must find at most one User
+		var in resource.User
+		decode(t, `{"userName":"carol","externalId":"e-1"}`, &in)
+		_, err = b.CreateUser(hr, &in)
+		serr := requireSCIMError(t, err, http.StatusConflict)
+		require.Equal(t, resource.ErrUniqueness, serr.ScimType(), `scimType should be uniqueness`)
+
+		// different groups may have the same displayName
+		for _, externalID := range []string{"g-1", "g-2"} {
+			_, created, err := upsertGroup(t, b, ctx, `{"displayName":"staff","externalId":"`+externalID+`"}`)
+			require.NoError(t, err, `UpsertGroup should succeed`)
+			require.True(t, created, `group should be created`)
+		}
+	})
+	t.Run("unreadable key", func(t *testing.T) {
+		b := newBackend(t)
+		helpdesk := server.WithPrincipal(ctx, server.NewPrincipal("helpdesk", server.ScopeUsersRead, server.ScopeUsersWrite).
+			WithAttributePolicy(server.NewAttributePolicy().DenyRead(`User`, "userName")))
+
+		_, _, err := upsertUser(t, b, helpdesk, `{"userName":"alice"}`)
+		requireSCIMError(t, err, http.StatusForbidden)
+		res, err := b.SearchUser(ctx, filterRequest(t, `userName eq "alice"`))
+		require.NoError(t, err, `SearchUser should succeed`)
+		require.Equal(t, 0, res.TotalResults(), `user should not be created`)
+	})
+	t.Run("concurrent upserts", func(t *testing.T) {
+		b := newBackend(t, server.WithUpsert(true))
+
+		const n = 8
+		var wg sync.WaitGroup
+		ids := make(chan string, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var in resource.Group
+				if err := json.Unmarshal([]byte(`{"displayName":"staff"}`), &in); err != nil {
+					return
+				}
+				// locking errors of SQLite are possible, duplicates are not
+				if g, err := b.CreateGroup(ctx, &in); err == nil {
+					ids <- g.ID()
+				}
+			}()
+		}
+		wg.Wait()
+		close(ids)
+
+		seen := map[string]struct{}{}
+		for id := range ids {
+			seen[id] = struct{}{}
+		}
+		require.Len(t, seen, 1, `upserts should all find the same group`)
+		require.Equal(t, []string{"staff"}, searchGroupNames(t, b, `displayName eq "staff"`), `a single group should be created`)
+	})
+	t.Run("middleware", func(t *testing.T) {
+		b := newBackend(t)
+		h := server.Upsert(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var in resource.User
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if _, err := b.CreateUser(r.Context(), &in); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		for _, expected := range []struct {
+			Status int
+			Result string
+		}{
+			{Status: http.StatusCreated, Result: "created"},
+			{Status: http.StatusOK, Result: "updated"},
+		} {
+			r := httptest.NewRequest(http.MethodPost, "/Users", strings.NewReader(`{"userName":"alice"}`))
+			r.Header.Set(server.UpsertRequestHeader, "true")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			require.Equal(t, expected.Status, w.Code, `status should be %d`, expected.Status)
+			require.Equal(t, expected.Result, w.Header().Get(server.UpsertResultHeader), `result should be %s`, expected.Result)
+		}
+
+		r := httptest.NewRequest(http.MethodPost, "/Users", strings.NewReader(`{"userName":"bob"}`))
+		r.Header.Set(server.UpsertRequestHeader, "maybe")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusBadRequest, w.Code, `invalid header should be rejected`)
+	})
+}
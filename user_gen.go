@@ -14,6 +14,7 @@ import (
 	"github.com/cybozu-go/scim-server/ent/email"
 	"github.com/cybozu-go/scim-server/ent/entitlement"
 	"github.com/cybozu-go/scim-server/ent/ims"
+	"github.com/cybozu-go/scim-server/ent/names"
 	"github.com/cybozu-go/scim-server/ent/phonenumber"
 	"github.com/cybozu-go/scim-server/ent/photo"
 	"github.com/cybozu-go/scim-server/ent/predicate"
@@ -26,20 +27,9 @@ import (
 )
 
 func userLoadEntFields(q *ent.UserQuery, scimFields, excludedFields []string) {
-	fields := make(map[string]struct{})
-	if len(scimFields) == 0 {
-		scimFields = []string{resource.UserActiveKey, resource.UserAddressesKey, resource.UserDisplayNameKey, resource.UserEmailsKey, resource.UserEntitlementsKey, resource.UserExternalIDKey, resource.UserGroupsKey, resource.UserIDKey, resource.UserIMSKey, resource.UserLocaleKey, resource.UserNameKey, resource.UserNickNameKey, resource.UserPasswordKey, resource.UserPhoneNumbersKey, resource.UserPhotosKey, resource.UserPreferredLanguageKey, resource.UserProfileURLKey, resource.UserRolesKey, resource.UserTimezoneKey, resource.UserTitleKey, resource.UserUserNameKey, resource.UserUserTypeKey, resource.UserX509CertificatesKey}
-	}
-
-	for _, name := range scimFields {
-		fields[name] = struct{}{}
-	}
-
-	for _, name := range excludedFields {
-		delete(fields, name)
-	}
+	fields := projectAttributes(`User`, scimFields, excludedFields)
 	selectNames := make([]string, 0, len(fields))
-	for f := range fields {
+	for _, f := range fields {
 		switch f {
 		case resource.UserActiveKey:
 			selectNames = append(selectNames, user.FieldActive)
@@ -53,6 +43,8 @@ func userLoadEntFields(q *ent.UserQuery, scimFields, excludedFields []string) {
 			q.WithEntitlements()
 		case resource.UserExternalIDKey:
 			selectNames = append(selectNames, user.FieldExternalID)
+		case resource.UserGroupsKey:
+			q.WithMemberships()
 		case resource.UserIDKey:
 			selectNames = append(selectNames, user.FieldID)
 		case resource.UserIMSKey:
@@ -92,18 +84,18 @@ func userLoadEntFields(q *ent.UserQuery, scimFields, excludedFields []string) {
 	q.Select(selectNames...)
 }
 
-func userLocation(id string) string {
-	return "https://foobar.com/scim/v2/Users/" + id
+func userLocation(baseURL, id string) string {
+	return baseURL + "/Users/" + id
 }
 
-func UserResourceFromEnt(in *ent.User) (*resource.User, error) {
+func UserResourceFromEnt(baseURL string, in *ent.User) (*resource.User, error) {
 	var b resource.Builder
 
 	builder := b.User()
 
 	meta, err := b.Meta().
 		ResourceType("User").
-		Location(userLocation(in.ID.String())).
+		Location(userLocation(baseURL, in.ID.String())).
 		Version(in.Etag).
 		Build()
 	if err != nil {
@@ -222,7 +214,7 @@ func UserResourceFromEnt(in *ent.User) (*resource.User, error) {
 		for i, m := range in.Groups {
 			var gmb resource.GroupMemberBuilder
 			gm, err := gmb.Value(m.Value).
-				Ref(m.Ref).
+				Ref(groupLocation(baseURL, m.Value)).
 				Type(m.Type).
 				Build()
 			if err != nil {
@@ -305,125 +297,838 @@ func userStartsWithPredicate(q *ent.UserQuery, scimField string, val interface{}
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.UserDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserEmailsKey:
 		switch subfield {
 		case resource.EmailDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.DisplayHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(email.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.EmailTypeKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.TypeHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(email.FieldType), v, caseExact))
+			})), nil
 		case resource.EmailValueKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.ValueHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(email.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
+	case resource.UserGroupsKey:
+		return userGroupsPredicate(scimField, matchStartsWith, val)
 	case resource.UserIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserLocaleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
+	case resource.UserMetaKey:
+		p, err := metaPredicate(`User`, scimField, matchStartsWith, val)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(p)
+		}), nil
+	case resource.UserNameKey:
+		switch subfield {
+		case resource.NamesFamilyNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldFamilyName), v, caseExact))
+			})), nil
+		case resource.NamesFormattedKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldFormatted), v, caseExact))
+			})), nil
+		case resource.NamesGivenNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldGivenName), v, caseExact))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldHonorificPrefix), v, caseExact))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldHonorificSuffix), v, caseExact))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(names.FieldMiddleName), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	case resource.UserNickNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
 		}), nil
-	case resource.UserPasswordKey:
+	case resource.UserPhoneNumbersKey:
+		switch subfield {
+		case resource.PhoneNumberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(phonenumber.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.PhoneNumberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(phonenumber.FieldType), v, caseExact))
+			})), nil
+		case resource.PhoneNumberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(phonenumber.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserPreferredLanguageKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserProfileURLKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserRolesKey:
+		switch subfield {
+		case resource.RoleDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(role.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.RoleTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(role.FieldType), v, caseExact))
+			})), nil
+		case resource.RoleValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchStartsWith, s.C(role.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserTimezoneKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserTitleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserUserNameKey:
+		return userNamePredicate(scimField, matchStartsWith, val)
+	case resource.UserUserTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchStartsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid filter field specification")
+	}
+}
+
+func userEndsWithPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
+	_ = q
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+	_ = subfield // TODO: remove later
+	switch field {
+	case resource.UserDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserEmailsKey:
+		switch subfield {
+		case resource.EmailDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(email.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.EmailTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(email.FieldType), v, caseExact))
+			})), nil
+		case resource.EmailValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(email.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserGroupsKey:
+		return userGroupsPredicate(scimField, matchEndsWith, val)
+	case resource.UserIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserLocaleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserMetaKey:
+		p, err := metaPredicate(`User`, scimField, matchEndsWith, val)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(p)
+		}), nil
+	case resource.UserNameKey:
+		switch subfield {
+		case resource.NamesFamilyNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldFamilyName), v, caseExact))
+			})), nil
+		case resource.NamesFormattedKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldFormatted), v, caseExact))
+			})), nil
+		case resource.NamesGivenNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldGivenName), v, caseExact))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldHonorificPrefix), v, caseExact))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldHonorificSuffix), v, caseExact))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(names.FieldMiddleName), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserNickNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserPhoneNumbersKey:
 		switch subfield {
 		case resource.PhoneNumberDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.DisplayHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(phonenumber.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.PhoneNumberTypeKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.TypeHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(phonenumber.FieldType), v, caseExact))
+			})), nil
 		case resource.PhoneNumberValueKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.ValueHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(phonenumber.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserPreferredLanguageKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserProfileURLKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserRolesKey:
 		switch subfield {
 		case resource.RoleDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.DisplayHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(role.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.RoleTypeKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.TypeHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(role.FieldType), v, caseExact))
+			})), nil
 		case resource.RoleValueKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.ValueHasPrefix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEndsWith, s.C(role.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserTimezoneKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserTitleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserUserNameKey:
+		return userNamePredicate(scimField, matchEndsWith, val)
+	case resource.UserUserTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchEndsWith, s.C(entFieldName), v, caseExact))
+		}), nil
+	default:
+		return nil, fmt.Errorf("invalid filter field specification")
+	}
+}
+
+func userContainsPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
+	_ = q
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+	_ = subfield // TODO: remove later
+	switch field {
+	case resource.UserDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserEmailsKey:
+		switch subfield {
+		case resource.EmailDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(email.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.EmailTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(email.FieldType), v, caseExact))
+			})), nil
+		case resource.EmailValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(email.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserGroupsKey:
+		return userGroupsPredicate(scimField, matchContains, val)
+	case resource.UserIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserLocaleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserMetaKey:
+		p, err := metaPredicate(`User`, scimField, matchContains, val)
+		if err != nil {
+			return nil, err
+		}
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(p)
 		}), nil
+	case resource.UserNameKey:
+		switch subfield {
+		case resource.NamesFamilyNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldFamilyName), v, caseExact))
+			})), nil
+		case resource.NamesFormattedKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldFormatted), v, caseExact))
+			})), nil
+		case resource.NamesGivenNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldGivenName), v, caseExact))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldHonorificPrefix), v, caseExact))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldHonorificSuffix), v, caseExact))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(names.FieldMiddleName), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserNickNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserPhoneNumbersKey:
+		switch subfield {
+		case resource.PhoneNumberDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(phonenumber.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.PhoneNumberTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(phonenumber.FieldType), v, caseExact))
+			})), nil
+		case resource.PhoneNumberValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(phonenumber.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserPreferredLanguageKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserProfileURLKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserRolesKey:
+		switch subfield {
+		case resource.RoleDisplayKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(role.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.RoleTypeKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(role.FieldType), v, caseExact))
+			})), nil
+		case resource.RoleValueKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchContains, s.C(role.FieldValue), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserTimezoneKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserTitleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
+		}), nil
+	case resource.UserUserNameKey:
+		return userNamePredicate(scimField, matchContains, val)
 	case resource.UserUserTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasPrefix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchContains, s.C(entFieldName), v, caseExact))
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
-func userEndsWithPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
+func userEqualsPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
 	_ = q
 	field, subfield, err := splitScimField(scimField)
 	if err != nil {
@@ -431,126 +1136,303 @@ func userEndsWithPredicate(q *ent.UserQuery, scimField string, val interface{})
 	}
 	_ = subfield // TODO: remove later
 	switch field {
+	case resource.UserActiveKey:
+		v, err := filterBool(scimField, val)
+		if err != nil {
+			return nil, err
+		}
+		return user.ActiveEQ(v), nil
 	case resource.UserDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserEmailsKey:
 		switch subfield {
 		case resource.EmailDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.DisplayHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(email.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.EmailPrimaryKey:
+			v, err := filterBool(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			return user.HasEmailsWith(email.PrimaryEQ(v)), nil
 		case resource.EmailTypeKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.TypeHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(email.FieldType), v, caseExact))
+			})), nil
 		case resource.EmailValueKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.ValueHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(email.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
+	case resource.UserGroupsKey:
+		return userGroupsPredicate(scimField, matchEqual, val)
 	case resource.UserIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserLocaleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
-	case resource.UserNickNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
+	case resource.UserMetaKey:
+		p, err := metaPredicate(`User`, scimField, matchEqual, val)
+		if err != nil {
+			return nil, err
+		}
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(p)
 		}), nil
-	case resource.UserPasswordKey:
+	case resource.UserNameKey:
+		switch subfield {
+		case resource.NamesFamilyNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldFamilyName), v, caseExact))
+			})), nil
+		case resource.NamesFormattedKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldFormatted), v, caseExact))
+			})), nil
+		case resource.NamesGivenNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldGivenName), v, caseExact))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldHonorificPrefix), v, caseExact))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldHonorificSuffix), v, caseExact))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(names.FieldMiddleName), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserNickNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserPhoneNumbersKey:
 		switch subfield {
 		case resource.PhoneNumberDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.DisplayHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(phonenumber.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.PhoneNumberPrimaryKey:
+			v, err := filterBool(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			return user.HasPhoneNumbersWith(phonenumber.PrimaryEQ(v)), nil
 		case resource.PhoneNumberTypeKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.TypeHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(phonenumber.FieldType), v, caseExact))
+			})), nil
 		case resource.PhoneNumberValueKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.ValueHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(phonenumber.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserPreferredLanguageKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserProfileURLKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserRolesKey:
 		switch subfield {
 		case resource.RoleDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.DisplayHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(role.FieldDisplay), v, caseExact))
+			})), nil
+		case resource.RolePrimaryKey:
+			v, err := filterBool(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			return user.HasRolesWith(role.PrimaryEQ(v)), nil
 		case resource.RoleTypeKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.TypeHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(role.FieldType), v, caseExact))
+			})), nil
 		case resource.RoleValueKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.ValueHasSuffix(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(matchEqual, s.C(role.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserTimezoneKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserTitleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserUserNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
-		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
-		}), nil
+		return userNamePredicate(scimField, matchEqual, val)
 	case resource.UserUserTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.HasSuffix(s.C(entFieldName), val.(string)))
+			s.Where(matchString(matchEqual, s.C(entFieldName), v, caseExact))
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
-func userContainsPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
+func userComparePredicate(q *ent.UserQuery, scimField string, m stringMatch, val interface{}) (predicate.User, error) {
 	_ = q
 	field, subfield, err := splitScimField(scimField)
 	if err != nil {
@@ -559,287 +1441,562 @@ func userContainsPredicate(q *ent.UserQuery, scimField string, val interface{})
 	_ = subfield // TODO: remove later
 	switch field {
 	case resource.UserDisplayNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserEmailsKey:
 		switch subfield {
 		case resource.EmailDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.DisplayContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(email.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.EmailTypeKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.TypeContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(email.FieldType), v, caseExact))
+			})), nil
 		case resource.EmailValueKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.ValueContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(email.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserExternalIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
+	case resource.UserGroupsKey:
+		return userGroupsPredicate(scimField, m, val)
 	case resource.UserIDKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserLocaleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
-	case resource.UserNickNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
+	case resource.UserMetaKey:
+		p, err := metaPredicate(`User`, scimField, m, val)
+		if err != nil {
+			return nil, err
+		}
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(p)
 		}), nil
-	case resource.UserPasswordKey:
+	case resource.UserNameKey:
+		switch subfield {
+		case resource.NamesFamilyNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldFamilyName), v, caseExact))
+			})), nil
+		case resource.NamesFormattedKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldFormatted), v, caseExact))
+			})), nil
+		case resource.NamesGivenNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldGivenName), v, caseExact))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldHonorificPrefix), v, caseExact))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldHonorificSuffix), v, caseExact))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(names.FieldMiddleName), v, caseExact))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserNickNameKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserPhoneNumbersKey:
 		switch subfield {
 		case resource.PhoneNumberDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.DisplayContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(phonenumber.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.PhoneNumberTypeKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.TypeContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(phonenumber.FieldType), v, caseExact))
+			})), nil
 		case resource.PhoneNumberValueKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.ValueContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(phonenumber.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserPreferredLanguageKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserProfileURLKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserRolesKey:
 		switch subfield {
 		case resource.RoleDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.DisplayContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(role.FieldDisplay), v, caseExact))
+			})), nil
 		case resource.RoleTypeKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.TypeContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(role.FieldType), v, caseExact))
+			})), nil
 		case resource.RoleValueKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.ValueContains(val.(string))), nil
+			v, err := filterString(scimField, val)
+			if err != nil {
+				return nil, err
+			}
+			caseExact := isCaseExact(`User`, scimField)
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(matchString(m, s.C(role.FieldValue), v, caseExact))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserTimezoneKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserTitleKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	case resource.UserUserNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
-		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
-		}), nil
+		return userNamePredicate(scimField, m, val)
 	case resource.UserUserTypeKey:
+		v, err := filterString(scimField, val)
+		if err != nil {
+			return nil, err
+		}
 		entFieldName := UserEntFieldFromSCIM(scimField)
+		caseExact := isCaseExact(`User`, scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.Contains(s.C(entFieldName), val.(string)))
+			s.Where(matchString(m, s.C(entFieldName), v, caseExact))
 		}), nil
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
-func userEqualsPredicate(q *ent.UserQuery, scimField string, val interface{}) (predicate.User, error) {
-	_ = q
+func userPresencePredicate(scimField string) (predicate.User, error) {
 	field, subfield, err := splitScimField(scimField)
 	if err != nil {
 		return nil, err
 	}
-	_ = subfield // TODO: remove later
+
 	switch field {
+	case resource.UserActiveKey:
+		return predicate.User(func(s *sql.Selector) {
+			s.Where(sql.NotNull(s.C(user.FieldActive)))
+		}), nil
+	case resource.UserAddressesKey:
+		switch subfield {
+		case "":
+			return user.HasAddresses(), nil
+		case resource.AddressCountryKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldCountry)))
+			})), nil
+		case resource.AddressFormattedKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldFormatted)))
+			})), nil
+		case resource.AddressLocalityKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldLocality)))
+			})), nil
+		case resource.AddressPostalCodeKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldPostalCode)))
+			})), nil
+		case resource.AddressRegionKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldRegion)))
+			})), nil
+		case resource.AddressStreetAddressKey:
+			return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(address.FieldStreetAddress)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	case resource.UserDisplayNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldDisplayName)))
 		}), nil
 	case resource.UserEmailsKey:
 		switch subfield {
+		case "":
+			return user.HasEmails(), nil
 		case resource.EmailDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.DisplayEQ(val.(string))), nil
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(email.FieldDisplay)))
+			})), nil
 		case resource.EmailPrimaryKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.PrimaryEQ(val.(bool))), nil
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(email.FieldPrimary)))
+			})), nil
 		case resource.EmailTypeKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.TypeEQ(val.(string))), nil
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(email.FieldType)))
+			})), nil
 		case resource.EmailValueKey:
-			//nolint:forcetypeassert
-			return user.HasEmailsWith(email.ValueEQ(val.(string))), nil
+			return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(email.FieldValue)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserEntitlementsKey:
+		switch subfield {
+		case "":
+			return user.HasEntitlements(), nil
+		case resource.EntitlementDisplayKey:
+			return user.HasEntitlementsWith(predicate.Entitlement(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(entitlement.FieldDisplay)))
+			})), nil
+		case resource.EntitlementPrimaryKey:
+			return user.HasEntitlementsWith(predicate.Entitlement(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(entitlement.FieldPrimary)))
+			})), nil
+		case resource.EntitlementTypeKey:
+			return user.HasEntitlementsWith(predicate.Entitlement(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(entitlement.FieldType)))
+			})), nil
+		case resource.EntitlementValueKey:
+			return user.HasEntitlementsWith(predicate.Entitlement(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(entitlement.FieldValue)))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserExternalIDKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldExternalID)))
 		}), nil
+	case resource.UserGroupsKey:
+		return userHasGroups(), nil
 	case resource.UserIDKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldID)))
 		}), nil
+	case resource.UserIMSKey:
+		switch subfield {
+		case "":
+			return user.HasIMS(), nil
+		case resource.IMSDisplayKey:
+			return user.HasIMSWith(predicate.IMS(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(ims.FieldDisplay)))
+			})), nil
+		case resource.IMSPrimaryKey:
+			return user.HasIMSWith(predicate.IMS(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(ims.FieldPrimary)))
+			})), nil
+		case resource.IMSTypeKey:
+			return user.HasIMSWith(predicate.IMS(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(ims.FieldType)))
+			})), nil
+		case resource.IMSValueKey:
+			return user.HasIMSWith(predicate.IMS(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(ims.FieldValue)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	case resource.UserLocaleKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldLocale)))
 		}), nil
+	case resource.UserNameKey:
+		switch subfield {
+		case "":
+			return user.HasName(), nil
+		case resource.NamesFamilyNameKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldFamilyName)))
+			})), nil
+		case resource.NamesFormattedKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldFormatted)))
+			})), nil
+		case resource.NamesGivenNameKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldGivenName)))
+			})), nil
+		case resource.NamesHonorificPrefixKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldHonorificPrefix)))
+			})), nil
+		case resource.NamesHonorificSuffixKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldHonorificSuffix)))
+			})), nil
+		case resource.NamesMiddleNameKey:
+			return user.HasNameWith(predicate.Names(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(names.FieldMiddleName)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	case resource.UserNickNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
-		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
-		}), nil
-	case resource.UserPasswordKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldNickName)))
 		}), nil
 	case resource.UserPhoneNumbersKey:
 		switch subfield {
+		case "":
+			return user.HasPhoneNumbers(), nil
 		case resource.PhoneNumberDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.DisplayEQ(val.(string))), nil
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(phonenumber.FieldDisplay)))
+			})), nil
 		case resource.PhoneNumberPrimaryKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.PrimaryEQ(val.(bool))), nil
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(phonenumber.FieldPrimary)))
+			})), nil
 		case resource.PhoneNumberTypeKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.TypeEQ(val.(string))), nil
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(phonenumber.FieldType)))
+			})), nil
 		case resource.PhoneNumberValueKey:
-			//nolint:forcetypeassert
-			return user.HasPhoneNumbersWith(phonenumber.ValueEQ(val.(string))), nil
+			return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(phonenumber.FieldValue)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
+	case resource.UserPhotosKey:
+		switch subfield {
+		case "":
+			return user.HasPhotos(), nil
+		case resource.PhotoDisplayKey:
+			return user.HasPhotosWith(predicate.Photo(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(photo.FieldDisplay)))
+			})), nil
+		case resource.PhotoPrimaryKey:
+			return user.HasPhotosWith(predicate.Photo(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(photo.FieldPrimary)))
+			})), nil
+		case resource.PhotoTypeKey:
+			return user.HasPhotosWith(predicate.Photo(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(photo.FieldType)))
+			})), nil
+		case resource.PhotoValueKey:
+			return user.HasPhotosWith(predicate.Photo(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(photo.FieldValue)))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserPreferredLanguageKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldPreferredLanguage)))
 		}), nil
 	case resource.UserProfileURLKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldProfileURL)))
 		}), nil
 	case resource.UserRolesKey:
 		switch subfield {
+		case "":
+			return user.HasRoles(), nil
 		case resource.RoleDisplayKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.DisplayEQ(val.(string))), nil
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(role.FieldDisplay)))
+			})), nil
 		case resource.RolePrimaryKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.PrimaryEQ(val.(bool))), nil
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(role.FieldPrimary)))
+			})), nil
 		case resource.RoleTypeKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.TypeEQ(val.(string))), nil
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(role.FieldType)))
+			})), nil
 		case resource.RoleValueKey:
-			//nolint:forcetypeassert
-			return user.HasRolesWith(role.ValueEQ(val.(string))), nil
+			return user.HasRolesWith(predicate.Role(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(role.FieldValue)))
+			})), nil
 		default:
 			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
 		}
 	case resource.UserTimezoneKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldTimezone)))
 		}), nil
 	case resource.UserTitleKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldTitle)))
 		}), nil
 	case resource.UserUserNameKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldUserName)))
 		}), nil
 	case resource.UserUserTypeKey:
-		entFieldName := UserEntFieldFromSCIM(scimField)
 		return predicate.User(func(s *sql.Selector) {
-			//nolint:forcetypeassert
-			s.Where(sql.EQ(s.C(entFieldName), val.(string)))
+			s.Where(isPresent(s.C(user.FieldUserType)))
 		}), nil
+	case resource.UserX509CertificatesKey:
+		switch subfield {
+		case "":
+			return user.HasX509Certificates(), nil
+		case resource.X509CertificateDisplayKey:
+			return user.HasX509CertificatesWith(predicate.X509Certificate(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(x509certificate.FieldDisplay)))
+			})), nil
+		case resource.X509CertificatePrimaryKey:
+			return user.HasX509CertificatesWith(predicate.X509Certificate(func(s *sql.Selector) {
+				s.Where(sql.NotNull(s.C(x509certificate.FieldPrimary)))
+			})), nil
+		case resource.X509CertificateTypeKey:
+			return user.HasX509CertificatesWith(predicate.X509Certificate(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(x509certificate.FieldType)))
+			})), nil
+		case resource.X509CertificateValueKey:
+			return user.HasX509CertificatesWith(predicate.X509Certificate(func(s *sql.Selector) {
+				s.Where(isPresent(s.C(x509certificate.FieldValue)))
+			})), nil
+		default:
+			return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+		}
 	default:
 		return nil, fmt.Errorf("invalid filter field specification")
 	}
 }
 
-func userPresencePredicate(scimField string) predicate.User {
-	switch scimField {
-	case resource.UserDisplayNameKey:
-		return user.And(user.DisplayNameNotNil(), user.DisplayNameNEQ(""))
-	case resource.UserExternalIDKey:
-		return user.And(user.ExternalIDNotNil(), user.ExternalIDNEQ(""))
-	case resource.UserLocaleKey:
-		return user.And(user.LocaleNotNil(), user.LocaleNEQ(""))
-	case resource.UserNickNameKey:
-		return user.And(user.NickNameNotNil(), user.NickNameNEQ(""))
-	case resource.UserPasswordKey:
-		return user.And(user.PasswordNotNil(), user.PasswordNEQ(""))
-	case resource.UserPreferredLanguageKey:
-		return user.And(user.PreferredLanguageNotNil(), user.PreferredLanguageNEQ(""))
-	case resource.UserProfileURLKey:
-		return user.And(user.ProfileURLNotNil(), user.ProfileURLNEQ(""))
-	case resource.UserTimezoneKey:
-		return user.And(user.TimezoneNotNil(), user.TimezoneNEQ(""))
-	case resource.UserTitleKey:
-		return user.And(user.TitleNotNil(), user.TitleNEQ(""))
-	case resource.UserUserTypeKey:
-		return user.And(user.UserTypeNotNil(), user.UserTypeNEQ(""))
-	default:
-		return nil
-	}
-}
-
 func (b *Backend) existsUserAddress(ctx context.Context, parent *ent.User, in *resource.Address) bool {
 	queryCall := parent.QueryAddresses()
 	var predicates []predicate.Address
@@ -1177,16 +2334,51 @@ func (b *Backend) createX509Certificate(ctx context.Context, resources ...*resou
 	return list, nil
 }
 
-func (b *Backend) CreateUser(ctx context.Context, in *resource.User) (*resource.User, error) {
+func (b *Backend) CreateUser(ctx context.Context, in *resource.User) (_ *resource.User, err error) {
+	// Failures are recorded in the audit log with their SCIM error
+	ctx, audited := b.auditFailures(ctx, AuditCreate, `User`, "", in)
+	defer audited(&err)
+	// Errors not explicitly converted to SCIM errors are mapped here
+	defer func() { err = convertError(err, internalError) }()
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	if b.shouldUpsert(ctx) {
+		res, _, err := b.UpsertUser(ctx, in)
+		return res, err
+	}
+	if err := checkWritable(ctx, `User`, in); err != nil {
+		return nil, err
+	}
+	if err := b.validateUser(ctx, in, ""); err != nil {
+		return nil, err
+	}
+
+	// The User, its first version and its audit entry are saved in a single transaction
+	var res *resource.User
+	err = b.inTx(ctx, func(b *Backend) (err error) {
+		res, err = b.createUser(ctx, in)
+		if err != nil {
+			return err
+		}
+		return b.recordAudit(ctx, b.db, AuditCreate, `User`, res.ID(), in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+func (b *Backend) createUser(ctx context.Context, in *resource.User) (*resource.User, error) {
 	createCall := b.db.User.Create()
 	password, err := b.generatePassword(in)
 	if err != nil {
-		return nil, fmt.Errorf("failed to process password: %w", err)
+		return nil, invalidValue(fmt.Errorf("failed to process password: %w", err))
 	}
 	createCall.SetPassword(password)
 	if !in.HasUserName() {
-		return nil, fmt.Errorf("required field userName not found")
+		return nil, invalidValue(fmt.Errorf("required field userName not found"))
 	}
 	createCall.SetUserName(in.UserName())
 	if in.HasActive() {
@@ -1378,29 +2570,74 @@ func (b *Backend) CreateUser(ctx context.Context, in *resource.User) (*resource.
 		return nil, fmt.Errorf("failed to save etag: %w", err)
 	}
 	rs.Etag = etag
-	return UserResourceFromEnt(rs)
+
+	if err := b.recordUserVersion(ctx, b.db, rs.ID, VersionCreated); err != nil {
+		return nil, err
+	}
+
+	scrubUser(ctx, rs)
+	return UserResourceFromEnt(b.baseURLFor(ctx), rs)
 }
 
-func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User) (*resource.User, error) {
+func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User) (_ *resource.User, err error) {
+	// Failures are recorded in the audit log with their SCIM error
+	ctx, audited := b.auditFailures(ctx, AuditReplace, `User`, id, in)
+	defer audited(&err)
+	// Errors not explicitly converted to SCIM errors are mapped here
+	defer func() { err = convertError(err, internalError) }()
+
+	if err := b.authorize(ctx, ScopeUsersWrite); err != nil {
+		return nil, err
+	}
+	if err := checkWritable(ctx, `User`, in); err != nil {
+		return nil, err
+	}
+	if err := b.validateUser(ctx, in, id); err != nil {
+		return nil, err
+	}
+
+	// The User, its new version and its audit entry are saved in a single transaction
+	var res *resource.User
+	err = b.inTx(ctx, func(b *Backend) (err error) {
+		res, err = b.replaceUser(ctx, id, in)
+		if err != nil {
+			return err
+		}
+		return b.recordAudit(ctx, b.db, AuditReplace, `User`, res.ID(), in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+func (b *Backend) replaceUser(ctx context.Context, id string, in *resource.User) (*resource.User, error) {
 	parsedUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ID: %w", err)
+		return nil, notFound("User", id)
 	}
 
-	r, err := b.db.User.Query().Where(user.ID(parsedUUID)).Only(ctx)
+	r, err := b.db.User.Query().Where(user.ID(parsedUUID), user.DeletedAtIsNil()).Only(ctx)
 	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, notFound("User", id)
+		}
 		return nil, fmt.Errorf("failed to retrieve resource for replacing: %w", err)
 	}
 
+	preserved := preservedAttributes(ctx, `User`, in)
 	replaceCall := r.Update()
 
-	replaceCall.ClearActive()
+	if !preserved.Has(resource.UserActiveKey) {
+		replaceCall.ClearActive()
+	}
 	if in.HasActive() {
 		replaceCall.SetActive(in.Active())
 	}
 
-	replaceCall.ClearAddresses()
+	if !preserved.Has(resource.UserAddressesKey) {
+		replaceCall.ClearAddresses()
+	}
 	var addressesCreateCalls []*ent.AddressCreate
 	if in.HasAddresses() {
 		calls, err := b.createAddress(ctx, in.Addresses()...)
@@ -1410,12 +2647,16 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		addressesCreateCalls = calls
 	}
 
-	replaceCall.ClearDisplayName()
+	if !preserved.Has(resource.UserDisplayNameKey) {
+		replaceCall.ClearDisplayName()
+	}
 	if in.HasDisplayName() {
 		replaceCall.SetDisplayName(in.DisplayName())
 	}
 
-	replaceCall.ClearEmails()
+	if !preserved.Has(resource.UserEmailsKey) {
+		replaceCall.ClearEmails()
+	}
 	var emailsCreateCalls []*ent.EmailCreate
 	if in.HasEmails() {
 		calls, err := b.createEmail(ctx, in.Emails()...)
@@ -1425,7 +2666,9 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		emailsCreateCalls = calls
 	}
 
-	replaceCall.ClearEntitlements()
+	if !preserved.Has(resource.UserEntitlementsKey) {
+		replaceCall.ClearEntitlements()
+	}
 	var entitlementsCreateCalls []*ent.EntitlementCreate
 	if in.HasEntitlements() {
 		calls, err := b.createEntitlement(ctx, in.Entitlements()...)
@@ -1435,12 +2678,16 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		entitlementsCreateCalls = calls
 	}
 
-	replaceCall.ClearExternalID()
+	if !preserved.Has(resource.UserExternalIDKey) {
+		replaceCall.ClearExternalID()
+	}
 	if in.HasExternalID() {
 		replaceCall.SetExternalID(in.ExternalID())
 	}
 
-	replaceCall.ClearIMS()
+	if !preserved.Has(resource.UserIMSKey) {
+		replaceCall.ClearIMS()
+	}
 	var imsCreateCalls []*ent.IMSCreate
 	if in.HasIMS() {
 		calls, err := b.createIMS(ctx, in.IMS()...)
@@ -1450,12 +2697,16 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		imsCreateCalls = calls
 	}
 
-	replaceCall.ClearLocale()
+	if !preserved.Has(resource.UserLocaleKey) {
+		replaceCall.ClearLocale()
+	}
 	if in.HasLocale() {
 		replaceCall.SetLocale(in.Locale())
 	}
 
-	replaceCall.ClearName()
+	if !preserved.Has(resource.UserNameKey) {
+		replaceCall.ClearName()
+	}
 	if in.HasName() {
 		created, err := b.createName(ctx, in.Name())
 		if err != nil {
@@ -1464,17 +2715,23 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		replaceCall.SetName(created)
 	}
 
-	replaceCall.ClearNickName()
+	if !preserved.Has(resource.UserNickNameKey) {
+		replaceCall.ClearNickName()
+	}
 	if in.HasNickName() {
 		replaceCall.SetNickName(in.NickName())
 	}
 
-	replaceCall.ClearPassword()
+	if !preserved.Has(resource.UserPasswordKey) {
+		replaceCall.ClearPassword()
+	}
 	if in.HasPassword() {
 		replaceCall.SetPassword(in.Password())
 	}
 
-	replaceCall.ClearPhoneNumbers()
+	if !preserved.Has(resource.UserPhoneNumbersKey) {
+		replaceCall.ClearPhoneNumbers()
+	}
 	var phoneNumbersCreateCalls []*ent.PhoneNumberCreate
 	if in.HasPhoneNumbers() {
 		calls, err := b.createPhoneNumber(ctx, in.PhoneNumbers()...)
@@ -1484,7 +2741,9 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		phoneNumbersCreateCalls = calls
 	}
 
-	replaceCall.ClearPhotos()
+	if !preserved.Has(resource.UserPhotosKey) {
+		replaceCall.ClearPhotos()
+	}
 	var photosCreateCalls []*ent.PhotoCreate
 	if in.HasPhotos() {
 		calls, err := b.createPhoto(ctx, in.Photos()...)
@@ -1494,17 +2753,23 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		photosCreateCalls = calls
 	}
 
-	replaceCall.ClearPreferredLanguage()
+	if !preserved.Has(resource.UserPreferredLanguageKey) {
+		replaceCall.ClearPreferredLanguage()
+	}
 	if in.HasPreferredLanguage() {
 		replaceCall.SetPreferredLanguage(in.PreferredLanguage())
 	}
 
-	replaceCall.ClearProfileURL()
+	if !preserved.Has(resource.UserProfileURLKey) {
+		replaceCall.ClearProfileURL()
+	}
 	if in.HasProfileURL() {
 		replaceCall.SetProfileURL(in.ProfileURL())
 	}
 
-	replaceCall.ClearRoles()
+	if !preserved.Has(resource.UserRolesKey) {
+		replaceCall.ClearRoles()
+	}
 	var rolesCreateCalls []*ent.RoleCreate
 	if in.HasRoles() {
 		calls, err := b.createRole(ctx, in.Roles()...)
@@ -1514,12 +2779,16 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		rolesCreateCalls = calls
 	}
 
-	replaceCall.ClearTimezone()
+	if !preserved.Has(resource.UserTimezoneKey) {
+		replaceCall.ClearTimezone()
+	}
 	if in.HasTimezone() {
 		replaceCall.SetTimezone(in.Timezone())
 	}
 
-	replaceCall.ClearTitle()
+	if !preserved.Has(resource.UserTitleKey) {
+		replaceCall.ClearTitle()
+	}
 	if in.HasTitle() {
 		replaceCall.SetTitle(in.Title())
 	}
@@ -1527,12 +2796,16 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 		replaceCall.SetUserName(in.UserName())
 	}
 
-	replaceCall.ClearUserType()
+	if !preserved.Has(resource.UserUserTypeKey) {
+		replaceCall.ClearUserType()
+	}
 	if in.HasUserType() {
 		replaceCall.SetUserType(in.UserType())
 	}
 
-	replaceCall.ClearX509Certificates()
+	if !preserved.Has(resource.UserX509CertificatesKey) {
+		replaceCall.ClearX509Certificates()
+	}
 	var x509CertificatesCreateCalls []*ent.X509CertificateCreate
 	if in.HasX509Certificates() {
 		calls, err := b.createX509Certificate(ctx, in.X509Certificates()...)
@@ -1603,6 +2876,7 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 	}
 
 	r2, err := b.db.User.Query().Where(user.ID(parsedUUID)).
+		WithMemberships().
 		WithAddresses().
 		WithEmails().
 		WithEntitlements().
@@ -1627,7 +2901,12 @@ func (b *Backend) ReplaceUser(ctx context.Context, id string, in *resource.User)
 	}
 	r2.Etag = etag
 
-	return UserResourceFromEnt(r2)
+	if err := b.recordUserVersion(ctx, b.db, r2.ID, VersionReplaced); err != nil {
+		return nil, err
+	}
+
+	scrubUser(ctx, r2)
+	return UserResourceFromEnt(b.baseURLFor(ctx), r2)
 }
 
 func (b *Backend) patchAddUser(ctx context.Context, parent *ent.User, op *resource.PatchOperation) error {
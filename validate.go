@@ -0,0 +1,334 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cybozu-go/scim/resource"
+	"github.com/cybozu-go/scim/schema"
+)
+
+// Values of the attribute characteristics defined in RFC7643 Section 7
+const (
+	mutabilityReadOnly  = `readOnly`
+	mutabilityImmutable = `immutable`
+
+	returnedAlways  = `always`
+	returnedNever   = `never`
+	returnedRequest = `request`
+
+	typeString    = `string`
+	typeBoolean   = `boolean`
+	typeDecimal   = `decimal`
+	typeInteger   = `integer`
+	typeDateTime  = `dateTime`
+	typeBinary    = `binary`
+	typeReference = `reference`
+	typeComplex   = `complex`
+)
+
+// violations collects the problems found while validating a payload,
+// so that they can be reported all at once
+type violations struct {
+	mutability []string
+	value      []string
+
+	// strictCanonical rejects values that are not among the canonical
+	// values of their attribute. See WithStrictCanonicalValues
+	strictCanonical bool
+}
+
+func (v *violations) addMutability(format string, args ...interface{}) {
+	v.mutability = append(v.mutability, fmt.Sprintf(format, args...))
+}
+
+func (v *violations) addValue(format string, args ...interface{}) {
+	v.value = append(v.value, fmt.Sprintf(format, args...))
+}
+
+// err builds a single SCIM error listing every violation. If any of the
+// violations concern mutability, the scimType is "mutability", otherwise
+// it is "invalidValue"
+func (v *violations) err(resourceType string) error {
+	if len(v.mutability) == 0 && len(v.value) == 0 {
+		return nil
+	}
+
+	list := append(append([]string(nil), v.mutability...), v.value...)
+	detail := fmt.Sprintf(`invalid %s: %s`, resourceType, strings.Join(list, `; `))
+
+	if len(v.mutability) > 0 {
+		return resource.NewErrorBuilder().
+			Status(http.StatusBadRequest).
+			ScimType(resource.ErrMutability).
+			Detail(detail).
+			MustBuild()
+	}
+	return resource.NewErrorBuilder().
+		Status(http.StatusBadRequest).
+		ScimType(resource.ErrInvalidValue).
+		Detail(detail).
+		MustBuild()
+}
+
+func lookupAttribute(attrs []*resource.SchemaAttribute, name string) (*resource.SchemaAttribute, bool) {
+	for _, attr := range attrs {
+		if strings.EqualFold(attr.Name(), name) {
+			return attr, true
+		}
+	}
+	return nil, false
+}
+
+// toJSONObject converts a resource to its JSON representation, so that
+// it can be compared against the schema without knowing its Go type
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to serialize resource: %w`, err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, fmt.Errorf(`failed to parse resource: %w`, err)
+	}
+	return m, nil
+}
+
+// validatePayload validates a User or Group payload against the schema of
+// its resource type, before anything is written to the database.
+//
+// For replace operations, current is used to retrieve the stored resource
+// when the payload contains immutable attributes, which must not change.
+// It must be nil for create operations. Values outside the canonical
+// values of their attribute are only rejected if strictCanonical is true.
+func validatePayload(resourceType string, in interface{}, current func() (interface{}, error), strictCanonical bool) error {
+	s, ok := schema.GetByResourceType(resourceType)
+	if !ok {
+		// Nothing to validate against
+		return nil
+	}
+
+	payload, err := toJSONObject(in)
+	if err != nil {
+		return invalidValue(err)
+	}
+
+	v := violations{strictCanonical: strictCanonical}
+	attrs := s.Attributes()
+
+	names := make([]string, 0, len(payload))
+	for name := range payload {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stored map[string]interface{}
+	for _, name := range names {
+		// "schemas" and schema extensions are not described by the core schema
+		if name == `schemas` || strings.HasPrefix(strings.ToLower(name), `urn:`) {
+			continue
+		}
+
+		attr, ok := lookupAttribute(attrs, name)
+		if !ok {
+			v.addValue(`unknown attribute %q`, name)
+			continue
+		}
+
+		value := payload[name]
+		switch string(attr.Mutability()) {
+		case mutabilityReadOnly:
+			v.addMutability(`attribute %q is read-only`, attr.Name())
+			continue
+		case mutabilityImmutable:
+			if current == nil {
+				break
+			}
+			if stored == nil {
+				r, err := current()
+				if err != nil {
+					return err
+				}
+				stored, err = toJSONObject(r)
+				if err != nil {
+					return err
+				}
+			}
+			if prev, ok := stored[attr.Name()]; ok && !reflect.DeepEqual(prev, value) {
+				v.addMutability(`attribute %q is immutable and cannot be modified`, attr.Name())
+				continue
+			}
+		}
+
+		validateValue(&v, attr, attr.Name(), value)
+	}
+
+	for _, attr := range attrs {
+		if attr.Required() && string(attr.Mutability()) != mutabilityReadOnly {
+			if _, ok := payload[attr.Name()]; !ok {
+				v.addValue(`required attribute %q is missing`, attr.Name())
+			}
+		}
+	}
+
+	return v.err(resourceType)
+}
+
+func validateValue(v *violations, attr *resource.SchemaAttribute, path string, value interface{}) {
+	if attr.MultiValued() {
+		list, ok := value.([]interface{})
+		if !ok {
+			v.addValue(`attribute %q must be multi-valued`, path)
+			return
+		}
+		for i, elem := range list {
+			validateSingleValue(v, attr, fmt.Sprintf(`%s[%d]`, path, i), elem)
+		}
+		return
+	}
+	validateSingleValue(v, attr, path, value)
+}
+
+func validateSingleValue(v *violations, attr *resource.SchemaAttribute, path string, value interface{}) {
+	switch typ := string(attr.Type()); typ {
+	case typeString, typeBinary, typeReference:
+		s, ok := value.(string)
+		if !ok {
+			v.addValue(`attribute %q must be a %s`, path, typ)
+			return
+		}
+		validateCanonicalValue(v, attr, path, s)
+	case typeDateTime:
+		s, ok := value.(string)
+		if !ok {
+			v.addValue(`attribute %q must be a dateTime`, path)
+			return
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			v.addValue(`attribute %q must be a valid dateTime: %s`, path, err)
+		}
+	case typeBoolean:
+		if _, ok := value.(bool); !ok {
+			v.addValue(`attribute %q must be a boolean`, path)
+		}
+	case typeInteger:
+		f, ok := value.(float64)
+		if !ok || f != math.Trunc(f) {
+			v.addValue(`attribute %q must be an integer`, path)
+		}
+	case typeDecimal:
+		if _, ok := value.(float64); !ok {
+			v.addValue(`attribute %q must be a decimal`, path)
+		}
+	case typeComplex:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			v.addValue(`attribute %q must be a complex value`, path)
+			return
+		}
+		subAttrs := attr.SubAttributes()
+		for name, subValue := range m {
+			subAttr, ok := lookupAttribute(subAttrs, name)
+			if !ok {
+				v.addValue(`unknown attribute %q`, path+`.`+name)
+				continue
+			}
+			// readOnly sub-attributes (e.g. members.display) are computed
+			// by the server, and silently ignored
+			if string(subAttr.Mutability()) == mutabilityReadOnly {
+				continue
+			}
+			validateValue(v, subAttr, path+`.`+subAttr.Name(), subValue)
+		}
+		for _, subAttr := range subAttrs {
+			if subAttr.Required() && string(subAttr.Mutability()) != mutabilityReadOnly {
+				if _, ok := m[subAttr.Name()]; !ok {
+					v.addValue(`required attribute %q is missing`, path+`.`+subAttr.Name())
+				}
+			}
+		}
+	}
+}
+
+// validateCanonicalValue checks that the value is one of the canonical
+// values defined for the attribute, if any. As canonical values are only
+// suggestions (RFC7643 Section 2.3.1), this is skipped unless the Backend
+// has been configured to enforce them
+func validateCanonicalValue(v *violations, attr *resource.SchemaAttribute, path, value string) {
+	canonical := attr.CanonicalValues()
+	if !v.strictCanonical || len(canonical) == 0 {
+		return
+	}
+
+	for _, cv := range canonical {
+		s := fmt.Sprint(cv)
+		if s == value || (!attr.CaseExact() && strings.EqualFold(s, value)) {
+			return
+		}
+	}
+
+	list := make([]string, len(canonical))
+	for i, cv := range canonical {
+		list[i] = fmt.Sprintf(`%q`, fmt.Sprint(cv))
+	}
+	v.addValue(`attribute %q must be one of %s (got %q)`, path, strings.Join(list, `, `), value)
+}
+
+// immutableAttributes returns the names of the immutable attributes of
+// a resource type that are not present in the payload. Their stored
+// values are preserved when the resource is replaced
+func immutableAttributes(resourceType string, in interface{}) []string {
+	s, ok := schema.GetByResourceType(resourceType)
+	if !ok {
+		return nil
+	}
+
+	names, err := attributeNames(in)
+	if err != nil {
+		return nil
+	}
+	present := make(attributeSet)
+	present.Add(names...)
+
+	var list []string
+	for _, attr := range s.Attributes() {
+		if string(attr.Mutability()) == mutabilityImmutable && !present.Has(attr.Name()) {
+			list = append(list, attr.Name())
+		}
+	}
+	return list
+}
+
+func (b *Backend) validateUser(ctx context.Context, in *resource.User, id string) error {
+	var current func() (interface{}, error)
+	if id != "" {
+		current = func() (interface{}, error) {
+			return b.retrieveUser(ctx, id, nil, nil)
+		}
+	}
+	if err := validatePayload(`User`, in, current, b.strictCanonical); err != nil {
+		return err
+	}
+	return b.checkUserExternalID(ctx, in, id)
+}
+
+func (b *Backend) validateGroup(ctx context.Context, in *resource.Group, id string) error {
+	var current func() (interface{}, error)
+	if id != "" {
+		current = func() (interface{}, error) {
+			return b.retrieveGroup(ctx, id, nil, nil)
+		}
+	}
+	if err := validatePayload(`Group`, in, current, b.strictCanonical); err != nil {
+		return err
+	}
+	return b.checkGroupExternalID(ctx, in, id)
+}
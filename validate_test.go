@@ -0,0 +1,59 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	server "github.com/cybozu-go/scim-server"
+	"github.com/cybozu-go/scim/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("canonical values are advisory", func(t *testing.T) {
+		b := newBackend(t)
+
+		var in resource.User
+		decode(t, `{"userName":"alice","emails":[{"value":"alice@example.com","type":"school"}]}`, &in)
+		u, err := b.CreateUser(ctx, &in)
+		require.NoError(t, err, `CreateUser should accept non-canonical values`)
+		require.Len(t, u.Emails(), 1, `email should be stored`)
+		require.Equal(t, "school", u.Emails()[0].Type(), `email type should be stored as is`)
+	})
+	t.Run("strict canonical values", func(t *testing.T) {
+		b := newBackend(t, server.WithStrictCanonicalValues(true))
+
+		var in resource.User
+		decode(t, `{"userName":"alice","emails":[{"value":"alice@example.com","type":"school"}]}`, &in)
+		_, err := b.CreateUser(ctx, &in)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrInvalidValue, serr.ScimType(), `scimType should be invalidValue`)
+		require.Contains(t, serr.Detail(), `emails[0].type`, `detail should name the attribute`)
+
+		decode(t, `{"userName":"bob","emails":[{"value":"bob@example.com","type":"WORK"}]}`, &in)
+		_, err = b.CreateUser(ctx, &in)
+		require.NoError(t, err, `canonical values should be matched case-insensitively`)
+	})
+	t.Run("read-only attributes", func(t *testing.T) {
+		b := newBackend(t)
+
+		var in resource.User
+		decode(t, `{"userName":"alice","groups":[{"value":"5b6a1f0e-2a2b-4a8e-9d6e-6b1f3c1d2e3f"}]}`, &in)
+		_, err := b.CreateUser(ctx, &in)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Equal(t, resource.ErrMutability, serr.ScimType(), `scimType should be mutability`)
+	})
+	t.Run("all violations are reported", func(t *testing.T) {
+		b := newBackend(t)
+
+		var in resource.User
+		decode(t, `{"nickName":"al","favoriteColor":"blue"}`, &in)
+		_, err := b.CreateUser(ctx, &in)
+		serr := requireSCIMError(t, err, http.StatusBadRequest)
+		require.Contains(t, serr.Detail(), `"favoriteColor"`, `detail should report the unknown attribute`)
+		require.Contains(t, serr.Detail(), `"userName"`, `detail should report the missing attribute`)
+	})
+}
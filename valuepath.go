@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/cybozu-go/scim-server/ent/address"
+	"github.com/cybozu-go/scim-server/ent/email"
+	"github.com/cybozu-go/scim-server/ent/entitlement"
+	"github.com/cybozu-go/scim-server/ent/ims"
+	"github.com/cybozu-go/scim-server/ent/phonenumber"
+	"github.com/cybozu-go/scim-server/ent/photo"
+	"github.com/cybozu-go/scim-server/ent/predicate"
+	"github.com/cybozu-go/scim-server/ent/role"
+	"github.com/cybozu-go/scim-server/ent/user"
+	"github.com/cybozu-go/scim/resource"
+)
+
+// userValueTable describes the table holding the values of a
+// multi-valued attribute of Users, such as emails
+type userValueTable struct {
+	table  string
+	column func(string) string
+	valid  func(string) bool
+	has    func(*sql.Predicate) predicate.User
+}
+
+// userValueTables lists the multi-valued attributes of Users that can be
+// filtered with value paths, such as `emails[type eq "work" and primary eq true]`
+var userValueTables = map[string]userValueTable{
+	resource.UserAddressesKey: {address.Table, AddressEntFieldFromSCIM, address.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasAddressesWith(predicate.Address(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserEmailsKey: {email.Table, EmailEntFieldFromSCIM, email.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasEmailsWith(predicate.Email(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserEntitlementsKey: {entitlement.Table, EntitlementEntFieldFromSCIM, entitlement.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasEntitlementsWith(predicate.Entitlement(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserIMSKey: {ims.Table, IMSEntFieldFromSCIM, ims.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasIMSWith(predicate.IMS(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserPhoneNumbersKey: {phonenumber.Table, PhoneNumberEntFieldFromSCIM, phonenumber.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasPhoneNumbersWith(predicate.PhoneNumber(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserPhotosKey: {photo.Table, PhotoEntFieldFromSCIM, photo.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasPhotosWith(predicate.Photo(func(s *sql.Selector) { s.Where(p) }))
+	}},
+	resource.UserRolesKey: {role.Table, RoleEntFieldFromSCIM, role.ValidColumn, func(p *sql.Predicate) predicate.User {
+		return user.HasRolesWith(predicate.Role(func(s *sql.Selector) { s.Where(p) }))
+	}},
+}
+
+// condition builds the condition on the table for the filters in value
+// paths. String sub-attributes support every operator, and boolean
+// sub-attributes (e.g. primary) only support equality
+func (t userValueTable) condition(scimField string, m stringMatch, val interface{}) (*sql.Predicate, error) {
+	field, subfield, err := splitScimField(scimField)
+	if err != nil {
+		return nil, err
+	}
+
+	column := t.column(subfield)
+	if _, ok := schemaAttribute(`User`, scimField); !ok || !t.valid(column) {
+		return nil, fmt.Errorf("invalid filter specification: invalid subfield for %q", field)
+	}
+	column = sql.Table(t.table).C(column)
+
+	switch v := val.(type) {
+	case string:
+		return matchString(m, column, v, isCaseExact(`User`, scimField)), nil
+	case bool:
+		if m != matchEqual {
+			return nil, invalidFilter(fmt.Errorf(`attribute %q can only be compared for equality`, scimField))
+		}
+		return sql.EQ(column, v), nil
+	default:
+		return nil, filterTypeMismatch(scimField, typeString, val)
+	}
+}